@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// anthropicVersion is the Messages API version sent with every request
+const anthropicVersion = "2023-06-01"
+
+// anthropicProvider talks to the Anthropic Messages API
+type anthropicProvider struct {
+	baseURL    string
+	apiKey     string
+	maxTokens  int
+	httpClient *http.Client
+}
+
+type anthropicBlock struct {
+	Type      string      `json:"type"`
+	Text      string      `json:"text,omitempty"`
+	ID        string      `json:"id,omitempty"`
+	Name      string      `json:"name,omitempty"`
+	Input     interface{} `json:"input,omitempty"`
+	ToolUseID string      `json:"tool_use_id,omitempty"`
+	Content   string      `json:"content,omitempty"`
+}
+
+type anthropicMessage struct {
+	Role    string           `json:"role"`
+	Content []anthropicBlock `json:"content"`
+}
+
+type anthropicTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"input_schema"`
+}
+
+type anthropicToolChoice struct {
+	Type string `json:"type"`
+}
+
+type anthropicRequest struct {
+	Model      string               `json:"model"`
+	MaxTokens  int                  `json:"max_tokens"`
+	System     string               `json:"system,omitempty"`
+	Messages   []anthropicMessage   `json:"messages"`
+	Tools      []anthropicTool      `json:"tools,omitempty"`
+	ToolChoice *anthropicToolChoice `json:"tool_choice,omitempty"`
+}
+
+type anthropicResponse struct {
+	Content []anthropicBlock `json:"content"`
+	Usage   struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+func (p *anthropicProvider) Chat(ctx context.Context, request ChatRequest) (*ChatResponse, error) {
+	body := anthropicRequest{Model: request.Model, MaxTokens: p.maxTokens}
+
+	for _, message := range request.Messages {
+		var role string
+		var blocks []anthropicBlock
+
+		switch message.Role {
+		case RoleSystem:
+			body.System = strings.TrimSpace(body.System + "\n\n" + message.Content)
+			continue
+		case RoleTool:
+			// Tool results are sent back as user content
+			role = RoleUser
+			blocks = []anthropicBlock{{Type: "tool_result", ToolUseID: message.ToolCallID, Content: message.Content}}
+		default:
+			role = message.Role
+			if message.Content != "" {
+				blocks = append(blocks, anthropicBlock{Type: "text", Text: message.Content})
+			}
+			for _, call := range message.ToolCalls {
+				input := call.Arguments
+				if input == nil {
+					input = map[string]interface{}{}
+				}
+				blocks = append(blocks, anthropicBlock{Type: "tool_use", ID: call.ID, Name: call.Name, Input: input})
+			}
+		}
+
+		// Consecutive messages of one role, such as several tool results, form a single turn
+		if last := len(body.Messages) - 1; last >= 0 && body.Messages[last].Role == role {
+			body.Messages[last].Content = append(body.Messages[last].Content, blocks...)
+			continue
+		}
+		body.Messages = append(body.Messages, anthropicMessage{Role: role, Content: blocks})
+	}
+
+	for _, tool := range request.Tools {
+		body.Tools = append(body.Tools, anthropicTool{Name: tool.Name, Description: tool.Description, InputSchema: tool.Parameters})
+	}
+	if request.DisableTools && len(body.Tools) > 0 {
+		body.ToolChoice = &anthropicToolChoice{Type: "none"}
+	}
+
+	headers := map[string]string{
+		"x-api-key":         p.apiKey,
+		"anthropic-version": anthropicVersion,
+	}
+
+	var response anthropicResponse
+	if err := postJSON(ctx, p.httpClient, p.baseURL+"/v1/messages", headers, body, &response); err != nil {
+		return nil, err
+	}
+
+	message := Message{Role: RoleAssistant}
+	var text []string
+	for _, block := range response.Content {
+		switch block.Type {
+		case "text":
+			text = append(text, block.Text)
+		case "tool_use":
+			arguments, _ := block.Input.(map[string]interface{})
+			message.ToolCalls = append(message.ToolCalls, ToolCall{ID: block.ID, Name: block.Name, Arguments: arguments})
+		}
+	}
+	message.Content = strings.Join(text, "")
+	if len(message.ToolCalls) == 0 && message.Content == "" {
+		return nil, fmt.Errorf("LLM response has no content")
+	}
+	request.emit(message.Content)
+	return &ChatResponse{
+		Message: message,
+		Usage:   Usage{InputTokens: response.Usage.InputTokens, OutputTokens: response.Usage.OutputTokens},
+	}, nil
+}
@@ -0,0 +1,362 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+)
+
+// Message roles
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+	RoleTool      = "tool"
+)
+
+// systemPrompt tells the model how to use the HWP tools
+const systemPrompt = "You control the Korean word processor HWP through the hwp_* tools. " +
+	"Create or open a document before editing it, use the tools to carry out the user's request, " +
+	"and answer in the user's language with a short summary of what was done."
+
+// Message is one entry of a conversation
+type Message struct {
+	Role       string     `json:"role"`
+	Content    string     `json:"content,omitempty"`
+	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string     `json:"tool_call_id,omitempty"`
+	Name       string     `json:"name,omitempty"` // tool name of a tool result
+}
+
+// ToolCall is a tool call requested by the model
+type ToolCall struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments"`
+}
+
+// Tool is an MCP tool offered to the model, with its JSON schema parameters
+type Tool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Parameters  map[string]interface{} `json:"parameters"`
+}
+
+// ChatRequest is a conversation sent to a provider
+type ChatRequest struct {
+	Model    string    `json:"model"`
+	Messages []Message `json:"messages"`
+	Tools    []Tool    `json:"tools,omitempty"`
+
+	// DisableTools asks for an answer without tool calls. The tools are still
+	// sent where the provider needs them to read earlier tool calls.
+	DisableTools bool `json:"-"`
+
+	// OnText receives the reply text as it is generated. Providers that
+	// don't stream pass the whole text at once.
+	OnText func(text string) `json:"-"`
+}
+
+// emit passes reply text to OnText, if set
+func (r ChatRequest) emit(text string) {
+	if r.OnText != nil && text != "" {
+		r.OnText(text)
+	}
+}
+
+// ChatResponse is the model's reply
+type ChatResponse struct {
+	Message Message `json:"message"`
+	Usage   Usage   `json:"usage"`
+}
+
+// Usage is the number of tokens a request used, as reported by the provider
+type Usage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// Total returns the input and output tokens together
+func (u Usage) Total() int {
+	return u.InputTokens + u.OutputTokens
+}
+
+// App connects the LLM to the HWP MCP server
+type App struct {
+	mcpClient *MCPClient
+	provider  Provider
+	model     string
+	tools     []Tool
+	messages  []Message
+
+	// MaxRounds limits the tool rounds of one turn (0 for no limit)
+	MaxRounds int
+
+	// TokenBudget limits the tokens one turn may use across its rounds (0 for no limit)
+	TokenBudget int
+
+	// MaxResultChars shortens longer tool results before they are added to
+	// the conversation (0 for no limit)
+	MaxResultChars int
+
+	// Policy limits the tools the model is offered and may call
+	Policy ToolPolicy
+
+	// OnText receives the model's reply text as it is generated
+	OnText func(text string)
+
+	// OnToolCall is told about each tool call the model made and its result
+	OnToolCall func(call ToolCall, result *ToolResult, elapsed time.Duration)
+
+	// Logger receives a line per round
+	Logger *log.Logger
+
+	// History saves each turn when set
+	History *HistoryStore
+
+	// Tracer records the timing of each turn when set
+	Tracer *Tracer
+
+	conversationID string
+	saved          int // messages already in History
+}
+
+// NewApp creates an app offering the tools of mcpClient to the model
+func NewApp(ctx context.Context, mcpClient *MCPClient, provider Provider, model string) (*App, error) {
+	tools, err := mcpClient.GetAvailableTools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &App{
+		mcpClient:      mcpClient,
+		provider:       provider,
+		model:          model,
+		tools:          tools,
+		messages:       []Message{{Role: RoleSystem, Content: systemPrompt}},
+		saved:          1,
+		MaxRounds:      10,
+		MaxResultChars: 8000,
+		Logger:         log.New(io.Discard, "", 0),
+	}, nil
+}
+
+// ConversationID returns the ID the conversation is saved under, empty until
+// its first turn is saved
+func (a *App) ConversationID() string {
+	return a.conversationID
+}
+
+// NewConversation starts over with an empty conversation
+func (a *App) NewConversation() {
+	a.messages = []Message{{Role: RoleSystem, Content: systemPrompt}}
+	a.saved = len(a.messages)
+	a.conversationID = ""
+}
+
+// ResumeConversation continues a conversation from History
+func (a *App) ResumeConversation(id string) error {
+	if a.History == nil {
+		return fmt.Errorf("conversation history is disabled")
+	}
+	messages, err := a.History.Load(id)
+	if err != nil {
+		return err
+	}
+	a.messages = append([]Message{{Role: RoleSystem, Content: systemPrompt}}, messages...)
+	a.saved = len(a.messages)
+	a.conversationID = id
+	return nil
+}
+
+// ProcessChatWithMCP sends the user's input to the model and keeps running
+// the tools it asks for and returning the results until it answers without
+// tool calls. When the round or token limit is reached, the model is asked to
+// answer without tools. The turn is saved to History, even if it failed.
+func (a *App) ProcessChatWithMCP(ctx context.Context, input string) (string, error) {
+	trace := &TurnTrace{Input: input, Started: time.Now()}
+	answer, err := a.runTurn(ctx, input, trace)
+	if saveErr := a.saveHistory(); saveErr != nil && err == nil {
+		err = saveErr
+	}
+	if a.Tracer != nil {
+		trace.ConversationID = a.conversationID
+		trace.DurationMS = time.Since(trace.Started).Milliseconds()
+		if err != nil {
+			trace.Error = err.Error()
+		}
+		if traceErr := a.Tracer.Write(trace); traceErr != nil {
+			a.Logger.Printf("%v", traceErr)
+		}
+	}
+	return answer, err
+}
+
+// saveHistory appends the messages added since the last save to History
+func (a *App) saveHistory() error {
+	if a.History == nil || a.saved == len(a.messages) {
+		return nil
+	}
+	if a.conversationID == "" {
+		a.conversationID = a.History.NewID()
+	}
+	if err := a.History.Append(a.conversationID, a.messages[a.saved:]); err != nil {
+		return err
+	}
+	a.saved = len(a.messages)
+	return nil
+}
+
+// runTurn runs the rounds of one user message, recording them in trace
+func (a *App) runTurn(ctx context.Context, input string, trace *TurnTrace) (string, error) {
+	a.messages = append(a.messages, Message{Role: RoleUser, Content: input})
+
+	var used Usage
+	for round := 1; ; round++ {
+		limit := a.roundLimit(round, used)
+		if limit != "" {
+			a.Logger.Printf("round %d: %s, asking for an answer without tools", round, limit)
+		}
+
+		started := time.Now()
+		response, err := a.sendToLLMServer(ctx, limit != "")
+		if err != nil {
+			return "", err
+		}
+		used.InputTokens += response.Usage.InputTokens
+		used.OutputTokens += response.Usage.OutputTokens
+		trace.Usage = used
+		trace.Rounds = append(trace.Rounds, RoundTrace{
+			Round:        round,
+			LLMMS:        time.Since(started).Milliseconds(),
+			Usage:        response.Usage,
+			ToolsOffered: limit == "",
+		})
+		roundTrace := &trace.Rounds[len(trace.Rounds)-1]
+		if limit != "" {
+			// Tool calls can't be answered any more
+			response.Message.ToolCalls = nil
+		}
+		a.messages = append(a.messages, response.Message)
+
+		a.Logger.Printf("round %d: %d tokens in %s, tool calls: %s",
+			round, response.Usage.Total(), time.Since(started).Round(time.Millisecond), toolCallNames(response.Message.ToolCalls))
+		if len(response.Message.ToolCalls) == 0 {
+			return response.Message.Content, nil
+		}
+
+		for _, call := range response.Message.ToolCalls {
+			a.messages = append(a.messages, Message{
+				Role:       RoleTool,
+				Content:    a.callTool(ctx, call, roundTrace),
+				ToolCallID: call.ID,
+				Name:       call.Name,
+			})
+		}
+	}
+}
+
+// toolCallNames lists the tools called, for logging
+func toolCallNames(calls []ToolCall) string {
+	if len(calls) == 0 {
+		return "none"
+	}
+	names := make([]string, len(calls))
+	for i, call := range calls {
+		names[i] = call.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// roundLimit describes the limit that keeps round from offering tools, if any
+func (a *App) roundLimit(round int, used Usage) string {
+	if a.MaxRounds > 0 && round > a.MaxRounds {
+		return fmt.Sprintf("reached %d tool rounds", a.MaxRounds)
+	}
+	if a.TokenBudget > 0 && used.Total() >= a.TokenBudget {
+		return fmt.Sprintf("used %d of %d tokens", used.Total(), a.TokenBudget)
+	}
+	return ""
+}
+
+// callTool runs a tool call and returns the text for the model. Failures are
+// reported to the model rather than ending the turn, so it can correct itself.
+// Calls the policy forbids are refused without reaching the server. The call
+// is added to trace.
+func (a *App) callTool(ctx context.Context, call ToolCall, trace *RoundTrace) string {
+	started := time.Now()
+	var result *ToolResult
+	if !a.Policy.Allows(call.Name) {
+		result = &ToolResult{Text: fmt.Sprintf("Error: tool %s is not allowed in this conversation", call.Name), IsError: true}
+	} else {
+		var err error
+		if result, err = a.mcpClient.CallTool(ctx, call.Name, call.Arguments); err != nil {
+			result = &ToolResult{Text: fmt.Sprintf("Error: %v", err), IsError: true}
+		}
+	}
+	elapsed := time.Since(started)
+	if a.OnToolCall != nil {
+		a.OnToolCall(call, result, elapsed)
+	}
+	text := truncateToolResult(result.Text, a.MaxResultChars)
+	trace.ToolCalls = append(trace.ToolCalls, ToolCallTrace{
+		Name:        call.Name,
+		DurationMS:  elapsed.Milliseconds(),
+		ResultChars: len([]rune(result.Text)),
+		SentChars:   len([]rune(text)),
+		Failed:      result.Failed(),
+	})
+	return text
+}
+
+// truncateToolResult shortens a result longer than limit characters to its
+// beginning and end, cut at line breaks where possible, with a note saying
+// what was left out. A limit of 0 keeps the whole result.
+func truncateToolResult(text string, limit int) string {
+	runes := []rune(text)
+	if limit <= 0 || len(runes) <= limit {
+		return text
+	}
+
+	head := string(runes[:limit*2/3])
+	tail := string(runes[len(runes)-limit/3:])
+	if i := strings.LastIndex(head, "\n"); i > len(head)/2 {
+		head = head[:i]
+	}
+	if i := strings.Index(tail, "\n"); i >= 0 && i < len(tail)/2 {
+		tail = tail[i+1:]
+	}
+
+	omitted := len(runes) - len([]rune(head)) - len([]rune(tail))
+	return fmt.Sprintf("%s\n\n[... %d of %d characters omitted to save context. "+
+		"Use hwp_find or a narrower request to see the omitted part ...]\n\n%s", head, omitted, len(runes), tail)
+}
+
+// AddNote tells the model about something the user did outside the
+// conversation, such as opening a document with a command
+func (a *App) AddNote(note string) {
+	a.messages = append(a.messages, Message{Role: RoleUser, Content: "Note: " + note})
+}
+
+// Tools returns the tools offered to the model
+func (a *App) Tools() []Tool {
+	return a.Policy.Filter(a.tools)
+}
+
+// CallTool runs a tool directly, outside the conversation
+func (a *App) CallTool(ctx context.Context, name string, arguments map[string]interface{}) (*ToolResult, error) {
+	return a.mcpClient.CallTool(ctx, name, arguments)
+}
+
+// sendToLLMServer sends the conversation and tools to the model
+func (a *App) sendToLLMServer(ctx context.Context, disableTools bool) (*ChatResponse, error) {
+	return a.provider.Chat(ctx, ChatRequest{
+		Model:        a.model,
+		Messages:     a.messages,
+		Tools:        a.Tools(),
+		DisableTools: disableTools,
+		OnText:       a.OnText,
+	})
+}
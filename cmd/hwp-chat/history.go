@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HistoryStore keeps conversations as JSONL files, one message per line, in a
+// directory only the user can read. Tool call arguments whose key contains
+// "password" are saved as redactedValue.
+type HistoryStore struct {
+	dir string
+}
+
+// ConversationInfo summarizes a stored conversation
+type ConversationInfo struct {
+	ID       string
+	Title    string
+	Messages int
+	Updated  time.Time
+}
+
+// conversationIDPattern keeps IDs usable as file names
+var conversationIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// redactedValue replaces secret tool call arguments in saved conversations
+const redactedValue = "[redacted]"
+
+// NewHistoryStore opens the history directory, creating it if needed
+func NewHistoryStore(dir string) (*HistoryStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+	return &HistoryStore{dir: dir}, nil
+}
+
+// DefaultHistoryDir returns the history directory under the user config directory
+func DefaultHistoryDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = "."
+	}
+	return filepath.Join(dir, "hwp-chat", "history")
+}
+
+// NewID returns an unused conversation ID based on the current time
+func (h *HistoryStore) NewID() string {
+	base := time.Now().Format("20060102-150405")
+	id := base
+	for i := 2; ; i++ {
+		if _, err := os.Stat(h.path(id)); os.IsNotExist(err) {
+			return id
+		}
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// Append adds messages to the end of a conversation
+func (h *HistoryStore) Append(id string, messages []Message) error {
+	if err := checkConversationID(id); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(h.path(id), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open conversation %s: %w", id, err)
+	}
+	defer file.Close()
+	// Files saved by earlier versions were readable by everyone
+	if err := file.Chmod(0o600); err != nil {
+		return fmt.Errorf("failed to restrict conversation %s: %w", id, err)
+	}
+
+	encoder := json.NewEncoder(file)
+	for _, message := range messages {
+		if err := encoder.Encode(redactMessage(message)); err != nil {
+			return fmt.Errorf("failed to save conversation %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// redactMessage returns message with the secret arguments of its tool calls
+// redacted, leaving message itself as it is
+func redactMessage(message Message) Message {
+	if len(message.ToolCalls) == 0 {
+		return message
+	}
+	calls := make([]ToolCall, len(message.ToolCalls))
+	for i, call := range message.ToolCalls {
+		call.Arguments, _ = redactArguments(call.Arguments).(map[string]interface{})
+		calls[i] = call
+	}
+	message.ToolCalls = calls
+	return message
+}
+
+// redactArguments returns a copy of value in which every object member whose
+// key contains "password", at any depth, is redactedValue
+func redactArguments(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if value == nil {
+			return value
+		}
+		redacted := make(map[string]interface{}, len(value))
+		for key, member := range value {
+			if strings.Contains(strings.ToLower(key), "password") {
+				redacted[key] = redactedValue
+			} else {
+				redacted[key] = redactArguments(member)
+			}
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(value))
+		for i, item := range value {
+			redacted[i] = redactArguments(item)
+		}
+		return redacted
+	default:
+		return value
+	}
+}
+
+// Load reads all messages of a conversation
+func (h *HistoryStore) Load(id string) ([]Message, error) {
+	if err := checkConversationID(id); err != nil {
+		return nil, err
+	}
+	file, err := os.Open(h.path(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("conversation not found: %s", id)
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	var messages []Message
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var message Message
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			return nil, fmt.Errorf("conversation %s line %d: %w", id, line, err)
+		}
+		messages = append(messages, message)
+	}
+	return messages, scanner.Err()
+}
+
+// List returns the stored conversations, most recently updated first
+func (h *HistoryStore) List() ([]ConversationInfo, error) {
+	entries, err := os.ReadDir(h.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var conversations []ConversationInfo
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".jsonl")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		messages, err := h.Load(id)
+		if err != nil {
+			continue
+		}
+
+		conversation := ConversationInfo{ID: id, Messages: len(messages), Updated: info.ModTime()}
+		for _, message := range messages {
+			if message.Role == RoleUser && !strings.HasPrefix(message.Content, "Note: ") {
+				conversation.Title = message.Content
+				break
+			}
+		}
+		conversations = append(conversations, conversation)
+	}
+
+	sort.Slice(conversations, func(i, j int) bool {
+		return conversations[i].Updated.After(conversations[j].Updated)
+	})
+	return conversations, nil
+}
+
+// Delete removes a conversation
+func (h *HistoryStore) Delete(id string) error {
+	if err := checkConversationID(id); err != nil {
+		return err
+	}
+	if err := os.Remove(h.path(id)); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("conversation not found: %s", id)
+		}
+		return err
+	}
+	return nil
+}
+
+func (h *HistoryStore) path(id string) string {
+	return filepath.Join(h.dir, id+".jsonl")
+}
+
+// checkConversationID rejects IDs that could name files outside the store
+func checkConversationID(id string) error {
+	if !conversationIDPattern.MatchString(id) {
+		return fmt.Errorf("invalid conversation ID: %q", id)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// LLM providers
+const (
+	ProviderCustom    = "custom"
+	ProviderOpenAI    = "openai"
+	ProviderAnthropic = "anthropic"
+	ProviderOllama    = "ollama"
+)
+
+// Provider sends a conversation to a model and returns its reply
+type Provider interface {
+	Chat(ctx context.Context, request ChatRequest) (*ChatResponse, error)
+}
+
+// ProviderConfig selects and configures a provider
+type ProviderConfig struct {
+	Name      string
+	BaseURL   string
+	APIKey    string
+	MaxTokens int
+}
+
+// NewProvider creates the named provider, using its default base URL if none is set
+func NewProvider(config ProviderConfig) (Provider, error) {
+	httpClient := &http.Client{Timeout: 5 * time.Minute}
+
+	switch strings.ToLower(config.Name) {
+	case ProviderCustom:
+		return &customProvider{url: defaultString(config.BaseURL, "http://localhost:8000/chat"), httpClient: httpClient}, nil
+	case ProviderOpenAI:
+		return &openAIProvider{
+			baseURL:    strings.TrimSuffix(defaultString(config.BaseURL, "https://api.openai.com/v1"), "/"),
+			apiKey:     config.APIKey,
+			httpClient: httpClient,
+		}, nil
+	case ProviderAnthropic:
+		if config.APIKey == "" {
+			return nil, fmt.Errorf("the anthropic provider needs an API key")
+		}
+		return &anthropicProvider{
+			baseURL:    strings.TrimSuffix(defaultString(config.BaseURL, "https://api.anthropic.com"), "/"),
+			apiKey:     config.APIKey,
+			maxTokens:  config.MaxTokens,
+			httpClient: httpClient,
+		}, nil
+	case ProviderOllama:
+		return &ollamaProvider{
+			baseURL: strings.TrimSuffix(defaultString(config.BaseURL, "http://localhost:11434"), "/"),
+			// Local models can take minutes to load, so requests are bounded only by the context
+			httpClient: &http.Client{},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown provider: %s (use %s, %s, %s or %s)", config.Name, ProviderCustom, ProviderOpenAI, ProviderAnthropic, ProviderOllama)
+	}
+}
+
+// customProvider talks to a server accepting ChatRequest and returning ChatResponse as is
+type customProvider struct {
+	url        string
+	httpClient *http.Client
+}
+
+func (p *customProvider) Chat(ctx context.Context, request ChatRequest) (*ChatResponse, error) {
+	if request.DisableTools {
+		request.Tools = nil
+	}
+
+	var response ChatResponse
+	if err := postJSON(ctx, p.httpClient, p.url, nil, request, &response); err != nil {
+		return nil, err
+	}
+	request.emit(response.Message.Content)
+	return &response, nil
+}
+
+// postJSON posts body as JSON and decodes the JSON reply into out
+func postJSON(ctx context.Context, httpClient *http.Client, url string, headers map[string]string, body, out interface{}) error {
+	response, err := postRequest(ctx, httpClient, url, headers, body)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if err := json.NewDecoder(response.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode LLM response: %w", err)
+	}
+	return nil
+}
+
+// postRequest posts body as JSON and returns the response if it succeeded.
+// The caller closes the response body.
+func postRequest(ctx context.Context, httpClient *http.Client, url string, headers map[string]string, body interface{}) (*http.Response, error) {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode chat request: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(encoded))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("LLM request failed: %w", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 4096))
+		return nil, fmt.Errorf("LLM server returned %s: %s", response.Status, bytes.TrimSpace(message))
+	}
+	return response, nil
+}
+
+// defaultString returns value, or fallback if value is empty
+func defaultString(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
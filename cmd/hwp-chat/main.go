@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+)
+
+// defaultModels are the models used when -model is not given
+var defaultModels = map[string]string{
+	ProviderCustom:    "gemini-1.5-flash",
+	ProviderOpenAI:    "gpt-4o",
+	ProviderAnthropic: "claude-3-5-sonnet-latest",
+	ProviderOllama:    "llama3.1",
+}
+
+// defaultAPIKeyEnv are the environment variables read when -api-key is not given
+var defaultAPIKeyEnv = map[string]string{
+	ProviderOpenAI:    "OPENAI_API_KEY",
+	ProviderAnthropic: "ANTHROPIC_API_KEY",
+}
+
+func main() {
+	serverCommand := flag.String("server", "hwp-mcp-go.exe",
+		"HWP MCP server executable, started over stdio")
+	serverArgs := flag.String("server-args", "",
+		"Space-separated arguments for the server, e.g. \"-backend hwpx\"")
+	providerName := flag.String("provider", ProviderCustom,
+		"LLM provider: custom (ChatRequest JSON server), openai (OpenAI or a compatible server), anthropic or ollama (local models)")
+	baseURL := flag.String("base-url", "",
+		"Provider URL; defaults to http://localhost:8000/chat, https://api.openai.com/v1, https://api.anthropic.com or http://localhost:11434")
+	apiKey := flag.String("api-key", "",
+		"Provider API key; defaults to OPENAI_API_KEY or ANTHROPIC_API_KEY")
+	model := flag.String("model", "",
+		"Model name; defaults to gemini-1.5-flash, gpt-4o, claude-3-5-sonnet-latest or llama3.1 by provider")
+	maxTokens := flag.Int("max-tokens", 4096,
+		"Maximum tokens per reply (anthropic)")
+	maxRounds := flag.Int("max-rounds", 10,
+		"Maximum tool rounds per message before the model must answer (0 for no limit)")
+	tokenBudget := flag.Int("token-budget", 0,
+		"Maximum tokens per message across its rounds before the model must answer (0 for no limit)")
+	maxResultChars := flag.Int("max-result-chars", 8000,
+		"Tool results longer than this are cut to their beginning and end before the model sees them (0 keeps them whole)")
+	allowTools := flag.String("allow-tools", "",
+		"Comma-separated tools the model may call, with * wildcards (e.g. \"hwp_get_*,hwp_find\"); all when empty")
+	denyTools := flag.String("deny-tools", "",
+		"Comma-separated tools the model may not call, with * wildcards (e.g. \"hwp_save,hwp_close\")")
+	historyDir := flag.String("history-dir", DefaultHistoryDir(),
+		"Directory conversations are saved in (empty disables history)")
+	resume := flag.String("resume", "",
+		"Continue the saved conversation with this ID")
+	list := flag.Bool("list", false,
+		"List saved conversations and exit")
+	deleteID := flag.String("delete", "",
+		"Delete the saved conversation with this ID and exit")
+	tracePath := flag.String("trace", "",
+		"Append a JSON line per message to this file with the model latency, token use and each tool call's duration and result size")
+	webAddr := flag.String("web", "",
+		"Serve a chat web UI on this address instead of the interactive prompt; bind it to loopback (e.g. 127.0.0.1:8090), as the UI has no login")
+	noColor := flag.Bool("no-color", false,
+		"Don't color the output (also set by the NO_COLOR environment variable)")
+	verbose := flag.Bool("verbose", false,
+		"Show the MCP server's log output, a line per round and full tool results")
+	flag.Parse()
+
+	var history *HistoryStore
+	if *historyDir != "" {
+		var err error
+		if history, err = NewHistoryStore(*historyDir); err != nil {
+			log.Fatalf("History error: %v", err)
+		}
+	}
+	if *list || *deleteID != "" {
+		if history == nil {
+			log.Fatalf("Conversation history is disabled")
+		}
+		if err := manageHistory(history, *list, *deleteID); err != nil {
+			log.Fatalf("History error: %v", err)
+		}
+		return
+	}
+
+	if *apiKey == "" {
+		*apiKey = os.Getenv(defaultAPIKeyEnv[*providerName])
+	}
+	if *model == "" {
+		*model = defaultModels[*providerName]
+	}
+	policy, err := NewToolPolicy(*allowTools, *denyTools)
+	if err != nil {
+		log.Fatalf("Invalid tool list: %v", err)
+	}
+	provider, err := NewProvider(ProviderConfig{Name: *providerName, BaseURL: *baseURL, APIKey: *apiKey, MaxTokens: *maxTokens})
+	if err != nil {
+		log.Fatalf("Invalid provider: %v", err)
+	}
+
+	// Ctrl+C cancels the request in progress, see REPL.Run
+	ctx := context.Background()
+
+	var serverLogs io.Writer = io.Discard
+	if *verbose {
+		serverLogs = os.Stderr
+	}
+	mcpClient, err := NewMCPClient(ctx, *serverCommand, strings.Fields(*serverArgs), serverLogs)
+	if err != nil {
+		log.Fatalf("MCP server error: %v", err)
+	}
+	defer mcpClient.Close()
+
+	app, err := NewApp(ctx, mcpClient, provider, *model)
+	if err != nil {
+		log.Fatalf("Failed to load tools: %v", err)
+	}
+	app.History = history
+	app.Policy = policy
+	if *tracePath != "" {
+		tracer, err := NewTracer(*tracePath)
+		if err != nil {
+			log.Fatalf("Trace error: %v", err)
+		}
+		defer tracer.Close()
+		app.Tracer = tracer
+	}
+	if *resume != "" {
+		if err := app.ResumeConversation(*resume); err != nil {
+			log.Fatalf("Failed to resume conversation: %v", err)
+		}
+		fmt.Fprintf(os.Stderr, "Resumed conversation %s\n", *resume)
+	}
+	app.MaxRounds = *maxRounds
+	app.TokenBudget = *tokenBudget
+	app.MaxResultChars = *maxResultChars
+	if *verbose {
+		app.Logger = log.New(os.Stderr, "[hwp-chat] ", log.Ltime)
+	}
+	if *webAddr != "" {
+		webCtx, stop := signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
+		if err := serveWeb(webCtx, app, *webAddr); err != nil {
+			log.Fatalf("Web UI error: %v", err)
+		}
+	} else {
+		color := !*noColor && os.Getenv("NO_COLOR") == "" && enableColor()
+		NewREPL(app, os.Stdout, color, *verbose).Run(ctx, os.Stdin)
+	}
+
+	if id := app.ConversationID(); id != "" {
+		fmt.Fprintf(os.Stderr, "Conversation saved as %s (continue with -resume %s)\n", id, id)
+	}
+}
+
+// manageHistory lists or deletes saved conversations
+func manageHistory(history *HistoryStore, list bool, deleteID string) error {
+	if deleteID != "" {
+		if err := history.Delete(deleteID); err != nil {
+			return err
+		}
+		fmt.Printf("Deleted conversation %s\n", deleteID)
+	}
+	if !list {
+		return nil
+	}
+
+	conversations, err := history.List()
+	if err != nil {
+		return err
+	}
+	if len(conversations) == 0 {
+		fmt.Println("No saved conversations")
+	}
+	for _, conversation := range conversations {
+		fmt.Printf("%-20s %4d messages  %s  %s\n", conversation.ID, conversation.Messages,
+			conversation.Updated.Format("2006-01-02 15:04"), truncateRunes(conversation.Title, 60))
+	}
+	return nil
+}
+
+// truncateRunes shortens text to at most n runes on one line
+func truncateRunes(text string, n int) string {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	if len(runes) <= n {
+		return string(runes)
+	}
+	return string(runes[:n-1]) + "…"
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// MCPClient runs the HWP MCP server as a subprocess and talks to it over stdio
+type MCPClient struct {
+	client *client.Client
+}
+
+// ToolResult is the text of a tool call result
+type ToolResult struct {
+	Text    string
+	IsError bool
+}
+
+// Failed reports whether the tool failed. HWP tools report most failures as
+// text starting with "Error" rather than as error results.
+func (r *ToolResult) Failed() bool {
+	return r.IsError || strings.HasPrefix(r.Text, "Error")
+}
+
+// NewMCPClient starts the server command and initializes the MCP session.
+// The server's log output is copied to logs.
+func NewMCPClient(ctx context.Context, command string, args []string, logs io.Writer) (*MCPClient, error) {
+	c, err := client.NewStdioMCPClient(command, nil, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start MCP server: %w", err)
+	}
+	if stderr, ok := client.GetStderr(c); ok {
+		go io.Copy(logs, stderr)
+	}
+
+	request := mcp.InitializeRequest{}
+	request.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	request.Params.ClientInfo = mcp.Implementation{Name: "hwp-chat", Version: "1.0.0"}
+	if _, err := c.Initialize(ctx, request); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to initialize MCP session: %w", err)
+	}
+	return &MCPClient{client: c}, nil
+}
+
+// GetAvailableTools lists the server's tools with their input schemas
+func (m *MCPClient) GetAvailableTools(ctx context.Context) ([]Tool, error) {
+	result, err := m.client.ListTools(ctx, mcp.ListToolsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tools: %w", err)
+	}
+
+	tools := make([]Tool, 0, len(result.Tools))
+	for _, tool := range result.Tools {
+		parameters, err := toolParameters(tool)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert schema of %s: %w", tool.Name, err)
+		}
+		tools = append(tools, Tool{
+			Name:        tool.Name,
+			Description: tool.Description,
+			Parameters:  parameters,
+		})
+	}
+	return tools, nil
+}
+
+// CallTool calls a tool and joins the text content of its result
+func (m *MCPClient) CallTool(ctx context.Context, name string, arguments map[string]interface{}) (*ToolResult, error) {
+	request := mcp.CallToolRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = arguments
+
+	result, err := m.client.CallTool(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("tool %s failed: %w", name, err)
+	}
+
+	var text []string
+	for _, content := range result.Content {
+		if textContent, ok := mcp.AsTextContent(content); ok {
+			text = append(text, textContent.Text)
+		}
+	}
+	return &ToolResult{Text: strings.Join(text, "\n"), IsError: result.IsError}, nil
+}
+
+// Close stops the server subprocess
+func (m *MCPClient) Close() error {
+	return m.client.Close()
+}
+
+// toolParameters converts a tool's input schema to a plain JSON schema object
+func toolParameters(tool mcp.Tool) (map[string]interface{}, error) {
+	schema := tool.RawInputSchema
+	if len(schema) == 0 {
+		encoded, err := json.Marshal(tool.InputSchema)
+		if err != nil {
+			return nil, err
+		}
+		schema = encoded
+	}
+
+	var parameters map[string]interface{}
+	if err := json.Unmarshal(schema, &parameters); err != nil {
+		return nil, err
+	}
+	if _, ok := parameters["properties"]; !ok {
+		// Providers expect an object schema even for tools without arguments
+		parameters["properties"] = map[string]interface{}{}
+	}
+	return parameters, nil
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ollamaProvider talks to a local Ollama server, streaming the reply
+type ollamaProvider struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+type ollamaMessage struct {
+	Role      string           `json:"role"`
+	Content   string           `json:"content"`
+	ToolCalls []ollamaToolCall `json:"tool_calls,omitempty"`
+	ToolName  string           `json:"tool_name,omitempty"`
+}
+
+type ollamaToolCall struct {
+	Function struct {
+		Name      string                 `json:"name"`
+		Arguments map[string]interface{} `json:"arguments"`
+	} `json:"function"`
+}
+
+type ollamaRequest struct {
+	Model    string          `json:"model"`
+	Messages []ollamaMessage `json:"messages"`
+	Tools    []openAITool    `json:"tools,omitempty"`
+	Stream   bool            `json:"stream"`
+}
+
+type ollamaChunk struct {
+	Message         ollamaMessage `json:"message"`
+	Done            bool          `json:"done"`
+	Error           string        `json:"error"`
+	PromptEvalCount int           `json:"prompt_eval_count"`
+	EvalCount       int           `json:"eval_count"`
+}
+
+func (p *ollamaProvider) Chat(ctx context.Context, request ChatRequest) (*ChatResponse, error) {
+	body := ollamaRequest{Model: request.Model, Stream: true}
+	for _, message := range request.Messages {
+		converted := ollamaMessage{Role: message.Role, Content: message.Content}
+		if message.Role == RoleTool {
+			converted.ToolName = message.Name
+		}
+		for _, call := range message.ToolCalls {
+			var toolCall ollamaToolCall
+			toolCall.Function.Name = call.Name
+			toolCall.Function.Arguments = call.Arguments
+			converted.ToolCalls = append(converted.ToolCalls, toolCall)
+		}
+		body.Messages = append(body.Messages, converted)
+	}
+	if !request.DisableTools {
+		for _, tool := range request.Tools {
+			body.Tools = append(body.Tools, openAITool{Type: "function", Function: tool})
+		}
+	}
+
+	response, err := postRequest(ctx, p.httpClient, p.baseURL+"/api/chat", nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	// The reply arrives as one JSON object per line. Text that looks like a
+	// tool call written out by the model is held back instead of streamed.
+	var content strings.Builder
+	var toolCalls []ollamaToolCall
+	var usage Usage
+	holding := false
+	scanner := bufio.NewScanner(response.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var chunk ollamaChunk
+		if err := json.Unmarshal(scanner.Bytes(), &chunk); err != nil {
+			return nil, fmt.Errorf("failed to decode LLM response: %w", err)
+		}
+		if chunk.Error != "" {
+			return nil, fmt.Errorf("ollama error: %s", chunk.Error)
+		}
+
+		toolCalls = append(toolCalls, chunk.Message.ToolCalls...)
+		if text := chunk.Message.Content; text != "" {
+			if strings.TrimSpace(content.String()) == "" {
+				trimmed := strings.TrimSpace(text)
+				holding = strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "```")
+			}
+			content.WriteString(text)
+			if !holding {
+				request.emit(text)
+			}
+		}
+		if chunk.Done {
+			usage = Usage{InputTokens: chunk.PromptEvalCount, OutputTokens: chunk.EvalCount}
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read LLM response: %w", err)
+	}
+
+	message := Message{Role: RoleAssistant, Content: content.String()}
+	for i, call := range toolCalls {
+		message.ToolCalls = append(message.ToolCalls, ToolCall{
+			ID:        fmt.Sprintf("call_%d", i+1),
+			Name:      call.Function.Name,
+			Arguments: call.Function.Arguments,
+		})
+	}
+
+	if len(message.ToolCalls) == 0 && holding {
+		if call, ok := parseTextToolCall(message.Content, request.Tools); ok && !request.DisableTools {
+			message.Content = ""
+			message.ToolCalls = []ToolCall{call}
+		} else {
+			request.emit(message.Content)
+		}
+	}
+	return &ChatResponse{Message: message, Usage: usage}, nil
+}
+
+// parseTextToolCall recognizes a tool call that a model wrote as JSON text,
+// such as {"name": "hwp_create", "arguments": {}}, optionally in a code fence
+func parseTextToolCall(text string, tools []Tool) (ToolCall, bool) {
+	text = strings.TrimSpace(text)
+	text = strings.TrimPrefix(text, "```json")
+	text = strings.TrimPrefix(text, "```")
+	text = strings.TrimSuffix(text, "```")
+
+	var call struct {
+		Name       string                 `json:"name"`
+		Arguments  map[string]interface{} `json:"arguments"`
+		Parameters map[string]interface{} `json:"parameters"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(text)), &call); err != nil {
+		return ToolCall{}, false
+	}
+
+	for _, tool := range tools {
+		if tool.Name == call.Name {
+			arguments := call.Arguments
+			if arguments == nil {
+				arguments = call.Parameters
+			}
+			if arguments == nil {
+				arguments = map[string]interface{}{}
+			}
+			return ToolCall{ID: "call_1", Name: call.Name, Arguments: arguments}, true
+		}
+	}
+	return ToolCall{}, false
+}
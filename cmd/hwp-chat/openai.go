@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// openAIProvider talks to the OpenAI chat completions API or a server
+// compatible with it
+type openAIProvider struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+type openAIMessage struct {
+	Role       string           `json:"role"`
+	Content    string           `json:"content"`
+	ToolCalls  []openAIToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string           `json:"tool_call_id,omitempty"`
+}
+
+type openAIToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+type openAITool struct {
+	Type     string `json:"type"`
+	Function Tool   `json:"function"`
+}
+
+type openAIRequest struct {
+	Model      string          `json:"model"`
+	Messages   []openAIMessage `json:"messages"`
+	Tools      []openAITool    `json:"tools,omitempty"`
+	ToolChoice string          `json:"tool_choice,omitempty"`
+}
+
+type openAIResponse struct {
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+func (p *openAIProvider) Chat(ctx context.Context, request ChatRequest) (*ChatResponse, error) {
+	body := openAIRequest{Model: request.Model}
+	for _, message := range request.Messages {
+		converted := openAIMessage{Role: message.Role, Content: message.Content, ToolCallID: message.ToolCallID}
+		for _, call := range message.ToolCalls {
+			arguments, err := json.Marshal(call.Arguments)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode arguments of %s: %w", call.Name, err)
+			}
+			toolCall := openAIToolCall{ID: call.ID, Type: "function"}
+			toolCall.Function.Name = call.Name
+			toolCall.Function.Arguments = string(arguments)
+			converted.ToolCalls = append(converted.ToolCalls, toolCall)
+		}
+		body.Messages = append(body.Messages, converted)
+	}
+	for _, tool := range request.Tools {
+		body.Tools = append(body.Tools, openAITool{Type: "function", Function: tool})
+	}
+	if request.DisableTools && len(body.Tools) > 0 {
+		body.ToolChoice = "none"
+	}
+
+	headers := map[string]string{}
+	if p.apiKey != "" {
+		headers["Authorization"] = "Bearer " + p.apiKey
+	}
+
+	var response openAIResponse
+	if err := postJSON(ctx, p.httpClient, p.baseURL+"/chat/completions", headers, body, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Choices) == 0 {
+		return nil, fmt.Errorf("LLM response has no choices")
+	}
+
+	reply := response.Choices[0].Message
+	message := Message{Role: RoleAssistant, Content: reply.Content}
+	for _, call := range reply.ToolCalls {
+		arguments := map[string]interface{}{}
+		if call.Function.Arguments != "" {
+			if err := json.Unmarshal([]byte(call.Function.Arguments), &arguments); err != nil {
+				return nil, fmt.Errorf("invalid arguments for %s: %w", call.Function.Name, err)
+			}
+		}
+		message.ToolCalls = append(message.ToolCalls, ToolCall{ID: call.ID, Name: call.Function.Name, Arguments: arguments})
+	}
+	request.emit(message.Content)
+	return &ChatResponse{
+		Message: message,
+		Usage:   Usage{InputTokens: response.Usage.PromptTokens, OutputTokens: response.Usage.CompletionTokens},
+	}, nil
+}
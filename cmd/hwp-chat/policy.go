@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ToolPolicy restricts the tools the model may call. Patterns use path.Match
+// syntax, so "hwp_get_*" matches every getter.
+type ToolPolicy struct {
+	// Allow lists the only tools the model may call (all when empty)
+	Allow []string
+
+	// Deny lists tools the model may not call, even if allowed
+	Deny []string
+}
+
+// NewToolPolicy parses comma-separated allow and deny patterns
+func NewToolPolicy(allow, deny string) (ToolPolicy, error) {
+	var policy ToolPolicy
+	var err error
+	if policy.Allow, err = parseToolPatterns(allow); err != nil {
+		return ToolPolicy{}, err
+	}
+	if policy.Deny, err = parseToolPatterns(deny); err != nil {
+		return ToolPolicy{}, err
+	}
+	return policy, nil
+}
+
+// parseToolPatterns splits a comma-separated pattern list and checks each pattern
+func parseToolPatterns(list string) ([]string, error) {
+	var patterns []string
+	for _, pattern := range strings.Split(list, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid tool pattern %q: %w", pattern, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// Allows reports whether the model may call the named tool
+func (p ToolPolicy) Allows(name string) bool {
+	if len(p.Allow) > 0 && !matchesAny(p.Allow, name) {
+		return false
+	}
+	return !matchesAny(p.Deny, name)
+}
+
+// Filter returns the tools the policy allows
+func (p ToolPolicy) Filter(tools []Tool) []Tool {
+	allowed := make([]Tool, 0, len(tools))
+	for _, tool := range tools {
+		if p.Allows(tool.Name) {
+			allowed = append(allowed, tool)
+		}
+	}
+	return allowed
+}
+
+// matchesAny reports whether name matches one of patterns
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
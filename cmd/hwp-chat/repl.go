@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ANSI colors for the REPL
+const (
+	colorReset  = "\033[0m"
+	colorDim    = "\033[2m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorCyan   = "\033[36m"
+)
+
+// REPL is the interactive chat prompt
+type REPL struct {
+	app     *App
+	out     io.Writer
+	color   bool
+	verbose bool
+}
+
+// replCommand is a /command of the REPL
+type replCommand struct {
+	usage       string
+	description string
+	run         func(r *REPL, ctx context.Context, args string) error
+}
+
+// replCommands are the REPL's /commands by name
+var replCommands = map[string]replCommand{
+	"open":  {"/open <path>", "Open a document", (*REPL).open},
+	"save":  {"/save [path]", "Save the document, to path if given", (*REPL).save},
+	"tools": {"/tools", "List the tools the model can use", (*REPL).listTools},
+	"reset": {"/reset", "Start a new conversation", (*REPL).reset},
+	"conversations": {"/conversations", "List saved conversations",
+		func(r *REPL, ctx context.Context, args string) error {
+			if r.app.History == nil {
+				return fmt.Errorf("conversation history is disabled")
+			}
+			return manageHistory(r.app.History, true, "")
+		}},
+	"resume": {"/resume <id>", "Continue a saved conversation", (*REPL).resume},
+	"delete": {"/delete <id>", "Delete a saved conversation",
+		func(r *REPL, ctx context.Context, args string) error {
+			if r.app.History == nil {
+				return fmt.Errorf("conversation history is disabled")
+			}
+			return manageHistory(r.app.History, false, args)
+		}},
+}
+
+// NewREPL creates a REPL printing to out, in color if color is set
+func NewREPL(app *App, out io.Writer, color, verbose bool) *REPL {
+	r := &REPL{app: app, out: out, color: color, verbose: verbose}
+	app.OnText = func(text string) {
+		fmt.Fprint(r.out, text)
+	}
+	app.OnToolCall = r.traceToolCall
+	return r
+}
+
+// Run reads requests and commands from in until /quit or end of input.
+// Ctrl+C cancels the request in progress.
+func (r *REPL) Run(ctx context.Context, in io.Reader) {
+	fmt.Fprintf(r.out, "HWP chat ready with %d tools. Type a request, /help for commands or /quit to exit.\n", len(r.app.Tools()))
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for {
+		fmt.Fprint(r.out, r.paint(colorGreen, "> "))
+		if !scanner.Scan() {
+			fmt.Fprintln(r.out)
+			return
+		}
+		input := strings.TrimSpace(scanner.Text())
+		if input == "" {
+			continue
+		}
+		if input == "/quit" || input == "/exit" {
+			return
+		}
+
+		turnCtx, stop := signal.NotifyContext(ctx, os.Interrupt)
+		if strings.HasPrefix(input, "/") {
+			r.runCommand(turnCtx, input)
+		} else if _, err := r.app.ProcessChatWithMCP(turnCtx, input); err != nil {
+			fmt.Fprintln(r.out)
+			r.printError(err)
+		} else {
+			fmt.Fprintln(r.out)
+		}
+		stop()
+	}
+}
+
+// runCommand runs a /command line
+func (r *REPL) runCommand(ctx context.Context, line string) {
+	name, args, _ := strings.Cut(strings.TrimPrefix(line, "/"), " ")
+	if name == "help" {
+		r.help()
+		return
+	}
+	command, ok := replCommands[name]
+	if !ok {
+		r.printError(fmt.Errorf("unknown command /%s (see /help)", name))
+		return
+	}
+	if err := command.run(r, ctx, strings.TrimSpace(args)); err != nil {
+		r.printError(err)
+	}
+}
+
+// help lists the commands
+func (r *REPL) help() {
+	names := make([]string, 0, len(replCommands))
+	for name := range replCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		command := replCommands[name]
+		fmt.Fprintf(r.out, "  %-16s %s\n", command.usage, command.description)
+	}
+	fmt.Fprintf(r.out, "  %-16s %s\n", "/help", "Show the commands")
+	fmt.Fprintf(r.out, "  %-16s %s\n", "/quit", "Exit")
+}
+
+func (r *REPL) open(ctx context.Context, path string) error {
+	if path == "" {
+		return fmt.Errorf("usage: /open <path>")
+	}
+	return r.runTool(ctx, "hwp_open", map[string]interface{}{"path": path},
+		fmt.Sprintf("the user opened %s", path))
+}
+
+func (r *REPL) save(ctx context.Context, path string) error {
+	arguments := map[string]interface{}{}
+	note := "the user saved the document"
+	if path != "" {
+		arguments["path"] = path
+		note = fmt.Sprintf("the user saved the document as %s", path)
+	}
+	return r.runTool(ctx, "hwp_save", arguments, note)
+}
+
+// runTool calls a tool for a command and tells the model what happened
+func (r *REPL) runTool(ctx context.Context, name string, arguments map[string]interface{}, note string) error {
+	started := time.Now()
+	result, err := r.app.CallTool(ctx, name, arguments)
+	if err != nil {
+		return err
+	}
+	r.traceToolCall(ToolCall{Name: name, Arguments: arguments}, result, time.Since(started))
+	if !result.Failed() {
+		r.app.AddNote(note)
+	}
+	return nil
+}
+
+func (r *REPL) listTools(ctx context.Context, args string) error {
+	for _, tool := range r.app.Tools() {
+		description, _, _ := strings.Cut(tool.Description, ". ")
+		fmt.Fprintf(r.out, "  %s %s\n", r.paint(colorCyan, tool.Name), r.paint(colorDim, truncateRunes(description, 80)))
+	}
+	return nil
+}
+
+func (r *REPL) reset(ctx context.Context, args string) error {
+	r.app.NewConversation()
+	fmt.Fprintln(r.out, "Started a new conversation")
+	return nil
+}
+
+func (r *REPL) resume(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("usage: /resume <id>")
+	}
+	if err := r.app.ResumeConversation(id); err != nil {
+		return err
+	}
+	fmt.Fprintf(r.out, "Resumed conversation %s\n", id)
+	return nil
+}
+
+// traceToolCall prints a tool call and its outcome
+func (r *REPL) traceToolCall(call ToolCall, result *ToolResult, elapsed time.Duration) {
+	arguments, _ := json.Marshal(call.Arguments)
+	fmt.Fprintf(r.out, "%s %s %s\n", r.paint(colorYellow, "→"), r.paint(colorCyan, call.Name),
+		r.paint(colorDim, truncateRunes(string(arguments), 100)))
+
+	elapsedText := elapsed.Round(time.Millisecond).String()
+	summary := truncateRunes(result.Text, 100)
+	if r.verbose {
+		summary = result.Text
+	}
+	if result.Failed() {
+		fmt.Fprintf(r.out, "  %s %s %s\n", r.paint(colorRed, "✗"), r.paint(colorDim, elapsedText), r.paint(colorRed, summary))
+		return
+	}
+	fmt.Fprintf(r.out, "  %s %s %s\n", r.paint(colorGreen, "✓"), r.paint(colorDim, elapsedText), summary)
+}
+
+func (r *REPL) printError(err error) {
+	fmt.Fprintln(r.out, r.paint(colorRed, "Error: "+err.Error()))
+}
+
+// paint colors text if color output is on
+func (r *REPL) paint(color, text string) string {
+	if !r.color {
+		return text
+	}
+	return color + text + colorReset
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// TurnTrace is the timing of one turn: each model request and the tool calls
+// it asked for. Traces are written as one JSON object per line.
+type TurnTrace struct {
+	ConversationID string       `json:"conversation_id,omitempty"`
+	Input          string       `json:"input"`
+	Started        time.Time    `json:"started"`
+	DurationMS     int64        `json:"duration_ms"`
+	Usage          Usage        `json:"usage"`
+	Rounds         []RoundTrace `json:"rounds"`
+	Error          string       `json:"error,omitempty"`
+}
+
+// RoundTrace is one model request and the tool calls it returned
+type RoundTrace struct {
+	Round        int             `json:"round"`
+	LLMMS        int64           `json:"llm_ms"`
+	Usage        Usage           `json:"usage"`
+	ToolsOffered bool            `json:"tools_offered"`
+	ToolCalls    []ToolCallTrace `json:"tool_calls,omitempty"`
+}
+
+// ToolCallTrace is one MCP tool call
+type ToolCallTrace struct {
+	Name        string `json:"name"`
+	DurationMS  int64  `json:"duration_ms"`
+	ResultChars int    `json:"result_chars"`
+	SentChars   int    `json:"sent_chars"` // after truncation
+	Failed      bool   `json:"failed,omitempty"`
+}
+
+// Tracer appends turn traces to a JSONL file
+type Tracer struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewTracer opens path for appending, creating it if needed. Traces hold the
+// user's input, so the file is readable by the user only.
+func NewTracer(path string) (*Tracer, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace file: %w", err)
+	}
+	if err := file.Chmod(0o600); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to restrict trace file: %w", err)
+	}
+	return &Tracer{file: file}, nil
+}
+
+// Write appends a turn trace
+func (t *Tracer) Write(turn *TurnTrace) error {
+	encoded, err := json.Marshal(turn)
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, err := t.file.Write(append(encoded, '\n')); err != nil {
+		return fmt.Errorf("failed to write trace: %w", err)
+	}
+	return nil
+}
+
+// Close closes the trace file
+func (t *Tracer) Close() error {
+	return t.file.Close()
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed web
+var webFiles embed.FS
+
+// webServer serves the chat web UI. There is one HWP instance, so there is
+// one conversation and requests are handled one at a time.
+type webServer struct {
+	app *App
+	mu  sync.Mutex
+}
+
+// serveWeb serves the web UI on addr until ctx is done
+func serveWeb(ctx context.Context, app *App, addr string) error {
+	static, err := fs.Sub(webFiles, "web")
+	if err != nil {
+		return err
+	}
+
+	w := &webServer{app: app}
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.FS(static)))
+	mux.HandleFunc("/api/chat", w.handleChat)
+	mux.HandleFunc("/api/reset", w.handleReset)
+
+	httpServer := &http.Server{Addr: addr, Handler: checkHost(addr, mux)}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("HWP chat web UI on http://%s", displayAddr(addr))
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// handleChat runs one message and streams the reply as server-sent events:
+// text (reply text), tool (a tool call and its result), done and error
+func (w *webServer) handleChat(rw http.ResponseWriter, r *http.Request) {
+	if !checkAPIRequest(rw, r) {
+		return
+	}
+	var request struct {
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || strings.TrimSpace(request.Message) == "" {
+		http.Error(rw, "message is required", http.StatusBadRequest)
+		return
+	}
+	flusher, ok := rw.(http.Flusher)
+	if !ok {
+		http.Error(rw, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	if !w.mu.TryLock() {
+		http.Error(rw, "another request is in progress", http.StatusConflict)
+		return
+	}
+	defer w.mu.Unlock()
+
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	send := func(event string, data interface{}) {
+		encoded, _ := json.Marshal(data)
+		fmt.Fprintf(rw, "event: %s\ndata: %s\n\n", event, encoded)
+		flusher.Flush()
+	}
+
+	w.app.OnText = func(text string) {
+		send("text", map[string]string{"text": text})
+	}
+	w.app.OnToolCall = func(call ToolCall, result *ToolResult, elapsed time.Duration) {
+		send("tool", map[string]interface{}{
+			"name":       call.Name,
+			"arguments":  call.Arguments,
+			"result":     truncateRunes(result.Text, 500),
+			"error":      result.Failed(),
+			"elapsed_ms": elapsed.Milliseconds(),
+		})
+	}
+	defer func() {
+		w.app.OnText = nil
+		w.app.OnToolCall = nil
+	}()
+
+	// A closed browser tab cancels the request
+	if _, err := w.app.ProcessChatWithMCP(r.Context(), request.Message); err != nil {
+		send("error", map[string]string{"message": err.Error()})
+		return
+	}
+	send("done", map[string]string{"conversation_id": w.app.ConversationID()})
+}
+
+// handleReset starts a new conversation
+func (w *webServer) handleReset(rw http.ResponseWriter, r *http.Request) {
+	if !checkAPIRequest(rw, r) {
+		return
+	}
+	if !w.mu.TryLock() {
+		http.Error(rw, "another request is in progress", http.StatusConflict)
+		return
+	}
+	defer w.mu.Unlock()
+
+	w.app.NewConversation()
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// checkAPIRequest answers API requests that other web pages could have sent
+// and reports whether r may go on. A page on another site can POST plain
+// text here without a CORS preflight, which would chat on the user's API
+// key and run HWP tools, so only JSON from a page of this server is taken.
+func checkAPIRequest(rw http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return false
+	}
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+		http.Error(rw, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+		return false
+	}
+	// Browsers send Origin with every cross-origin POST; other clients may
+	// leave it out
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if u, err := url.Parse(origin); err != nil || u.Host != r.Host {
+			http.Error(rw, "cross-origin requests are not allowed", http.StatusForbidden)
+			return false
+		}
+	}
+	return true
+}
+
+// loopbackHosts are the names of this machine a browser may use for the UI
+var loopbackHosts = []string{"localhost", "127.0.0.1", "::1"}
+
+// checkHost refuses requests whose Host header is neither the listen
+// address addr nor a loopback name with its port. Checking Origin against
+// Host alone does not stop DNS rebinding: a page whose own domain has been
+// made to resolve to 127.0.0.1 is same-origin with itself, but its requests
+// carry its domain as Host.
+func checkHost(addr string, next http.Handler) http.Handler {
+	listenHost, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		listenHost, port = addr, "80"
+	}
+	allowed := map[string]bool{}
+	for _, host := range append([]string{listenHost}, loopbackHosts...) {
+		if host != "" {
+			allowed[strings.ToLower(net.JoinHostPort(host, port))] = true
+		}
+	}
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		host := strings.ToLower(r.Host)
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			host = net.JoinHostPort(strings.Trim(host, "[]"), "80")
+		}
+		if !allowed[host] {
+			http.Error(rw, "unknown host", http.StatusMisdirectedRequest)
+			return
+		}
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// displayAddr makes a listen address like ":8090" usable in a URL
+func displayAddr(addr string) string {
+	if strings.HasPrefix(addr, ":") {
+		return "localhost" + addr
+	}
+	return addr
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCheckHost(t *testing.T) {
+	ok := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if checkAPIRequest(rw, r) {
+			rw.WriteHeader(http.StatusNoContent)
+		}
+	})
+	tests := []struct {
+		name   string
+		addr   string
+		host   string
+		origin string
+		want   int
+	}{
+		{name: "listen address", addr: "127.0.0.1:8090", host: "127.0.0.1:8090", origin: "http://127.0.0.1:8090", want: http.StatusNoContent},
+		{name: "localhost", addr: "127.0.0.1:8090", host: "localhost:8090", origin: "http://localhost:8090", want: http.StatusNoContent},
+		{name: "IPv6 loopback", addr: ":8090", host: "[::1]:8090", want: http.StatusNoContent},
+		{name: "configured name", addr: "hwp-desk:8090", host: "HWP-DESK:8090", want: http.StatusNoContent},
+		{name: "DNS rebinding", addr: "127.0.0.1:8090", host: "attacker.example:8090", origin: "http://attacker.example:8090", want: http.StatusMisdirectedRequest},
+		{name: "foreign host without port", addr: "127.0.0.1:8090", host: "attacker.example", origin: "http://attacker.example", want: http.StatusMisdirectedRequest},
+		{name: "other port", addr: "127.0.0.1:8090", host: "localhost:9000", want: http.StatusMisdirectedRequest},
+		{name: "foreign origin", addr: "127.0.0.1:8090", host: "127.0.0.1:8090", origin: "http://attacker.example", want: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/api/reset", strings.NewReader("{}"))
+			r.Host = tt.host
+			r.Header.Set("Content-Type", "application/json")
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			rw := httptest.NewRecorder()
+			checkHost(tt.addr, ok).ServeHTTP(rw, r)
+			if rw.Code != tt.want {
+				t.Errorf("Host %s, Origin %q: status %d, want %d", tt.host, tt.origin, rw.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	hwpmcp "hwp-mcp-go"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Windows service accounts
+const (
+	// defaultServiceUser has no rights beyond the local machine's basics,
+	// unlike LocalSystem, which the service manager uses when none is given
+	defaultServiceUser = `NT AUTHORITY\LocalService`
+	servicePasswordEnv = "HWP_SERVICE_PASSWORD"
+)
+
+// serviceAccount is the account the Windows service runs as
+type serviceAccount struct {
+	user     string
+	password string
+}
+
+// newMCPServer creates and configures the MCP server with all HWP tools
+func newMCPServer(opts hwpmcp.Options, subscriptions *hwpmcp.Subscriptions) (*server.MCPServer, error) {
+	hooks := &server.Hooks{}
+	subscriptions.AddHooks(hooks)
+	mcpServer := server.NewMCPServer(
+		"hwp-mcp-go",
+		"1.0.0",
+		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(true, false),
+		server.WithHooks(hooks),
+	)
+
+	if err := hwpmcp.RegisterTools(mcpServer, opts); err != nil {
+		return nil, err
+	}
+	return mcpServer, nil
+}
+
+func main() {
+	// Cleanup on exit
+	defer hwpmcp.Close()
+
+	backend := flag.String("backend", os.Getenv("HWP_BACKEND"),
+		"Document backend: com (live HWP through COM) or hwpx (write HWPX files directly, no HWP needed). Defaults to com on Windows and hwpx elsewhere")
+	watchInterval := flag.Duration("watch-interval", 2*time.Second,
+		"How often to check the open document for changes, including edits made by hand in HWP, while a client is subscribed to hwp://current/text (0 disables)")
+	transport := flag.String("transport", transportStdio,
+		"MCP transport: stdio, sse or http (streamable HTTP)")
+	addr := flag.String("addr", "127.0.0.1:8080",
+		"Listen address for the sse and http transports; listening beyond this machine lets anyone with the token write files as the server's account")
+	authToken := flag.String("auth-token", os.Getenv(authTokenEnv),
+		"Bearer token the sse and http transports require of every request (default from "+authTokenEnv+")")
+	authTokenFile := flag.String("auth-token-file", "",
+		"File holding the bearer token, used instead of -auth-token; the Windows service requires it so the token stays out of the service configuration")
+	metricsAddr := flag.String("metrics-addr", "",
+		"Listen address for a Prometheus /metrics endpoint, which takes the bearer token like the network transports (disabled if empty)")
+	dryRun := flag.Bool("dry-run", false,
+		"Make every destructive tool (save, close, revert, protect, import, clean formatting, transform, convert) only report what it would do")
+	recentFiles := flag.String("recent-files", hwpmcp.DefaultRecentFilesPath(),
+		"JSON file recording the documents opened and saved, listed by hwp_list_recent (empty turns tracking off)")
+	allowedDirs := flag.String("allowed-dirs", os.Getenv("HWP_ALLOWED_DIRS"),
+		"Directories hwp_list_files may browse, separated by "+string(os.PathListSeparator)+" (none if empty)")
+	presets := flag.String("presets", os.Getenv("HWP_PRESETS"),
+		"JSON file of style presets (font, size, bold, color, spacing by name) used by hwp_apply_preset and the built-in document types")
+	service := flag.String("service", "",
+		"Windows service control: install, uninstall, start or stop. install registers the server with the other flags given; run is used by the service manager")
+	serviceUser := flag.String("service-user", defaultServiceUser,
+		"Account the Windows service runs as; a user account takes its password from "+servicePasswordEnv)
+	flag.Parse()
+
+	// Create and configure MCP server
+	subscriptions := hwpmcp.NewSubscriptions()
+	mcpServer, err := newMCPServer(hwpmcp.Options{Backend: *backend, DryRun: *dryRun, Resources: true, RecentFiles: *recentFiles, AllowedDirs: filepath.SplitList(*allowedDirs), Presets: *presets}, subscriptions)
+	if err != nil {
+		log.Fatalf("Invalid options: %v", err)
+	}
+
+	token, err := readAuthToken(*authToken, *authTokenFile)
+	if err != nil {
+		log.Fatalf("Invalid options: %v", err)
+	}
+	if *metricsAddr != "" && token == "" {
+		log.Fatalf("Invalid options: -metrics-addr needs a token (-auth-token-file or %s)", authTokenEnv)
+	}
+
+	run := func(ctx context.Context) error {
+		fmt.Fprintf(os.Stderr, "Starting HWP MCP Go server (%s backend, %s transport)\n", hwpmcp.Backend(), *transport)
+
+		// Notify subscribed clients about document changes
+		if *watchInterval > 0 {
+			go hwpmcp.WatchDocument(ctx, mcpServer, subscriptions, *watchInterval)
+		}
+
+		if *metricsAddr != "" {
+			go func() {
+				if err := serveMetrics(ctx, *metricsAddr, token); err != nil {
+					log.Printf("Metrics endpoint error: %v", err)
+				}
+			}()
+		}
+
+		return serve(ctx, mcpServer, subscriptions, *transport, *addr, token)
+	}
+
+	if *service != "" {
+		// A service has no console, so it always listens on the network
+		if *transport == transportStdio {
+			*transport = transportHTTP
+		}
+		if *service == "install" && *authTokenFile == "" {
+			log.Fatalf("Service install needs -auth-token-file, readable by the service account only")
+		}
+		args := []string{"-service", "run", "-transport", *transport, "-addr", *addr, "-auth-token-file", *authTokenFile, "-watch-interval", watchInterval.String()}
+		if *backend != "" {
+			args = append(args, "-backend", *backend)
+		}
+		if *metricsAddr != "" {
+			args = append(args, "-metrics-addr", *metricsAddr)
+		}
+		if *dryRun {
+			args = append(args, "-dry-run")
+		}
+		args = append(args, "-recent-files", *recentFiles)
+		if *allowedDirs != "" {
+			args = append(args, "-allowed-dirs", *allowedDirs)
+		}
+		if *presets != "" {
+			args = append(args, "-presets", *presets)
+		}
+		account := serviceAccount{user: *serviceUser, password: os.Getenv(servicePasswordEnv)}
+		if err := controlService(*service, args, account, run); err != nil {
+			log.Fatalf("Service %s failed: %v", *service, err)
+		}
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := run(ctx); err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !windows
+
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// controlService is only available on Windows
+func controlService(command string, args []string, account serviceAccount, run func(ctx context.Context) error) error {
+	return fmt.Errorf("service mode is only supported on Windows")
+}
@@ -0,0 +1,218 @@
+//go:build windows
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/eventlog"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// Windows service registration
+const (
+	serviceName        = "hwp-mcp-go"
+	serviceDisplayName = "HWP MCP Server"
+	serviceDescription = "Serves HWP document tools to MCP clients over the network"
+)
+
+// Event log ids
+const (
+	eventStarted = 1
+	eventStopped = 2
+	eventFailed  = 3
+)
+
+// serviceRestartDelay is how long the service manager waits before restarting
+// the service after a failure
+const serviceRestartDelay = 5 * time.Second
+
+// controlService carries out a -service command. run serves until its context
+// is cancelled; it is used when the service manager starts the service.
+func controlService(command string, args []string, account serviceAccount, run func(ctx context.Context) error) error {
+	switch command {
+	case "install":
+		return installService(args, account)
+	case "uninstall":
+		return removeService()
+	case "start":
+		return startService()
+	case "stop":
+		return stopService()
+	case "run":
+		return svc.Run(serviceName, &hwpService{run: run})
+	}
+	return fmt.Errorf("unknown service command: %s (use install, uninstall, start, stop or run)", command)
+}
+
+// installService registers the service to start automatically as account
+// with args, restart after failures and report to the Application event log
+func installService(args []string, account serviceAccount) error {
+	exePath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find executable: %v", err)
+	}
+	exePath, err = filepath.Abs(exePath)
+	if err != nil {
+		return err
+	}
+
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the service manager: %v", err)
+	}
+	defer m.Disconnect()
+
+	if s, err := m.OpenService(serviceName); err == nil {
+		s.Close()
+		return fmt.Errorf("service %s is already installed", serviceName)
+	}
+
+	s, err := m.CreateService(serviceName, exePath, mgr.Config{
+		DisplayName:      serviceDisplayName,
+		Description:      serviceDescription,
+		StartType:        mgr.StartAutomatic,
+		ServiceStartName: account.user,
+		Password:         account.password,
+	}, args...)
+	if err != nil {
+		return fmt.Errorf("failed to create service: %v", err)
+	}
+	defer s.Close()
+
+	// Restart after each failure; the failure count resets after a day
+	restart := mgr.RecoveryAction{Type: mgr.ServiceRestart, Delay: serviceRestartDelay}
+	if err := s.SetRecoveryActions([]mgr.RecoveryAction{restart, restart, restart}, uint32((24 * time.Hour).Seconds())); err != nil {
+		s.Delete()
+		return fmt.Errorf("failed to set recovery actions: %v", err)
+	}
+
+	if err := eventlog.InstallAsEventCreate(serviceName, eventlog.Error|eventlog.Warning|eventlog.Info); err != nil {
+		s.Delete()
+		return fmt.Errorf("failed to register event log source: %v", err)
+	}
+	return nil
+}
+
+// removeService deletes the service and its event log source
+func removeService() error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the service manager: %v", err)
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(serviceName)
+	if err != nil {
+		return fmt.Errorf("service %s is not installed", serviceName)
+	}
+	defer s.Close()
+
+	if err := s.Delete(); err != nil {
+		return fmt.Errorf("failed to delete service: %v", err)
+	}
+	if err := eventlog.Remove(serviceName); err != nil {
+		return fmt.Errorf("failed to remove event log source: %v", err)
+	}
+	return nil
+}
+
+// startService asks the service manager to start the service
+func startService() error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the service manager: %v", err)
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(serviceName)
+	if err != nil {
+		return fmt.Errorf("service %s is not installed", serviceName)
+	}
+	defer s.Close()
+
+	if err := s.Start(); err != nil {
+		return fmt.Errorf("failed to start service: %v", err)
+	}
+	return nil
+}
+
+// stopService asks the service manager to stop the service
+func stopService() error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the service manager: %v", err)
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(serviceName)
+	if err != nil {
+		return fmt.Errorf("service %s is not installed", serviceName)
+	}
+	defer s.Close()
+
+	if _, err := s.Control(svc.Stop); err != nil {
+		return fmt.Errorf("failed to stop service: %v", err)
+	}
+	return nil
+}
+
+// hwpService runs the server under the service manager
+type hwpService struct {
+	run func(ctx context.Context) error
+}
+
+// Execute implements svc.Handler
+func (s *hwpService) Execute(args []string, requests <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+	changes <- svc.Status{State: svc.StartPending}
+
+	elog, err := eventlog.Open(serviceName)
+	if err != nil {
+		return true, 1
+	}
+	defer elog.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- s.run(ctx)
+	}()
+
+	changes <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+	elog.Info(eventStarted, fmt.Sprintf("%s started", serviceDisplayName))
+
+	for {
+		select {
+		case err := <-done:
+			if err == nil {
+				err = fmt.Errorf("server exited unexpectedly")
+			}
+			elog.Error(eventFailed, fmt.Sprintf("%s failed: %v", serviceDisplayName, err))
+			// Exit without reporting SERVICE_STOPPED so the service manager
+			// treats this as a failure and applies the restart recovery action
+			elog.Close()
+			os.Exit(1)
+
+		case request := <-requests:
+			switch request.Cmd {
+			case svc.Interrogate:
+				changes <- request.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				changes <- svc.Status{State: svc.StopPending}
+				cancel()
+				if err := <-done; err != nil {
+					elog.Warning(eventStopped, fmt.Sprintf("%s stopped with error: %v", serviceDisplayName, err))
+				} else {
+					elog.Info(eventStopped, fmt.Sprintf("%s stopped", serviceDisplayName))
+				}
+				return false, 0
+			}
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	hwpmcp "hwp-mcp-go"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Transports
+const (
+	transportStdio = "stdio"
+	transportSSE   = "sse"
+	transportHTTP  = "http"
+)
+
+// shutdownTimeout bounds how long network transports wait for open requests
+const shutdownTimeout = 5 * time.Second
+
+// httpTransport is a network transport of mcp-go
+type httpTransport interface {
+	Start(addr string) error
+	Shutdown(ctx context.Context) error
+}
+
+// serve runs the MCP server on a transport until ctx is done or the transport
+// fails. The stdio transport ends when its input is closed. The network
+// transports refuse requests without the bearer token, since any tool can
+// write files as the account the server runs as.
+func serve(ctx context.Context, mcpServer *server.MCPServer, subscriptions *hwpmcp.Subscriptions, transport, addr, token string) error {
+	switch transport {
+	case transportStdio:
+		return serveStdio(ctx, mcpServer, subscriptions)
+	case transportSSE, transportHTTP:
+	default:
+		return fmt.Errorf("unknown transport: %s (use %s, %s or %s)", transport, transportStdio, transportSSE, transportHTTP)
+	}
+	if token == "" {
+		return fmt.Errorf("the %s transport needs a token: set -auth-token-file or %s", transport, authTokenEnv)
+	}
+
+	srv := &http.Server{}
+	if transport == transportSSE {
+		sse := server.NewSSEServer(mcpServer, server.WithHTTPServer(srv))
+		srv.Handler = requireToken(token, handleSubscriptions(subscriptions, sse, func(r *http.Request) string {
+			return r.URL.Query().Get("sessionId")
+		}, func(w http.ResponseWriter, r *http.Request, response any) {
+			// SSE answers come over the event stream, not the POST
+			if err := sse.SendEventToSession(r.URL.Query().Get("sessionId"), response); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		return serveHTTP(ctx, sse, addr)
+	}
+	streamable := server.NewStreamableHTTPServer(mcpServer, server.WithStreamableHTTPServer(srv))
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", handleSubscriptions(subscriptions, streamable, func(r *http.Request) string {
+		return r.Header.Get("Mcp-Session-Id")
+	}, func(w http.ResponseWriter, r *http.Request, response any) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	srv.Handler = requireToken(token, mux)
+	return serveHTTP(ctx, streamable, addr)
+}
+
+// handleSubscriptions answers resources/subscribe and resources/unsubscribe
+// POSTs with reply, which sends the answer the way the transport does, and
+// passes other requests to next. session reads the request's session ID.
+func handleSubscriptions(subscriptions *hwpmcp.Subscriptions, next http.Handler, session func(*http.Request) string, reply func(http.ResponseWriter, *http.Request, any)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read the request", http.StatusBadRequest)
+			return
+		}
+		if response, handled := subscriptions.HandleMessage(session(r), body); handled {
+			reply(w, r, response)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// stdioSessionID is the ID mcp-go gives the single stdio session
+const stdioSessionID = "stdio"
+
+// lockedWriter serializes writes, so answers written here don't interleave
+// with the stdio server's
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+// serveStdio serves over standard input and output, answering subscription
+// requests itself and passing every other line on to the stdio server
+func serveStdio(ctx context.Context, mcpServer *server.MCPServer, subscriptions *hwpmcp.Subscriptions) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stdout := &lockedWriter{w: os.Stdout}
+	input, forward := io.Pipe()
+	go func() {
+		reader := bufio.NewReader(os.Stdin)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(line) > 0 {
+				if response, handled := subscriptions.HandleMessage(stdioSessionID, line); handled {
+					encoded, _ := json.Marshal(response)
+					fmt.Fprintf(stdout, "%s\n", encoded)
+				} else if _, err := forward.Write(line); err != nil {
+					return
+				}
+			}
+			if err != nil {
+				forward.CloseWithError(err)
+				return
+			}
+		}
+	}()
+
+	return server.NewStdioServer(mcpServer).Listen(ctx, input, stdout)
+}
+
+// authTokenEnv is the environment variable holding the network transport token
+const authTokenEnv = "HWP_MCP_AUTH_TOKEN"
+
+// readAuthToken returns the token of the network transports: the contents of
+// file if given, otherwise token
+func readAuthToken(token, file string) (string, error) {
+	if file == "" {
+		return strings.TrimSpace(token), nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the auth token: %v", err)
+	}
+	token = strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("the auth token file %s is empty", file)
+	}
+	return token, nil
+}
+
+// requireToken passes on only requests carrying "Authorization: Bearer
+// <token>"
+func requireToken(token string, next http.Handler) http.Handler {
+	want := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(got, want) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// serveHTTP starts a network transport and shuts it down when ctx is done
+func serveHTTP(ctx context.Context, transport httpTransport, addr string) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- transport.Start(addr)
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return transport.Shutdown(shutdownCtx)
+	}
+}
+
+// metricsServer serves the Prometheus endpoint
+type metricsServer struct {
+	*http.Server
+}
+
+func (s metricsServer) Start(addr string) error {
+	s.Addr = addr
+	return s.ListenAndServe()
+}
+
+// serveMetrics serves /metrics until ctx is done. Like the network
+// transports it needs the bearer token, as the metrics name the tools called
+// and their errors.
+func serveMetrics(ctx context.Context, addr, token string) error {
+	if token == "" {
+		return fmt.Errorf("the metrics endpoint needs a token: set -auth-token-file or %s", authTokenEnv)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", hwpmcp.MetricsHandler())
+	return serveHTTP(ctx, metricsServer{&http.Server{Handler: requireToken(token, mux)}}, addr)
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// benchScenario is one measured tool call, repeated for each iteration
+type benchScenario struct {
+	name  string
+	setup func(client *MCPTestClient) error // runs once before timing, may be nil
+	tool  string
+	args  map[string]interface{}
+}
+
+// benchStats summarizes the latencies of one scenario
+type benchStats struct {
+	name      string
+	ops       int
+	total     time.Duration
+	p50       time.Duration
+	p95       time.Duration
+	max       time.Duration
+	opsPerSec float64
+}
+
+// parseTableSizes reads a comma-separated list of ROWSxCOLS table sizes
+func parseTableSizes(list string) ([][2]int, error) {
+	var sizes [][2]int
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		rows, cols, ok := strings.Cut(item, "x")
+		r, rowErr := strconv.Atoi(rows)
+		c, colErr := strconv.Atoi(cols)
+		if !ok || rowErr != nil || colErr != nil || r < 1 || c < 1 {
+			return nil, fmt.Errorf("invalid table size %q, expected ROWSxCOLS such as 10x5", item)
+		}
+		sizes = append(sizes, [2]int{r, c})
+	}
+	return sizes, nil
+}
+
+// benchScenarios returns the insert_text, fill_table_with_data (one per
+// table size) and batch_operations scenarios
+func benchScenarios(tableSizes [][2]int) []benchScenario {
+	scenarios := []benchScenario{{
+		name: "insert_text",
+		tool: "hwp_insert_text",
+		args: map[string]interface{}{"text": "벤치마크 문장입니다. Benchmark sentence. "},
+	}}
+
+	for _, size := range tableSizes {
+		rows, cols := size[0], size[1]
+		scenarios = append(scenarios, benchScenario{
+			name: fmt.Sprintf("fill_table_with_data %dx%d", rows, cols),
+			setup: func(client *MCPTestClient) error {
+				_, err := client.CallTool("hwp_insert_table", map[string]interface{}{"rows": rows, "cols": cols})
+				return err
+			},
+			tool: "hwp_fill_table_with_data",
+			args: map[string]interface{}{"data": benchTableData(rows, cols)},
+		})
+	}
+
+	scenarios = append(scenarios, benchScenario{
+		name: "batch_operations",
+		tool: "hwp_batch_operations",
+		args: map[string]interface{}{"operations": []interface{}{
+			map[string]interface{}{"type": "insert_text", "text": "배치 "},
+			map[string]interface{}{"type": "set_font", "name": "바탕", "size": 11, "bold": true},
+			map[string]interface{}{"type": "insert_text", "text": "굵게"},
+			map[string]interface{}{"type": "insert_paragraph"},
+		}},
+	})
+	return scenarios
+}
+
+// benchTableData builds rows x cols of cell text
+func benchTableData(rows, cols int) [][]interface{} {
+	data := make([][]interface{}, rows)
+	for r := range data {
+		data[r] = make([]interface{}, cols)
+		for c := range data[r] {
+			data[r][c] = fmt.Sprintf("R%dC%d", r+1, c+1)
+		}
+	}
+	return data
+}
+
+// runBenchmarks creates a document and times iterations calls of each
+// scenario, printing ops/sec and latency percentiles. Each scenario is added
+// to report; a scenario fails on its first failing call.
+func runBenchmarks(client *MCPTestClient, iterations int, tableSizes [][2]int, report *Report) error {
+	if _, err := client.CallTool("hwp_create", map[string]interface{}{}); err != nil {
+		return fmt.Errorf("failed to create a document: %v", err)
+	}
+
+	var results []benchStats
+	for _, scenario := range benchScenarios(tableSizes) {
+		fmt.Printf("⏱️  %s (%d iterations)...\n", scenario.name, iterations)
+		started := time.Now()
+		stats, err := runBenchScenario(client, scenario, iterations)
+		report.Add("bench/"+scenario.name, started, err)
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", scenario.name, err)
+			continue
+		}
+		results = append(results, stats)
+	}
+
+	if _, err := client.CallTool("hwp_close", map[string]interface{}{"discard_changes": true}); err != nil {
+		fmt.Printf("⚠️  Failed to close the benchmark document: %v\n", err)
+	}
+
+	fmt.Printf("\n%-32s %6s %10s %10s %10s %10s\n", "scenario", "ops", "ops/sec", "p50", "p95", "max")
+	for _, stats := range results {
+		fmt.Printf("%-32s %6d %10.1f %10s %10s %10s\n", stats.name, stats.ops, stats.opsPerSec,
+			stats.p50.Round(10*time.Microsecond), stats.p95.Round(10*time.Microsecond), stats.max.Round(10*time.Microsecond))
+	}
+	return nil
+}
+
+// runBenchScenario runs the setup of scenario, then calls its tool iterations times
+func runBenchScenario(client *MCPTestClient, scenario benchScenario, iterations int) (benchStats, error) {
+	if scenario.setup != nil {
+		if err := scenario.setup(client); err != nil {
+			return benchStats{}, fmt.Errorf("setup failed: %v", err)
+		}
+	}
+
+	latencies := make([]time.Duration, 0, iterations)
+	for i := 0; i < iterations; i++ {
+		started := time.Now()
+		result, err := client.CallTool(scenario.tool, scenario.args)
+		if err != nil {
+			return benchStats{}, err
+		}
+		if result.IsError || strings.HasPrefix(result.Text, "Error") {
+			return benchStats{}, fmt.Errorf("%s", result.Text)
+		}
+		latencies = append(latencies, time.Since(started))
+	}
+	return summarizeLatencies(scenario.name, latencies), nil
+}
+
+// summarizeLatencies computes the throughput and percentiles of latencies
+func summarizeLatencies(name string, latencies []time.Duration) benchStats {
+	stats := benchStats{name: name, ops: len(latencies)}
+	if len(latencies) == 0 {
+		return stats
+	}
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for _, latency := range sorted {
+		stats.total += latency
+	}
+	stats.p50 = percentile(sorted, 0.50)
+	stats.p95 = percentile(sorted, 0.95)
+	stats.max = sorted[len(sorted)-1]
+	stats.opsPerSec = float64(stats.ops) / stats.total.Seconds()
+	return stats
+}
+
+// percentile returns the nearest-rank percentile p (0-1) of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
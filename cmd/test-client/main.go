@@ -0,0 +1,645 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MCP Protocol structures
+type MCPRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      int         `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+type MCPResponse struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      int         `json:"id"`
+	Result  interface{} `json:"result,omitempty"`
+	Error   *MCPError   `json:"error,omitempty"`
+}
+
+type MCPError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type InitializeParams struct {
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    ClientCapabilities `json:"capabilities"`
+	ClientInfo      ClientInfo         `json:"clientInfo"`
+}
+
+type ClientCapabilities struct {
+	Roots    *RootsCapability    `json:"roots,omitempty"`
+	Sampling *SamplingCapability `json:"sampling,omitempty"`
+}
+
+type RootsCapability struct {
+	ListChanged bool `json:"listChanged,omitempty"`
+}
+
+type SamplingCapability struct{}
+
+type ClientInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type ToolCallParams struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+}
+
+// ClientConfig selects the server the test client starts
+type ClientConfig struct {
+	Server  string        // server executable
+	Args    []string      // arguments passed to every server start
+	Timeout time.Duration // how long to wait for each response
+
+	Recorder *SessionRecorder // saves the session when set
+}
+
+// Test client
+type MCPTestClient struct {
+	config ClientConfig
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+	stderr io.ReadCloser
+	reader *bufio.Scanner
+	reqID  int
+
+	stderrMu   sync.Mutex
+	stderrLog  strings.Builder
+	stderrDone chan struct{}
+
+	// Quiet stops SendRequest printing every request and response
+	Quiet bool
+
+	// Recorder saves every request and response when set
+	Recorder *SessionRecorder
+}
+
+// ToolResult is the text of a tool call result
+type ToolResult struct {
+	Text    string
+	IsError bool
+}
+
+func NewMCPTestClient(config ClientConfig) *MCPTestClient {
+	return &MCPTestClient{
+		config:   config,
+		reqID:    1,
+		Recorder: config.Recorder,
+	}
+}
+
+// Start runs the configured server with its arguments followed by args
+func (c *MCPTestClient) Start(args ...string) error {
+	// Start the MCP server
+	c.cmd = exec.Command(c.config.Server, append(append([]string{}, c.config.Args...), args...)...)
+
+	var err error
+	c.stdin, err = c.cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create stdin pipe: %v", err)
+	}
+
+	c.stdout, err = c.cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create stdout pipe: %v", err)
+	}
+
+	c.stderr, err = c.cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create stderr pipe: %v", err)
+	}
+
+	if err := c.cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start server: %v", err)
+	}
+
+	c.reader = bufio.NewScanner(c.stdout)
+	// The tools/list response alone is larger than the default line limit
+	c.reader.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	// Start stderr reader, keeping the output for the report
+	c.stderrDone = make(chan struct{})
+	go func() {
+		defer close(c.stderrDone)
+		scanner := bufio.NewScanner(c.stderr)
+		for scanner.Scan() {
+			fmt.Printf("[SERVER] %s\n", scanner.Text())
+			c.stderrMu.Lock()
+			c.stderrLog.WriteString(scanner.Text() + "\n")
+			c.stderrMu.Unlock()
+		}
+	}()
+
+	fmt.Println("✅ MCP Server started successfully")
+	return nil
+}
+
+func (c *MCPTestClient) SendRequest(method string, params interface{}) (*MCPResponse, error) {
+	req := MCPRequest{
+		JSONRPC: "2.0",
+		ID:      c.reqID,
+		Method:  method,
+		Params:  params,
+	}
+	c.reqID++
+
+	reqBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %v", err)
+	}
+	return c.send(req.ID, reqBytes)
+}
+
+// send writes an encoded request and waits for the response with its ID.
+// Both are added to the recorder, if set.
+func (c *MCPTestClient) send(id int, reqBytes []byte) (*MCPResponse, error) {
+	if !c.Quiet {
+		fmt.Printf("📤 Sending: %s\n", string(reqBytes))
+	}
+
+	if _, err := c.stdin.Write(append(reqBytes, '\n')); err != nil {
+		return nil, fmt.Errorf("failed to write request: %v", err)
+	}
+	c.Recorder.Record(directionSent, reqBytes)
+
+	// Read response with timeout, skipping notifications and progress
+	// messages sent before it
+	timeout := time.After(c.config.Timeout)
+	for {
+		done := make(chan string, 1)
+		go func() {
+			if c.reader.Scan() {
+				done <- c.reader.Text()
+			} else {
+				done <- ""
+			}
+		}()
+
+		select {
+		case response := <-done:
+			if response == "" {
+				return nil, fmt.Errorf("no response received")
+			}
+
+			if !c.Quiet {
+				fmt.Printf("📥 Received: %s\n", response)
+			}
+			c.Recorder.Record(directionReceived, []byte(response))
+
+			var resp MCPResponse
+			if err := json.Unmarshal([]byte(response), &resp); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal response: %v", err)
+			}
+			if resp.ID != id {
+				continue
+			}
+
+			return &resp, nil
+		case <-timeout:
+			return nil, fmt.Errorf("timeout waiting for response")
+		}
+	}
+}
+
+// sendNotification writes an encoded notification, which gets no response
+func (c *MCPTestClient) sendNotification(message []byte) error {
+	if _, err := c.stdin.Write(append(message, '\n')); err != nil {
+		return fmt.Errorf("failed to write notification: %v", err)
+	}
+	c.Recorder.Record(directionSent, message)
+	return nil
+}
+
+// CallTool calls a tool and joins the text content of its result; images
+// are shown as their MIME type
+func (c *MCPTestClient) CallTool(name string, arguments map[string]interface{}) (*ToolResult, error) {
+	resp, err := c.SendRequest("tools/call", ToolCallParams{Name: name, Arguments: arguments})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("%s error: %s", name, resp.Error.Message)
+	}
+
+	var result struct {
+		Content []struct {
+			Type     string `json:"type"`
+			Text     string `json:"text"`
+			MIMEType string `json:"mimeType"`
+		} `json:"content"`
+		IsError bool `json:"isError"`
+	}
+	encoded, _ := json.Marshal(resp.Result)
+	if err := json.Unmarshal(encoded, &result); err != nil {
+		return nil, fmt.Errorf("failed to read %s result: %v", name, err)
+	}
+
+	var text []string
+	for _, content := range result.Content {
+		switch content.Type {
+		case "text":
+			text = append(text, content.Text)
+		case "image":
+			text = append(text, fmt.Sprintf("[image %s]", content.MIMEType))
+		}
+	}
+	return &ToolResult{Text: strings.Join(text, "\n"), IsError: result.IsError}, nil
+}
+
+// ListToolNames returns the names of the server's tools
+func (c *MCPTestClient) ListToolNames() ([]string, error) {
+	resp, err := c.SendRequest("tools/list", nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("tools/list error: %s", resp.Error.Message)
+	}
+
+	var result struct {
+		Tools []struct {
+			Name string `json:"name"`
+		} `json:"tools"`
+	}
+	encoded, _ := json.Marshal(resp.Result)
+	if err := json.Unmarshal(encoded, &result); err != nil {
+		return nil, fmt.Errorf("failed to read tools/list result: %v", err)
+	}
+
+	names := make([]string, len(result.Tools))
+	for i, tool := range result.Tools {
+		names[i] = tool.Name
+	}
+	return names, nil
+}
+
+func (c *MCPTestClient) Close() error {
+	if c.stdin != nil {
+		c.stdin.Close()
+	}
+	if c.cmd != nil && c.cmd.Process != nil {
+		c.cmd.Process.Kill()
+		// Finish reading stderr before Wait closes the pipe
+		<-c.stderrDone
+		c.cmd.Wait()
+	}
+	return nil
+}
+
+// Stderr returns the server's log output so far
+func (c *MCPTestClient) Stderr() string {
+	c.stderrMu.Lock()
+	defer c.stderrMu.Unlock()
+	return c.stderrLog.String()
+}
+
+// Initialize starts the MCP session
+func (c *MCPTestClient) Initialize() error {
+	initParams := InitializeParams{
+		ProtocolVersion: "2024-11-05",
+		Capabilities: ClientCapabilities{
+			Roots: &RootsCapability{
+				ListChanged: true,
+			},
+			Sampling: &SamplingCapability{},
+		},
+		ClientInfo: ClientInfo{
+			Name:    "hwp-mcp-test-client",
+			Version: "1.0.0",
+		},
+	}
+
+	resp, err := c.SendRequest("initialize", initParams)
+	if err != nil {
+		return fmt.Errorf("initialize failed: %v", err)
+	}
+	if resp.Error != nil {
+		return fmt.Errorf("initialize error: %s", resp.Error.Message)
+	}
+	return nil
+}
+
+// startClient starts the server with args and waits for it to be ready
+func startClient(config ClientConfig, args ...string) (*MCPTestClient, error) {
+	client := NewMCPTestClient(config)
+	if err := client.Start(args...); err != nil {
+		return nil, fmt.Errorf("failed to start client: %v", err)
+	}
+
+	// Wait a bit for server to be ready
+	time.Sleep(1 * time.Second)
+	return client, nil
+}
+
+// runSuite runs the tool coverage suite against the hwpx backend, which
+// needs no HWP installation
+func runSuite(config ClientConfig, goldenDir string, update bool, report *Report) error {
+	workDir, err := os.MkdirTemp("", "hwp-suite-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(workDir)
+	// The server reports paths with symbolic links resolved
+	if resolved, err := filepath.EvalSymlinks(workDir); err == nil {
+		workDir = resolved
+	}
+	if err := writeSuiteFiles(workDir); err != nil {
+		return fmt.Errorf("failed to write suite files: %v", err)
+	}
+
+	// A fresh recent files store keeps the user's history out of the results
+	stateDir, err := os.MkdirTemp("", "hwp-suite-state-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(stateDir)
+
+	client, err := startClient(config, "-backend", "hwpx", "-watch-interval", "0",
+		"-recent-files", filepath.Join(stateDir, "recent.json"), "-allowed-dirs", workDir)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		client.Close()
+		report.ServerStderr = client.Stderr()
+	}()
+	client.Quiet = true
+
+	started := time.Now()
+	err = client.Initialize()
+	report.Add("initialize", started, err)
+	if err != nil {
+		return err
+	}
+	fmt.Println("\n🧪 Running tool coverage suite...")
+	return runToolSuite(client, workDir, goldenDir, update, report)
+}
+
+// runBench times tool calls on the configured server. Document change
+// notifications are turned off so polling doesn't skew the timings.
+func runBench(config ClientConfig, iterations int, tableSizes [][2]int, report *Report) error {
+	client, err := startClient(config, "-watch-interval", "0")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		client.Close()
+		report.ServerStderr = client.Stderr()
+	}()
+	client.Quiet = true
+
+	started := time.Now()
+	err = client.Initialize()
+	report.Add("initialize", started, err)
+	if err != nil {
+		return err
+	}
+	fmt.Println("\n🏁 Running benchmarks...")
+	return runBenchmarks(client, iterations, tableSizes, report)
+}
+
+// runReplay re-sends a recorded session to the configured server
+func runReplay(config ClientConfig, path string, report *Report) error {
+	client, err := startClient(config, "-watch-interval", "0")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		client.Close()
+		report.ServerStderr = client.Stderr()
+	}()
+	client.Quiet = true
+
+	fmt.Printf("\n🔁 Replaying %s...\n", path)
+	return replaySession(client, path, report)
+}
+
+// runTests checks the protocol basics, then creates, edits and closes a
+// document unless skipHWP is set. Each test is added to report.
+func runTests(config ClientConfig, skipHWP bool, report *Report) error {
+	client, err := startClient(config)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		client.Close()
+		report.ServerStderr = client.Stderr()
+	}()
+
+	fmt.Println("\n🧪 Starting MCP Tests...")
+
+	// Test 1: Initialize
+	fmt.Println("\n1️⃣ Testing Initialize...")
+	started := time.Now()
+	err = client.Initialize()
+	report.Add("initialize", started, err)
+	if err != nil {
+		return err
+	}
+	fmt.Println("✅ Initialize successful")
+
+	// Test 2: List Tools
+	fmt.Println("\n2️⃣ Testing List Tools...")
+	started = time.Now()
+	tools, err := client.ListToolNames()
+	report.Add("tools/list", started, err)
+	if err != nil {
+		return fmt.Errorf("tools/list failed: %v", err)
+	}
+	fmt.Printf("✅ Found %d tools:\n", len(tools))
+	for i, name := range tools {
+		fmt.Printf("   %d. %s\n", i+1, name)
+	}
+
+	// Test 3: Diagnostics
+	fmt.Println("\n3️⃣ Testing Diagnostics...")
+	started = time.Now()
+	_, err = client.CallTool("hwp_diagnostics", map[string]interface{}{})
+	report.Add("hwp_diagnostics", started, err)
+	if err != nil {
+		return fmt.Errorf("diagnostics failed: %v", err)
+	}
+	fmt.Println("✅ Diagnostics call successful")
+
+	if skipHWP {
+		fmt.Println("\n⏭️  Skipping HWP document tests (-skip-hwp)")
+		for _, name := range []string{"hwp_create", "hwp_insert_text", "hwp_close"} {
+			report.Skip(name, time.Now(), "skipped with -skip-hwp")
+		}
+		fmt.Println("\n🎉 All tests completed!")
+		return nil
+	}
+
+	// Test 4: HWP Create (if HWP is available)
+	fmt.Println("\n4️⃣ Testing HWP Create...")
+	started = time.Now()
+	if _, err := client.CallTool("hwp_create", map[string]interface{}{}); err != nil {
+		fmt.Printf("⚠️  HWP Create failed (HWP may not be installed): %v\n", err)
+		report.Skip("hwp_create", started, fmt.Sprintf("HWP may not be installed: %v", err))
+		report.Skip("hwp_insert_text", time.Now(), "no document")
+		report.Skip("hwp_close", time.Now(), "no document")
+	} else {
+		report.Add("hwp_create", started, nil)
+		fmt.Println("✅ HWP Create successful")
+
+		// Test 5: Insert Text (if create was successful)
+		fmt.Println("\n5️⃣ Testing HWP Insert Text...")
+		started = time.Now()
+		_, err := client.CallTool("hwp_insert_text", map[string]interface{}{
+			"text": "안녕하세요! MCP 테스트입니다.",
+		})
+		report.Add("hwp_insert_text", started, err)
+		if err != nil {
+			fmt.Printf("⚠️  Insert text failed: %v\n", err)
+		} else {
+			fmt.Println("✅ Insert text successful")
+		}
+
+		// Test 6: Close HWP
+		fmt.Println("\n6️⃣ Testing HWP Close...")
+		started = time.Now()
+		_, err = client.CallTool("hwp_close", map[string]interface{}{"discard_changes": true})
+		report.Add("hwp_close", started, err)
+		if err != nil {
+			fmt.Printf("⚠️  HWP Close failed: %v\n", err)
+		} else {
+			fmt.Println("✅ HWP Close successful")
+		}
+	}
+
+	fmt.Println("\n🎉 All tests completed!")
+	return nil
+}
+
+func main() {
+	server := flag.String("server", envOr("HWP_TEST_SERVER", "./hwp-mcp-go.exe"),
+		"Server executable to test (env HWP_TEST_SERVER)")
+	serverArgs := flag.String("server-args", os.Getenv("HWP_TEST_SERVER_ARGS"),
+		"Space-separated arguments for the server, e.g. \"-backend hwpx\" (env HWP_TEST_SERVER_ARGS)")
+	timeout := flag.Duration("timeout", envDuration("HWP_TEST_TIMEOUT", 10*time.Second),
+		"How long to wait for each response (env HWP_TEST_TIMEOUT)")
+	skipHWP := flag.Bool("skip-hwp", os.Getenv("HWP_TEST_SKIP_HWP") != "",
+		"Skip the tests that need an HWP installation (env HWP_TEST_SKIP_HWP)")
+	suite := flag.Bool("suite", false,
+		"Run every registered tool against the hwpx backend and compare the results with golden files")
+	goldenDir := flag.String("golden", filepath.Join("cmd", "test-client", "testdata", "golden"),
+		"Directory of the suite's golden files")
+	update := flag.Bool("update", false,
+		"Rewrite the golden files with the current results instead of comparing")
+	bench := flag.Bool("bench", false,
+		"Measure ops/sec and latency of insert_text, fill_table_with_data and batch_operations instead of testing")
+	benchIterations := flag.Int("bench-iterations", 100,
+		"Calls per benchmark scenario")
+	benchTableSizes := flag.String("bench-table-sizes", "5x5,20x10,50x10",
+		"Comma-separated ROWSxCOLS table sizes for the fill_table_with_data benchmark")
+	recordPath := flag.String("record", "",
+		"Save every request and response of the run to this JSONL file")
+	replayPath := flag.String("replay", "",
+		"Re-send the requests of a recorded session and compare the responses with the recording")
+	reportFormat := flag.String("report", "",
+		"Write a test report: json or junit")
+	reportPath := flag.String("report-file", "",
+		"Report file (default: test-report.json or test-report.xml)")
+	flag.Parse()
+
+	if *reportFormat != "" && *reportFormat != "json" && *reportFormat != "junit" {
+		log.Fatalf("❌ Unknown report format %q (use json or junit)", *reportFormat)
+	}
+	tableSizes, err := parseTableSizes(*benchTableSizes)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	if *benchIterations < 1 {
+		log.Fatalf("❌ -bench-iterations must be at least 1")
+	}
+	if *reportPath == "" {
+		*reportPath = "test-report.json"
+		if *reportFormat == "junit" {
+			*reportPath = "test-report.xml"
+		}
+	}
+
+	fmt.Println("🚀 HWP MCP Server Test Client")
+	fmt.Println("=============================")
+
+	// Check if server executable exists
+	if _, err := exec.LookPath(*server); err != nil {
+		log.Fatalf("❌ Server %s not found. Please build the server first or pass -server.", *server)
+	}
+	config := ClientConfig{Server: *server, Args: strings.Fields(*serverArgs), Timeout: *timeout}
+	if *recordPath != "" {
+		recorder, err := NewSessionRecorder(*recordPath)
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		defer recorder.Close()
+		config.Recorder = recorder
+	}
+
+	report := NewReport("hwp-mcp-go")
+	run := func() error { return runTests(config, *skipHWP, report) }
+	if *suite {
+		report.Name = "hwp-mcp-go-suite"
+		run = func() error { return runSuite(config, *goldenDir, *update, report) }
+	}
+	if *bench {
+		report.Name = "hwp-mcp-go-bench"
+		run = func() error { return runBench(config, *benchIterations, tableSizes, report) }
+	}
+	if *replayPath != "" {
+		report.Name = "hwp-mcp-go-replay"
+		run = func() error { return runReplay(config, *replayPath, report) }
+	}
+	err = run()
+	if err == nil && report.Count(StatusFailed) > 0 {
+		err = fmt.Errorf("%d tests failed", report.Count(StatusFailed))
+	}
+
+	if *reportFormat != "" {
+		if writeErr := report.Write(*reportFormat, *reportPath); writeErr != nil {
+			log.Fatalf("❌ Failed to write report: %v", writeErr)
+		}
+		fmt.Printf("📝 Report written to %s\n", *reportPath)
+	}
+	if err != nil {
+		log.Fatalf("❌ Tests failed: %v", err)
+	}
+
+	fmt.Println("\n✨ All tests passed successfully!")
+}
+
+// envOr returns the environment variable name, or fallback if it is empty
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// envDuration parses the environment variable name as a duration, returning
+// fallback if it is empty or invalid
+func envDuration(name string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(name))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Directions of recorded messages
+const (
+	directionSent     = "sent"
+	directionReceived = "received"
+)
+
+// sessionEntry is one line of a session recording
+type sessionEntry struct {
+	Direction string          `json:"direction"`
+	TimeMS    int64           `json:"time_ms"` // since the recording started
+	Message   json.RawMessage `json:"message"`
+}
+
+// SessionRecorder writes every message of a session to a JSONL file
+type SessionRecorder struct {
+	mu      sync.Mutex
+	file    *os.File
+	started time.Time
+}
+
+// NewSessionRecorder creates the recording file at path
+func NewSessionRecorder(path string) (*SessionRecorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording: %v", err)
+	}
+	return &SessionRecorder{file: file, started: time.Now()}, nil
+}
+
+// Record appends a message. A nil recorder records nothing.
+func (r *SessionRecorder) Record(direction string, message []byte) {
+	if r == nil || !json.Valid(message) {
+		return
+	}
+	encoded, err := json.Marshal(sessionEntry{
+		Direction: direction,
+		TimeMS:    time.Since(r.started).Milliseconds(),
+		Message:   message,
+	})
+	if err != nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.file.Write(append(encoded, '\n'))
+}
+
+// Close closes the recording file
+func (r *SessionRecorder) Close() error {
+	return r.file.Close()
+}
+
+// recordedCall is a request of a recording with the response it got
+type recordedCall struct {
+	id       *int
+	method   string
+	tool     string
+	request  json.RawMessage
+	response *MCPResponse
+}
+
+// loadRecording reads the requests of a recording, each with its response
+func loadRecording(path string) ([]*recordedCall, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording: %v", err)
+	}
+	defer file.Close()
+
+	var calls []*recordedCall
+	byID := map[int]*recordedCall{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		entry, err := parseSessionLine(scanner.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+
+		switch entry.Direction {
+		case directionSent:
+			var request struct {
+				ID     *int   `json:"id"`
+				Method string `json:"method"`
+				Params struct {
+					Name string `json:"name"`
+				} `json:"params"`
+			}
+			if err := json.Unmarshal(entry.Message, &request); err != nil {
+				return nil, fmt.Errorf("line %d: %v", line, err)
+			}
+			call := &recordedCall{id: request.ID, method: request.Method, tool: request.Params.Name, request: entry.Message}
+			calls = append(calls, call)
+			if request.ID != nil {
+				byID[*request.ID] = call
+			}
+		case directionReceived:
+			var response MCPResponse
+			if json.Unmarshal(entry.Message, &response) != nil {
+				continue
+			}
+			if call, ok := byID[response.ID]; ok && call.response == nil {
+				call.response = &response
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recording: %v", err)
+	}
+	return calls, nil
+}
+
+// parseSessionLine reads a recording line. Plain JSON-RPC messages, as in a
+// raw stdio transcript, are accepted too: messages with a method were sent.
+func parseSessionLine(line []byte) (sessionEntry, error) {
+	var entry sessionEntry
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return entry, err
+	}
+	if entry.Direction != "" {
+		return entry, nil
+	}
+
+	var message struct {
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal(line, &message); err != nil {
+		return entry, err
+	}
+	entry.Message = append(json.RawMessage(nil), line...)
+	entry.Direction = directionReceived
+	if message.Method != "" {
+		entry.Direction = directionSent
+	}
+	return entry, nil
+}
+
+// replaySession sends the requests of a recording to client in order and
+// compares each response with the recorded one after normalizing values
+// that change between runs. Each request is added to report.
+func replaySession(client *MCPTestClient, path string, report *Report) error {
+	calls, err := loadRecording(path)
+	if err != nil {
+		return err
+	}
+
+	differences := 0
+	for i, call := range calls {
+		name := fmt.Sprintf("%03d %s", i+1, call.method)
+		if call.tool != "" {
+			name += " " + call.tool
+		}
+		started := time.Now()
+
+		if call.id == nil {
+			// Notifications get no response
+			err := client.sendNotification(call.request)
+			report.Add(name, started, err)
+			continue
+		}
+
+		response, err := client.send(*call.id, call.request)
+		if err == nil && call.response != nil {
+			err = compareResponses(call.response, response)
+		}
+		report.Add(name, started, err)
+		if err != nil {
+			differences++
+			fmt.Printf("❌ %s: %v\n", name, err)
+			continue
+		}
+		fmt.Printf("✅ %s\n", name)
+	}
+
+	fmt.Printf("\n%d requests replayed, %d differ from the recording\n", len(calls), differences)
+	if differences > 0 {
+		return fmt.Errorf("%d responses differ from the recording", differences)
+	}
+	return nil
+}
+
+// compareResponses reports how actual differs from the recorded response
+func compareResponses(recorded, actual *MCPResponse) error {
+	expected, got := normalizeResponse(recorded), normalizeResponse(actual)
+	if expected != got {
+		return fmt.Errorf("response differs:\n--- recorded\n%s\n--- replayed\n%s", expected, got)
+	}
+	return nil
+}
+
+// normalizeResponse encodes the result or error of a response with values
+// that change between runs replaced by placeholders
+func normalizeResponse(response *MCPResponse) string {
+	var encoded []byte
+	if response.Error != nil {
+		encoded, _ = json.Marshal(response.Error)
+	} else {
+		encoded, _ = json.Marshal(response.Result)
+	}
+	text := string(encoded)
+	for _, volatile := range volatilePatterns {
+		text = volatile.pattern.ReplaceAllString(text, volatile.replacement)
+	}
+	return text
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Test result statuses
+const (
+	StatusPassed  = "passed"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+)
+
+// TestResult is the outcome of one test
+type TestResult struct {
+	Name     string
+	Status   string
+	Duration time.Duration
+	Message  string
+}
+
+// Report collects the test results of a run for -report
+type Report struct {
+	Name         string
+	Started      time.Time
+	Tests        []TestResult
+	ServerStderr string
+}
+
+// NewReport starts a report for the named run
+func NewReport(name string) *Report {
+	return &Report{Name: name, Started: time.Now()}
+}
+
+// Add records a test that began at started; it passed if err is nil
+func (r *Report) Add(name string, started time.Time, err error) {
+	result := TestResult{Name: name, Status: StatusPassed, Duration: time.Since(started)}
+	if err != nil {
+		result.Status = StatusFailed
+		result.Message = err.Error()
+	}
+	r.Tests = append(r.Tests, result)
+}
+
+// Skip records a test that could not run
+func (r *Report) Skip(name string, started time.Time, reason string) {
+	r.Tests = append(r.Tests, TestResult{Name: name, Status: StatusSkipped, Duration: time.Since(started), Message: reason})
+}
+
+// Count returns the number of tests with status
+func (r *Report) Count(status string) int {
+	count := 0
+	for _, test := range r.Tests {
+		if test.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Write saves the report to path as json or junit
+func (r *Report) Write(format, path string) error {
+	var encoded []byte
+	var err error
+	switch format {
+	case "json":
+		encoded, err = r.marshalJSON()
+	case "junit":
+		encoded, err = r.marshalJUnit()
+	default:
+		return fmt.Errorf("unknown report format %q (use json or junit)", format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %v", err)
+	}
+	return os.WriteFile(path, encoded, 0o644)
+}
+
+// jsonTest is a test in the JSON report
+type jsonTest struct {
+	Name       string  `json:"name"`
+	Status     string  `json:"status"`
+	DurationMS float64 `json:"duration_ms"`
+	Message    string  `json:"message,omitempty"`
+}
+
+func (r *Report) marshalJSON() ([]byte, error) {
+	tests := make([]jsonTest, len(r.Tests))
+	for i, test := range r.Tests {
+		tests[i] = jsonTest{Name: test.Name, Status: test.Status, DurationMS: milliseconds(test.Duration), Message: test.Message}
+	}
+	return json.MarshalIndent(map[string]interface{}{
+		"name":          r.Name,
+		"started":       r.Started.Format(time.RFC3339),
+		"duration_ms":   milliseconds(r.duration()),
+		"passed":        r.Count(StatusPassed),
+		"failed":        r.Count(StatusFailed),
+		"skipped":       r.Count(StatusSkipped),
+		"tests":         tests,
+		"server_stderr": r.ServerStderr,
+	}, "", "  ")
+}
+
+// JUnit XML elements, as read by CI test report tools
+type junitSuites struct {
+	XMLName xml.Name   `xml:"testsuites"`
+	Suites  []junitRun `xml:"testsuite"`
+}
+
+type junitRun struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Skipped   int         `xml:"skipped,attr"`
+	Time      string      `xml:"time,attr"`
+	Timestamp string      `xml:"timestamp,attr"`
+	Cases     []junitCase `xml:"testcase"`
+	SystemErr string      `xml:"system-err,omitempty"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (r *Report) marshalJUnit() ([]byte, error) {
+	run := junitRun{
+		Name:      r.Name,
+		Tests:     len(r.Tests),
+		Failures:  r.Count(StatusFailed),
+		Skipped:   r.Count(StatusSkipped),
+		Time:      seconds(r.duration()),
+		Timestamp: r.Started.Format("2006-01-02T15:04:05"),
+		SystemErr: r.ServerStderr,
+	}
+	for _, test := range r.Tests {
+		testCase := junitCase{Name: test.Name, Classname: r.Name, Time: seconds(test.Duration)}
+		switch test.Status {
+		case StatusFailed:
+			testCase.Failure = &junitMessage{Message: firstLine(test.Message), Text: test.Message}
+		case StatusSkipped:
+			testCase.Skipped = &junitMessage{Message: test.Message}
+		}
+		run.Cases = append(run.Cases, testCase)
+	}
+
+	encoded, err := xml.MarshalIndent(junitSuites{Suites: []junitRun{run}}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), encoded...), nil
+}
+
+// duration is the time since the run started
+func (r *Report) duration() time.Duration {
+	return time.Since(r.Started)
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// firstLine returns text up to its first line break
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	return line
+}
@@ -0,0 +1,563 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// toolCase is one tool call of the coverage suite. The cases run in order
+// against one server, so later cases see the document earlier ones built.
+type toolCase struct {
+	name      string // golden file name, defaults to the tool name
+	tool      string
+	arguments map[string]interface{}
+}
+
+// caseName returns the name of c, which keys its golden file
+func (c toolCase) caseName() string {
+	if c.name != "" {
+		return c.name
+	}
+	return c.tool
+}
+
+// checkCaseNames fails if two cases share a name, as they would share a
+// golden file; give the later one a name of its own
+func checkCaseNames(cases []toolCase) error {
+	seen := map[string]int{}
+	for i, c := range cases {
+		name := c.caseName()
+		if first, taken := seen[name]; taken {
+			return fmt.Errorf("cases %d and %d are both named %s; name one of them", first+1, i+1, name)
+		}
+		seen[name] = i
+	}
+	return nil
+}
+
+// toolCases call every registered tool at least once. String arguments may
+// use {{dir}} for the suite's working directory and {{token}} for the token
+// returned by the last hwp_begin_table_fill (an unknown token if it failed).
+var toolCases = []toolCase{
+	{tool: "hwp_create"},
+	{tool: "hwp_get_document_status"},
+	{tool: "hwp_get_page_count"},
+	{tool: "hwp_render_current_page", arguments: map[string]interface{}{"dpi": 72}},
+	{tool: "hwp_export_page_image", arguments: map[string]interface{}{"path": "{{dir}}/page.png", "page": 1}},
+	{tool: "hwp_export_page_image", name: "hwp_export_page_image-dry-run", arguments: map[string]interface{}{"path": "{{dir}}/page.png", "dry_run": true}},
+	{tool: "hwp_set_metadata", arguments: map[string]interface{}{
+		"title": "MCP 테스트 문서", "author": "홍길동", "keywords": "테스트, MCP", "created": "2024-03-01"}},
+	{tool: "hwp_get_metadata"},
+	{tool: "hwp_insert_text", arguments: map[string]interface{}{"text": "안녕하세요! MCP 테스트입니다."}},
+	{tool: "hwp_insert_paragraph"},
+	{tool: "hwp_import_text_file", arguments: map[string]interface{}{"path": "{{dir}}/notes.txt"}},
+	{tool: "hwp_import_text_file", name: "hwp_import_text_file-wrong-encoding",
+		arguments: map[string]interface{}{"path": "{{dir}}/legacy.txt", "encoding": "utf-8"}},
+	{tool: "hwp_set_font", arguments: map[string]interface{}{"name": "맑은 고딕", "size": 14, "bold": true}},
+	{tool: "hwp_set_font", name: "hwp_set_font-matches", arguments: map[string]interface{}{"bold": true, "scope": "matches", "find": "MCP"}},
+	{tool: "hwp_set_font", name: "hwp_set_font-matches-no-find", arguments: map[string]interface{}{"bold": true, "scope": "matches"}},
+	{tool: "hwp_format_matches", arguments: map[string]interface{}{"text": "MCP", "bold": true, "highlight": "yellow"}},
+	{tool: "hwp_format_matches", name: "hwp_format_matches-no-style", arguments: map[string]interface{}{"text": "MCP"}},
+	{tool: "hwp_apply_preset", arguments: map[string]interface{}{"name": "제목1"}},
+	{tool: "hwp_apply_preset", name: "hwp_apply_preset-unknown", arguments: map[string]interface{}{"name": "큰제목"}},
+	{tool: "hwp_insert_text", name: "hwp_insert_text-linebreaks",
+		arguments: map[string]interface{}{"text": "첫째 줄\n둘째 줄", "preserve_linebreaks": true}},
+	{tool: "hwp_insert_code_block", arguments: map[string]interface{}{
+		"code": "func main() {\n\tfmt.Println(\"안녕\")\n\n}\n", "line_numbers": true}},
+	{tool: "hwp_insert_callout", arguments: map[string]interface{}{
+		"text": "저장하기 전에 문서를 닫지 마세요.", "variant": "warning"}},
+	{tool: "hwp_insert_date_stamp", arguments: map[string]interface{}{"date": "2024-03-01", "format": "long", "weekday": true}},
+	{tool: "hwp_insert_date_stamp", name: "hwp_insert_date_stamp-live-format", arguments: map[string]interface{}{"live": true, "format": "dot", "weekday": true}},
+	{tool: "hwp_insert_symbol", arguments: map[string]interface{}{"category": "circled_number", "name": "3"}},
+	{tool: "hwp_format_number", arguments: map[string]interface{}{"value": 1210000, "style": "amount", "with_figures": true}},
+	{tool: "hwp_format_number", name: "hwp_format_number-korean", arguments: map[string]interface{}{"value": "1015000", "style": "korean"}},
+	{tool: "hwp_format_number", name: "hwp_format_number-mixed", arguments: map[string]interface{}{"value": 123456789, "style": "mixed", "insert": true}},
+	{tool: "hwp_format_number", name: "hwp_format_number-fraction", arguments: map[string]interface{}{"value": 12.5, "style": "amount"}},
+	{tool: "hwp_find", arguments: map[string]interface{}{"text": "테스트"}},
+	{tool: "hwp_goto_match", arguments: map[string]interface{}{"index": 1}},
+	{tool: "hwp_get_selection_text"},
+	{tool: "hwp_set_outline_numbering", arguments: map[string]interface{}{"scheme": "korean"}},
+	{tool: "hwp_apply_heading", arguments: map[string]interface{}{"level": 1}},
+	{tool: "hwp_set_spacing", arguments: map[string]interface{}{"preset": "double"}},
+	{tool: "hwp_set_spacing", name: "hwp_set_spacing-document", arguments: map[string]interface{}{"after": 6, "scope": "document"}},
+	{tool: "hwp_set_spacing", name: "hwp_set_spacing-drop-cap",
+		arguments: map[string]interface{}{"first_line": "indent", "drop_cap": "3_lines"}},
+	{tool: "hwp_clean_formatting", arguments: map[string]interface{}{"scope": "document"}},
+	{tool: "hwp_transform_text", arguments: map[string]interface{}{"transform": "upper"}},
+	{tool: "hwp_sort_lines", arguments: map[string]interface{}{"order": "numeric", "reverse": true}},
+	{tool: "hwp_set_page_setup", arguments: map[string]interface{}{"orientation": "landscape"}},
+	{tool: "hwp_insert_section", arguments: map[string]interface{}{"orientation": "portrait"}},
+	{tool: "hwp_set_page_border", arguments: map[string]interface{}{"style": "solid"}},
+	{tool: "hwp_set_page_background", arguments: map[string]interface{}{"color": "#FFFFFF"}},
+	{tool: "hwp_set_line_numbering", arguments: map[string]interface{}{"enabled": true}},
+	{tool: "hwp_insert_image", arguments: map[string]interface{}{"path": "{{dir}}/image.png", "width": 40, "alt_text": "분기별 매출 추이 그래프"}},
+	{tool: "hwp_list_images"},
+	{tool: "hwp_set_image_description", arguments: map[string]interface{}{"index": 0, "description": "회사 로고"}},
+	{name: "hwp_insert_image-floating", tool: "hwp_insert_image", arguments: map[string]interface{}{
+		"path": "{{dir}}/image.png", "wrap": "behind_text", "horizontal_relative_to": "page", "horizontal_align": "right", "vertical_offset_mm": 10, "rotation": 15, "max_dpi": 150}},
+	{tool: "hwp_set_image_wrap", arguments: map[string]interface{}{"index": 0, "wrap": "square", "horizontal_align": "center"}},
+	{name: "hwp_set_image_wrap-rotate-crop", tool: "hwp_set_image_wrap", arguments: map[string]interface{}{"index": 0, "rotation": -2, "crop_left_mm": 5, "crop_bottom_mm": 3}},
+	{name: "hwp_set_image_wrap-nothing", tool: "hwp_set_image_wrap", arguments: map[string]interface{}{"index": 0}},
+	{name: "hwp_set_image_wrap-inline-offset", tool: "hwp_set_image_wrap", arguments: map[string]interface{}{"index": 0, "wrap": "inline", "vertical_offset_mm": 5}},
+	{tool: "hwp_insert_video_link", arguments: map[string]interface{}{
+		"url": "https://example.com/training/safety", "caption": "안전교육", "duration": "3:25", "thumbnail": "{{dir}}/image.png"}},
+	{name: "hwp_insert_video_link-invalid-kind", tool: "hwp_insert_video_link", arguments: map[string]interface{}{"url": "https://example.com/a.mp3", "kind": "podcast"}},
+	{tool: "hwp_insert_ole_object", arguments: map[string]interface{}{"path": "{{dir}}/appendix.xlsx"}},
+	{name: "hwp_insert_ole_object-unsupported", tool: "hwp_insert_ole_object", arguments: map[string]interface{}{"path": "{{dir}}/data.csv"}},
+	{tool: "hwp_list_objects"},
+	{tool: "hwp_select_object", arguments: map[string]interface{}{"id": 0}},
+	{tool: "hwp_group_objects", arguments: map[string]interface{}{"ids": []interface{}{0, 1}}},
+	{name: "hwp_group_objects-invalid", tool: "hwp_group_objects", arguments: map[string]interface{}{"ids": []interface{}{0, "first"}}},
+	{tool: "hwp_ungroup_object", arguments: map[string]interface{}{"id": 0}},
+	{tool: "hwp_arrange_object", arguments: map[string]interface{}{"id": 0, "order": "back"}},
+	{tool: "hwp_insert_linked_text_frames", arguments: map[string]interface{}{"frames": []interface{}{
+		map[string]interface{}{"x_mm": 15, "y_mm": 20, "width_mm": 85, "height_mm": 250},
+		map[string]interface{}{"x_mm": 110, "y_mm": 20, "width_mm": 85, "height_mm": 250},
+	}, "text": "첫 단락\n둘째 단락"}},
+	{name: "hwp_insert_linked_text_frames-single", tool: "hwp_insert_linked_text_frames", arguments: map[string]interface{}{"frames": []interface{}{
+		map[string]interface{}{"x_mm": 15, "y_mm": 20, "width_mm": 85, "height_mm": 250},
+	}}},
+	{tool: "hwp_insert_org_chart", arguments: map[string]interface{}{"root": map[string]interface{}{
+		"label": "대표이사",
+		"children": []interface{}{
+			map[string]interface{}{"label": "경영지원본부"},
+			map[string]interface{}{"label": "사업본부", "children": []interface{}{map[string]interface{}{"label": "영업팀"}}},
+		},
+	}}},
+	{name: "hwp_insert_org_chart-too-wide", tool: "hwp_insert_org_chart", arguments: map[string]interface{}{"width_mm": 40, "root": map[string]interface{}{
+		"label":    "본부",
+		"children": []interface{}{map[string]interface{}{"label": "1팀"}, map[string]interface{}{"label": "2팀"}, map[string]interface{}{"label": "3팀"}},
+	}}},
+	{tool: "hwp_insert_flow_diagram", arguments: map[string]interface{}{
+		"nodes": []interface{}{
+			map[string]interface{}{"id": "a", "label": "접수"},
+			map[string]interface{}{"id": "b", "label": "검토"},
+			map[string]interface{}{"id": "c", "label": "승인"},
+		},
+		"edges": []interface{}{map[string]interface{}{"from": "a", "to": "b"}, map[string]interface{}{"from": "b", "to": "c"}},
+	}},
+	{name: "hwp_insert_flow_diagram-loop", tool: "hwp_insert_flow_diagram", arguments: map[string]interface{}{
+		"nodes": []interface{}{map[string]interface{}{"id": "a", "label": "접수"}, map[string]interface{}{"id": "b", "label": "검토"}},
+		"edges": []interface{}{map[string]interface{}{"from": "a", "to": "b"}, map[string]interface{}{"from": "b", "to": "a"}},
+	}},
+	{tool: "hwp_insert_table", arguments: map[string]interface{}{"rows": 3, "cols": 3}},
+	{tool: "hwp_fill_table_with_data", arguments: map[string]interface{}{
+		"data": [][]interface{}{{"월", "화", "수"}, {1, 2, 3}, {4, 5, 6}}, "has_header": true}},
+	{tool: "hwp_fill_column_numbers", arguments: map[string]interface{}{"start": 1, "end": 3, "column": 1}},
+	{tool: "hwp_create_table_with_data", arguments: map[string]interface{}{
+		"rows": 2, "cols": 2, "data": [][]interface{}{{"이름", "점수"}, {"홍길동", 90}}, "has_header": true,
+		"column_formats": []interface{}{"text", "number:1"}}},
+	{tool: "hwp_begin_table_fill", arguments: map[string]interface{}{"total_rows": 2}},
+	{tool: "hwp_append_table_rows", arguments: map[string]interface{}{"token": "{{token}}", "data": [][]interface{}{{"a", "b"}, {"c", "d"}}}},
+	{tool: "hwp_end_table_fill", arguments: map[string]interface{}{"token": "{{token}}"}},
+	{tool: "hwp_fill_table_from_csv", arguments: map[string]interface{}{"path": "{{dir}}/data.csv", "has_header": true}},
+	{tool: "hwp_fill_table_from_csv", name: "hwp_fill_table_from_csv-delimiter", arguments: map[string]interface{}{"path": "{{dir}}/data.csv", "delimiter": "::"}},
+	{tool: "hwp_insert_left_column"},
+	{tool: "hwp_insert_right_column"},
+	{tool: "hwp_insert_upper_row"},
+	{tool: "hwp_insert_lower_row", arguments: map[string]interface{}{"count": 3}},
+	{tool: "hwp_move_to_left_cell"},
+	{tool: "hwp_move_to_right_cell"},
+	{tool: "hwp_move_to_upper_cell"},
+	{tool: "hwp_move_to_lower_cell"},
+	{tool: "hwp_merge_table_cells"},
+	{tool: "hwp_merge_tables"},
+	{tool: "hwp_set_cell_style", arguments: map[string]interface{}{"padding": 1.5, "padding_left": 3, "diagonal": "backslash"}},
+	{tool: "hwp_convert_text_to_table", arguments: map[string]interface{}{"delimiter": "comma"}},
+	{tool: "hwp_convert_table_to_chart", arguments: map[string]interface{}{"index": 1, "type": "line", "title": "월별 매출"}},
+	{tool: "hwp_convert_table_to_chart", name: "hwp_convert_table_to_chart-unknown-series",
+		arguments: map[string]interface{}{"index": 2, "series": []interface{}{"매출"}}},
+	{tool: "hwp_create_table_with_data", name: "hwp_create_table_with_data-row-template", arguments: map[string]interface{}{
+		"rows": 3, "cols": 3, "data": [][]interface{}{{"번호", "품명", "수량"}, {"{{row_number}}", "{{name}}", "{{qty}}"}, {"", "합계", "3"}}, "has_header": true}},
+	{tool: "hwp_expand_row_template", name: "hwp_expand_row_template-undefined", arguments: map[string]interface{}{
+		"row": 2, "data": []interface{}{map[string]interface{}{"qty": 1}}}},
+	{tool: "hwp_expand_row_template", arguments: map[string]interface{}{
+		"data": []interface{}{map[string]interface{}{"name": "노트북", "qty": 2}, map[string]interface{}{"name": "마우스", "qty": 1}}}},
+	{tool: "hwp_expand_row_template", name: "hwp_expand_row_template-no-template", arguments: map[string]interface{}{"data": []interface{}{}}},
+	{tool: "hwp_convert_table_to_text", arguments: map[string]interface{}{"delimiter": "tab"}},
+	{tool: "hwp_batch_operations", arguments: map[string]interface{}{"operations": []interface{}{
+		map[string]interface{}{"type": "insert_text", "text": "배치 작업"},
+		map[string]interface{}{"type": "insert_paragraph"},
+		map[string]interface{}{"type": "set_font", "name": "바탕", "size": 12},
+		map[string]interface{}{"type": "insert_table", "rows": 2, "cols": 2},
+	}}},
+	{tool: "hwp_batch_operations", name: "hwp_batch_operations-invalid", arguments: map[string]interface{}{"operations": []interface{}{
+		map[string]interface{}{"type": "insert_table", "rows": 2},
+	}}},
+	{tool: "hwp_insert_cover_page", arguments: map[string]interface{}{"title": "분기 보고서", "author": "개발팀", "date": "2024-03-01"}},
+	{tool: "hwp_list_hyperlinks"},
+	{tool: "hwp_insert_text", name: "hwp_insert_text-pii", arguments: map[string]interface{}{
+		"text": "담당: 홍길동 (900101-1234567, 010-1234-5678, hong@example.com)\n대표: 02-123-4567, 사번 A-1024", "preserve_linebreaks": true}},
+	{tool: "hwp_scan_pii"},
+	{tool: "hwp_scan_pii", name: "hwp_scan_pii-custom", arguments: map[string]interface{}{
+		"patterns": []interface{}{}, "custom_patterns": map[string]interface{}{"employee_id": `A-\d{4}`}}},
+	{tool: "hwp_scan_pii", name: "hwp_scan_pii-invalid", arguments: map[string]interface{}{"custom_patterns": map[string]interface{}{"broken": "("}}},
+	{tool: "hwp_redact", name: "hwp_redact-dry-run", arguments: map[string]interface{}{"dry_run": true}},
+	{tool: "hwp_redact", name: "hwp_redact-unknown", arguments: map[string]interface{}{"patterns": []interface{}{"passport"}}},
+	{tool: "hwp_redact", name: "hwp_redact-box", arguments: map[string]interface{}{"patterns": []interface{}{"phone"}}},
+	{tool: "hwp_redact", arguments: map[string]interface{}{"mode": "mask", "custom_patterns": map[string]interface{}{"employee_id": `A-\d{4}`}}},
+	{tool: "hwp_replace_between_bookmarks", arguments: map[string]interface{}{
+		"start_bookmark": "summary_start", "end_bookmark": "summary_end", "text": "3분기 매출은 전년 대비 12% 늘었다."}},
+	{tool: "hwp_replace_between_bookmarks", name: "hwp_replace_between_bookmarks-no-content",
+		arguments: map[string]interface{}{"start_bookmark": "summary_start", "end_bookmark": "summary_end"}},
+	{tool: "hwp_replace_between_bookmarks", name: "hwp_replace_between_bookmarks-invalid-block", arguments: map[string]interface{}{
+		"start_bookmark": "summary_start", "end_bookmark": "summary_end", "blocks": []interface{}{map[string]interface{}{"type": "chart"}}}},
+	{tool: "hwp_apply_patch", arguments: map[string]interface{}{"operations": []interface{}{
+		map[string]interface{}{"op": "replace_range", "start": map[string]interface{}{"para": 2, "pos": 0}, "end": map[string]interface{}{"para": 2, "pos": 4}, "text": "개요"},
+		map[string]interface{}{"op": "insert_at", "at": map[string]interface{}{"para": 0, "pos": 0}, "text": "[초안] "},
+	}}},
+	{tool: "hwp_apply_patch", name: "hwp_apply_patch-invalid", arguments: map[string]interface{}{"operations": []interface{}{
+		map[string]interface{}{"op": "format_range", "start": map[string]interface{}{"bookmark": "a"}, "end": map[string]interface{}{"bookmark": "b"}},
+	}}},
+	{tool: "hwp_set_bibliography", arguments: map[string]interface{}{"style": "numbered", "sources": []interface{}{
+		map[string]interface{}{"key": "kim2023", "authors": []interface{}{"Kim, Minsu", "Lee, Jiwon"}, "title": "HWP Automation in Practice",
+			"container": "Journal of Office Software", "volume": 12, "issue": 3, "pages": "45--67", "year": 2023},
+		map[string]interface{}{"key": "hong2021", "authors": []interface{}{"홍길동"}, "title": "문서 자동화", "publisher": "한빛", "year": 2021},
+	}}},
+	{tool: "hwp_set_bibliography", name: "hwp_set_bibliography-invalid", arguments: map[string]interface{}{"bibtex": "@book{hong2021, title = {문서 자동화}"}},
+	{tool: "hwp_insert_citation", arguments: map[string]interface{}{"keys": []interface{}{"hong2021", "kim2023"}, "page": "12"}},
+	{tool: "hwp_insert_citation", name: "hwp_insert_citation-unknown", arguments: map[string]interface{}{"keys": []interface{}{"park2019"}}},
+	{tool: "hwp_insert_bibliography"},
+	{tool: "hwp_get_text"},
+	{tool: "hwp_get_paragraphs", arguments: map[string]interface{}{"start_index": 1, "count": 3}},
+	{tool: "hwp_move_paragraphs", arguments: map[string]interface{}{"start_index": 1, "count": 2, "to_index": 4}},
+	{tool: "hwp_delete_paragraphs", arguments: map[string]interface{}{"start_index": 2, "dry_run": true}},
+	{tool: "hwp_delete_paragraphs", name: "hwp_delete_paragraphs-out-of-range", arguments: map[string]interface{}{"start_index": 1000, "count": 2}},
+	{tool: "hwp_validate_document", arguments: map[string]interface{}{"rules": map[string]interface{}{"min_pages": 1, "max_pages": 5}}},
+	{tool: "hwp_validate_document", name: "hwp_validate_document-violations", arguments: map[string]interface{}{"rules": map[string]interface{}{
+		"required_sections": []interface{}{"배치 작업", "1. 결론"}, "allowed_fonts": []interface{}{"바탕"}}}},
+	{tool: "hwp_find_duplicates", arguments: map[string]interface{}{"threshold": 0.6, "min_length": 4}},
+	{tool: "hwp_export_model", arguments: map[string]interface{}{"path": "{{dir}}/model.json"}},
+	{tool: "hwp_save", arguments: map[string]interface{}{"path": "{{dir}}/suite.hwpx"}},
+	{tool: "hwp_print_to_pdf", arguments: map[string]interface{}{"path": "{{dir}}/printed.pdf", "printer": "hancom", "pages": "1-2"}},
+	{tool: "hwp_print_to_pdf", name: "hwp_print_to_pdf-pages", arguments: map[string]interface{}{"path": "{{dir}}/printed.pdf", "pages": "1-"}},
+	{tool: "hwp_save", name: "hwp_save-dry-run", arguments: map[string]interface{}{"path": "{{dir}}/suite.hwpx", "dry_run": true}},
+	{tool: "hwp_change_password", arguments: map[string]interface{}{"current_password": "old-secret", "new_password": "new-secret"}},
+	{tool: "hwp_change_password", name: "hwp_change_password-missing", arguments: map[string]interface{}{"current_password": ""}},
+	{tool: "hwp_protect_document", arguments: map[string]interface{}{"mode": "read_only"}},
+	{tool: "hwp_revert"},
+	{tool: "hwp_close", arguments: map[string]interface{}{"discard_changes": true}},
+	{tool: "hwp_open", arguments: map[string]interface{}{"path": "{{dir}}/suite.hwpx", "read_only": true}},
+	{tool: "hwp_close", name: "hwp_close-opened", arguments: map[string]interface{}{"discard_changes": true}},
+	{tool: "hwp_list_recent", arguments: map[string]interface{}{"query": "SUITE"}},
+	{tool: "hwp_list_files", arguments: map[string]interface{}{"pattern": "*.hwpx, *.CSV"}},
+	{tool: "hwp_list_files", name: "hwp_list_files-outside", arguments: map[string]interface{}{"dir": "{{dir}}/.."}},
+	{tool: "hwp_convert_file", arguments: map[string]interface{}{"src": "{{dir}}/suite.hwpx", "dst": "{{dir}}/suite.pdf"}},
+	{tool: "hwp_convert_file", name: "hwp_convert_file-dry-run",
+		arguments: map[string]interface{}{"src": "{{dir}}/suite.hwpx", "dst": "{{dir}}/data.csv", "format": "txt", "overwrite": true, "dry_run": true}},
+	{tool: "hwp_compress_images", arguments: map[string]interface{}{"src": "{{dir}}/suite.hwpx", "max_pixels": 1600}},
+	{tool: "hwp_compress_images", name: "hwp_compress_images-dry-run",
+		arguments: map[string]interface{}{"src": "{{dir}}/suite.hwpx", "dst": "{{dir}}/suite_small.hwp", "dry_run": true}},
+	{tool: "hwp_insert_page_of_document", arguments: map[string]interface{}{"path": "{{dir}}/suite.hwpx", "from": 1, "to": 2}},
+	{tool: "hwp_import_model", arguments: map[string]interface{}{"model": `{"version": 1, "blocks": [{"type": "paragraph", "runs": [{"text": "모델", "bold": true}]}, {"type": "table", "rows": [["a", "b"]]}]}`}},
+	{tool: "hwp_create_document_from_text", arguments: map[string]interface{}{"title": "회의록", "content": "안건 1\n안건 2"}},
+	{tool: "hwp_create_complete_document", arguments: map[string]interface{}{"spec": map[string]interface{}{
+		"type":     "report",
+		"title":    "{{team}} 보고서",
+		"data":     map[string]interface{}{"team": "개발팀"},
+		"sections": []interface{}{map[string]interface{}{"title": "개요", "content": "내용"}},
+	}}},
+	{tool: "hwp_create_complete_document", name: "hwp_create_complete_document-theme", arguments: map[string]interface{}{
+		"spec": map[string]interface{}{"type": "memo", "subject": "공지", "body": "본문"},
+		"theme": map[string]interface{}{
+			"font": "나눔고딕", "heading_font": "나눔스퀘어", "accent_color": "#1F4E79",
+			"sizes": map[string]interface{}{"본문": 10, "제목2": 20},
+		},
+	}},
+	{tool: "hwp_create_complete_document", name: "hwp_create_complete_document-letterhead", arguments: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"type": "letter", "recipient": "홍길동", "body": "본문",
+			"letterhead": map[string]interface{}{"organization": "한국회사", "address": "서울특별시 중구 세종대로 110"},
+		},
+	}},
+	{tool: "hwp_create_complete_document", name: "hwp_create_complete_document-letterhead-invalid", arguments: map[string]interface{}{
+		"spec": map[string]interface{}{"type": "letter", "letterhead": map[string]interface{}{"address": "서울", "rule": "maybe"}},
+	}},
+	{tool: "hwp_create_complete_document", name: "hwp_create_complete_document-official", arguments: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"type": "official", "organization": "행정안전부", "receiver": "수신자 참조", "title": "2024년 정보화 교육 협조 요청",
+			"clauses": []interface{}{
+				"관련: 행정안전부 지침(2024. 2. 1.)",
+				map[string]interface{}{"text": "다음과 같이 교육을 실시하오니 협조하여 주시기 바랍니다.",
+					"items": []interface{}{"일시: 2024. 3. 15.(금) 14:00", "장소: 정부서울청사 대회의실"}},
+			},
+			"attachments": []interface{}{"교육 계획서 1부.", "참석자 명단 1부."},
+			"sender":      "행정안전부장관", "approvals": []interface{}{"주무관 홍길동", "과장 김철수"},
+			"document_number": "정보화담당관-123", "date": "2024. 3. 1.",
+			"postal_code": "03171", "address": "서울특별시 종로구 세종대로 209", "phone": "02-2100-0000", "disclosure": "대국민 공개",
+		},
+	}},
+	{tool: "hwp_get_text", name: "hwp_get_text-official"},
+	{tool: "hwp_create_complete_document", name: "hwp_create_complete_document-invoice", arguments: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"type": "invoice", "number": "2024-031", "date": "2024. 3. 1.", "customer": "한국상사",
+			"supplier": map[string]interface{}{"registration_number": "123-45-67890", "name": "한빛소프트", "representative": "홍길동", "phone": "02-123-4567"},
+			"items": []interface{}{
+				map[string]interface{}{"name": "노트북", "spec": "15인치", "quantity": 2, "unit_price": 1250000},
+				map[string]interface{}{"name": "설치비", "quantity": 1, "unit_price": "150000", "note": "현장 설치"},
+			},
+			"notes": "납품 후 30일 이내 결제",
+		},
+	}},
+	{tool: "hwp_get_text", name: "hwp_get_text-invoice"},
+	{tool: "hwp_create_complete_document", name: "hwp_create_complete_document-minutes", arguments: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"type": "minutes", "title": "주간 회의록", "date": "2024. 3. 4. 10:00", "location": "3층 회의실",
+			"attendees": []interface{}{"김팀장", "이대리", "박주임"}, "recorder": "박주임",
+			"agenda": []interface{}{"1분기 실적 점검", "신규 프로젝트 일정"},
+			"discussions": []interface{}{
+				map[string]interface{}{"title": "1분기 실적 점검", "content": "매출 목표 대비 95% 달성"},
+				map[string]interface{}{"title": "신규 프로젝트 일정", "content": "착수일을 4월 1일로 확정"},
+			},
+			"decisions": []interface{}{"착수일 4월 1일"},
+			"action_items": []interface{}{
+				map[string]interface{}{"task": "실적 보고서 작성", "owner": "이대리", "due": "3. 8."},
+				map[string]interface{}{"task": "착수 회의 준비", "owner": "박주임", "due": "3. 29."},
+			},
+		},
+	}},
+	{tool: "hwp_get_text", name: "hwp_get_text-minutes"},
+	{tool: "hwp_create_complete_document", name: "hwp_create_complete_document-certificate", arguments: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"type": "certificate", "number": "제 2024-015 호", "award": "최우수상", "affiliation": "개발팀",
+			"recipient": "홍길동", "body": "위 사람은 2024년 사내 혁신 공모전에서 탁월한 성과를 거두었으므로 이 상장을 수여합니다.",
+			"date": "2024년 3월 15일", "issuer": "한빛소프트 대표이사",
+		},
+	}},
+	{tool: "hwp_get_text", name: "hwp_get_text-certificate"},
+	{tool: "hwp_create_complete_document", name: "hwp_create_complete_document-certificate-invalid", arguments: map[string]interface{}{
+		"spec": map[string]interface{}{"type": "certificate", "border": "wavy"},
+	}},
+	{tool: "hwp_create_complete_document", name: "hwp_create_complete_document-resume", arguments: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"type": "resume", "name": "홍길동", "name_hanja": "洪吉童", "birth_date": "1995. 5. 5.",
+			"phone": "010-1234-5678", "email": "hong@example.com", "address": "서울특별시 중구 세종대로 110",
+			"education": []interface{}{
+				map[string]interface{}{"period": "2014. 3. ~ 2020. 2.", "school": "한국대학교", "major": "컴퓨터공학", "status": "졸업"},
+			},
+			"career": []interface{}{
+				map[string]interface{}{"period": "2020. 3. ~ 현재", "company": "한빛소프트", "position": "선임", "duties": "백엔드 개발"},
+			},
+			"certifications": []interface{}{map[string]interface{}{"date": "2019. 8. 30.", "name": "정보처리기사", "issuer": "한국산업인력공단"}},
+			"date":           "2024년 3월 1일",
+			"introduction": []interface{}{
+				map[string]interface{}{"title": "성장과정", "content": "어릴 때부터 컴퓨터를 좋아했습니다."},
+				map[string]interface{}{"title": "지원동기", "content": "귀사의 서비스를 함께 만들고 싶습니다."},
+			},
+		},
+	}},
+	{tool: "hwp_get_text", name: "hwp_get_text-resume"},
+	{tool: "hwp_create_complete_document", name: "hwp_create_complete_document-invoice-invalid", arguments: map[string]interface{}{
+		"spec": map[string]interface{}{"type": "invoice", "items": []interface{}{map[string]interface{}{"name": "노트북", "quantity": -1}}},
+	}},
+	{tool: "hwp_generate_documents", arguments: map[string]interface{}{
+		"specs":            []interface{}{map[string]interface{}{"type": "memo", "to": "전 직원", "subject": "공지", "body": "본문"}},
+		"output_dir":       "{{dir}}/generated",
+		"filename_pattern": "memo_{index}.hwpx",
+	}},
+	{tool: "hwp_generate_documents", name: "hwp_generate_documents-duplicate", arguments: map[string]interface{}{
+		"specs": []interface{}{
+			map[string]interface{}{"type": "memo", "subject": "공지"},
+			map[string]interface{}{"type": "memo", "subject": "공지"},
+		},
+		"output_dir":       "{{dir}}/generated",
+		"filename_pattern": "{subject}.hwpx",
+	}},
+	{tool: "hwp_close", name: "hwp_close-final", arguments: map[string]interface{}{"discard_changes": true}},
+	{tool: "hwp_diagnostics"},
+	{tool: "hwp_metrics"},
+}
+
+// volatilePatterns match result text that changes between runs
+var volatilePatterns = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`\b\d+(\.\d+)?(ns|µs|ms|s)\b`), "<duration>"},
+	{regexp.MustCompile(`(\\?")(\w*_ms|duration\w*|avg\w*|p50\w*|p95\w*|p99\w*)(\\?"): *[0-9.]+`), `$1$2$3: <duration>`},
+	{regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})`), "<time>"},
+	{regexp.MustCompile(`\d{8}-\d{6}`), "<timestamp>"},
+	{regexp.MustCompile(`\d+ bytes`), "<size> bytes"},
+	{regexp.MustCompile(`(\\?"size\\?"): *\d+`), "$1: <size>"},
+	{regexp.MustCompile(`\b[0-9a-f]{16,}\b`), "<id>"},
+}
+
+// runToolSuite calls every tool case with files in workDir, compares each
+// normalized result with its golden file in goldenDir and fails if a listed
+// tool has no case. With update set the golden files are rewritten instead.
+// Each case is added to report.
+func runToolSuite(client *MCPTestClient, workDir, goldenDir string, update bool, report *Report) error {
+	if err := checkCaseNames(toolCases); err != nil {
+		return err
+	}
+	tools, err := client.ListToolNames()
+	if err != nil {
+		return err
+	}
+	covered := map[string]bool{}
+	for _, c := range toolCases {
+		covered[c.tool] = true
+	}
+	var missing []string
+	for _, tool := range tools {
+		if !covered[tool] {
+			missing = append(missing, tool)
+		}
+	}
+
+	if update {
+		if err := os.MkdirAll(goldenDir, 0o755); err != nil {
+			return err
+		}
+	}
+
+	token := "unknown-token"
+	failed := 0
+	for _, c := range toolCases {
+		name := c.caseName()
+		started := time.Now()
+		err := runToolCase(client, c, filepath.Join(goldenDir, name+".golden"), workDir, &token, update)
+		report.Add(name, started, err)
+		if err != nil {
+			failed++
+			fmt.Printf("❌ %s: %v\n", name, err)
+			continue
+		}
+		fmt.Printf("✅ %s\n", name)
+	}
+
+	for _, tool := range missing {
+		report.Add("coverage/"+tool, time.Now(), fmt.Errorf("registered but has no test case"))
+		fmt.Printf("❌ %s: registered but has no test case\n", tool)
+	}
+	fmt.Printf("\n%d cases, %d failed, %d of %d tools without a case\n", len(toolCases), failed, len(missing), len(tools))
+
+	if failed > 0 || len(missing) > 0 {
+		return fmt.Errorf("%d cases failed and %d tools are not covered", failed, len(missing))
+	}
+	return nil
+}
+
+// runToolCase calls the tool of c and compares the result with goldenPath, or
+// writes it there if update is set. token is the current table fill token.
+func runToolCase(client *MCPTestClient, c toolCase, goldenPath, workDir string, token *string, update bool) error {
+	arguments := expandArguments(c.arguments, workDir, *token).(map[string]interface{})
+	result, err := client.CallTool(c.tool, arguments)
+	if err != nil {
+		return err
+	}
+	if c.tool == "hwp_begin_table_fill" {
+		if t := extractToken(result.Text); t != "" {
+			*token = t
+		}
+	}
+
+	actual := normalizeResult(result, workDir)
+	if update {
+		return os.WriteFile(goldenPath, []byte(actual), 0o644)
+	}
+
+	expected, err := os.ReadFile(goldenPath)
+	if err != nil {
+		return fmt.Errorf("no golden file (run with -update): %v", err)
+	}
+	if string(expected) != actual {
+		return fmt.Errorf("result differs from %s:\n--- expected\n%s\n--- actual\n%s", goldenPath, expected, actual)
+	}
+	return nil
+}
+
+// writeSuiteFiles creates the CSV, text and image files the cases read
+func writeSuiteFiles(dir string) error {
+	if err := os.WriteFile(filepath.Join(dir, "data.csv"), []byte("이름,점수\n홍길동,90\n김철수,85\n"), 0o644); err != nil {
+		return err
+	}
+	// UTF-16LE with a byte order mark and Windows line breaks
+	notes := []byte{0xFF, 0xFE}
+	for _, unit := range utf16.Encode([]rune("첫째 줄\r\n둘째 줄\r\n")) {
+		notes = append(notes, byte(unit), byte(unit>>8))
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), notes, 0o644); err != nil {
+		return err
+	}
+	// "한글" in EUC-KR
+	if err := os.WriteFile(filepath.Join(dir, "legacy.txt"), []byte{0xC7, 0xD1, 0xB1, 0xDB}, 0o644); err != nil {
+		return err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for x := 0; x < 8; x++ {
+		for y := 0; y < 8; y++ {
+			img.Set(x, y, color.RGBA{R: 200, G: 30, B: 30, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "image.png"), buf.Bytes(), 0o644)
+}
+
+// expandArguments replaces {{dir}} and {{token}} in string arguments
+func expandArguments(value interface{}, dir, token string) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return map[string]interface{}{}
+	case string:
+		return strings.NewReplacer("{{dir}}", filepath.ToSlash(dir), "{{token}}", token).Replace(v)
+	case map[string]interface{}:
+		expanded := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			expanded[key] = expandArguments(item, dir, token)
+		}
+		return expanded
+	case []interface{}:
+		expanded := make([]interface{}, len(v))
+		for i, item := range v {
+			expanded[i] = expandArguments(item, dir, token)
+		}
+		return expanded
+	default:
+		return v
+	}
+}
+
+// extractToken reads the token of a hwp_begin_table_fill result
+func extractToken(text string) string {
+	var result struct {
+		Token string `json:"token"`
+	}
+	if json.Unmarshal([]byte(text), &result) == nil && result.Token != "" {
+		return result.Token
+	}
+	if match := regexp.MustCompile(`token[^A-Za-z0-9]+([A-Za-z0-9_-]+)`).FindStringSubmatch(text); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// normalizeResult renders a result for comparison, with the working directory
+// and values that change between runs replaced by placeholders
+func normalizeResult(result *ToolResult, workDir string) string {
+	text := result.Text
+	for _, dir := range []string{filepath.ToSlash(workDir), workDir} {
+		text = strings.ReplaceAll(text, dir, "{{dir}}")
+	}
+	if escaped, err := json.Marshal(workDir); err == nil {
+		text = strings.ReplaceAll(text, strings.Trim(string(escaped), `"`), "{{dir}}")
+	}
+	for _, volatile := range volatilePatterns {
+		text = volatile.pattern.ReplaceAllString(text, volatile.replacement)
+	}
+	return fmt.Sprintf("error: %t\n---\n%s\n", result.IsError, text)
+}
@@ -0,0 +1,881 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Tool names for advanced document creation
+const (
+	HWP_CREATE_COMPLETE_DOCUMENT = "hwp_create_complete_document"
+	HWP_GENERATE_DOCUMENTS       = "hwp_generate_documents"
+	HWP_INSERT_COVER_PAGE        = "hwp_insert_cover_page"
+)
+
+// Advanced document creation tool handlers
+
+func HandleHwpCreateCompleteDocument(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	spec, ok, err := objectArgument(request, "spec")
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	if !ok {
+		return hwp.CreateTextResult("Error: Document specification is required"), nil
+	}
+	// Report spec errors before replacing the open document
+	if _, err := prepareSpec(spec); err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	theme, err := themeArgument(request, "theme")
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil {
+			controller = hwp.NewController()
+			hwp.SetGlobalController(controller)
+		}
+
+		// Create new document
+		err := controller.CreateNewDocument()
+		if err != nil {
+			hwp.SetGlobalController(nil)
+			result = hwp.CreateTextResult(fmt.Sprintf("Error creating document: %v", err))
+			return
+		}
+
+		docType, _ := spec["type"].(string)
+
+		if err := buildDocument(controller, spec, theme); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error creating %s document: %v", docType, err))
+			return
+		}
+
+		message := fmt.Sprintf("Complete %s document created successfully", docType)
+		if theme != nil {
+			message += " with the given theme"
+		}
+		result = hwp.CreateTextResult(message)
+	})
+
+	return result, nil
+}
+
+func HandleHwpGenerateDocuments(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	specs, ok, err := objectArrayArgument(request, "specs")
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	if !ok {
+		return hwp.CreateTextResult("Error: Document specifications are required"), nil
+	}
+	outputDir := request.GetString("output_dir", "")
+	if outputDir == "" {
+		return hwp.CreateTextResult("Error: Output directory is required"), nil
+	}
+	pattern := request.GetString("filename_pattern", "document_{index}.hwp")
+	theme, err := themeArgument(request, "theme")
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	if len(specs) == 0 {
+		return hwp.CreateTextResult("Error: Specs array is empty"), nil
+	}
+
+	paths, err := generatedDocumentPaths(outputDir, pattern, specs)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: Failed to create output directory - %v", err)), nil
+	}
+
+	type documentReport struct {
+		Index  int    `json:"index"`
+		Type   string `json:"type"`
+		Path   string `json:"path,omitempty"`
+		Status string `json:"status"`
+		Error  string `json:"error,omitempty"`
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil {
+			controller = hwp.NewController()
+			hwp.SetGlobalController(controller)
+		}
+
+		var reports []documentReport
+		succeeded := 0
+		for i, spec := range specs {
+			docType, _ := spec["type"].(string)
+			report := documentReport{Index: i + 1, Type: docType}
+
+			path := paths[i]
+			err := controller.CreateNewDocument()
+			if err == nil {
+				err = buildDocument(controller, spec, theme)
+				if err == nil {
+					err = controller.SaveDocument(path)
+					path = controller.CurrentPath()
+				}
+				// Close each generated document, failed ones too, so
+				// windows don't pile up
+				if closeErr := controller.CloseDocument(); err == nil {
+					err = closeErr
+				}
+			}
+
+			if err != nil {
+				report.Status = "error"
+				report.Error = err.Error()
+			} else {
+				report.Status = "success"
+				report.Path = path
+				succeeded++
+			}
+			reports = append(reports, report)
+			sendProgress(ctx, request, i+1, len(specs), fmt.Sprintf("%d/%d documents generated", i+1, len(specs)))
+		}
+
+		resultJSON, _ := json.Marshal(map[string]interface{}{
+			"total_documents": len(specs),
+			"succeeded":       succeeded,
+			"failed":          len(specs) - succeeded,
+			"documents":       reports,
+		})
+		result = hwp.CreateTextResult(string(resultJSON))
+	})
+
+	return result, nil
+}
+
+// generatedDocumentPaths returns the absolute path of each document of
+// hwp_generate_documents, failing if two specs would be saved to the same
+// file. Case is ignored, as it is in Windows file names.
+func generatedDocumentPaths(outputDir, pattern string, specs []map[string]interface{}) ([]string, error) {
+	paths := make([]string, len(specs))
+	first := map[string]int{}
+	for i, spec := range specs {
+		path, err := filepath.Abs(filepath.Join(outputDir, expandFilenamePattern(pattern, i+1, spec)))
+		if err != nil {
+			return nil, fmt.Errorf("specs[%d]: %v", i, err)
+		}
+		key := strings.ToLower(path)
+		if j, taken := first[key]; taken {
+			return nil, fmt.Errorf("specs[%d] and specs[%d] would both be saved as %s; use {index} or a spec field in filename_pattern to tell them apart", j, i, path)
+		}
+		first[key] = i
+		paths[i] = path
+	}
+	return paths, nil
+}
+
+func HandleHwpInsertCoverPage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	cover := coverPage{
+		Title:        request.GetString("title", ""),
+		Subtitle:     request.GetString("subtitle", ""),
+		Author:       request.GetString("author", ""),
+		Date:         request.GetString("date", ""),
+		Organization: request.GetString("organization", ""),
+		LogoPath:     request.GetString("logo_path", ""),
+	}
+	if cover.Title == "" {
+		return hwp.CreateTextResult("Error: Title is required"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := insertCoverPage(controller, cover, currentPresets()); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error inserting cover page: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Cover page inserted: %s", cover.Title))
+	})
+
+	return result, nil
+}
+
+// filenamePlaceholder matches {name} placeholders in a filename pattern
+var filenamePlaceholder = regexp.MustCompile(`\{([a-zA-Z0-9_]+)\}`)
+
+// expandFilenamePattern fills {index} and top-level string spec fields such as
+// {title} or {type} into pattern, stripping characters Windows rejects in file names
+func expandFilenamePattern(pattern string, index int, spec map[string]interface{}) string {
+	name := filenamePlaceholder.ReplaceAllStringFunc(pattern, func(match string) string {
+		key := match[1 : len(match)-1]
+		if key == "index" {
+			return strconv.Itoa(index)
+		}
+		if value, ok := spec[key]; ok {
+			return fmt.Sprintf("%v", value)
+		}
+		return ""
+	})
+
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`\/:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+
+	if filepath.Ext(name) == "" {
+		name += ".hwp"
+	}
+	return name
+}
+
+// Document creation helper functions
+
+// buildDocument expands spec templating and renders it into the current document
+// according to its type, in theme if one is given
+func buildDocument(controller *hwp.Controller, spec map[string]interface{}, theme *documentTheme) error {
+	spec, err := prepareSpec(spec)
+	if err != nil {
+		return err
+	}
+	docType, _ := spec["type"].(string)
+
+	styles := currentPresets()
+	if theme != nil {
+		styles = theme.presets(styles)
+		if err := theme.applyPage(controller); err != nil {
+			return err
+		}
+	}
+	if head, ok := specLetterhead(spec); ok {
+		if err := insertLetterhead(controller, head, styles); err != nil {
+			return fmt.Errorf("letterhead: %v", err)
+		}
+	}
+
+	switch docType {
+	case "report":
+		return createReportDocument(controller, spec, styles)
+	case "letter":
+		return createLetterDocument(controller, spec, styles)
+	case "memo":
+		return createMemoDocument(controller, spec, styles)
+	case "official":
+		return createOfficialDocument(controller, spec, styles)
+	case "invoice":
+		return createInvoiceDocument(controller, spec, styles)
+	case "minutes":
+		return createMinutesDocument(controller, spec, styles)
+	case "certificate":
+		return createCertificateDocument(controller, spec, styles)
+	case "resume":
+		return createResumeDocument(controller, spec, styles)
+	default:
+		return createGenericDocument(controller, spec, styles)
+	}
+}
+
+// specTextFields are the text fields of each document type; other types are
+// generic documents
+var specTextFields = map[string][]string{
+	"report":      {"title", "subtitle", "author", "date", "organization", "logo_path"},
+	"letter":      {"recipient", "sender", "date", "subject", "body", "closing"},
+	"memo":        {"to", "from", "date", "subject", "body"},
+	"official":    officialTextFields,
+	"invoice":     invoiceTextFields,
+	"minutes":     minutesTextFields,
+	"certificate": certificateTextFields,
+	"resume":      resumeTextFields,
+	"":            {"title", "content"},
+}
+
+// prepareSpec expands spec templating and validates the result
+func prepareSpec(spec map[string]interface{}) (map[string]interface{}, error) {
+	spec, err := expandSpec(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid spec template: %v", err)
+	}
+	if err := validateSpec(spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// validateSpec checks the field types of an expanded document spec
+func validateSpec(spec map[string]interface{}) error {
+	fields := &fieldReader{path: "spec", object: spec}
+	docType := fields.String("type")
+
+	names, ok := specTextFields[docType]
+	if !ok {
+		names = specTextFields[""]
+	}
+	for _, name := range names {
+		fields.String(name)
+	}
+
+	if head := fields.Object("letterhead"); head != nil {
+		headFields := &fieldReader{path: "spec.letterhead", object: head}
+		logoPath := headFields.String("logo_path")
+		organization := headFields.String("organization")
+		headFields.String("address")
+		headFields.Bool("rule")
+		if organization == "" && logoPath == "" {
+			headFields.fail("organization", "is required without logo_path")
+		}
+		if headFields.err != nil && fields.err == nil {
+			fields.err = headFields.err
+		}
+	}
+
+	if docType == "official" {
+		readOfficialDocument(fields)
+	}
+	if docType == "invoice" {
+		readInvoiceDocument(fields)
+	}
+	if docType == "minutes" {
+		readMinutesDocument(fields)
+	}
+	if docType == "certificate" {
+		readCertificateDocument(fields)
+	}
+	if docType == "resume" {
+		readResumeDocument(fields)
+	}
+
+	if docType == "report" {
+		fields.Bool("cover")
+		if sections, ok := spec["sections"]; ok && sections != nil {
+			items, isArray := sections.([]interface{})
+			if !isArray {
+				fields.fail("sections", "must be an array of {title, content} objects")
+			}
+			for i, item := range items {
+				section, isObject := item.(map[string]interface{})
+				if !isObject {
+					fields.fail(fmt.Sprintf("sections[%d]", i), "must be an object")
+					break
+				}
+				sectionFields := &fieldReader{path: fmt.Sprintf("spec.sections[%d]", i), object: section}
+				sectionFields.String("title")
+				sectionFields.String("content")
+				if sectionFields.err != nil && fields.err == nil {
+					fields.err = sectionFields.err
+				}
+			}
+		}
+	}
+	return fields.err
+}
+
+// letterhead holds the organization block laid out by insertLetterhead
+type letterhead struct {
+	LogoPath     string
+	Organization string
+	Address      string
+	Rule         bool
+}
+
+// Letterhead logo bounds (hwpunit), small enough for the header area
+const (
+	letterheadLogoMaxWidth  = 8000
+	letterheadLogoMaxHeight = 3400
+)
+
+// specLetterhead reads the letterhead block of a validated spec; the rule
+// under it is drawn unless "rule" is false
+func specLetterhead(spec map[string]interface{}) (letterhead, bool) {
+	object, ok := spec["letterhead"].(map[string]interface{})
+	if !ok {
+		return letterhead{}, false
+	}
+	fields := &fieldReader{path: "spec.letterhead", object: object}
+	head := letterhead{
+		LogoPath:     fields.String("logo_path"),
+		Organization: fields.String("organization"),
+		Address:      fields.String("address"),
+		Rule:         true,
+	}
+	if _, set := fields.value("rule"); set {
+		head.Rule = fields.Bool("rule")
+	}
+	return head, true
+}
+
+// logoDescription is the alternative text of an organization's logo
+func logoDescription(organization string) string {
+	if organization == "" {
+		return "로고"
+	}
+	return organization + " 로고"
+}
+
+// insertLetterhead writes the logo, organization name and address line into
+// the page header, with a rule below, so it repeats on every page
+func insertLetterhead(controller *hwp.Controller, head letterhead, styles stylePresetSet) error {
+	return controller.EditPageHeader(func() error {
+		if err := controller.SetParagraphAlignment("left"); err != nil {
+			return err
+		}
+		if head.LogoPath != "" {
+			maxWidth, maxHeight := letterheadLogoMaxWidth, letterheadLogoMaxHeight
+			if err := controller.InsertImage(head.LogoPath, nil, nil, false, &maxWidth, &maxHeight, nil, true, true, false, false, 0, logoDescription(head.Organization), nil, nil, nil); err != nil {
+				return err
+			}
+			if head.Organization != "" {
+				if err := controller.InsertText("  ", false); err != nil {
+					return err
+				}
+			}
+		}
+		if head.Organization != "" {
+			if err := styles.apply(controller, "머리말기관"); err != nil {
+				return err
+			}
+			if err := controller.InsertText(head.Organization, false); err != nil {
+				return err
+			}
+		}
+		if head.Address != "" {
+			if err := controller.InsertParagraph(); err != nil {
+				return err
+			}
+			if err := styles.apply(controller, "머리말주소"); err != nil {
+				return err
+			}
+			if err := controller.InsertText(head.Address, false); err != nil {
+				return err
+			}
+		}
+		if head.Rule {
+			return controller.SetParagraphRule(0.4, "black")
+		}
+		return nil
+	})
+}
+
+// coverPage holds the fields laid out by insertCoverPage
+type coverPage struct {
+	Title        string
+	Subtitle     string
+	Author       string
+	Date         string
+	Organization string
+	LogoPath     string
+}
+
+// Cover page logo bounds (hwpunit)
+const (
+	coverLogoMaxWidth  = 12000
+	coverLogoMaxHeight = 8000
+)
+
+// insertCoverPage lays out a centered cover page and starts a new page after it
+func insertCoverPage(controller *hwp.Controller, cover coverPage, styles stylePresetSet) error {
+	if err := controller.SetParagraphAlignment("center"); err != nil {
+		return err
+	}
+
+	// Logo at the top
+	if cover.LogoPath != "" {
+		maxWidth, maxHeight := coverLogoMaxWidth, coverLogoMaxHeight
+		if err := controller.InsertImage(cover.LogoPath, nil, nil, false, &maxWidth, &maxHeight, nil, true, true, false, false, 0, logoDescription(cover.Organization), nil, nil, nil); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+	}
+
+	// Title block in the upper middle of the page
+	if err := insertBlankLines(controller, 6); err != nil {
+		return err
+	}
+	if err := styles.apply(controller, "표지제목"); err != nil {
+		return err
+	}
+	if err := controller.InsertText(cover.Title, true); err != nil {
+		return err
+	}
+	if err := controller.InsertParagraph(); err != nil {
+		return err
+	}
+	if cover.Subtitle != "" {
+		if err := styles.apply(controller, "표지부제목"); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+		if err := controller.InsertText(cover.Subtitle, true); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+	}
+
+	// Date, author and organization near the bottom
+	if err := insertBlankLines(controller, 10); err != nil {
+		return err
+	}
+	if err := styles.apply(controller, "표지정보"); err != nil {
+		return err
+	}
+	for _, line := range []string{cover.Date, cover.Author} {
+		if line == "" {
+			continue
+		}
+		if err := controller.InsertText(line, false); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+	}
+	if cover.Organization != "" {
+		if err := styles.apply(controller, "제목2"); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+		if err := controller.InsertText(cover.Organization, false); err != nil {
+			return err
+		}
+	}
+
+	// Body starts on the next page with default formatting
+	if err := controller.InsertPageBreak(); err != nil {
+		return err
+	}
+	if err := controller.SetParagraphAlignment("justify"); err != nil {
+		return err
+	}
+	return styles.apply(controller, "본문")
+}
+
+// insertBlankLines inserts count empty paragraphs
+func insertBlankLines(controller *hwp.Controller, count int) error {
+	for i := 0; i < count; i++ {
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createReportDocument(controller *hwp.Controller, spec map[string]interface{}, styles stylePresetSet) error {
+	title, _ := spec["title"].(string)
+	author, _ := spec["author"].(string)
+	date, _ := spec["date"].(string)
+	sections, _ := spec["sections"].([]interface{})
+
+	// Cover page replaces the inline title block
+	if withCover, _ := spec["cover"].(bool); withCover {
+		subtitle, _ := spec["subtitle"].(string)
+		organization, _ := spec["organization"].(string)
+		logoPath, _ := spec["logo_path"].(string)
+		cover := coverPage{
+			Title:        title,
+			Subtitle:     subtitle,
+			Author:       author,
+			Date:         date,
+			Organization: organization,
+			LogoPath:     logoPath,
+		}
+		if err := insertCoverPage(controller, cover, styles); err != nil {
+			return err
+		}
+		return createReportSections(controller, sections, styles)
+	}
+
+	// Title
+	if err := styles.apply(controller, "제목1"); err != nil {
+		return err
+	}
+	if err := controller.InsertText(title, false); err != nil {
+		return err
+	}
+	if err := controller.InsertParagraph(); err != nil {
+		return err
+	}
+
+	// Author and date
+	if err := styles.apply(controller, "본문"); err != nil {
+		return err
+	}
+	if author != "" {
+		if err := controller.InsertText(fmt.Sprintf("작성자: %s", author), false); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+	}
+	if date != "" {
+		if err := controller.InsertText(fmt.Sprintf("작성일: %s", date), false); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+	}
+	if err := controller.InsertParagraph(); err != nil {
+		return err
+	}
+
+	return createReportSections(controller, sections, styles)
+}
+
+// createReportSections writes report sections as bold titles followed by content
+func createReportSections(controller *hwp.Controller, sections []interface{}, styles stylePresetSet) error {
+	for _, sectionInterface := range sections {
+		section, ok := sectionInterface.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		sectionTitle, _ := section["title"].(string)
+		sectionContent, _ := section["content"].(string)
+
+		// Section title
+		if err := styles.apply(controller, "제목3"); err != nil {
+			return err
+		}
+		if err := controller.InsertText(sectionTitle, false); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+
+		// Section content
+		if err := styles.apply(controller, "본문"); err != nil {
+			return err
+		}
+		if err := controller.InsertText(sectionContent, true); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func createLetterDocument(controller *hwp.Controller, spec map[string]interface{}, styles stylePresetSet) error {
+	recipient, _ := spec["recipient"].(string)
+	sender, _ := spec["sender"].(string)
+	date, _ := spec["date"].(string)
+	subject, _ := spec["subject"].(string)
+	body, _ := spec["body"].(string)
+	closing, _ := spec["closing"].(string)
+
+	// Date
+	if err := styles.apply(controller, "본문"); err != nil {
+		return err
+	}
+	if date != "" {
+		if err := controller.InsertText(date, false); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+	}
+
+	// Recipient
+	if recipient != "" {
+		if err := controller.InsertText(fmt.Sprintf("%s 귀하", recipient), false); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+	}
+
+	// Subject
+	if subject != "" {
+		if err := styles.apply(controller, "강조"); err != nil {
+			return err
+		}
+		if err := controller.InsertText(fmt.Sprintf("제목: %s", subject), false); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+	}
+
+	// Body
+	if err := styles.apply(controller, "본문"); err != nil {
+		return err
+	}
+	if err := controller.InsertText(body, true); err != nil {
+		return err
+	}
+	if err := controller.InsertParagraph(); err != nil {
+		return err
+	}
+	if err := controller.InsertParagraph(); err != nil {
+		return err
+	}
+
+	// Closing and sender
+	if closing != "" {
+		if err := controller.InsertText(closing, false); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+	}
+	if sender != "" {
+		if err := controller.InsertText(sender, false); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func createMemoDocument(controller *hwp.Controller, spec map[string]interface{}, styles stylePresetSet) error {
+	to, _ := spec["to"].(string)
+	from, _ := spec["from"].(string)
+	date, _ := spec["date"].(string)
+	subject, _ := spec["subject"].(string)
+	body, _ := spec["body"].(string)
+
+	// Header
+	if err := styles.apply(controller, "제목2"); err != nil {
+		return err
+	}
+	if err := controller.InsertText("메모", false); err != nil {
+		return err
+	}
+	if err := controller.InsertParagraph(); err != nil {
+		return err
+	}
+	if err := controller.InsertParagraph(); err != nil {
+		return err
+	}
+
+	// Memo details
+	if err := styles.apply(controller, "본문"); err != nil {
+		return err
+	}
+	if to != "" {
+		if err := controller.InsertText(fmt.Sprintf("받는 사람: %s", to), false); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+	}
+	if from != "" {
+		if err := controller.InsertText(fmt.Sprintf("보내는 사람: %s", from), false); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+	}
+	if date != "" {
+		if err := controller.InsertText(fmt.Sprintf("날짜: %s", date), false); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+	}
+	if subject != "" {
+		if err := controller.InsertText(fmt.Sprintf("제목: %s", subject), false); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+	}
+	if err := controller.InsertParagraph(); err != nil {
+		return err
+	}
+
+	// Body
+	if err := controller.InsertText(body, true); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func createGenericDocument(controller *hwp.Controller, spec map[string]interface{}, styles stylePresetSet) error {
+	title, _ := spec["title"].(string)
+	content, _ := spec["content"].(string)
+
+	// Title
+	if title != "" {
+		if err := styles.apply(controller, "제목2"); err != nil {
+			return err
+		}
+		if err := controller.InsertText(title, false); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+	}
+
+	// Content
+	if err := styles.apply(controller, "본문"); err != nil {
+		return err
+	}
+	if err := controller.InsertText(content, true); err != nil {
+		return err
+	}
+
+	return nil
+}
\ No newline at end of file
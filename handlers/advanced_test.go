@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGeneratedDocumentPaths(t *testing.T) {
+	specs := []map[string]interface{}{
+		{"type": "report", "title": "1분기"},
+		{"type": "report", "title": "2분기"},
+	}
+	paths, err := generatedDocumentPaths("out", "{type}_{index}.hwp", specs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, want := range []string{"report_1.hwp", "report_2.hwp"} {
+		if !filepath.IsAbs(paths[i]) || filepath.Base(paths[i]) != want {
+			t.Errorf("paths[%d] = %s, want an absolute path to %s", i, paths[i], want)
+		}
+	}
+
+	for _, pattern := range []string{"{type}.hwp", "{missing}", "Report.hwp"} {
+		specs := []map[string]interface{}{{"type": "report"}, {"type": "REPORT"}}
+		if pattern == "Report.hwp" {
+			specs[1]["type"] = "memo"
+		}
+		_, err := generatedDocumentPaths("out", pattern, specs)
+		if err == nil || !strings.Contains(err.Error(), "specs[0] and specs[1]") {
+			t.Errorf("pattern %s: error = %v, want the clash of specs[0] and specs[1]", pattern, err)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Structured tool arguments
+//
+// Table data, batch operations and document specs are declared as JSON arrays
+// and objects in the tool schemas. Older clients sent them as JSON text inside
+// a string, so a string argument is still parsed. Validation errors name the
+// offending field, e.g. "data[2][1]" or "operations[0].size".
+
+// structuredArgument returns an array or object argument, parsing it if it was
+// sent as JSON text. ok is false when the argument is missing.
+func structuredArgument(request mcp.CallToolRequest, name string) (value interface{}, ok bool, err error) {
+	value, ok = request.GetArguments()[name]
+	if !ok || value == nil {
+		return nil, false, nil
+	}
+	if text, isString := value.(string); isString {
+		if text == "" {
+			return nil, false, nil
+		}
+		if err := json.Unmarshal([]byte(text), &value); err != nil {
+			return nil, true, fmt.Errorf("%s: invalid JSON - %v", name, err)
+		}
+	}
+	return value, true, nil
+}
+
+// tableDataArgument reads a 2D array of cell values. Numbers and booleans are
+// converted to text and null becomes an empty cell.
+func tableDataArgument(request mcp.CallToolRequest, name string) ([][]string, bool, error) {
+	value, ok, err := structuredArgument(request, name)
+	if !ok || err != nil {
+		return nil, ok, err
+	}
+
+	rows, isArray := value.([]interface{})
+	if !isArray {
+		return nil, true, fmt.Errorf("%s: must be an array of rows", name)
+	}
+
+	tableData := make([][]string, 0, len(rows))
+	for r, rowValue := range rows {
+		cells, isArray := rowValue.([]interface{})
+		if !isArray {
+			return nil, true, fmt.Errorf("%s[%d]: must be an array of cell values", name, r)
+		}
+		row := make([]string, 0, len(cells))
+		for c, cell := range cells {
+			switch v := cell.(type) {
+			case nil:
+				row = append(row, "")
+			case string, float64, bool:
+				row = append(row, formatTemplateValue(v))
+			default:
+				return nil, true, fmt.Errorf("%s[%d][%d]: must be a string, number, boolean or null", name, r, c)
+			}
+		}
+		tableData = append(tableData, row)
+	}
+	return tableData, true, nil
+}
+
+// objectArgument reads a JSON object argument
+func objectArgument(request mcp.CallToolRequest, name string) (map[string]interface{}, bool, error) {
+	value, ok, err := structuredArgument(request, name)
+	if !ok || err != nil {
+		return nil, ok, err
+	}
+	object, isObject := value.(map[string]interface{})
+	if !isObject {
+		return nil, true, fmt.Errorf("%s: must be an object", name)
+	}
+	return object, true, nil
+}
+
+// objectArrayArgument reads an array of JSON objects
+func objectArrayArgument(request mcp.CallToolRequest, name string) ([]map[string]interface{}, bool, error) {
+	value, ok, err := structuredArgument(request, name)
+	if !ok || err != nil {
+		return nil, ok, err
+	}
+	items, isArray := value.([]interface{})
+	if !isArray {
+		return nil, true, fmt.Errorf("%s: must be an array of objects", name)
+	}
+	objects := make([]map[string]interface{}, 0, len(items))
+	for i, item := range items {
+		object, isObject := item.(map[string]interface{})
+		if !isObject {
+			return nil, true, fmt.Errorf("%s[%d]: must be an object", name, i)
+		}
+		objects = append(objects, object)
+	}
+	return objects, true, nil
+}
+
+// intArrayArgument reads an array of whole numbers, accepting numbers sent
+// as strings
+func intArrayArgument(request mcp.CallToolRequest, name string) ([]int, bool, error) {
+	value, ok, err := structuredArgument(request, name)
+	if !ok || err != nil {
+		return nil, ok, err
+	}
+	items, isArray := value.([]interface{})
+	if !isArray {
+		return nil, true, fmt.Errorf("%s: must be an array of numbers", name)
+	}
+	numbers := make([]int, 0, len(items))
+	for i, item := range items {
+		reader := fieldReader{path: name, object: map[string]interface{}{fmt.Sprint(i): item}}
+		number := reader.Int(fmt.Sprint(i))
+		if reader.err != nil {
+			return nil, true, fmt.Errorf("%s[%d]: must be a whole number, got %s", name, i, describeValue(item))
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, true, nil
+}
+
+// fieldReader reads typed fields of a JSON object. Numbers and booleans sent
+// as strings, as LLMs often do, are accepted. Every invalid field is reported
+// once in err, prefixed with the field's path.
+type fieldReader struct {
+	path   string
+	object map[string]interface{}
+	err    error
+	failed map[string]bool
+}
+
+func (f *fieldReader) fail(key, format string, args ...interface{}) {
+	if f.failed[key] {
+		return
+	}
+	if f.failed == nil {
+		f.failed = map[string]bool{}
+	}
+	f.failed[key] = true
+
+	message := fmt.Sprintf("%s.%s: %s", f.path, key, fmt.Sprintf(format, args...))
+	if f.err != nil {
+		message = f.err.Error() + "; " + message
+	}
+	f.err = errors.New(message)
+}
+
+// value returns a field, treating null and blank strings as missing
+func (f *fieldReader) value(key string) (interface{}, bool) {
+	value, ok := f.object[key]
+	if !ok || value == nil {
+		return nil, false
+	}
+	if text, isString := value.(string); isString && strings.TrimSpace(text) == "" {
+		return nil, false
+	}
+	return value, true
+}
+
+// String reads an optional string field
+func (f *fieldReader) String(key string) string {
+	value, ok := f.object[key]
+	if !ok || value == nil {
+		return ""
+	}
+	text, isString := value.(string)
+	if !isString {
+		f.fail(key, "must be a string")
+	}
+	return text
+}
+
+// RequiredString reads a string field that must be present and non-empty
+func (f *fieldReader) RequiredString(key string) string {
+	text := f.String(key)
+	if text == "" {
+		f.fail(key, "is required")
+	}
+	return text
+}
+
+// Bool reads an optional boolean field
+func (f *fieldReader) Bool(key string) bool {
+	value, ok := f.value(key)
+	if !ok {
+		return false
+	}
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		if flag, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+			return flag
+		}
+	}
+	f.fail(key, "must be a boolean, got %s", describeValue(value))
+	return false
+}
+
+// OptionalBool reads a boolean field, returning nil when it is left out
+func (f *fieldReader) OptionalBool(key string) *bool {
+	if _, ok := f.value(key); !ok {
+		return nil
+	}
+	flag := f.Bool(key)
+	return &flag
+}
+
+// Int reads an optional whole-number field
+func (f *fieldReader) Int(key string) int {
+	value, ok := f.value(key)
+	if !ok {
+		return 0
+	}
+	number, isNumber := value.(float64)
+	if text, isString := value.(string); isString {
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		number, isNumber = parsed, err == nil
+	}
+	if !isNumber || math.IsInf(number, 0) || math.IsNaN(number) || number != math.Trunc(number) ||
+		math.Abs(number) > math.MaxInt32 {
+		f.fail(key, "must be a whole number, got %s", describeValue(value))
+		return 0
+	}
+	return int(number)
+}
+
+// Float reads an optional number field
+func (f *fieldReader) Float(key string) (float64, bool) {
+	value, ok := f.value(key)
+	if !ok {
+		return 0, false
+	}
+	number, isNumber := value.(float64)
+	if text, isString := value.(string); isString {
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		number, isNumber = parsed, err == nil
+	}
+	if !isNumber || math.IsInf(number, 0) || math.IsNaN(number) {
+		f.fail(key, "must be a number, got %s", describeValue(value))
+		return 0, false
+	}
+	return number, true
+}
+
+// Object reads an optional object field
+func (f *fieldReader) Object(key string) map[string]interface{} {
+	value, ok := f.value(key)
+	if !ok {
+		return nil
+	}
+	object, isObject := value.(map[string]interface{})
+	if !isObject {
+		f.fail(key, "must be an object, got %s", describeValue(value))
+	}
+	return object
+}
+
+// describeValue quotes a field value for an error message
+func describeValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		return "an array"
+	case map[string]interface{}:
+		return "an object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// PositiveInt reads a whole-number field that must be present and above zero
+func (f *fieldReader) PositiveInt(key string) int {
+	if _, ok := f.value(key); !ok {
+		f.fail(key, "is required")
+		return 0
+	}
+	number := f.Int(key)
+	if number <= 0 {
+		f.fail(key, "must be greater than 0")
+	}
+	return number
+}
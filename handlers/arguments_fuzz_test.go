@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Fuzz targets for argument parsing. Malformed LLM output is the normal case,
+// so parsing must return an error for any input rather than panic. Run one
+// with e.g. go test ./handlers -run '^$' -fuzz FuzzBatchOperations.
+
+// argumentRequest builds a call whose argument name holds value
+func argumentRequest(name string, value interface{}) mcp.CallToolRequest {
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{name: value}
+	return request
+}
+
+// fuzzArguments feeds text both as a JSON-in-string argument and, when it is
+// valid JSON, as the decoded structured argument
+func fuzzArguments(t *testing.T, name, text string, parse func(mcp.CallToolRequest)) {
+	t.Helper()
+	parse(argumentRequest(name, text))
+
+	var decoded interface{}
+	if json.Unmarshal([]byte(text), &decoded) == nil {
+		parse(argumentRequest(name, decoded))
+	}
+}
+
+func FuzzTableDataArgument(f *testing.F) {
+	for _, seed := range []string{
+		`[["a", "b"], [1, 2.5], [true, null]]`,
+		`[["only one row"]]`,
+		`[]`,
+		`[[["nested"]]]`,
+		`{"rows": 2}`,
+		`["a", "b"]`,
+		`[[{"value": 1}]]`,
+		`"[[\"double encoded\"]]"`,
+		`[[1e400]]`,
+		`not json`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, text string) {
+		fuzzArguments(t, "data", text, func(request mcp.CallToolRequest) {
+			tableData, ok, err := tableDataArgument(request, "data")
+			if err != nil && tableData != nil {
+				t.Errorf("tableDataArgument returned data with error %v", err)
+			}
+			if err == nil && ok && tableData == nil {
+				t.Errorf("tableDataArgument returned no data and no error for %q", text)
+			}
+		})
+	})
+}
+
+func FuzzBatchOperations(f *testing.F) {
+	for _, seed := range []string{
+		`[{"type": "insert_text", "text": "hi"}, {"type": "insert_paragraph"}]`,
+		`[{"type": "set_font", "name": "바탕", "size": 12, "bold": true}]`,
+		`[{"type": "set_font", "size": "12"}]`,
+		`[{"type": "set_font", "size": 11.5}]`,
+		`[{"type": "insert_table", "rows": "3", "cols": 2}]`,
+		`[{"type": "insert_table", "rows": 3}]`,
+		`[{"type": "insert_table", "rows": -1, "cols": 1e300}]`,
+		`[{"type": 5}]`,
+		`[{"text": "no type"}]`,
+		`[null, 1, "x"]`,
+		`{"type": "insert_text"}`,
+		`[]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, text string) {
+		fuzzArguments(t, "operations", text, func(request mcp.CallToolRequest) {
+			operations, err := parseBatchOperations(request)
+			if err == nil && len(operations) == 0 {
+				t.Errorf("parseBatchOperations accepted %q without operations", text)
+			}
+			for _, op := range operations {
+				if op.Type == "insert_table" && (op.Rows <= 0 || op.Cols <= 0) {
+					t.Errorf("parseBatchOperations accepted table size %dx%d", op.Rows, op.Cols)
+				}
+			}
+		})
+	})
+}
+
+func FuzzDocumentSpec(f *testing.F) {
+	for _, seed := range []string{
+		`{"type": "report", "title": "보고서", "sections": [{"title": "개요", "content": "내용"}]}`,
+		`{"type": "report", "sections": "not a list"}`,
+		`{"type": "report", "sections": [1, 2]}`,
+		`{"type": "letter", "recipient": 3}`,
+		`{"type": "memo", "to": "all", "body": {"nested": true}}`,
+		`{"type": "letter", "letterhead": {"organization": "한국회사", "address": "서울시", "rule": "false"}}`,
+		`{"letterhead": {"address": 5}}`,
+		`{"letterhead": "not an object"}`,
+		`{"type": "official", "title": "협조 요청", "clauses": ["관련", {"text": "요청", "items": ["가", {"items": [1]}]}], "attachments": ["계획서 1부."], "stamp": "true"}`,
+		`{"type": "official", "clauses": "not a list", "approvals": [1, 2]}`,
+		`{"type": "invoice", "customer": "한국상사", "supplier": {"name": "공급사"}, "items": [{"name": "노트북", "quantity": "2", "unit_price": 1500000}], "vat": "false"}`,
+		`{"type": "invoice", "items": [{"quantity": -1, "unit_price": 1e300}, "x"], "supplier": []}`,
+		`{"type": "minutes", "attendees": ["김", "이"], "agenda": ["예산"], "discussions": [{"title": "예산", "content": "논의"}], "action_items": [{"task": "보고", "owner": "김", "due": "3/15"}]}`,
+		`{"type": "minutes", "attendees": "all", "discussions": [1], "action_items": [{"owner": 2}]}`,
+		`{"type": "certificate", "recipient": "홍길동", "award": "최우수상", "border": "DOUBLE", "border_color": "#C09030"}`,
+		`{"type": "certificate", "border": "wavy", "border_color": "nope"}`,
+		`{"type": "resume", "name": "홍길동", "education": [{"period": "2010-2014", "school": "한국대학교"}], "introduction": [{"title": "성장과정", "content": "..."}]}`,
+		`{"type": "resume", "career": {"company": 1}, "certifications": [1], "introduction": [{"title": 2}]}`,
+		`{"data": {"team": "A", "items": [1, 2]}, "title": "{{team}}", "sections": {"for_each": "items", "as": "i", "do": {"title": "{{i}}"}}}`,
+		`{"data": {"ok": true}, "title": {"if": "ok", "then": "yes", "else": "no"}}`,
+		`{"data": [], "title": "{{missing.path}}"}`,
+		`{"title": {"for_each": 5}}`,
+		`{"title": {"when": "x == 'y'", "value": "z"}}`,
+		`[]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, text string) {
+		fuzzArguments(t, "spec", text, func(request mcp.CallToolRequest) {
+			spec, ok, err := objectArgument(request, "spec")
+			if !ok || err != nil {
+				return
+			}
+			prepareSpec(spec)
+		})
+	})
+}
+
+func FuzzThemeArgument(f *testing.F) {
+	for _, seed := range []string{
+		`{"font": "나눔고딕", "heading_font": "나눔스퀘어", "accent_color": "#1F4E79"}`,
+		`{"sizes": {"본문": 10, "제목1": "20"}, "margins": {"left": 20, "top": "15.5"}}`,
+		`{"sizes": {"없는프리셋": 10}}`,
+		`{"sizes": {"본문": 0, "제목1": 1e300}}`,
+		`{"sizes": [10, 12]}`,
+		`{"margins": {"left": -5, "right": "wide"}}`,
+		`{"accent_color": "not a color"}`,
+		`{"font": 3}`,
+		`[]`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, text string) {
+		fuzzArguments(t, "theme", text, func(request mcp.CallToolRequest) {
+			theme, err := themeArgument(request, "theme")
+			if err != nil && theme != nil {
+				t.Errorf("themeArgument returned a theme with error %v", err)
+			}
+			if theme != nil {
+				for name, size := range theme.Sizes {
+					if size < 1 {
+						t.Errorf("themeArgument accepted size %d for %s", size, name)
+					}
+				}
+			}
+		})
+	})
+}
+
+var registerSchemasOnce sync.Once
+
+// registeredToolNames registers every tool and returns their names in order
+func registeredToolNames() []string {
+	registerSchemasOnce.Do(func() {
+		RegisterTools(server.NewMCPServer("fuzz", "1.0.0"))
+	})
+
+	toolSchemasMu.RLock()
+	defer toolSchemasMu.RUnlock()
+	names := make([]string, 0, len(toolSchemas))
+	for name := range toolSchemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func FuzzValidateArguments(f *testing.F) {
+	for _, seed := range []string{
+		`{"rows": 3, "cols": 3}`,
+		`{"rows": "3", "cols": "three"}`,
+		`{"rows": -1, "cols": 1e309}`,
+		`{"path": "a.png", "width": "40"}`,
+		`{"path": ["a.png"]}`,
+		`{"data": "[[1, 2]]", "has_header": "true"}`,
+		`{"operations": {"type": "insert_text"}}`,
+		`{"spec": "{\"type\": \"memo\"}"}`,
+		`{"format": 5, "level": null}`,
+		`{}`,
+	} {
+		f.Add(seed, uint8(0))
+	}
+	f.Fuzz(func(t *testing.T, text string, tool uint8) {
+		var arguments map[string]interface{}
+		if json.Unmarshal([]byte(text), &arguments) != nil {
+			return
+		}
+		names := registeredToolNames()
+		name := names[int(tool)%len(names)]
+
+		toolSchemasMu.RLock()
+		schema := toolSchemas[name]
+		toolSchemasMu.RUnlock()
+		for _, violation := range validateArguments(schema, arguments) {
+			if violation.Field == "" || violation.Message == "" {
+				t.Errorf("%s: incomplete violation %+v", name, violation)
+			}
+		}
+	})
+}
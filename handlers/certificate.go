@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"strings"
+
+	"hwp-mcp-go/hwp"
+)
+
+// Certificates (상장, 증명서)
+//
+// The "certificate" document type lays out a one-page certificate: the
+// certificate number in the corner, the large centered title, the award,
+// the recipient, the citation, the date and the issuer with the stamp image
+// after the name, inside a decorative page border. The border needs the COM
+// backend and is left out on HWPX; a stamp image needs COM like any image.
+
+// certificateTextFields are the text fields of a certificate
+var certificateTextFields = []string{
+	"title", "number", "award", "affiliation", "recipient", "body", "date", "issuer",
+	"stamp_path", "border", "border_color",
+}
+
+// Certificate defaults
+const (
+	certificateTitle       = "상    장"
+	certificateBorder      = "thick_thin"
+	certificateBorderColor = "black"
+	certificateBorderMM    = 1.5
+)
+
+// Certificate stamp bounds (hwpunit), about 20mm square
+const (
+	certificateStampMaxWidth  = 5700
+	certificateStampMaxHeight = 5700
+)
+
+// certificateDocument is a validated certificate spec
+type certificateDocument struct {
+	Text map[string]string
+}
+
+// readCertificateDocument reads the fields of a certificate spec, filling in
+// the defaults
+func readCertificateDocument(fields *fieldReader) certificateDocument {
+	document := certificateDocument{Text: map[string]string{}}
+	for _, name := range certificateTextFields {
+		document.Text[name] = fields.String(name)
+	}
+	text := document.Text
+	if text["title"] == "" {
+		text["title"] = certificateTitle
+	}
+	if text["border"] == "" {
+		text["border"] = certificateBorder
+	}
+	text["border"] = strings.ToLower(text["border"])
+	if _, ok := hwp.BorderTypes[text["border"]]; !ok {
+		fields.fail("border", "unknown border style %q (use none, solid, double, thin_thick, thick_thin, triple, dash, dot or dash_dot)", text["border"])
+	}
+	if text["border_color"] == "" {
+		text["border_color"] = certificateBorderColor
+	}
+	if _, err := hwp.ParseColor(text["border_color"]); err != nil {
+		fields.fail("border_color", "%v", err)
+	}
+	if text["recipient"] == "" {
+		fields.fail("recipient", "is required")
+	}
+	return document
+}
+
+func createCertificateDocument(controller *hwp.Controller, spec map[string]interface{}, styles stylePresetSet) error {
+	text := readCertificateDocument(&fieldReader{path: "spec", object: spec}).Text
+
+	writeLines := func(lines ...string) error {
+		for _, line := range lines {
+			if err := controller.InsertText(line, true); err != nil {
+				return err
+			}
+			if err := controller.InsertParagraph(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	centered := func(preset string, lines ...string) error {
+		if err := controller.SetParagraphAlignment("center"); err != nil {
+			return err
+		}
+		if err := styles.apply(controller, preset); err != nil {
+			return err
+		}
+		return writeLines(lines...)
+	}
+
+	if text["border"] != "none" && controller.Backend() != hwp.BackendHWPX {
+		err := controller.SetPageBorder(hwp.PageBorder{
+			Style:   text["border"],
+			WidthMM: certificateBorderMM,
+			Color:   text["border_color"],
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Number in the top corner, then the title a third of the way down
+	if err := controller.SetParagraphAlignment("left"); err != nil {
+		return err
+	}
+	if err := styles.apply(controller, "본문"); err != nil {
+		return err
+	}
+	if text["number"] != "" {
+		if err := writeLines(text["number"]); err != nil {
+			return err
+		}
+	}
+	if err := insertBlankLines(controller, 4); err != nil {
+		return err
+	}
+	if err := centered("표지제목", text["title"], "", ""); err != nil {
+		return err
+	}
+	if text["award"] != "" {
+		if err := centered("제목2", text["award"], ""); err != nil {
+			return err
+		}
+	}
+
+	recipient := []string{"성명  " + text["recipient"], ""}
+	if text["affiliation"] != "" {
+		recipient = append([]string{text["affiliation"]}, recipient...)
+	}
+	if err := centered("표지부제목", recipient...); err != nil {
+		return err
+	}
+
+	if text["body"] != "" {
+		if err := controller.SetParagraphAlignment("justify"); err != nil {
+			return err
+		}
+		if err := styles.apply(controller, "표지정보"); err != nil {
+			return err
+		}
+		if err := writeLines(text["body"], "", ""); err != nil {
+			return err
+		}
+	}
+	if text["date"] != "" {
+		if err := centered("표지정보", text["date"], "", ""); err != nil {
+			return err
+		}
+	}
+
+	// Issuer with the stamp right after the name, where a seal is pressed
+	if text["issuer"] != "" {
+		if err := centered("제목1"); err != nil {
+			return err
+		}
+		if err := controller.InsertText(text["issuer"], false); err != nil {
+			return err
+		}
+		if text["stamp_path"] != "" {
+			if err := controller.InsertText("  ", false); err != nil {
+				return err
+			}
+			maxWidth, maxHeight := certificateStampMaxWidth, certificateStampMaxHeight
+			if err := controller.InsertImage(text["stamp_path"], nil, nil, false, &maxWidth, &maxHeight, nil, true, true, false, false, 0, "직인", nil, nil, nil); err != nil {
+				return err
+			}
+		}
+		if err := controller.InsertParagraph(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Citation tools
+//
+// hwp_set_bibliography loads the sources of the open document, from JSON or
+// BibTeX; hwp_insert_citation cites them at the cursor and
+// hwp_insert_bibliography writes the references section.
+
+// Tool names for citations
+const (
+	HWP_SET_BIBLIOGRAPHY    = "hwp_set_bibliography"
+	HWP_INSERT_CITATION     = "hwp_insert_citation"
+	HWP_INSERT_BIBLIOGRAPHY = "hwp_insert_bibliography"
+)
+
+// defaultBibliographyTitle is the heading of the references section
+const defaultBibliographyTitle = "참고문헌"
+
+// readSource reads a source object of the sources argument. authors may be
+// an array or one string of names joined with "and", as in BibTeX.
+func readSource(fields *fieldReader) hwp.Source {
+	source := hwp.Source{
+		Key:       fields.RequiredString("key"),
+		Title:     fields.RequiredString("title"),
+		Container: fields.String("container"),
+		Publisher: fields.String("publisher"),
+		Volume:    sourceNumber(fields, "volume"),
+		Issue:     sourceNumber(fields, "issue"),
+		Pages:     sourceNumber(fields, "pages"),
+		Year:      sourceNumber(fields, "year"),
+		DOI:       fields.String("doi"),
+		URL:       fields.String("url"),
+	}
+	if source.Container == "" {
+		source.Container = fields.String("journal")
+	}
+	if value, ok := fields.value("authors"); ok {
+		if names, isString := value.(string); isString {
+			for _, name := range strings.Split(names, " and ") {
+				if name = strings.TrimSpace(name); name != "" {
+					source.Authors = append(source.Authors, name)
+				}
+			}
+		} else {
+			source.Authors = readStringList(fields, "authors")
+		}
+	}
+	return source
+}
+
+// sourceNumber reads a field such as year or volume that may be sent as a
+// number or as text like "2023a" or "12-15"
+func sourceNumber(fields *fieldReader, key string) string {
+	value, ok := fields.value(key)
+	if !ok {
+		return ""
+	}
+	if number, isNumber := value.(float64); isNumber {
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	return fields.String(key)
+}
+
+// sourcesArgument reads the sources of hwp_set_bibliography from exactly one
+// of sources (JSON), bibtex or path (a .bib or .json file)
+func sourcesArgument(request mcp.CallToolRequest) ([]hwp.Source, error) {
+	bibtex := request.GetString("bibtex", "")
+	path := request.GetString("path", "")
+	_, hasSources := request.GetArguments()["sources"]
+
+	given := 0
+	for _, set := range []bool{hasSources, bibtex != "", path != ""} {
+		if set {
+			given++
+		}
+	}
+	if given != 1 {
+		return nil, fmt.Errorf("Specify one of sources, bibtex or path")
+	}
+
+	if path != "" {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("File not found: %s", path)
+		}
+		if info.Size() > maxImportTextSize {
+			return nil, fmt.Errorf("%s is %d bytes; bibliography files over %d MB are not read", path, info.Size(), maxImportTextSize>>20)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read %s - %v", path, err)
+		}
+		text, _, err := hwp.DecodeText(data, "auto")
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(filepath.Ext(path), ".json") {
+			return hwp.ParseBibTeX(text)
+		}
+		var items []map[string]interface{}
+		if err := json.Unmarshal([]byte(text), &items); err != nil {
+			return nil, fmt.Errorf("%s: must be a JSON array of sources - %v", path, err)
+		}
+		return readSources(items)
+	}
+	if bibtex != "" {
+		return hwp.ParseBibTeX(bibtex)
+	}
+
+	items, _, err := objectArrayArgument(request, "sources")
+	if err != nil {
+		return nil, err
+	}
+	return readSources(items)
+}
+
+// readSources reads the source objects of the sources argument
+func readSources(items []map[string]interface{}) ([]hwp.Source, error) {
+	sources := make([]hwp.Source, 0, len(items))
+	for i, item := range items {
+		fields := &fieldReader{path: fmt.Sprintf("sources[%d]", i), object: item}
+		source := readSource(fields)
+		if fields.err != nil {
+			return nil, fields.err
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+func HandleHwpSetBibliography(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	sources, err := sourcesArgument(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	style := request.GetString("style", hwp.CitationAuthorYear)
+	bibliography, err := hwp.NewBibliography(sources, style)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		message := fmt.Sprintf("Loaded %d sources for %s citations", bibliography.Len(), style)
+		if previous, err := controller.Bibliography(); err == nil && previous.Cited() > 0 {
+			message += fmt.Sprintf("; the %d sources cited before are numbered afresh", previous.Cited())
+		}
+		controller.SetBibliography(bibliography)
+		result = hwp.CreateTextResult(message)
+	})
+
+	return result, nil
+}
+
+func HandleHwpInsertCitation(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	keys := request.GetStringSlice("keys", nil)
+	if len(keys) == 0 {
+		return hwp.CreateTextResult("Error: Keys are required"), nil
+	}
+	page := request.GetString("page", "")
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		text, err := controller.InsertCitation(keys, page)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Inserted citation %s", text))
+	})
+
+	return result, nil
+}
+
+func HandleHwpInsertBibliography(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	title := request.GetString("title", defaultBibliographyTitle)
+	includeAll := request.GetBool("include_all", false)
+	styles := currentPresets()
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		bibliography, err := controller.Bibliography()
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		entries := bibliography.References(includeAll)
+		if len(entries) == 0 {
+			result = hwp.CreateTextResult("Error: No source has been cited yet; cite with hwp_insert_citation or set include_all to list every source")
+			return
+		}
+
+		write := func(preset, line string) error {
+			if err := styles.apply(controller, preset); err != nil {
+				return err
+			}
+			if err := controller.InsertText(line, false); err != nil {
+				return err
+			}
+			return controller.InsertParagraph()
+		}
+		// Start the section on a paragraph of its own
+		err = controller.InsertParagraph()
+		if err == nil && title != "" {
+			err = write("제목2", title)
+		}
+		for _, entry := range entries {
+			if err != nil {
+				break
+			}
+			err = write("본문", entry)
+		}
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Inserted the references section with %d of %d sources", len(entries), bibliography.Len()))
+	})
+
+	return result, nil
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestSourcesArgumentReadsBibTeX(t *testing.T) {
+	sources, err := sourcesArgument(argumentRequest("bibtex", `
+		@comment{exported from a reference manager}
+		@article{kim2023,
+			author  = {Kim, Minsu and Lee, Jiwon and Park, Sora},
+			title   = {{HWP} Automation in Practice},
+			journal = "Journal of Office Software",
+			year    = 2023,
+			volume  = {12},
+			number  = {3},
+			pages   = {45--67},
+		}
+		@book{hong2021, author = {홍길동}, title = {문서 자동화}, publisher = {한빛}, year = {2021}}
+	`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sources) != 2 {
+		t.Fatalf("sourcesArgument() = %+v, want 2 sources", sources)
+	}
+	kim := sources[0]
+	if kim.Key != "kim2023" || kim.Title != "HWP Automation in Practice" || len(kim.Authors) != 3 ||
+		kim.Container != "Journal of Office Software" || kim.Year != "2023" || kim.Issue != "3" || kim.Pages != "45--67" {
+		t.Errorf("sources[0] = %+v", kim)
+	}
+	if sources[1].Publisher != "한빛" || sources[1].Authors[0] != "홍길동" {
+		t.Errorf("sources[1] = %+v", sources[1])
+	}
+}
+
+func TestSourcesArgumentReportsFieldErrors(t *testing.T) {
+	_, err := sourcesArgument(argumentRequest("sources", `[{"key": "a", "title": "A"}, {"key": "b", "year": 2020}]`))
+	if err == nil || !strings.Contains(err.Error(), "sources[1].title: is required") {
+		t.Errorf("sourcesArgument() error = %v, want the missing title of sources[1]", err)
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{"sources": `[]`, "bibtex": "@book{a, title = {A}}"}
+	if _, err := sourcesArgument(request); err == nil {
+		t.Error("sourcesArgument() with sources and bibtex succeeded, want an error")
+	}
+}
+
+func TestBibliographyCitations(t *testing.T) {
+	sources, err := sourcesArgument(argumentRequest("sources", `[
+		{"key": "kim2023", "authors": "Minsu Kim and Jiwon Lee and Sora Park", "title": "HWP Automation", "year": 2023},
+		{"key": "hong2021", "authors": ["홍길동", "김철수"], "title": "문서 자동화", "publisher": "한빛", "year": "2021"},
+		{"key": "lee2020", "authors": ["Lee, Jiwon"], "title": "Unused", "year": 2020}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authorYear, _ := hwp.NewBibliography(sources, hwp.CitationAuthorYear)
+	if got, _ := authorYear.Cite([]string{"kim2023", "hong2021"}, "12-15"); got != "(Kim et al., 2023; 홍길동·김철수, 2021, pp. 12-15)" {
+		t.Errorf("author-year citation = %q", got)
+	}
+	if got := authorYear.References(false); len(got) != 2 || got[0] != "Minsu Kim, Jiwon Lee, & Sora Park (2023). HWP Automation." {
+		t.Errorf("author-year references = %q", got)
+	}
+
+	numbered, _ := hwp.NewBibliography(sources, hwp.CitationNumbered)
+	numbered.Cite([]string{"hong2021"}, "")
+	if got, _ := numbered.Cite([]string{"kim2023", "hong2021"}, ""); got != "[2, 1]" {
+		t.Errorf("numbered citation = %q, want [2, 1]", got)
+	}
+	if _, err := numbered.Cite([]string{"missing"}, ""); err == nil {
+		t.Error("citing an unknown key succeeded, want an error")
+	}
+	references := numbered.References(true)
+	if len(references) != 3 || references[0] != "[1] 홍길동, 김철수 (2021). 문서 자동화. 한빛." || !strings.HasPrefix(references[2], "[3] Lee, Jiwon") {
+		t.Errorf("numbered references = %q", references)
+	}
+}
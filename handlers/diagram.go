@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Diagram tools
+//
+// Org charts and flow diagrams are drawn from a JSON tree or graph as
+// grouped text boxes and connectors, so an agent can show a structure
+// without an external drawing tool and the result stays editable in HWP.
+
+// Tool names for diagrams
+const (
+	HWP_INSERT_ORG_CHART    = "hwp_insert_org_chart"
+	HWP_INSERT_FLOW_DIAGRAM = "hwp_insert_flow_diagram"
+)
+
+// diagramNodeArgument reads an org chart node and its children
+func diagramNodeArgument(path string, object map[string]interface{}) (hwp.DiagramNode, error) {
+	fields := &fieldReader{path: path, object: object}
+	node := hwp.DiagramNode{Label: fields.RequiredString("label")}
+	var children []interface{}
+	if value, ok := fields.value("children"); ok {
+		var isArray bool
+		if children, isArray = value.([]interface{}); !isArray {
+			fields.fail("children", "must be an array of nodes, got %s", describeValue(value))
+		}
+	}
+	if fields.err != nil {
+		return node, fields.err
+	}
+
+	for i, value := range children {
+		childPath := fmt.Sprintf("%s.children[%d]", path, i)
+		childObject, isObject := value.(map[string]interface{})
+		if !isObject {
+			return node, fmt.Errorf("%s: must be an object, got %s", childPath, describeValue(value))
+		}
+		child, err := diagramNodeArgument(childPath, childObject)
+		if err != nil {
+			return node, err
+		}
+		node.Children = append(node.Children, child)
+	}
+	return node, nil
+}
+
+// orgChartArguments reads the tree of hwp_insert_org_chart
+func orgChartArguments(request mcp.CallToolRequest) (hwp.Diagram, error) {
+	diagram := hwp.Diagram{Kind: "org", WidthMM: request.GetFloat("width_mm", 0)}
+	object, ok, err := objectArgument(request, "root")
+	if err != nil {
+		return diagram, err
+	}
+	if !ok {
+		return diagram, fmt.Errorf("root is required")
+	}
+	root, err := diagramNodeArgument("root", object)
+	if err != nil {
+		return diagram, err
+	}
+	diagram.Root = &root
+	return diagram, nil
+}
+
+// flowDiagramArguments reads the nodes and edges of hwp_insert_flow_diagram
+func flowDiagramArguments(request mcp.CallToolRequest) (hwp.Diagram, error) {
+	diagram := hwp.Diagram{Kind: "flow", WidthMM: request.GetFloat("width_mm", 0)}
+	nodes, ok, err := objectArrayArgument(request, "nodes")
+	if err != nil {
+		return diagram, err
+	}
+	if !ok {
+		return diagram, fmt.Errorf("nodes are required")
+	}
+	edges, _, err := objectArrayArgument(request, "edges")
+	if err != nil {
+		return diagram, err
+	}
+
+	for i, object := range nodes {
+		fields := &fieldReader{path: fmt.Sprintf("nodes[%d]", i), object: object}
+		node := hwp.DiagramNode{ID: fields.RequiredString("id"), Label: fields.RequiredString("label")}
+		if fields.err != nil {
+			return diagram, fields.err
+		}
+		diagram.Nodes = append(diagram.Nodes, node)
+	}
+	for i, object := range edges {
+		fields := &fieldReader{path: fmt.Sprintf("edges[%d]", i), object: object}
+		edge := hwp.DiagramEdge{From: fields.RequiredString("from"), To: fields.RequiredString("to")}
+		if fields.err != nil {
+			return diagram, fields.err
+		}
+		diagram.Edges = append(diagram.Edges, edge)
+	}
+	return diagram, nil
+}
+
+// insertDiagram lays out and draws a diagram read by arguments
+func insertDiagram(request mcp.CallToolRequest, arguments func(mcp.CallToolRequest) (hwp.Diagram, error)) *mcp.CallToolResult {
+	diagram, err := arguments(request)
+	if err == nil {
+		// Check the layout before touching the document
+		_, err = hwp.LayoutDiagram(diagram)
+	}
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		layout, err := controller.InsertDiagram(diagram)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Inserted a diagram of %d boxes and %d connectors (%gx%gmm) as one grouped object",
+			len(layout.Boxes), len(layout.Lines), layout.WidthMM, layout.HeightMM))
+	})
+
+	return result
+}
+
+func HandleHwpInsertOrgChart(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return insertDiagram(request, orgChartArguments), nil
+}
+
+func HandleHwpInsertFlowDiagram(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return insertDiagram(request, flowDiagramArguments), nil
+}
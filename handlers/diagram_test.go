@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"hwp-mcp-go/hwp"
+)
+
+func TestOrgChartLayout(t *testing.T) {
+	diagram, err := orgChartArguments(argumentRequest("root", map[string]interface{}{
+		"label": "대표이사",
+		"children": []interface{}{
+			map[string]interface{}{"label": "경영지원본부"},
+			map[string]interface{}{"label": "사업본부", "children": []interface{}{
+				map[string]interface{}{"label": "영업팀"},
+				map[string]interface{}{"label": "기술팀"},
+			}},
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	layout, err := hwp.LayoutDiagram(diagram)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(layout.Boxes) != 5 {
+		t.Fatalf("boxes = %d, want 5", len(layout.Boxes))
+	}
+	center := func(box hwp.DiagramBox) float64 { return box.XMM + box.WidthMM/2 }
+	root, support, business, sales, tech := layout.Boxes[0], layout.Boxes[1], layout.Boxes[2], layout.Boxes[3], layout.Boxes[4]
+	if center(business) != (center(sales)+center(tech))/2 {
+		t.Errorf("사업본부 at %g, want centered over its teams at %g and %g", center(business), center(sales), center(tech))
+	}
+	if center(root) != (center(support)+center(business))/2 {
+		t.Errorf("대표이사 at %g, want centered over 경영지원본부 and 사업본부", center(root))
+	}
+	if sales.YMM <= business.YMM || business.YMM <= root.YMM {
+		t.Errorf("rows at %g, %g, %g, want going down", root.YMM, business.YMM, sales.YMM)
+	}
+	for _, line := range layout.Lines {
+		if line.X1MM != line.X2MM && line.Y1MM != line.Y2MM {
+			t.Errorf("connector %+v is slanted", line)
+		}
+	}
+
+	if _, err := orgChartArguments(argumentRequest("root", map[string]interface{}{
+		"label": "대표이사", "children": []interface{}{map[string]interface{}{"children": []interface{}{}}},
+	})); err == nil || !strings.Contains(err.Error(), "root.children[0].label: is required") {
+		t.Errorf("a node without a label: error = %v", err)
+	}
+}
+
+func TestFlowDiagramLayout(t *testing.T) {
+	request := argumentRequest("nodes", []interface{}{
+		map[string]interface{}{"id": "a", "label": "접수"},
+		map[string]interface{}{"id": "b", "label": "검토"},
+		map[string]interface{}{"id": "c", "label": "보완 요청"},
+		map[string]interface{}{"id": "d", "label": "승인"},
+	})
+	request.Params.Arguments.(map[string]interface{})["edges"] = []interface{}{
+		map[string]interface{}{"from": "a", "to": "b"},
+		map[string]interface{}{"from": "b", "to": "c"},
+		map[string]interface{}{"from": "b", "to": "d"},
+		map[string]interface{}{"from": "a", "to": "d"},
+	}
+	diagram, err := flowDiagramArguments(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	layout, err := hwp.LayoutDiagram(diagram)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b, c, d := layout.Boxes[0], layout.Boxes[1], layout.Boxes[2], layout.Boxes[3]
+	if !(a.YMM < b.YMM && b.YMM < c.YMM && c.YMM == d.YMM && c.XMM < d.XMM) {
+		t.Errorf("boxes %+v, want 접수 over 검토 over 보완 요청 left of 승인", layout.Boxes)
+	}
+
+	diagram.Edges = append(diagram.Edges, hwp.DiagramEdge{From: "d", To: "a"})
+	if _, err := hwp.LayoutDiagram(diagram); err == nil || !strings.Contains(err.Error(), "loop") {
+		t.Errorf("a loop: error = %v, want a loop error", err)
+	}
+	diagram.Edges = []hwp.DiagramEdge{{From: "a", To: "x"}}
+	if _, err := hwp.LayoutDiagram(diagram); err == nil || !strings.Contains(err.Error(), `unknown node "x"`) {
+		t.Errorf("an edge to an unknown node: error = %v", err)
+	}
+}
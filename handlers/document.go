@@ -0,0 +1,776 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Tool names for document management
+const (
+	HWP_CREATE              = "hwp_create"
+	HWP_OPEN                = "hwp_open"
+	HWP_SAVE                = "hwp_save"
+	HWP_CLOSE               = "hwp_close"
+	HWP_REVERT              = "hwp_revert"
+	HWP_GET_TEXT            = "hwp_get_text"
+	HWP_GET_PARAGRAPHS      = "hwp_get_paragraphs"
+	HWP_DIAGNOSTICS         = "hwp_diagnostics"
+	HWP_PROTECT_DOCUMENT    = "hwp_protect_document"
+	HWP_CHANGE_PASSWORD     = "hwp_change_password"
+	HWP_LIST_HYPERLINKS     = "hwp_list_hyperlinks"
+	HWP_EXPORT_MODEL        = "hwp_export_model"
+	HWP_IMPORT_MODEL        = "hwp_import_model"
+	HWP_GET_DOCUMENT_STATUS = "hwp_get_document_status"
+	HWP_GET_PAGE_COUNT      = "hwp_get_page_count"
+	HWP_RENDER_CURRENT_PAGE = "hwp_render_current_page"
+	HWP_EXPORT_PAGE_IMAGE   = "hwp_export_page_image"
+	HWP_GET_METADATA        = "hwp_get_metadata"
+	HWP_SET_METADATA        = "hwp_set_metadata"
+	HWP_CONVERT_FILE        = "hwp_convert_file"
+	HWP_PRINT_TO_PDF        = "hwp_print_to_pdf"
+
+	HWP_INSERT_PAGE_OF_DOCUMENT = "hwp_insert_page_of_document"
+)
+
+// Document management tool handlers
+
+func HandleHwpCreate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil {
+			controller = hwp.NewController()
+			hwp.SetGlobalController(controller)
+		}
+
+		err := controller.CreateNewDocument()
+		if err != nil {
+			// Reset controller on error
+			hwp.SetGlobalController(nil)
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult("New document created successfully")
+	})
+
+	return result, nil
+}
+
+func HandleHwpOpen(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	path := request.GetString("path", "")
+	if path == "" {
+		return hwp.CreateTextResult("Error: File path is required"), nil
+	}
+	readOnly := request.GetBool("read_only", false)
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil {
+			controller = hwp.NewController()
+			hwp.SetGlobalController(controller)
+		}
+
+		err := controller.OpenDocument(path, readOnly)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		recordRecentFile(path, "opened")
+
+		if readOnly {
+			result = hwp.CreateTextResult(fmt.Sprintf("Document opened read-only: %s", path))
+		} else {
+			result = hwp.CreateTextResult(fmt.Sprintf("Document opened: %s", path))
+		}
+	})
+
+	return result, nil
+}
+
+func HandleHwpSave(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	path := request.GetString("path", "")
+	backups := request.GetInt("backups", 0)
+	if backups < 0 {
+		return hwp.CreateTextResult("Error: backups must not be negative"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		backup, err := controller.BackupBeforeSave(path, backups)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		err = controller.SaveDocument(path)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		recordRecentFile(controller.CurrentPath(), "saved")
+
+		var message string
+		if path != "" {
+			// The HWPX backend may adjust the file extension
+			message = fmt.Sprintf("Document saved to: %s", controller.CurrentPath())
+		} else {
+			message = "Document saved successfully"
+		}
+		if backup != "" {
+			message += fmt.Sprintf(" (previous version backed up to %s)", backup)
+		}
+		result = hwp.CreateTextResult(message)
+	})
+
+	return result, nil
+}
+
+func HandleHwpGetText(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		text, err := controller.GetText()
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(text)
+	})
+
+	return result, nil
+}
+
+// defaultParagraphCount is the number of paragraphs hwp_get_paragraphs
+// returns when count is not given
+const defaultParagraphCount = 50
+
+func HandleHwpGetParagraphs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	start := request.GetInt("start_index", 0)
+	count := request.GetInt("count", defaultParagraphCount)
+	if start < 0 || count < 1 {
+		return hwp.CreateTextResult("Error: start_index must not be negative and count must be at least 1"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		paragraphs, total, err := controller.Paragraphs(start, count)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		resultJSON, _ := json.Marshal(map[string]interface{}{
+			"total":      total,
+			"paragraphs": paragraphs,
+		})
+		result = hwp.CreateTextResult(string(resultJSON))
+	})
+
+	return result, nil
+}
+
+func HandleHwpClose(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	discardChanges := request.GetBool("discard_changes", false)
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil {
+			result = hwp.CreateTextResult("HWP is already closed")
+			return
+		}
+
+		// Don't lose unsaved edits unless asked to
+		if controller.HasDocument() && !controller.IsReadOnly() && !discardChanges {
+			if modified, err := controller.IsModified(); err == nil && modified {
+				result = hwp.CreateTextResult("Error: The document has unsaved changes. Save it with hwp_save or pass discard_changes=true to close anyway.")
+				return
+			}
+		}
+
+		err := controller.Disconnect()
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		hwp.SetGlobalController(nil)
+		result = hwp.CreateTextResult("HWP connection closed successfully")
+	})
+
+	return result, nil
+}
+
+func HandleHwpRevert(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := controller.RevertDocument(); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Reverted to saved document: %s", controller.CurrentPath()))
+	})
+
+	return result, nil
+}
+
+func HandleHwpDiagnostics(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	report := hwp.ExecuteHWPOperationWithResult(hwp.RunDiagnostics)
+
+	resultJSON, _ := json.Marshal(report)
+	return hwp.CreateTextResult(string(resultJSON)), nil
+}
+
+func HandleHwpProtectDocument(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	mode := request.GetString("mode", "")
+	password := request.GetString("password", "")
+	removePassword := request.GetBool("remove_password", false)
+
+	if mode == "" && password == "" && !removePassword {
+		return hwp.CreateTextResult("Error: Specify mode, password or remove_password"), nil
+	}
+	if password != "" && removePassword {
+		return hwp.CreateTextResult("Error: password and remove_password cannot be used together"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		var applied []string
+
+		// Change the password first; it can't be edited once the document is read-only
+		if password != "" || removePassword {
+			if err := controller.SetDocumentPassword(password); err != nil {
+				result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+				return
+			}
+			if removePassword {
+				applied = append(applied, "password removed")
+			} else {
+				applied = append(applied, "password set")
+			}
+		}
+
+		if mode != "" {
+			if err := controller.SetEditMode(mode); err != nil {
+				result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+				return
+			}
+			applied = append(applied, fmt.Sprintf("edit mode: %s", mode))
+		}
+
+		message := fmt.Sprintf("Document protection updated (%s)", strings.Join(applied, ", "))
+		if password != "" || removePassword {
+			message += ". Save the document to apply the password change"
+		}
+		result = hwp.CreateTextResult(message)
+	})
+
+	return result, nil
+}
+
+func HandleHwpChangePassword(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	current, err := request.RequireString("current_password")
+	if err != nil || current == "" {
+		return hwp.CreateTextResult("Error: current_password is required"), nil
+	}
+	password := request.GetString("new_password", "")
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := controller.ChangeDocumentPassword(current, password); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		if password == "" {
+			result = hwp.CreateTextResult("Document password removed. Save the document to apply the change")
+		} else {
+			result = hwp.CreateTextResult("Document password changed. Save the document to apply the change")
+		}
+	})
+
+	return result, nil
+}
+
+func HandleHwpListHyperlinks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		links, err := controller.ListHyperlinks()
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		resultJSON, _ := json.Marshal(map[string]interface{}{
+			"total_links": len(links),
+			"links":       links,
+		})
+		result = hwp.CreateTextResult(string(resultJSON))
+	})
+
+	return result, nil
+}
+
+func HandleHwpExportModel(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	path := request.GetString("path", "")
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		model, err := controller.ExportModel()
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		if path == "" {
+			modelJSON, _ := json.Marshal(model)
+			result = hwp.CreateTextResult(string(modelJSON))
+			return
+		}
+
+		modelJSON, _ := json.MarshalIndent(model, "", "  ")
+		if err := os.WriteFile(path, modelJSON, 0644); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: Failed to write model - %v", err))
+			return
+		}
+		result = hwp.CreateTextResult(fmt.Sprintf("Document model with %d blocks exported to %s", len(model.Blocks), path))
+	})
+
+	return result, nil
+}
+
+// readModelArgument reads the document model given inline or by file path
+func readModelArgument(request mcp.CallToolRequest) (*hwp.DocumentModel, error) {
+	modelStr := request.GetString("model", "")
+	path := request.GetString("path", "")
+
+	if modelStr == "" && path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read model - %v", err)
+		}
+		modelStr = string(data)
+	}
+	if modelStr == "" {
+		return nil, fmt.Errorf("Either model or path is required")
+	}
+
+	var model hwp.DocumentModel
+	if err := json.Unmarshal([]byte(modelStr), &model); err != nil {
+		return nil, fmt.Errorf("Failed to parse model JSON - %v", err)
+	}
+	return &model, nil
+}
+
+func HandleHwpImportModel(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	newDocument := request.GetBool("new_document", true)
+
+	model, err := readModelArgument(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if newDocument {
+			if controller == nil {
+				controller = hwp.NewController()
+				hwp.SetGlobalController(controller)
+			}
+			if err := controller.CreateNewDocument(); err != nil {
+				hwp.SetGlobalController(nil)
+				result = hwp.CreateTextResult(fmt.Sprintf("Error creating document: %v", err))
+				return
+			}
+		} else if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := controller.ImportModel(model); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Document model with %d blocks imported", len(model.Blocks)))
+	})
+
+	return result, nil
+}
+
+func HandleHwpGetDocumentStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		modified, err := controller.IsModified()
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		resultJSON, _ := json.Marshal(map[string]interface{}{
+			"path":      controller.CurrentPath(),
+			"backend":   controller.Backend(),
+			"read_only": controller.IsReadOnly(),
+			"modified":  modified,
+		})
+		result = hwp.CreateTextResult(string(resultJSON))
+	})
+
+	return result, nil
+}
+
+func HandleHwpGetPageCount(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		pages, err := controller.PageCount()
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		resultJSON, _ := json.Marshal(map[string]int{"pages": pages})
+		result = hwp.CreateTextResult(string(resultJSON))
+	})
+
+	return result, nil
+}
+
+func HandleHwpRenderCurrentPage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	page := request.GetInt("page", 0)
+	dpi := request.GetInt("dpi", hwp.DefaultRenderDPI)
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		image, rendered, err := controller.RenderPage(page, dpi)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateImageResult(fmt.Sprintf("Page %d at %d dpi", rendered, dpi), image, "image/png")
+	})
+
+	return result, nil
+}
+
+// exportPageImageArguments reads and checks the arguments of
+// hwp_export_page_image, returning the target, the page and the resolution
+func exportPageImageArguments(request mcp.CallToolRequest) (string, int, int, error) {
+	dst := request.GetString("path", "")
+	if dst == "" {
+		return "", 0, 0, fmt.Errorf("path is required")
+	}
+	if !strings.EqualFold(filepath.Ext(dst), ".png") {
+		return "", 0, 0, fmt.Errorf("path must end in .png")
+	}
+	if _, err := os.Stat(dst); err == nil && !request.GetBool("overwrite", false) {
+		return "", 0, 0, fmt.Errorf("%s already exists (pass overwrite=true to replace it)", dst)
+	}
+	return dst, request.GetInt("page", 0), request.GetInt("dpi", hwp.DefaultRenderDPI), nil
+}
+
+func HandleHwpExportPageImage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	dst, page, dpi, err := exportPageImageArguments(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		image, rendered, err := controller.RenderPage(page, dpi)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		if err := os.WriteFile(dst, image, 0644); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: failed to write %s - %v", dst, err))
+			return
+		}
+
+		result = hwp.CreateImageResult(fmt.Sprintf("Saved page %d at %d dpi to %s", rendered, dpi, dst), image, "image/png")
+	})
+
+	return result, nil
+}
+
+func HandleHwpGetMetadata(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		metadata, err := controller.GetMetadata()
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		resultJSON, _ := json.Marshal(metadata)
+		result = hwp.CreateTextResult(string(resultJSON))
+	})
+
+	return result, nil
+}
+
+// optionalString returns a string argument, or nil if it was not given
+func optionalString(request mcp.CallToolRequest, key string) *string {
+	if _, ok := request.GetArguments()[key]; !ok {
+		return nil
+	}
+	value := request.GetString(key, "")
+	return &value
+}
+
+func HandleHwpSetMetadata(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	update := hwp.MetadataUpdate{
+		Title:    optionalString(request, "title"),
+		Subject:  optionalString(request, "subject"),
+		Author:   optionalString(request, "author"),
+		Keywords: optionalString(request, "keywords"),
+		Comments: optionalString(request, "comments"),
+		Created:  optionalString(request, "created"),
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := controller.SetMetadata(update); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult("Document metadata updated; save the document to keep it")
+	})
+
+	return result, nil
+}
+
+// printToPDFArguments reads and checks the arguments of hwp_print_to_pdf,
+// returning the target, the printer and the pages
+func printToPDFArguments(request mcp.CallToolRequest) (string, string, string, error) {
+	dst := request.GetString("path", "")
+	if dst == "" {
+		return "", "", "", fmt.Errorf("path is required")
+	}
+	if !strings.EqualFold(filepath.Ext(dst), ".pdf") {
+		return "", "", "", fmt.Errorf("path must end in .pdf")
+	}
+	pages := request.GetString("pages", "")
+	if err := hwp.ValidatePageRange(pages); err != nil {
+		return "", "", "", err
+	}
+	if _, err := os.Stat(dst); err == nil && !request.GetBool("overwrite", false) {
+		return "", "", "", fmt.Errorf("%s already exists (pass overwrite=true to replace it)", dst)
+	}
+	return dst, request.GetString("printer", "microsoft"), pages, nil
+}
+
+func HandleHwpPrintToPDF(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	dst, printer, pages, err := printToPDFArguments(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := controller.PrintToPDF(dst, printer, pages); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		result = hwp.CreateTextResult(fmt.Sprintf("Printed to %s with %s", dst, hwp.PDFPrinterName(printer)))
+	})
+
+	return result, nil
+}
+
+// convertFileArguments reads and checks the arguments of hwp_convert_file,
+// returning the source, the target and the target format
+func convertFileArguments(request mcp.CallToolRequest) (string, string, string, error) {
+	src := request.GetString("src", "")
+	dst := request.GetString("dst", "")
+	if src == "" || dst == "" {
+		return "", "", "", fmt.Errorf("src and dst are required")
+	}
+	format, err := hwp.ConvertFormat(dst, request.GetString("format", ""))
+	if err != nil {
+		return "", "", "", err
+	}
+	if _, err := os.Stat(src); err != nil {
+		return "", "", "", fmt.Errorf("source file not found: %s", src)
+	}
+	if _, err := os.Stat(dst); err == nil && !request.GetBool("overwrite", false) {
+		return "", "", "", fmt.Errorf("%s already exists (pass overwrite=true to replace it)", dst)
+	}
+	return src, dst, format, nil
+}
+
+func HandleHwpConvertFile(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	src, dst, format, err := convertFileArguments(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		if err := hwp.ConvertFile(src, dst, format); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		result = hwp.CreateTextResult(fmt.Sprintf("Converted %s to %s (%s)", src, dst, strings.ToUpper(format)))
+	})
+
+	return result, nil
+}
+
+func HandleHwpInsertPageOfDocument(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	path := request.GetString("path", "")
+	if path == "" {
+		return hwp.CreateTextResult("Error: Path is required"), nil
+	}
+	from := request.GetInt("from", 1)
+	to := request.GetInt("to", from)
+	if from < 1 || to < 0 {
+		return hwp.CreateTextResult("Error: from must be at least 1 and to must not be negative"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		pages, err := controller.InsertDocumentPages(path, from, to)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		if pages == 1 {
+			result = hwp.CreateTextResult(fmt.Sprintf("Inserted page %d of %s", from, path))
+			return
+		}
+		result = hwp.CreateTextResult(fmt.Sprintf("Inserted pages %d-%d of %s (%d pages)", from, from+pages-1, path, pages))
+	})
+
+	return result, nil
+}
@@ -0,0 +1,450 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// dryRunAll makes every destructive tool a dry run, as if dry_run were passed
+var dryRunAll bool
+
+// SetDryRun turns dry runs on or off for all destructive tools
+func SetDryRun(enabled bool) {
+	dryRunAll = enabled
+}
+
+// DryRunOption is the dry_run parameter of destructive tools
+func DryRunOption() mcp.ToolOption {
+	return mcp.WithBoolean("dry_run",
+		mcp.Description("Only report what the tool would do, without changing or saving anything (default: false)"),
+	)
+}
+
+// dryRunPreview validates a call and describes its effect. It runs on the COM
+// thread and must not change the document.
+type dryRunPreview func(controller *hwp.Controller, request mcp.CallToolRequest) (string, error)
+
+// dryRunPreviews are the previews of the destructive tools
+var dryRunPreviews = map[string]dryRunPreview{
+	HWP_SAVE:             previewSave,
+	HWP_CLOSE:            previewClose,
+	HWP_REVERT:           previewRevert,
+	HWP_PROTECT_DOCUMENT: previewProtectDocument,
+	HWP_CHANGE_PASSWORD:  previewChangePassword,
+	HWP_IMPORT_MODEL:     previewImportModel,
+	HWP_CLEAN_FORMATTING: previewCleanFormatting,
+	HWP_TRANSFORM_TEXT:   previewTransformText,
+	HWP_SORT_LINES:       previewSortLines,
+	HWP_CONVERT_FILE:     previewConvertFile,
+	HWP_COMPRESS_IMAGES:  previewCompressImages,
+	HWP_PRINT_TO_PDF:     previewPrintToPDF,
+	HWP_REDACT:           previewRedact,
+
+	HWP_REPLACE_BETWEEN_BOOKMARKS: previewReplaceBetweenBookmarks,
+	HWP_APPLY_PATCH:               previewApplyPatch,
+	HWP_DELETE_PARAGRAPHS:         previewDeleteParagraphs,
+	HWP_MOVE_PARAGRAPHS:           previewMoveParagraphs,
+	HWP_EXPORT_PAGE_IMAGE:         previewExportPageImage,
+}
+
+// DryRun is tool middleware that answers destructive tools with a preview
+// instead of running them when dry_run is set or dry runs are on for all calls
+func DryRun(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		preview, ok := dryRunPreviews[request.Params.Name]
+		if !ok || !(dryRunAll || request.GetBool("dry_run", false)) {
+			return next(ctx, request)
+		}
+
+		var result *mcp.CallToolResult
+
+		hwp.ExecuteHWPOperation(func() {
+			controller := hwp.GetGlobalController()
+			needsDocument := true
+			switch request.Params.Name {
+			case HWP_IMPORT_MODEL:
+				// Importing into a new document doesn't need an open one
+				needsDocument = !request.GetBool("new_document", true)
+			case HWP_CONVERT_FILE, HWP_COMPRESS_IMAGES:
+				// Conversions run in their own HWP instance
+				needsDocument = false
+			}
+			if needsDocument && (controller == nil || !controller.HasDocument()) {
+				result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+				return
+			}
+
+			description, err := preview(controller, request)
+			if err != nil {
+				result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+				return
+			}
+			result = hwp.CreateTextResult("Dry run, nothing was changed: " + description)
+		})
+
+		return result, nil
+	}
+}
+
+func previewSave(controller *hwp.Controller, request mcp.CallToolRequest) (string, error) {
+	path := request.GetString("path", "")
+	backups := request.GetInt("backups", 0)
+	if backups < 0 {
+		return "", fmt.Errorf("backups must not be negative")
+	}
+
+	target := controller.SavePath(path)
+	if target == "" {
+		if controller.Backend() == hwp.BackendHWPX {
+			return "", fmt.Errorf("a file path is required to save with the HWPX backend")
+		}
+		return "would ask for a file name in HWP's Save As dialog", nil
+	}
+
+	if _, err := os.Stat(target); err != nil {
+		return fmt.Sprintf("would save to new file %s", target), nil
+	}
+	description := fmt.Sprintf("would save over existing file %s", target)
+	if backups > 0 {
+		description += fmt.Sprintf(" after copying it to the backups directory (keeping %d)", backups)
+	}
+	return description, nil
+}
+
+func previewConvertFile(controller *hwp.Controller, request mcp.CallToolRequest) (string, error) {
+	src, dst, format, err := convertFileArguments(request)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(dst); err == nil {
+		return fmt.Sprintf("would convert %s to %s, replacing the existing file %s", src, strings.ToUpper(format), dst), nil
+	}
+	return fmt.Sprintf("would convert %s to %s as new file %s", src, strings.ToUpper(format), dst), nil
+}
+
+func previewCompressImages(controller *hwp.Controller, request mcp.CallToolRequest) (string, error) {
+	src, dst, compression, err := compressImagesArguments(request)
+	if err != nil {
+		return "", err
+	}
+	what := fmt.Sprintf("would write %s with the images of %s downscaled to %s", dst, src, describeCompression(compression))
+	if _, err := os.Stat(dst); err == nil {
+		return what + ", replacing the existing file", nil
+	}
+	return what + " as a new file", nil
+}
+
+func previewExportPageImage(controller *hwp.Controller, request mcp.CallToolRequest) (string, error) {
+	dst, page, dpi, err := exportPageImageArguments(request)
+	if err != nil {
+		return "", err
+	}
+	if controller.Backend() == hwp.BackendHWPX {
+		return "", hwp.ErrCOMRequired
+	}
+	what := "the page at the cursor"
+	if page > 0 {
+		what = fmt.Sprintf("page %d", page)
+	}
+	if _, err := os.Stat(dst); err == nil {
+		return fmt.Sprintf("would save %s at %d dpi, replacing the existing file %s", what, dpi, dst), nil
+	}
+	return fmt.Sprintf("would save %s at %d dpi as new file %s", what, dpi, dst), nil
+}
+
+func previewPrintToPDF(controller *hwp.Controller, request mcp.CallToolRequest) (string, error) {
+	dst, printer, pages, err := printToPDFArguments(request)
+	if err != nil {
+		return "", err
+	}
+	if controller.Backend() == hwp.BackendHWPX {
+		return "", hwp.ErrCOMRequired
+	}
+	what := "the document"
+	if pages != "" {
+		what = "pages " + pages
+	}
+	if _, err := os.Stat(dst); err == nil {
+		return fmt.Sprintf("would print %s with %s, replacing the existing file %s", what, hwp.PDFPrinterName(printer), dst), nil
+	}
+	return fmt.Sprintf("would print %s with %s to new file %s", what, hwp.PDFPrinterName(printer), dst), nil
+}
+
+func previewClose(controller *hwp.Controller, request mcp.CallToolRequest) (string, error) {
+	if controller == nil {
+		return "HWP is already closed", nil
+	}
+	if !controller.HasDocument() || controller.IsReadOnly() {
+		return "would close HWP", nil
+	}
+
+	modified, err := controller.IsModified()
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case !modified:
+		return "would close the document; it has no unsaved changes", nil
+	case request.GetBool("discard_changes", false):
+		return "would close the document and discard its unsaved changes", nil
+	}
+	return "would refuse to close: the document has unsaved changes (pass discard_changes=true or save first)", nil
+}
+
+func previewRevert(controller *hwp.Controller, request mcp.CallToolRequest) (string, error) {
+	path := controller.CurrentPath()
+	if path == "" {
+		return "", fmt.Errorf("the document has never been saved; there is nothing to revert to")
+	}
+
+	modified, err := controller.IsModified()
+	if err != nil {
+		return "", err
+	}
+	if !modified {
+		return fmt.Sprintf("would reopen %s; there are no unsaved changes to discard", path), nil
+	}
+	return fmt.Sprintf("would discard all unsaved changes and reopen %s", path), nil
+}
+
+func previewProtectDocument(controller *hwp.Controller, request mcp.CallToolRequest) (string, error) {
+	mode := request.GetString("mode", "")
+	password := request.GetString("password", "")
+	removePassword := request.GetBool("remove_password", false)
+
+	if mode == "" && password == "" && !removePassword {
+		return "", fmt.Errorf("Specify mode, password or remove_password")
+	}
+	if password != "" && removePassword {
+		return "", fmt.Errorf("password and remove_password cannot be used together")
+	}
+
+	var actions []string
+	if mode != "" {
+		if mode != "normal" && mode != "read_only" && mode != "form" {
+			return "", fmt.Errorf("invalid edit mode: %s (use normal, read_only or form)", mode)
+		}
+		actions = append(actions, fmt.Sprintf("set the edit mode to %s", mode))
+	}
+	if password != "" {
+		actions = append(actions, "set the document password")
+	}
+	if removePassword {
+		actions = append(actions, "remove the document password")
+	}
+	return "would " + strings.Join(actions, " and "), nil
+}
+
+func previewChangePassword(controller *hwp.Controller, request mcp.CallToolRequest) (string, error) {
+	if request.GetString("current_password", "") == "" {
+		return "", fmt.Errorf("current_password is required")
+	}
+	if controller.Backend() == hwp.BackendHWPX {
+		return "", hwp.ErrCOMRequired
+	}
+	if request.GetString("new_password", "") == "" {
+		return "would remove the document password when the document is next saved", nil
+	}
+	return "would change the document password when the document is next saved", nil
+}
+
+func previewImportModel(controller *hwp.Controller, request mcp.CallToolRequest) (string, error) {
+	model, err := readModelArgument(request)
+	if err != nil {
+		return "", err
+	}
+
+	counts := map[string]int{}
+	for _, block := range model.Blocks {
+		counts[block.Type]++
+	}
+	var parts []string
+	for _, blockType := range []string{hwp.BlockParagraph, hwp.BlockTable, hwp.BlockImage, hwp.BlockPageBreak} {
+		if counts[blockType] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[blockType], blockType))
+		}
+	}
+	summary := strings.Join(parts, ", ")
+	if summary == "" {
+		summary = "no blocks"
+	}
+
+	if request.GetBool("new_document", true) {
+		if controller != nil && controller.HasDocument() {
+			return fmt.Sprintf("would replace the open document with a new one containing %s", summary), nil
+		}
+		return fmt.Sprintf("would create a new document containing %s", summary), nil
+	}
+	return fmt.Sprintf("would insert %s at the cursor", summary), nil
+}
+
+func previewCleanFormatting(controller *hwp.Controller, request mcp.CallToolRequest) (string, error) {
+	scope := request.GetString("scope", "document")
+	fontName := request.GetString("font_name", "맑은 고딕")
+	fontSize := request.GetInt("font_size", 11)
+
+	var text string
+	var err error
+	switch scope {
+	case "document":
+		text, err = controller.GetText()
+	case "selection":
+		var selection hwp.Selection
+		var ok bool
+		selection, ok, err = controller.GetSelection()
+		if err == nil && !ok {
+			err = fmt.Errorf("nothing is selected")
+		}
+		text = selection.Text
+	default:
+		return "", fmt.Errorf("Invalid scope: %s (use document or selection)", scope)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	description := fmt.Sprintf("would reset the formatting of %d characters (%s) to %s %dpt", utf8.RuneCountInString(text), scope, fontName, fontSize)
+	if percent := request.GetInt("line_spacing", 0); percent > 0 {
+		description += fmt.Sprintf(", line spacing %d%%", percent)
+	}
+	return description, nil
+}
+
+func previewTransformText(controller *hwp.Controller, request mcp.CallToolRequest) (string, error) {
+	transform := request.GetString("transform", "")
+	if transform == "" {
+		return "", fmt.Errorf("Transform is required")
+	}
+
+	text, converted, err := controller.PreviewTransform(transform)
+	if err != nil {
+		return "", err
+	}
+	if text == converted {
+		return fmt.Sprintf("would leave %q unchanged", text), nil
+	}
+	return fmt.Sprintf("would replace %q with %q", text, converted), nil
+}
+
+func previewSortLines(controller *hwp.Controller, request mcp.CallToolRequest) (string, error) {
+	lines, sorted, err := controller.PreviewSort(request.GetString("order", "alphabetical"), request.GetBool("reverse", false))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("would reorder %d lines as %q", len(lines), sorted), nil
+}
+
+func previewRedact(controller *hwp.Controller, request mcp.CallToolRequest) (string, error) {
+	patterns, err := piiPatternsArgument(request)
+	if err != nil {
+		return "", err
+	}
+	mode, err := redactModeArgument(request)
+	if err != nil {
+		return "", err
+	}
+	text, err := controller.GetText()
+	if err != nil {
+		return "", err
+	}
+
+	redactions := hwp.PlanRedactions(text, patterns, mode)
+	if len(redactions) == 0 {
+		return "would redact nothing; no pattern matches", nil
+	}
+	report := countRedactions(patterns, redactions)
+	var parts []string
+	for _, pattern := range patterns {
+		if count := report.Counts[pattern.Name]; count > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", pattern.Name, count))
+		}
+	}
+	return fmt.Sprintf("would redact %d matches (%s) in %s mode", report.Total, strings.Join(parts, ", "), mode), nil
+}
+
+func previewReplaceBetweenBookmarks(controller *hwp.Controller, request mcp.CallToolRequest) (string, error) {
+	start, end, err := bookmarkRangeArguments(request)
+	if err != nil {
+		return "", err
+	}
+	text, err := controller.TextBetweenBookmarks(start, end)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("would replace %d characters between bookmarks %q and %q: %q", utf8.RuneCountInString(text), start, end, text), nil
+}
+
+func previewApplyPatch(controller *hwp.Controller, request mcp.CallToolRequest) (string, error) {
+	operations, err := parsePatchOperations(request)
+	if err != nil {
+		return "", err
+	}
+	if err := controller.CheckPatch(operations); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("would apply %d operations (%s); positions are checked as each operation runs", len(operations), describePatch(operations)), nil
+}
+
+// previewParagraphs reads the paragraphs a paragraph tool would change and
+// checks that they all exist
+func previewParagraphs(controller *hwp.Controller, start, count int) ([]hwp.Paragraph, int, error) {
+	paragraphs, total, err := controller.Paragraphs(start, count)
+	if err != nil {
+		return nil, 0, err
+	}
+	if start+count > total {
+		return nil, 0, fmt.Errorf("paragraphs %d to %d are past the end of the document, which has %d", start, start+count-1, total)
+	}
+	return paragraphs, total, nil
+}
+
+// paragraphPreviewRunes is how much of a paragraph a preview quotes
+const paragraphPreviewRunes = 40
+
+// firstParagraph quotes the start of the first of paragraphs
+func firstParagraph(paragraphs []hwp.Paragraph) string {
+	text := []rune(paragraphs[0].Text)
+	if len(text) > paragraphPreviewRunes {
+		return fmt.Sprintf("%q", string(text[:paragraphPreviewRunes])+"…")
+	}
+	return fmt.Sprintf("%q", string(text))
+}
+
+func previewDeleteParagraphs(controller *hwp.Controller, request mcp.CallToolRequest) (string, error) {
+	start, count, err := paragraphRangeArguments(request)
+	if err != nil {
+		return "", err
+	}
+	paragraphs, _, err := previewParagraphs(controller, start, count)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("would delete %s, starting %s", describeParagraphs(start, count), firstParagraph(paragraphs)), nil
+}
+
+func previewMoveParagraphs(controller *hwp.Controller, request mcp.CallToolRequest) (string, error) {
+	start, count, err := paragraphRangeArguments(request)
+	if err != nil {
+		return "", err
+	}
+	to := request.GetInt("to_index", 0)
+	paragraphs, total, err := previewParagraphs(controller, start, count)
+	if err != nil {
+		return "", err
+	}
+	if err := hwp.CheckMoveTarget(start, count, to, total); err != nil {
+		return "", err
+	}
+	if to == total {
+		return fmt.Sprintf("would move %s, starting %s, to the end of the document", describeParagraphs(start, count), firstParagraph(paragraphs)), nil
+	}
+	return fmt.Sprintf("would move %s, starting %s, before paragraph %d", describeParagraphs(start, count), firstParagraph(paragraphs), to), nil
+}
@@ -0,0 +1,319 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Editing tools
+//
+// These tools change parts of an existing document in place, located by
+// bookmarks or positions, and leave the rest of it untouched.
+
+// Tool names for editing
+const (
+	HWP_REPLACE_BETWEEN_BOOKMARKS = "hwp_replace_between_bookmarks"
+	HWP_APPLY_PATCH               = "hwp_apply_patch"
+	HWP_DELETE_PARAGRAPHS         = "hwp_delete_paragraphs"
+	HWP_MOVE_PARAGRAPHS           = "hwp_move_paragraphs"
+)
+
+// blocksArgument reads an array of document model blocks, as in the blocks of
+// hwp_import_model, and checks their types before anything is changed
+func blocksArgument(request mcp.CallToolRequest, name string) ([]hwp.Block, error) {
+	value, ok, err := structuredArgument(request, name)
+	if !ok || err != nil {
+		return nil, err
+	}
+	if _, isArray := value.([]interface{}); !isArray {
+		return nil, fmt.Errorf("%s: must be an array of blocks", name)
+	}
+
+	data, _ := json.Marshal(value)
+	var blocks []hwp.Block
+	if err := json.Unmarshal(data, &blocks); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	for i, block := range blocks {
+		switch block.Type {
+		case hwp.BlockParagraph, hwp.BlockTable, hwp.BlockImage, hwp.BlockPageBreak:
+		default:
+			return nil, fmt.Errorf("%s[%d].type: unknown block type %q (use %s, %s, %s or %s)", name, i, block.Type,
+				hwp.BlockParagraph, hwp.BlockTable, hwp.BlockImage, hwp.BlockPageBreak)
+		}
+	}
+	return blocks, nil
+}
+
+// bookmarkRangeArguments reads the start_bookmark and end_bookmark arguments
+func bookmarkRangeArguments(request mcp.CallToolRequest) (start, end string, err error) {
+	start = request.GetString("start_bookmark", "")
+	end = request.GetString("end_bookmark", "")
+	if start == "" || end == "" {
+		return "", "", fmt.Errorf("start_bookmark and end_bookmark are required")
+	}
+	if start == end {
+		return "", "", fmt.Errorf("start_bookmark and end_bookmark must differ")
+	}
+	return start, end, nil
+}
+
+func HandleHwpReplaceBetweenBookmarks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	start, end, err := bookmarkRangeArguments(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	blocks, err := blocksArgument(request, "blocks")
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	// An empty text is allowed and clears the region
+	_, hasText := request.GetArguments()["text"]
+	text := request.GetString("text", "")
+	if !hasText && len(blocks) == 0 {
+		return hwp.CreateTextResult("Error: Specify text or blocks (text \"\" empties the region)"), nil
+	}
+	if text != "" && len(blocks) > 0 {
+		return hwp.CreateTextResult("Error: Specify either text or blocks, not both"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		removed, err := controller.ReplaceBetweenBookmarks(start, end, text, blocks)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		inserted := fmt.Sprintf("%d characters of text", len([]rune(text)))
+		if len(blocks) > 0 {
+			inserted = fmt.Sprintf("%d blocks", len(blocks))
+		}
+		result = hwp.CreateTextResult(fmt.Sprintf("Replaced %d characters between bookmarks %q and %q with %s", removed, start, end, inserted))
+	})
+
+	return result, nil
+}
+
+// readPatchAnchor reads the anchor object in key: {"bookmark": name} or
+// {"para": n, "pos": m}
+func readPatchAnchor(fields *fieldReader, key string) hwp.PatchAnchor {
+	object := fields.Object(key)
+	if object == nil {
+		if _, ok := fields.value(key); !ok {
+			fields.fail(key, "is required")
+		}
+		return hwp.PatchAnchor{}
+	}
+
+	anchorFields := &fieldReader{path: fields.path + "." + key, object: object}
+	anchor := hwp.PatchAnchor{Bookmark: anchorFields.String("bookmark")}
+	if anchor.Bookmark == "" {
+		if _, ok := anchorFields.value("para"); !ok {
+			anchorFields.fail("para", "is required without bookmark")
+		}
+		anchor.Para = anchorFields.Int("para")
+		anchor.Pos = anchorFields.Int("pos")
+		if anchor.Para < 0 {
+			anchorFields.fail("para", "must not be negative")
+		}
+		if anchor.Pos < 0 {
+			anchorFields.fail("pos", "must not be negative")
+		}
+	}
+	if anchorFields.err != nil && fields.err == nil {
+		fields.err = anchorFields.err
+	}
+	return anchor
+}
+
+// parsePatchOperations validates the operations argument of hwp_apply_patch
+func parsePatchOperations(request mcp.CallToolRequest) ([]hwp.PatchOperation, error) {
+	items, ok, err := objectArrayArgument(request, "operations")
+	if err != nil {
+		return nil, err
+	}
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("Operations list is required")
+	}
+
+	operations := make([]hwp.PatchOperation, 0, len(items))
+	for i, item := range items {
+		fields := &fieldReader{path: fmt.Sprintf("operations[%d]", i), object: item}
+		op := hwp.PatchOperation{Op: normalizeOperationType(fields.RequiredString("op"))}
+
+		switch op.Op {
+		case hwp.PatchInsertAt:
+			op.At = readPatchAnchor(fields, "at")
+			op.Text = fields.RequiredString("text")
+		case hwp.PatchDeleteRange, hwp.PatchReplaceRange, hwp.PatchFormatRange:
+			op.Start = readPatchAnchor(fields, "start")
+			op.End = readPatchAnchor(fields, "end")
+			if op.Op == hwp.PatchReplaceRange {
+				op.Text = fields.String("text")
+			}
+			if op.Op == hwp.PatchFormatRange {
+				op.Format = hwp.CharFormat{
+					Font:      fields.String("font"),
+					Size:      fields.Int("size"),
+					Bold:      fields.OptionalBool("bold"),
+					Italic:    fields.OptionalBool("italic"),
+					Underline: fields.OptionalBool("underline"),
+					Color:     fields.String("color"),
+					Highlight: fields.String("highlight"),
+				}
+				if op.Format == (hwp.CharFormat{}) {
+					fields.fail("op", "format_range needs font, size, bold, italic, underline, color or highlight")
+				}
+			}
+		case "":
+		default:
+			fields.fail("op", "unknown operation %q (use %s)", op.Op, strings.Join(hwp.PatchOperations, ", "))
+		}
+
+		if fields.err != nil {
+			return nil, fields.err
+		}
+		operations = append(operations, op)
+	}
+	return operations, nil
+}
+
+// describePatch counts the operations of a patch by kind, e.g. "2 insert_at,
+// 1 delete_range"
+func describePatch(operations []hwp.PatchOperation) string {
+	counts := map[string]int{}
+	for _, op := range operations {
+		counts[op.Op]++
+	}
+	var parts []string
+	for _, name := range hwp.PatchOperations {
+		if counts[name] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[name], name))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func HandleHwpApplyPatch(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Validate every operation before running any of them
+	operations, err := parsePatchOperations(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := controller.ApplyPatch(operations); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Patch applied: %d operations (%s)", len(operations), describePatch(operations)))
+	})
+
+	return result, nil
+}
+
+// paragraphRangeArguments reads the start_index and count of the paragraph
+// tools (count defaults to 1)
+func paragraphRangeArguments(request mcp.CallToolRequest) (start, count int, err error) {
+	if _, ok := request.GetArguments()["start_index"]; !ok {
+		return 0, 0, fmt.Errorf("start_index is required")
+	}
+	start = request.GetInt("start_index", 0)
+	count = request.GetInt("count", 1)
+	if start < 0 || count < 1 {
+		return 0, 0, fmt.Errorf("start_index must not be negative and count must be at least 1")
+	}
+	return start, count, nil
+}
+
+// describeParagraphs names a paragraph range, e.g. "paragraphs 3-5"
+func describeParagraphs(start, count int) string {
+	if count == 1 {
+		return fmt.Sprintf("paragraph %d", start)
+	}
+	return fmt.Sprintf("paragraphs %d-%d", start, start+count-1)
+}
+
+func HandleHwpDeleteParagraphs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	start, count, err := paragraphRangeArguments(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := controller.DeleteParagraphs(start, count); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Deleted %s; the paragraphs after them moved up by %d", describeParagraphs(start, count), count))
+	})
+
+	return result, nil
+}
+
+func HandleHwpMoveParagraphs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	start, count, err := paragraphRangeArguments(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	if _, ok := request.GetArguments()["to_index"]; !ok {
+		return hwp.CreateTextResult("Error: to_index is required"), nil
+	}
+	to := request.GetInt("to_index", 0)
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := controller.MoveParagraphs(start, count, to); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		// Report where the paragraphs are now
+		newStart := to
+		if to > start {
+			newStart = to - count
+		}
+		result = hwp.CreateTextResult(fmt.Sprintf("Moved %s; they are now %s", describeParagraphs(start, count), describeParagraphs(newStart, count)))
+	})
+
+	return result, nil
+}
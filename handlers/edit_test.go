@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"hwp-mcp-go/hwp"
+)
+
+func TestParsePatchOperations(t *testing.T) {
+	operations, err := parsePatchOperations(argumentRequest("operations", `[
+		{"op": "Insert-At", "at": {"bookmark": "intro"}, "text": "서론"},
+		{"op": "delete_range", "start": {"para": "3", "pos": 0}, "end": {"para": 3, "pos": 12}},
+		{"op": "format_range", "start": {"bookmark": "a"}, "end": {"bookmark": "b"}, "bold": "false", "highlight": "yellow"}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []hwp.PatchOperation{
+		{Op: hwp.PatchInsertAt, At: hwp.PatchAnchor{Bookmark: "intro"}, Text: "서론"},
+		{Op: hwp.PatchDeleteRange, Start: hwp.PatchAnchor{Para: 3}, End: hwp.PatchAnchor{Para: 3, Pos: 12}},
+	}
+	for i, op := range want {
+		if operations[i] != op {
+			t.Errorf("operations[%d] = %+v, want %+v", i, operations[i], op)
+		}
+	}
+	format := operations[2].Format
+	if format.Bold == nil || *format.Bold || format.Italic != nil || format.Highlight != "yellow" {
+		t.Errorf("operations[2].Format = %+v, want bold false and highlight yellow only", format)
+	}
+}
+
+func TestParsePatchOperationsReportsFieldErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		operations string
+		want       string
+	}{
+		{
+			name:       "unknown operation",
+			operations: `[{"op": "move_range"}]`,
+			want:       `operations[0].op: unknown operation "move_range"`,
+		},
+		{
+			name:       "missing anchor",
+			operations: `[{"op": "delete_range", "start": {"para": 1, "pos": 0}}]`,
+			want:       "operations[0].end: is required",
+		},
+		{
+			name:       "anchor without bookmark or para",
+			operations: `[{"op": "insert_at", "at": {"pos": 4}, "text": "x"}]`,
+			want:       "operations[0].at.para: is required without bookmark",
+		},
+		{
+			name:       "negative position",
+			operations: `[{"op": "insert_at", "at": {"para": 0, "pos": -1}, "text": "x"}]`,
+			want:       "operations[0].at.pos: must not be negative",
+		},
+		{
+			name:       "format without settings",
+			operations: `[{"op": "format_range", "start": {"bookmark": "a"}, "end": {"bookmark": "b"}}]`,
+			want:       "operations[0].op: format_range needs",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parsePatchOperations(argumentRequest("operations", test.operations))
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("parsePatchOperations() error = %v, want %q", err, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Tool names for file browsing
+const (
+	HWP_LIST_FILES = "hwp_list_files"
+)
+
+// listFilesLimit caps the files one listing returns
+const listFilesLimit = 500
+
+// defaultFilePatterns are matched when hwp_list_files gets no pattern
+var defaultFilePatterns = []string{"*.hwp", "*.hwpx"}
+
+// allowedDirs are the directories hwp_list_files may look into, with
+// symbolic links resolved. Nothing can be listed while it is empty.
+var allowedDirs []string
+
+// SetAllowedDirs sets the directories hwp_list_files may browse, including
+// their subdirectories
+func SetAllowedDirs(dirs []string) error {
+	var resolved []string
+	for _, dir := range dirs {
+		if strings.TrimSpace(dir) == "" {
+			continue
+		}
+		path, err := resolvePath(dir)
+		if err != nil {
+			return fmt.Errorf("invalid allowed directory %s: %v", dir, err)
+		}
+		resolved = append(resolved, path)
+	}
+	allowedDirs = resolved
+	return nil
+}
+
+// resolvePath makes path absolute and resolves symbolic links, so a link
+// can't lead out of an allowed directory
+func resolvePath(path string) (string, error) {
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(absolute)
+}
+
+// allowedDir returns dir resolved if it is inside an allowed directory.
+// A relative dir is taken from the first allowed directory.
+func allowedDir(dir string) (string, error) {
+	if len(allowedDirs) == 0 {
+		return "", fmt.Errorf("no directories are allowed for browsing; start the server with -allowed-dirs")
+	}
+	if dir == "" {
+		dir = allowedDirs[0]
+	} else if !filepath.IsAbs(dir) {
+		dir = filepath.Join(allowedDirs[0], dir)
+	}
+
+	path, err := resolvePath(dir)
+	if err != nil {
+		return "", fmt.Errorf("directory not found: %s", dir)
+	}
+	for _, root := range allowedDirs {
+		if relative, err := filepath.Rel(root, path); err == nil && relative != ".." && !strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("%s is outside the allowed directories (%s)", dir, strings.Join(allowedDirs, ", "))
+}
+
+// browsedFile is a file found by hwp_list_files
+type browsedFile struct {
+	Path     string    `json:"path"`
+	Name     string    `json:"name"`
+	Size     int64     `json:"size"`
+	Modified time.Time `json:"modified"`
+}
+
+// matchesAnyPattern reports whether a file name matches one of the
+// patterns, ignoring case as Windows file names do
+func matchesAnyPattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := filepath.Match(strings.ToLower(pattern), strings.ToLower(name)); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func HandleHwpListFiles(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	recursive := request.GetBool("recursive", false)
+	patterns := defaultFilePatterns
+	if pattern := strings.TrimSpace(request.GetString("pattern", "")); pattern != "" {
+		patterns = strings.Split(pattern, ",")
+		for i, p := range patterns {
+			patterns[i] = strings.TrimSpace(p)
+			if _, err := filepath.Match(patterns[i], ""); err != nil {
+				return hwp.CreateTextResult(fmt.Sprintf("Error: invalid pattern %q", patterns[i])), nil
+			}
+		}
+	}
+
+	dir, err := allowedDir(request.GetString("dir", ""))
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	files := []browsedFile{}
+	dirs := []string{}
+	truncated := false
+	err = filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			// Skip unreadable directories instead of failing the listing
+			if entry != nil && entry.IsDir() && path != dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if entry.IsDir() {
+			if path == dir {
+				return nil
+			}
+			if strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			if !recursive {
+				dirs = append(dirs, entry.Name())
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !matchesAnyPattern(patterns, entry.Name()) {
+			return nil
+		}
+		if len(files) == listFilesLimit {
+			truncated = true
+			return filepath.SkipAll
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		files = append(files, browsedFile{Path: path, Name: entry.Name(), Size: info.Size(), Modified: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: failed to list %s: %v", dir, err)), nil
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	resultJSON, _ := json.Marshal(map[string]interface{}{
+		"dir":         dir,
+		"subdirs":     dirs,
+		"total_files": len(files),
+		"files":       files,
+		"truncated":   truncated,
+	})
+	return hwp.CreateTextResult(string(resultJSON)), nil
+}
@@ -0,0 +1,383 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Tool names for formatting
+const (
+	HWP_SET_OUTLINE_NUMBERING = "hwp_set_outline_numbering"
+	HWP_APPLY_HEADING         = "hwp_apply_heading"
+	HWP_SET_SPACING           = "hwp_set_spacing"
+	HWP_CLEAN_FORMATTING      = "hwp_clean_formatting"
+	HWP_TRANSFORM_TEXT        = "hwp_transform_text"
+	HWP_SORT_LINES            = "hwp_sort_lines"
+	HWP_FORMAT_MATCHES        = "hwp_format_matches"
+)
+
+// formatTargetArgument reads the scope of a formatting tool and, for scope
+// matches, the search: find, regex and match_case
+func formatTargetArgument(request mcp.CallToolRequest) (hwp.FormatTarget, error) {
+	target := hwp.FormatTarget{
+		Scope:     request.GetString("scope", hwp.ScopeCursor),
+		Find:      request.GetString("find", ""),
+		Regex:     request.GetBool("regex", false),
+		MatchCase: request.GetBool("match_case", false),
+	}
+	return target, target.Validate()
+}
+
+// describeScope returns the end of a result message saying what a scoped
+// formatting call applied to; nothing for the cursor scope
+func describeScope(target hwp.FormatTarget, formatted int) string {
+	switch target.Scope {
+	case hwp.ScopeSelection:
+		return " on the selection"
+	case hwp.ScopeParagraph:
+		return " on the paragraph at the cursor"
+	case hwp.ScopeDocument:
+		return " on the whole document"
+	case hwp.ScopeMatches:
+		if formatted == 1 {
+			return fmt.Sprintf(" on 1 match of %q", target.Find)
+		}
+		return fmt.Sprintf(" on %d matches of %q", formatted, target.Find)
+	}
+	return ""
+}
+
+// Formatting tool handlers
+
+func HandleHwpSetOutlineNumbering(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	scheme := request.GetString("scheme", "decimal")
+	levelsStr := request.GetString("levels", "")
+
+	var levels []hwp.OutlineLevel
+	if levelsStr != "" {
+		if err := json.Unmarshal([]byte(levelsStr), &levels); err != nil {
+			return hwp.CreateTextResult(fmt.Sprintf("Error: Failed to parse levels JSON - %v", err)), nil
+		}
+		scheme = "custom"
+	} else {
+		var ok bool
+		levels, ok = hwp.OutlineSchemes[scheme]
+		if !ok {
+			return hwp.CreateTextResult(fmt.Sprintf("Error: Unknown numbering scheme: %s (use decimal, korean, hangul or pass levels)", scheme)), nil
+		}
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		err := controller.SetOutlineNumbering(levels)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Outline numbering set to %s scheme (%d levels)", scheme, len(levels)))
+	})
+
+	return result, nil
+}
+
+func HandleHwpApplyHeading(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	level := request.GetInt("level", 1)
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		err := controller.ApplyOutlineLevel(level)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Heading level %d applied to current paragraph", level))
+	})
+
+	return result, nil
+}
+
+func HandleHwpSetSpacing(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	preset := request.GetString("preset", "")
+	spacing := hwp.ParagraphSpacing{
+		BeforePt:    optionalFloat(request, "before"),
+		AfterPt:     optionalFloat(request, "after"),
+		FirstLinePt: optionalFloat(request, "first_line_indent"),
+	}
+	dropCap := request.GetString("drop_cap", "")
+	dropCapFont := request.GetString("drop_cap_font", "")
+
+	if percent := request.GetInt("line_spacing", 0); percent > 0 {
+		spacing.LineSpacingPercent = &percent
+	} else if preset != "" {
+		percent, ok := hwp.LineSpacingPresets[preset]
+		if !ok {
+			return hwp.CreateTextResult(fmt.Sprintf("Error: Unknown spacing preset: %s (use single, 1.15, 1.5, 160%%, double)", preset)), nil
+		}
+		spacing.LineSpacingPercent = &percent
+	}
+
+	if firstLine := request.GetString("first_line", ""); firstLine != "" && spacing.FirstLinePt == nil {
+		indent, ok := hwp.FirstLinePresets[firstLine]
+		if !ok {
+			return hwp.CreateTextResult(fmt.Sprintf("Error: Unknown first line preset: %s (use none, indent, wide, hanging)", firstLine)), nil
+		}
+		spacing.FirstLinePt = &indent
+	}
+	if _, ok := hwp.DropCapStyles[dropCap]; dropCap != "" && !ok {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: Unknown drop cap style: %s (use none, 2_lines, 3_lines, margin)", dropCap)), nil
+	}
+
+	changesSpacing := spacing.LineSpacingPercent != nil || spacing.BeforePt != nil || spacing.AfterPt != nil || spacing.FirstLinePt != nil
+	if !changesSpacing && dropCap == "" {
+		return hwp.CreateTextResult("Error: Specify a preset, line_spacing, before, after, first_line, first_line_indent or drop_cap"), nil
+	}
+	target, err := formatTargetArgument(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		formatted, err := controller.ApplyToScope(target, func() error {
+			if changesSpacing {
+				if err := controller.SetParagraphSpacing(spacing); err != nil {
+					return err
+				}
+			}
+			if dropCap != "" {
+				return controller.SetDropCap(dropCap, dropCapFont)
+			}
+			return nil
+		})
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		var applied []string
+		if spacing.LineSpacingPercent != nil {
+			applied = append(applied, fmt.Sprintf("line spacing %d%%", *spacing.LineSpacingPercent))
+		}
+		if spacing.BeforePt != nil {
+			applied = append(applied, fmt.Sprintf("before %gpt", *spacing.BeforePt))
+		}
+		if spacing.AfterPt != nil {
+			applied = append(applied, fmt.Sprintf("after %gpt", *spacing.AfterPt))
+		}
+		if spacing.FirstLinePt != nil {
+			if *spacing.FirstLinePt < 0 {
+				applied = append(applied, fmt.Sprintf("hanging indent %gpt", -*spacing.FirstLinePt))
+			} else {
+				applied = append(applied, fmt.Sprintf("first line indent %gpt", *spacing.FirstLinePt))
+			}
+		}
+		if dropCap != "" {
+			applied = append(applied, fmt.Sprintf("drop cap %s", dropCap))
+		}
+		result = hwp.CreateTextResult(fmt.Sprintf("Spacing set (%s)%s", strings.Join(applied, ", "), describeScope(target, formatted)))
+	})
+
+	return result, nil
+}
+
+func HandleHwpCleanFormatting(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	scope := request.GetString("scope", "document")
+	if scope != "document" && scope != "selection" {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: Invalid scope: %s (use document or selection)", scope)), nil
+	}
+	fontName := request.GetString("font_name", "맑은 고딕")
+	fontSize := request.GetInt("font_size", 11)
+
+	var spacing *hwp.ParagraphSpacing
+	if percent := request.GetInt("line_spacing", 0); percent > 0 {
+		spacing = &hwp.ParagraphSpacing{LineSpacingPercent: &percent}
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		err := controller.CleanFormatting(scope == "document", fontName, fontSize, spacing)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		message := fmt.Sprintf("Formatting of %s reset to %s %dpt", scope, fontName, fontSize)
+		if spacing != nil {
+			message += fmt.Sprintf(", line spacing %d%%", *spacing.LineSpacingPercent)
+		}
+		result = hwp.CreateTextResult(message)
+	})
+
+	return result, nil
+}
+
+func HandleHwpTransformText(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	transform := request.GetString("transform", "")
+	if transform == "" {
+		return hwp.CreateTextResult("Error: Transform is required"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		err := controller.TransformSelection(transform)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Transform %s applied to selection", transform))
+	})
+
+	return result, nil
+}
+
+func HandleHwpSortLines(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	order := request.GetString("order", "alphabetical")
+	reverse := request.GetBool("reverse", false)
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		count, err := controller.SortSelection(order, reverse)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		direction := "ascending"
+		if reverse {
+			direction = "descending"
+		}
+		result = hwp.CreateTextResult(fmt.Sprintf("Sorted %d lines of the selection (%s, %s)", count, order, direction))
+	})
+
+	return result, nil
+}
+
+func HandleHwpFormatMatches(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	target := hwp.FormatTarget{
+		Scope:     hwp.ScopeMatches,
+		Find:      request.GetString("text", ""),
+		Regex:     request.GetBool("regex", false),
+		MatchCase: request.GetBool("match_case", false),
+	}
+	if target.Find == "" {
+		return hwp.CreateTextResult("Error: Text is required"), nil
+	}
+	format := hwp.CharFormat{
+		Font:      request.GetString("font", ""),
+		Size:      request.GetInt("size", 0),
+		Bold:      optionalBool(request, "bold"),
+		Italic:    optionalBool(request, "italic"),
+		Underline: optionalBool(request, "underline"),
+		Color:     request.GetString("color", ""),
+		Highlight: request.GetString("highlight", ""),
+	}
+
+	var applied []string
+	if format.Font != "" {
+		applied = append(applied, format.Font)
+	}
+	if format.Size > 0 {
+		applied = append(applied, fmt.Sprintf("%dpt", format.Size))
+	}
+	for _, flag := range []struct {
+		name  string
+		value *bool
+	}{{"bold", format.Bold}, {"italic", format.Italic}, {"underline", format.Underline}} {
+		if flag.value != nil {
+			if *flag.value {
+				applied = append(applied, flag.name)
+			} else {
+				applied = append(applied, "no "+flag.name)
+			}
+		}
+	}
+	if format.Color != "" {
+		applied = append(applied, fmt.Sprintf("color: %s", format.Color))
+	}
+	if format.Highlight != "" {
+		applied = append(applied, fmt.Sprintf("highlight: %s", format.Highlight))
+	}
+	if len(applied) == 0 {
+		return hwp.CreateTextResult("Error: Specify font, size, bold, italic, underline, color or highlight"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		formatted, err := controller.ApplyToScope(target, func() error {
+			return controller.SetCharFormat(format)
+		})
+		if err != nil && formatted > 0 {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v (%d matches were formatted before it)", err, formatted))
+			return
+		} else if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		if formatted == 0 {
+			result = hwp.CreateTextResult(fmt.Sprintf("No matches of %q; nothing was formatted", target.Find))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Formatted (%s)%s", strings.Join(applied, ", "), describeScope(target, formatted)))
+	})
+
+	return result, nil
+}
@@ -0,0 +1,340 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Picture tools
+//
+// These tools work on pictures already in the document, numbered from 0 in
+// document order as hwp_list_images lists them.
+
+// Tool names for pictures
+const (
+	HWP_LIST_IMAGES           = "hwp_list_images"
+	HWP_SET_IMAGE_DESCRIPTION = "hwp_set_image_description"
+	HWP_SET_IMAGE_WRAP        = "hwp_set_image_wrap"
+	HWP_COMPRESS_IMAGES       = "hwp_compress_images"
+)
+
+// defaultMaxImagePixels is the longest image edge hwp_compress_images keeps
+const defaultMaxImagePixels = 2000
+
+// imagePositionArguments are the placement arguments other than wrap
+var imagePositionArguments = []string{
+	"horizontal_relative_to", "horizontal_align", "horizontal_offset_mm",
+	"vertical_relative_to", "vertical_align", "vertical_offset_mm",
+}
+
+// imagePlacementArgument reads the wrap and position arguments of the
+// picture tools; it returns nil when none is given. Positions only apply to
+// floating pictures, so they are refused without a wrap other than inline.
+func imagePlacementArgument(request mcp.CallToolRequest) (*hwp.ImagePlacement, error) {
+	arguments := request.GetArguments()
+	_, hasWrap := arguments["wrap"]
+	var positioned []string
+	for _, name := range imagePositionArguments {
+		if _, ok := arguments[name]; ok {
+			positioned = append(positioned, name)
+		}
+	}
+	if !hasWrap && len(positioned) == 0 {
+		return nil, nil
+	}
+
+	placement := &hwp.ImagePlacement{
+		Wrap:         request.GetString("wrap", "inline"),
+		HorzRelTo:    request.GetString("horizontal_relative_to", ""),
+		HorzAlign:    request.GetString("horizontal_align", ""),
+		HorzOffsetMM: request.GetFloat("horizontal_offset_mm", 0),
+		VertRelTo:    request.GetString("vertical_relative_to", ""),
+		VertAlign:    request.GetString("vertical_align", ""),
+		VertOffsetMM: request.GetFloat("vertical_offset_mm", 0),
+	}
+	if len(positioned) > 0 && strings.EqualFold(placement.Wrap, "inline") {
+		return nil, fmt.Errorf("%s: positions apply to floating pictures only; set wrap to one of %s", strings.Join(positioned, ", "), strings.Join(hwp.ImageWraps, ", "))
+	}
+	if err := placement.Validate(); err != nil {
+		return nil, err
+	}
+	return placement, nil
+}
+
+// imageTransformArguments are the rotation and crop arguments of the picture tools
+var imageTransformArguments = []string{"rotation", "crop_left_mm", "crop_top_mm", "crop_right_mm", "crop_bottom_mm"}
+
+// imageTransformArgument reads the rotation and crop arguments of the picture
+// tools; it returns nil when none is given
+func imageTransformArgument(request mcp.CallToolRequest) (*hwp.ImageTransform, error) {
+	arguments := request.GetArguments()
+	values := make([]*float64, len(imageTransformArguments))
+	given := false
+	for i, name := range imageTransformArguments {
+		if _, ok := arguments[name]; ok {
+			value := request.GetFloat(name, 0)
+			values[i] = &value
+			given = true
+		}
+	}
+	if !given {
+		return nil, nil
+	}
+
+	transform := &hwp.ImageTransform{
+		Rotation:     values[0],
+		CropLeftMM:   values[1],
+		CropTopMM:    values[2],
+		CropRightMM:  values[3],
+		CropBottomMM: values[4],
+	}
+	if err := transform.Validate(); err != nil {
+		return nil, err
+	}
+	return transform, nil
+}
+
+// describeTransform describes a transform for the tool results, e.g.
+// "rotated 90°, cropped left 5mm, top 3mm"
+func describeTransform(transform hwp.ImageTransform) string {
+	var parts, crops []string
+	if transform.Rotation != nil {
+		parts = append(parts, fmt.Sprintf("rotated %g°", *transform.Rotation))
+	}
+	for _, crop := range []struct {
+		edge string
+		mm   *float64
+	}{
+		{"left", transform.CropLeftMM},
+		{"top", transform.CropTopMM},
+		{"right", transform.CropRightMM},
+		{"bottom", transform.CropBottomMM},
+	} {
+		if crop.mm != nil {
+			crops = append(crops, fmt.Sprintf("%s %gmm", crop.edge, *crop.mm))
+		}
+	}
+	if len(crops) > 0 {
+		parts = append(parts, "cropped "+strings.Join(crops, ", "))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// imageCompressionArgument reads the downscaling arguments of
+// hwp_insert_image; it returns nil when neither max_pixels nor max_dpi is given
+func imageCompressionArgument(request mcp.CallToolRequest) (*hwp.ImageCompression, error) {
+	compression := &hwp.ImageCompression{
+		MaxPixels: request.GetInt("max_pixels", 0),
+		MaxDPI:    request.GetInt("max_dpi", 0),
+		Quality:   request.GetInt("jpeg_quality", 0),
+	}
+	if compression.MaxPixels == 0 && compression.MaxDPI == 0 {
+		if compression.Quality != 0 {
+			return nil, fmt.Errorf("jpeg_quality needs max_pixels or max_dpi")
+		}
+		return nil, nil
+	}
+	if err := compression.Validate(); err != nil {
+		return nil, err
+	}
+	return compression, nil
+}
+
+// describeCompression describes the limits of a compression, e.g.
+// "1600 px or 150 dpi"
+func describeCompression(compression hwp.ImageCompression) string {
+	var limits []string
+	if compression.MaxPixels > 0 {
+		limits = append(limits, fmt.Sprintf("%d px", compression.MaxPixels))
+	}
+	if compression.MaxDPI > 0 {
+		limits = append(limits, fmt.Sprintf("%d dpi", compression.MaxDPI))
+	}
+	return strings.Join(limits, " or ")
+}
+
+// compressImagesArguments reads and checks the arguments of
+// hwp_compress_images, returning the source, the target and the limits. The
+// target defaults to the source name with _compressed added.
+func compressImagesArguments(request mcp.CallToolRequest) (string, string, hwp.ImageCompression, error) {
+	compression := hwp.ImageCompression{
+		MaxPixels: request.GetInt("max_pixels", defaultMaxImagePixels),
+		Quality:   request.GetInt("jpeg_quality", hwp.DefaultImageQuality),
+	}
+	src := request.GetString("src", "")
+	if src == "" {
+		return "", "", compression, fmt.Errorf("src is required")
+	}
+	dst := request.GetString("dst", "")
+	if dst == "" {
+		ext := filepath.Ext(src)
+		dst = strings.TrimSuffix(src, ext) + "_compressed" + ext
+	}
+	if err := compression.Validate(); err != nil {
+		return "", "", compression, err
+	}
+	if _, err := os.Stat(src); err != nil {
+		return "", "", compression, fmt.Errorf("source file not found: %s", src)
+	}
+	if _, err := os.Stat(dst); err == nil && !request.GetBool("overwrite", false) {
+		return "", "", compression, fmt.Errorf("%s already exists (pass overwrite=true to replace it)", dst)
+	}
+	return src, dst, compression, nil
+}
+
+// describePlacement describes a placement for the tool results, e.g.
+// "square wrap, center of page (offset 0mm), top of para (offset 20mm)"
+func describePlacement(placement hwp.ImagePlacement) string {
+	if strings.EqualFold(placement.Wrap, "inline") {
+		return "inline"
+	}
+	orDefault := func(value, fallback string) string {
+		if value == "" {
+			return fallback
+		}
+		return strings.ToLower(value)
+	}
+	return fmt.Sprintf("%s wrap, %s of %s (offset %gmm), %s of %s (offset %gmm)",
+		strings.ToLower(placement.Wrap),
+		orDefault(placement.HorzAlign, "left"), orDefault(placement.HorzRelTo, "column"), placement.HorzOffsetMM,
+		orDefault(placement.VertAlign, "top"), orDefault(placement.VertRelTo, "para"), placement.VertOffsetMM)
+}
+
+func HandleHwpListImages(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		pictures, err := controller.Pictures()
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		resultJSON, _ := json.Marshal(pictures)
+		result = hwp.CreateTextResult(string(resultJSON))
+	})
+
+	return result, nil
+}
+
+func HandleHwpSetImageDescription(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if _, ok := request.GetArguments()["index"]; !ok {
+		return hwp.CreateTextResult("Error: Index is required"), nil
+	}
+	index := request.GetInt("index", 0)
+	if _, ok := request.GetArguments()["description"]; !ok {
+		return hwp.CreateTextResult("Error: Description is required (\"\" removes it)"), nil
+	}
+	description := request.GetString("description", "")
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := controller.SetPictureDescription(index, description); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		if description == "" {
+			result = hwp.CreateTextResult(fmt.Sprintf("Removed the description of picture %d", index))
+			return
+		}
+		result = hwp.CreateTextResult(fmt.Sprintf("Description of picture %d set to %q", index, description))
+	})
+
+	return result, nil
+}
+
+func HandleHwpSetImageWrap(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if _, ok := request.GetArguments()["index"]; !ok {
+		return hwp.CreateTextResult("Error: Index is required"), nil
+	}
+	index := request.GetInt("index", 0)
+	placement, err := imagePlacementArgument(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	transform, err := imageTransformArgument(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	if placement == nil && transform == nil {
+		return hwp.CreateTextResult("Error: Specify a wrap, a position, a rotation or a crop"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := controller.UpdatePicture(index, placement, transform); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		var changes []string
+		if placement != nil {
+			changes = append(changes, "placed "+describePlacement(*placement))
+		}
+		if transform != nil {
+			changes = append(changes, describeTransform(*transform))
+		}
+		result = hwp.CreateTextResult(fmt.Sprintf("Picture %d %s", index, strings.Join(changes, "; ")))
+	})
+
+	return result, nil
+}
+
+func HandleHwpCompressImages(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	src, dst, compression, err := compressImagesArguments(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		report, err := hwp.CompressDocumentImages(src, dst, compression)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Compressed %d of %d images (max %s, JPEG quality %d) into %s: %s -> %s",
+			report.Compressed, report.Images, describeCompression(compression), compression.Quality, dst, formatBytes(report.BytesBefore), formatBytes(report.BytesAfter)))
+	})
+
+	return result, nil
+}
+
+// formatBytes formats a file size as KB or MB
+func formatBytes(size int64) string {
+	if size < 1<<20 {
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	}
+	return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+}
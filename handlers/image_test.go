@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"hwp-mcp-go/hwp"
+)
+
+// writeTestPackage writes an HWPX-like package with a stored mimetype, a
+// section and the given BinData images
+func writeTestPackage(t *testing.T, path string, images map[string][]byte) {
+	t.Helper()
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	mimetype, _ := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	mimetype.Write([]byte("application/hwp+zip"))
+	section, _ := archive.Create("Contents/section0.xml")
+	section.Write([]byte("<hs:sec/>"))
+	for name, data := range images {
+		entry, _ := archive.Create("BinData/" + name)
+		entry.Write(data)
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buffer.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testImage returns a noisy image, so that it doesn't compress to nothing
+func testImage(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{uint8(x*7 ^ y*13), uint8(x * y), uint8(x + y*3), 255})
+		}
+	}
+	return img
+}
+
+func TestCompressImagesDownscalesHwpxImages(t *testing.T) {
+	var photo, icon bytes.Buffer
+	jpeg.Encode(&photo, testImage(1200, 800), &jpeg.Options{Quality: 100})
+	png.Encode(&icon, testImage(32, 32))
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "report.hwpx")
+	writeTestPackage(t, src, map[string][]byte{"image1.jpg": photo.Bytes(), "image2.png": icon.Bytes()})
+
+	request := argumentRequest("src", src)
+	request.Params.Arguments.(map[string]interface{})["max_pixels"] = 300
+	src, dst, compression, err := compressImagesArguments(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dst != filepath.Join(dir, "report_compressed.hwpx") {
+		t.Errorf("default dst = %s", dst)
+	}
+	report, err := hwp.CompressDocumentImages(src, dst, compression)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Images != 2 || report.Compressed != 1 || report.BytesAfter >= report.BytesBefore {
+		t.Errorf("report = %+v, want the photo of 2 images compressed", report)
+	}
+
+	reader, err := zip.OpenReader(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	if reader.File[0].Name != "mimetype" || reader.File[0].Method != zip.Store {
+		t.Errorf("first entry = %s (method %d), want the stored mimetype", reader.File[0].Name, reader.File[0].Method)
+	}
+	for _, file := range reader.File {
+		if file.Name != "BinData/image1.jpg" {
+			continue
+		}
+		entry, _ := file.Open()
+		config, _, err := image.DecodeConfig(entry)
+		entry.Close()
+		if err != nil || config.Width != 300 || config.Height != 200 {
+			t.Errorf("compressed photo is %dx%d (%v), want 300x200", config.Width, config.Height, err)
+		}
+	}
+
+	if _, _, _, err := compressImagesArguments(argumentRequest("src", src)); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("compressing onto an existing dst: error = %v, want already exists", err)
+	}
+}
+
+func TestImagePlacementArgument(t *testing.T) {
+	placement, err := imagePlacementArgument(argumentRequest("wrap", "behind_text"))
+	if err != nil || placement == nil || placement.Wrap != "behind_text" {
+		t.Errorf("imagePlacementArgument(wrap) = %+v, %v", placement, err)
+	}
+	if placement, err := imagePlacementArgument(argumentRequest("alt_text", "로고")); placement != nil || err != nil {
+		t.Errorf("imagePlacementArgument() without placement = %+v, %v, want nil", placement, err)
+	}
+	if _, err := imagePlacementArgument(argumentRequest("horizontal_align", "center")); err == nil {
+		t.Error("positioning an inline picture succeeded, want an error")
+	}
+	if _, err := imagePlacementArgument(argumentRequest("wrap", "tight")); err == nil {
+		t.Error("an unknown wrap succeeded, want an error")
+	}
+}
@@ -0,0 +1,291 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"hwp-mcp-go/hwp"
+)
+
+// Invoices and estimates (견적서)
+//
+// The "invoice" document type writes a 견적서 or 청구서: the title, the
+// customer and date, a 공급자 table, the total in words and figures, and a
+// line-item table whose amounts, 공급가액, 부가세 and 합계 are computed from
+// the quantities and unit prices. Everything is plain text and tables, so
+// both backends can write it.
+
+// invoiceTextFields are the text fields of an invoice
+var invoiceTextFields = []string{"title", "number", "date", "customer", "valid_until", "notes"}
+
+// invoiceSupplierFields are the 공급자 fields in table order, with their labels
+var invoiceSupplierFields = []struct{ key, label string }{
+	{"registration_number", "등록번호"},
+	{"name", "상호"},
+	{"representative", "대표자"},
+	{"address", "주소"},
+	{"business_type", "업태"},
+	{"business_item", "종목"},
+	{"phone", "전화"},
+}
+
+// invoiceVATRate is the Korean value-added tax rate
+const invoiceVATRate = 0.1
+
+// maxInvoiceAmount keeps computed amounts exact in a float64 and in words
+const maxInvoiceAmount = 1e15
+
+// invoiceItem is a line of an invoice
+type invoiceItem struct {
+	Name      string
+	Spec      string // 규격
+	Quantity  float64
+	UnitPrice float64
+	Note      string
+}
+
+// Amount returns quantity × unit price rounded to whole won, so that the
+// figures and the total in words add up to the same amount
+func (i invoiceItem) Amount() float64 {
+	return math.Round(i.Quantity * i.UnitPrice)
+}
+
+// invoiceDocument is a validated invoice spec
+type invoiceDocument struct {
+	Text     map[string]string
+	Supplier map[string]string
+	Items    []invoiceItem
+	VAT      bool // add 10% 부가세 to the supply amount
+}
+
+// readInvoiceDocument reads the fields of an invoice spec
+func readInvoiceDocument(fields *fieldReader) invoiceDocument {
+	document := invoiceDocument{Text: map[string]string{}, Supplier: map[string]string{}, VAT: true}
+	for _, name := range invoiceTextFields {
+		document.Text[name] = fields.String(name)
+	}
+	if _, set := fields.value("vat"); set {
+		document.VAT = fields.Bool("vat")
+	}
+
+	if supplier := fields.Object("supplier"); supplier != nil {
+		supplierFields := &fieldReader{path: fields.path + ".supplier", object: supplier}
+		for _, field := range invoiceSupplierFields {
+			document.Supplier[field.key] = supplierFields.String(field.key)
+		}
+		if supplierFields.err != nil && fields.err == nil {
+			fields.err = supplierFields.err
+		}
+	}
+
+	value, ok := fields.value("items")
+	if !ok {
+		fields.fail("items", "is required")
+		return document
+	}
+	items, isArray := value.([]interface{})
+	if !isArray || len(items) == 0 {
+		fields.fail("items", "must be a non-empty array of {name, quantity, unit_price} objects")
+		return document
+	}
+	for i, item := range items {
+		object, isObject := item.(map[string]interface{})
+		if !isObject {
+			fields.fail(fmt.Sprintf("items[%d]", i), "must be an object")
+			continue
+		}
+		itemFields := &fieldReader{path: fmt.Sprintf("%s.items[%d]", fields.path, i), object: object}
+		line := invoiceItem{
+			Name: itemFields.RequiredString("name"),
+			Spec: itemFields.String("spec"),
+			Note: itemFields.String("note"),
+		}
+		line.Quantity = invoiceNumber(itemFields, "quantity")
+		line.UnitPrice = invoiceNumber(itemFields, "unit_price")
+		if math.Abs(line.Amount()) > maxInvoiceAmount {
+			itemFields.fail("unit_price", "amount is too large")
+		}
+		if itemFields.err != nil && fields.err == nil {
+			fields.err = itemFields.err
+		}
+		document.Items = append(document.Items, line)
+	}
+	// Items within bounds can still add up past them
+	if fields.err == nil {
+		if _, _, total := document.Totals(); total > maxInvoiceAmount {
+			fields.fail("items", "total %s is over the limit of %s", hwp.FormatNumber(total, 0), hwp.FormatNumber(maxInvoiceAmount, 0))
+		}
+	}
+	return document
+}
+
+// invoiceNumber reads a required, non-negative and bounded number field
+func invoiceNumber(fields *fieldReader, key string) float64 {
+	number, ok := fields.Float(key)
+	if !ok {
+		fields.fail(key, "is required")
+		return 0
+	}
+	if number < 0 || number > maxInvoiceAmount {
+		fields.fail(key, "must be between 0 and %s", hwp.FormatNumber(maxInvoiceAmount, 0))
+		return 0
+	}
+	return number
+}
+
+// Totals returns the supply amount, the VAT on it and their sum. VAT below
+// one won is cut off, as tax invoices do.
+func (d invoiceDocument) Totals() (supply, vat, total float64) {
+	for _, item := range d.Items {
+		supply += item.Amount()
+	}
+	if d.VAT {
+		vat = math.Floor(supply * invoiceVATRate)
+	}
+	return supply, vat, supply + vat
+}
+
+// invoiceItemRows returns the line-item table: a header, one row per item
+// and the 공급가액, 부가세 and 합계 rows
+func (d invoiceDocument) invoiceItemRows() [][]string {
+	rows := [][]string{{"번호", "품명", "규격", "수량", "단가", "금액", "비고"}}
+	for i, item := range d.Items {
+		rows = append(rows, []string{
+			fmt.Sprint(i + 1), item.Name, item.Spec,
+			hwp.FormatNumber(item.Quantity, -1), hwp.FormatNumber(item.UnitPrice, -1),
+			hwp.FormatNumber(item.Amount(), -1), item.Note,
+		})
+	}
+	supply, vat, total := d.Totals()
+	rows = append(rows, []string{"", "공급가액", "", "", "", hwp.FormatNumber(supply, -1), ""})
+	if d.VAT {
+		rows = append(rows, []string{"", "부가세 (10%)", "", "", "", hwp.FormatNumber(vat, -1), ""})
+	}
+	rows = append(rows, []string{"", "합계", "", "", "", hwp.FormatNumber(total, -1), ""})
+	return rows
+}
+
+// invoiceTotalLine returns the total in words and figures, such as
+// 합계금액: 금 일백일십만원정 (₩1,100,000, 부가세 포함)
+func (d invoiceDocument) invoiceTotalLine() string {
+	_, _, total := d.Totals()
+	note := "부가세 별도"
+	if d.VAT {
+		note = "부가세 포함"
+	}
+	return fmt.Sprintf("합계금액: %s (₩%s, %s)", hwp.KoreanAmount(int64(total)), hwp.FormatNumber(total, -1), note)
+}
+
+func createInvoiceDocument(controller *hwp.Controller, spec map[string]interface{}, styles stylePresetSet) error {
+	document := readInvoiceDocument(&fieldReader{path: "spec", object: spec})
+	text := document.Text
+
+	writeLines := func(lines ...string) error {
+		for _, line := range lines {
+			if err := controller.InsertText(line, false); err != nil {
+				return err
+			}
+			if err := controller.InsertParagraph(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	writeTable := func(rows [][]string, hasHeader bool) error {
+		if err := controller.InsertTable(len(rows), len(rows[0])); err != nil {
+			return err
+		}
+		if err := controller.FillTableWithData(rows, 1, 1, hasHeader); err != nil {
+			return err
+		}
+		return controller.InsertParagraph()
+	}
+
+	title := text["title"]
+	if title == "" {
+		title = "견 적 서"
+	}
+	if err := controller.SetParagraphAlignment("center"); err != nil {
+		return err
+	}
+	if err := styles.apply(controller, "제목1"); err != nil {
+		return err
+	}
+	if err := writeLines(title, ""); err != nil {
+		return err
+	}
+	if err := controller.SetParagraphAlignment("justify"); err != nil {
+		return err
+	}
+
+	// Customer, date and validity on the left; the supplier table below
+	if err := styles.apply(controller, "본문"); err != nil {
+		return err
+	}
+	var heading []string
+	if text["number"] != "" {
+		heading = append(heading, "번호: "+text["number"])
+	}
+	if text["date"] != "" {
+		heading = append(heading, "일자: "+text["date"])
+	}
+	if text["valid_until"] != "" {
+		heading = append(heading, "유효기간: "+text["valid_until"])
+	}
+	if text["customer"] != "" {
+		heading = append(heading, text["customer"]+" 귀하")
+	}
+	if err := writeLines(heading...); err != nil {
+		return err
+	}
+	intro := "아래와 같이 견적합니다."
+	if strings.Contains(title, "청구") {
+		intro = "아래와 같이 청구합니다."
+	}
+	if err := writeLines(intro, ""); err != nil {
+		return err
+	}
+
+	var supplierRows [][]string
+	for _, field := range invoiceSupplierFields {
+		if value := document.Supplier[field.key]; value != "" {
+			supplierRows = append(supplierRows, []string{field.label, value})
+		}
+	}
+	if len(supplierRows) > 0 {
+		if err := styles.apply(controller, "강조"); err != nil {
+			return err
+		}
+		if err := writeLines("공급자"); err != nil {
+			return err
+		}
+		if err := styles.apply(controller, "본문"); err != nil {
+			return err
+		}
+		if err := writeTable(supplierRows, false); err != nil {
+			return err
+		}
+	}
+
+	// Total in words, then the line items
+	if err := styles.apply(controller, "강조"); err != nil {
+		return err
+	}
+	if err := writeLines(document.invoiceTotalLine(), ""); err != nil {
+		return err
+	}
+	if err := styles.apply(controller, "본문"); err != nil {
+		return err
+	}
+	if err := writeTable(document.invoiceItemRows(), true); err != nil {
+		return err
+	}
+
+	if text["notes"] != "" {
+		if err := writeLines("", "비고: "+text["notes"]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
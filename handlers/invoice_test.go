@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"hwp-mcp-go/hwp"
+)
+
+func TestInvoiceTotalsRoundEachLine(t *testing.T) {
+	spec := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"name": "용지", "quantity": 2.5, "unit_price": 333.0},
+			map[string]interface{}{"name": "토너", "quantity": 1.5, "unit_price": 1001.0},
+		},
+	}
+	fields := &fieldReader{path: "spec", object: spec}
+	document := readInvoiceDocument(fields)
+	if fields.err != nil {
+		t.Fatalf("unexpected error: %v", fields.err)
+	}
+
+	supply, vat, total := document.Totals()
+	if supply != 833+1502 || vat != 233 || total != 2568 {
+		t.Errorf("Totals() = %v, %v, %v, want 2335, 233, 2568", supply, vat, total)
+	}
+	line := document.invoiceTotalLine()
+	if !strings.Contains(line, hwp.KoreanAmount(2568)) || !strings.Contains(line, "₩2,568,") {
+		t.Errorf("total line %q does not give 2568 won in both words and figures", line)
+	}
+}
+
+func TestInvoiceTotalIsBounded(t *testing.T) {
+	item := map[string]interface{}{"name": "설비", "quantity": 1.0, "unit_price": 6e14}
+	fields := &fieldReader{path: "spec", object: map[string]interface{}{"items": []interface{}{item, item}}}
+	readInvoiceDocument(fields)
+	if fields.err == nil || !strings.Contains(fields.err.Error(), "items") {
+		t.Errorf("error = %v, want the total of items refused", fields.err)
+	}
+}
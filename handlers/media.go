@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Media link tools
+//
+// HWP can't play video or audio, so hwp_insert_video_link writes a linked
+// placeholder that readers follow to the clip.
+
+// Tool names for media links
+const (
+	HWP_INSERT_VIDEO_LINK = "hwp_insert_video_link"
+)
+
+func HandleHwpInsertVideoLink(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	media := hwp.MediaLink{
+		URL:       request.GetString("url", ""),
+		Kind:      request.GetString("kind", "video"),
+		Caption:   request.GetString("caption", ""),
+		Duration:  request.GetString("duration", ""),
+		Thumbnail: request.GetString("thumbnail", ""),
+		WidthMM:   request.GetInt("width", 0),
+	}
+	if err := media.Validate(); err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := controller.InsertMediaLink(media); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		thumbnail := "without a thumbnail"
+		if media.Thumbnail != "" {
+			thumbnail = "with thumbnail " + media.Thumbnail
+		}
+		result = hwp.CreateTextResult(fmt.Sprintf("Inserted %q linked to %s, %s", media.Label(), media.URL, thumbnail))
+	})
+
+	return result, nil
+}
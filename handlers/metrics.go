@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Tool names for server introspection
+const (
+	HWP_METRICS = "hwp_metrics"
+)
+
+// metricsSamples is the number of recent durations kept per tool for percentiles
+const metricsSamples = 1000
+
+// toolMetrics holds the counters of one tool
+type toolMetrics struct {
+	calls   int
+	errors  int
+	total   time.Duration
+	max     time.Duration
+	samples []time.Duration // ring buffer of recent durations
+	next    int
+}
+
+// ToolStats summarizes the calls of one tool
+type ToolStats struct {
+	Tool      string  `json:"tool"`
+	Calls     int     `json:"calls"`
+	Errors    int     `json:"errors"`
+	ErrorRate float64 `json:"error_rate"`
+	AvgMs     float64 `json:"avg_ms"`
+	P50Ms     float64 `json:"p50_ms"`
+	P95Ms     float64 `json:"p95_ms"`
+	P99Ms     float64 `json:"p99_ms"`
+	MaxMs     float64 `json:"max_ms"`
+}
+
+// metricsRecorder collects tool call metrics
+type metricsRecorder struct {
+	mu       sync.Mutex
+	started  time.Time
+	inFlight int
+	tools    map[string]*toolMetrics
+}
+
+var metrics = &metricsRecorder{started: time.Now(), tools: map[string]*toolMetrics{}}
+
+// RecordMetrics is tool middleware that counts calls, errors and durations
+func RecordMetrics(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		metrics.mu.Lock()
+		metrics.inFlight++
+		metrics.mu.Unlock()
+
+		start := time.Now()
+		result, err := next(ctx, request)
+		metrics.record(request.Params.Name, time.Since(start), err != nil || isErrorResult(result))
+
+		return result, err
+	}
+}
+
+// isErrorResult reports whether a tool result is an error. Handlers return
+// errors as text starting with "Error".
+func isErrorResult(result *mcp.CallToolResult) bool {
+	if result == nil {
+		return true
+	}
+	if result.IsError {
+		return true
+	}
+	if len(result.Content) > 0 {
+		if text, ok := result.Content[0].(mcp.TextContent); ok {
+			return strings.HasPrefix(text.Text, "Error")
+		}
+	}
+	return false
+}
+
+// record adds a finished call
+func (m *metricsRecorder) record(tool string, duration time.Duration, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.inFlight--
+	t, ok := m.tools[tool]
+	if !ok {
+		t = &toolMetrics{}
+		m.tools[tool] = t
+	}
+	t.calls++
+	if failed {
+		t.errors++
+	}
+	t.total += duration
+	if duration > t.max {
+		t.max = duration
+	}
+	if len(t.samples) < metricsSamples {
+		t.samples = append(t.samples, duration)
+	} else {
+		t.samples[t.next] = duration
+		t.next = (t.next + 1) % metricsSamples
+	}
+}
+
+// stats returns per-tool statistics sorted by tool name and the number of
+// calls in progress
+func (m *metricsRecorder) stats() ([]ToolStats, int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := make([]ToolStats, 0, len(m.tools))
+	for name, t := range m.tools {
+		sorted := append([]time.Duration(nil), t.samples...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		stats = append(stats, ToolStats{
+			Tool:      name,
+			Calls:     t.calls,
+			Errors:    t.errors,
+			ErrorRate: float64(t.errors) / float64(t.calls),
+			AvgMs:     milliseconds(t.total / time.Duration(t.calls)),
+			P50Ms:     milliseconds(percentile(sorted, 0.50)),
+			P95Ms:     milliseconds(percentile(sorted, 0.95)),
+			P99Ms:     milliseconds(percentile(sorted, 0.99)),
+			MaxMs:     milliseconds(t.max),
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Tool < stats[j].Tool })
+	return stats, m.inFlight
+}
+
+// percentile returns the p-th percentile of sorted durations (nearest rank)
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// milliseconds converts a duration to fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+func HandleHwpMetrics(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	stats, inFlight := metrics.stats()
+
+	resultJSON, _ := json.Marshal(map[string]interface{}{
+		"uptime_seconds": int(time.Since(metrics.started).Seconds()),
+		"queue_depth":    hwp.QueueDepth(),
+		"in_flight":      inFlight,
+		"tools":          stats,
+	})
+	return hwp.CreateTextResult(string(resultJSON)), nil
+}
+
+// MetricsHandler serves the metrics in the Prometheus text format
+func MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stats, inFlight := metrics.stats()
+
+		var b strings.Builder
+		b.WriteString("# HELP hwp_mcp_queue_depth HWP operations waiting for the COM thread.\n")
+		b.WriteString("# TYPE hwp_mcp_queue_depth gauge\n")
+		fmt.Fprintf(&b, "hwp_mcp_queue_depth %d\n", hwp.QueueDepth())
+		b.WriteString("# HELP hwp_mcp_in_flight Tool calls in progress.\n")
+		b.WriteString("# TYPE hwp_mcp_in_flight gauge\n")
+		fmt.Fprintf(&b, "hwp_mcp_in_flight %d\n", inFlight)
+
+		b.WriteString("# HELP hwp_mcp_tool_calls_total Tool calls.\n")
+		b.WriteString("# TYPE hwp_mcp_tool_calls_total counter\n")
+		for _, s := range stats {
+			fmt.Fprintf(&b, "hwp_mcp_tool_calls_total{tool=%q} %d\n", s.Tool, s.Calls)
+		}
+		b.WriteString("# HELP hwp_mcp_tool_errors_total Tool calls that returned an error.\n")
+		b.WriteString("# TYPE hwp_mcp_tool_errors_total counter\n")
+		for _, s := range stats {
+			fmt.Fprintf(&b, "hwp_mcp_tool_errors_total{tool=%q} %d\n", s.Tool, s.Errors)
+		}
+		b.WriteString("# HELP hwp_mcp_tool_duration_seconds Tool call duration over recent calls.\n")
+		b.WriteString("# TYPE hwp_mcp_tool_duration_seconds summary\n")
+		for _, s := range stats {
+			for _, q := range []struct {
+				quantile string
+				ms       float64
+			}{{"0.5", s.P50Ms}, {"0.95", s.P95Ms}, {"0.99", s.P99Ms}} {
+				fmt.Fprintf(&b, "hwp_mcp_tool_duration_seconds{tool=%q,quantile=%q} %g\n", s.Tool, q.quantile, q.ms/1000)
+			}
+			fmt.Fprintf(&b, "hwp_mcp_tool_duration_seconds_sum{tool=%q} %g\n", s.Tool, s.AvgMs*float64(s.Calls)/1000)
+			fmt.Fprintf(&b, "hwp_mcp_tool_duration_seconds_count{tool=%q} %d\n", s.Tool, s.Calls)
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		w.Write([]byte(b.String()))
+	})
+}
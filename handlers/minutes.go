@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"hwp-mcp-go/hwp"
+)
+
+// Meeting minutes (회의록)
+//
+// The "minutes" document type writes the title, a table of the meeting
+// details (일시, 장소, 참석자, 작성자), the numbered agenda, a titled section
+// per discussion item, the decisions and a table of action items with their
+// owners and due dates. Like invoices it is plain text and tables.
+
+// minutesTextFields are the text fields of meeting minutes
+var minutesTextFields = []string{"title", "date", "location", "recorder"}
+
+// minutesSection is a discussion item of meeting minutes
+type minutesSection struct {
+	Title   string
+	Content string
+}
+
+// minutesAction is an action item: what is to be done, by whom and when
+type minutesAction struct {
+	Task  string
+	Owner string
+	Due   string
+}
+
+// minutesDocument is a validated meeting minutes spec
+type minutesDocument struct {
+	Text        map[string]string
+	Attendees   []string
+	Agenda      []string
+	Discussions []minutesSection
+	Decisions   []string
+	Actions     []minutesAction
+}
+
+// readMinutesDocument reads the fields of a meeting minutes spec
+func readMinutesDocument(fields *fieldReader) minutesDocument {
+	document := minutesDocument{Text: map[string]string{}}
+	for _, name := range minutesTextFields {
+		document.Text[name] = fields.String(name)
+	}
+	document.Attendees = readStringList(fields, "attendees")
+	document.Agenda = readStringList(fields, "agenda")
+	document.Decisions = readStringList(fields, "decisions")
+
+	readObjects(fields, "discussions", "{title, content}", func(item *fieldReader) {
+		document.Discussions = append(document.Discussions, minutesSection{
+			Title:   item.String("title"),
+			Content: item.String("content"),
+		})
+	})
+	readObjects(fields, "action_items", "{task, owner, due}", func(item *fieldReader) {
+		document.Actions = append(document.Actions, minutesAction{
+			Task:  item.RequiredString("task"),
+			Owner: item.String("owner"),
+			Due:   item.String("due"),
+		})
+	})
+	return document
+}
+
+// readObjects calls read with a reader for each object of the optional array
+// field key, reporting invalid items on fields. shape describes the objects
+// in errors, e.g. "{title, content}".
+func readObjects(fields *fieldReader, key, shape string, read func(item *fieldReader)) {
+	value, ok := fields.value(key)
+	if !ok {
+		return
+	}
+	items, isArray := value.([]interface{})
+	if !isArray {
+		fields.fail(key, "must be an array of %s objects", shape)
+		return
+	}
+	for i, item := range items {
+		object, isObject := item.(map[string]interface{})
+		if !isObject {
+			fields.fail(fmt.Sprintf("%s[%d]", key, i), "must be an object")
+			continue
+		}
+		itemFields := &fieldReader{path: fmt.Sprintf("%s.%s[%d]", fields.path, key, i), object: object}
+		read(itemFields)
+		if itemFields.err != nil && fields.err == nil {
+			fields.err = itemFields.err
+		}
+	}
+}
+
+// minutesInfoRows returns the label/value rows of the meeting details that
+// are set
+func (d minutesDocument) minutesInfoRows() [][]string {
+	rows := [][]string{
+		{"일시", d.Text["date"]},
+		{"장소", d.Text["location"]},
+		{"참석자", strings.Join(d.Attendees, ", ")},
+		{"작성자", d.Text["recorder"]},
+	}
+	kept := rows[:0]
+	for _, row := range rows {
+		if row[1] != "" {
+			kept = append(kept, row)
+		}
+	}
+	return kept
+}
+
+// minutesActionRows returns the action item table with its header
+func (d minutesDocument) minutesActionRows() [][]string {
+	rows := [][]string{{"번호", "내용", "담당자", "기한"}}
+	for i, action := range d.Actions {
+		rows = append(rows, []string{fmt.Sprint(i + 1), action.Task, action.Owner, action.Due})
+	}
+	return rows
+}
+
+func createMinutesDocument(controller *hwp.Controller, spec map[string]interface{}, styles stylePresetSet) error {
+	document := readMinutesDocument(&fieldReader{path: "spec", object: spec})
+
+	writeLines := func(lines ...string) error {
+		for _, line := range lines {
+			if err := controller.InsertText(line, false); err != nil {
+				return err
+			}
+			if err := controller.InsertParagraph(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	writeTable := func(rows [][]string, hasHeader bool) error {
+		if err := controller.InsertTable(len(rows), len(rows[0])); err != nil {
+			return err
+		}
+		if err := controller.FillTableWithData(rows, 1, 1, hasHeader); err != nil {
+			return err
+		}
+		return controller.InsertParagraph()
+	}
+	heading := func(title string) error {
+		if err := styles.apply(controller, "제목2"); err != nil {
+			return err
+		}
+		if err := writeLines(title); err != nil {
+			return err
+		}
+		return styles.apply(controller, "본문")
+	}
+	numbered := func(items []string) []string {
+		lines := make([]string, len(items))
+		for i, item := range items {
+			lines[i] = fmt.Sprintf("%d. %s", i+1, item)
+		}
+		return append(lines, "")
+	}
+
+	title := document.Text["title"]
+	if title == "" {
+		title = "회 의 록"
+	}
+	if err := controller.SetParagraphAlignment("center"); err != nil {
+		return err
+	}
+	if err := styles.apply(controller, "제목1"); err != nil {
+		return err
+	}
+	if err := writeLines(title, ""); err != nil {
+		return err
+	}
+	if err := controller.SetParagraphAlignment("justify"); err != nil {
+		return err
+	}
+	if err := styles.apply(controller, "본문"); err != nil {
+		return err
+	}
+
+	if rows := document.minutesInfoRows(); len(rows) > 0 {
+		if err := writeTable(rows, false); err != nil {
+			return err
+		}
+		if err := writeLines(""); err != nil {
+			return err
+		}
+	}
+
+	if len(document.Agenda) > 0 {
+		if err := heading("안건"); err != nil {
+			return err
+		}
+		if err := writeLines(numbered(document.Agenda)...); err != nil {
+			return err
+		}
+	}
+
+	if len(document.Discussions) > 0 {
+		if err := heading("논의 내용"); err != nil {
+			return err
+		}
+		for _, section := range document.Discussions {
+			if section.Title != "" {
+				if err := styles.apply(controller, "제목3"); err != nil {
+					return err
+				}
+				if err := writeLines(section.Title); err != nil {
+					return err
+				}
+				if err := styles.apply(controller, "본문"); err != nil {
+					return err
+				}
+			}
+			if err := controller.InsertText(section.Content, true); err != nil {
+				return err
+			}
+			if err := insertBlankLines(controller, 2); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(document.Decisions) > 0 {
+		if err := heading("결정 사항"); err != nil {
+			return err
+		}
+		if err := writeLines(numbered(document.Decisions)...); err != nil {
+			return err
+		}
+	}
+
+	if len(document.Actions) > 0 {
+		if err := heading("실행 항목"); err != nil {
+			return err
+		}
+		if err := writeTable(document.minutesActionRows(), true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Object tools
+//
+// These tools insert objects other than pictures and tables, such as
+// embedded Office files, and find drawing objects by the IDs hwp_list_objects
+// gives them, numbered from 0 in document order. Grouping and ungrouping
+// change the IDs, so list the objects again after either.
+
+// Tool names for objects
+const (
+	HWP_INSERT_OLE_OBJECT = "hwp_insert_ole_object"
+	HWP_LIST_OBJECTS      = "hwp_list_objects"
+	HWP_SELECT_OBJECT     = "hwp_select_object"
+	HWP_GROUP_OBJECTS     = "hwp_group_objects"
+	HWP_UNGROUP_OBJECT    = "hwp_ungroup_object"
+	HWP_ARRANGE_OBJECT    = "hwp_arrange_object"
+
+	HWP_INSERT_LINKED_TEXT_FRAMES = "hwp_insert_linked_text_frames"
+)
+
+func HandleHwpInsertOLEObject(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	path := request.GetString("path", "")
+	if path == "" {
+		return hwp.CreateTextResult("Error: Path is required"), nil
+	}
+	link := request.GetBool("link", false)
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := controller.InsertOLEObject(path, link); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		if link {
+			result = hwp.CreateTextResult(fmt.Sprintf("Inserted an object linked to %s", path))
+			return
+		}
+		result = hwp.CreateTextResult(fmt.Sprintf("Embedded %s as an editable object", path))
+	})
+
+	return result, nil
+}
+
+func HandleHwpListObjects(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		objects, err := controller.DrawingObjects()
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		resultJSON, _ := json.Marshal(objects)
+		result = hwp.CreateTextResult(string(resultJSON))
+	})
+
+	return result, nil
+}
+
+func HandleHwpSelectObject(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if _, ok := request.GetArguments()["id"]; !ok {
+		return hwp.CreateTextResult("Error: ID is required"), nil
+	}
+	id := request.GetInt("id", 0)
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		object, err := controller.SelectObject(id)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Selected object %d (%s, %gx%gmm, paragraph %d)", object.ID, object.Kind, object.WidthMM, object.HeightMM, object.Para))
+	})
+
+	return result, nil
+}
+
+func HandleHwpGroupObjects(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ids, ok, err := intArrayArgument(request, "ids")
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	if !ok {
+		return hwp.CreateTextResult("Error: IDs are required"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := controller.GroupObjects(ids); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Grouped %d objects; object IDs have changed, list them again", len(ids)))
+	})
+
+	return result, nil
+}
+
+func HandleHwpUngroupObject(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if _, ok := request.GetArguments()["id"]; !ok {
+		return hwp.CreateTextResult("Error: ID is required"), nil
+	}
+	id := request.GetInt("id", 0)
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := controller.UngroupObject(id); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Ungrouped object %d; object IDs have changed, list them again", id))
+	})
+
+	return result, nil
+}
+
+func HandleHwpArrangeObject(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if _, ok := request.GetArguments()["id"]; !ok {
+		return hwp.CreateTextResult("Error: ID is required"), nil
+	}
+	id := request.GetInt("id", 0)
+	order := request.GetString("order", "")
+	if order == "" {
+		return hwp.CreateTextResult("Error: Order is required"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := controller.ArrangeObject(id, order); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Moved object %d %s", id, orderDescriptions[order]))
+	})
+
+	return result, nil
+}
+
+// orderDescriptions describe each z-order move in results
+var orderDescriptions = map[string]string{
+	"front":    "to the front",
+	"back":     "to the back",
+	"forward":  "one step forward",
+	"backward": "one step backward",
+}
+
+// textFramesArgument reads the frames of a linked text frame chain, as
+// objects of x_mm, y_mm, width_mm and height_mm
+func textFramesArgument(request mcp.CallToolRequest) ([]hwp.TextFrame, error) {
+	objects, ok, err := objectArrayArgument(request, "frames")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("frames are required")
+	}
+	if len(objects) < 2 {
+		return nil, fmt.Errorf("frames: a chain takes at least 2 frames, got %d", len(objects))
+	}
+
+	frames := make([]hwp.TextFrame, len(objects))
+	for i, object := range objects {
+		fields := &fieldReader{path: fmt.Sprintf("frames[%d]", i), object: object}
+		numbers := make([]float64, 4)
+		for j, key := range []string{"x_mm", "y_mm", "width_mm", "height_mm"} {
+			if _, present := fields.value(key); !present {
+				fields.fail(key, "is required")
+				continue
+			}
+			numbers[j], _ = fields.Float(key)
+		}
+		if fields.err != nil {
+			return nil, fields.err
+		}
+		frames[i] = hwp.TextFrame{XMM: numbers[0], YMM: numbers[1], WidthMM: numbers[2], HeightMM: numbers[3]}
+		if err := frames[i].Validate(); err != nil {
+			return nil, fmt.Errorf("frames[%d]: %v", i, err)
+		}
+	}
+	return frames, nil
+}
+
+func HandleHwpInsertLinkedTextFrames(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	frames, err := textFramesArgument(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	text := request.GetString("text", "")
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		ids, err := controller.InsertLinkedTextFrames(frames, text)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		names := make([]string, len(ids))
+		for i, id := range ids {
+			names[i] = fmt.Sprint(id)
+		}
+		result = hwp.CreateTextResult(fmt.Sprintf("Inserted %d linked text frames (object IDs %s, in flow order)", len(ids), strings.Join(names, " → ")))
+	})
+
+	return result, nil
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTextFramesArgument(t *testing.T) {
+	frames, err := textFramesArgument(argumentRequest("frames", []interface{}{
+		map[string]interface{}{"x_mm": 15.0, "y_mm": 20.0, "width_mm": 85.0, "height_mm": 250.0},
+		map[string]interface{}{"x_mm": "110", "y_mm": 20.0, "width_mm": 85.0, "height_mm": 250.0},
+	}))
+	if err != nil || len(frames) != 2 || frames[1].XMM != 110 || frames[1].HeightMM != 250 {
+		t.Errorf("textFramesArgument = %+v, %v", frames, err)
+	}
+
+	for _, test := range []struct {
+		frames interface{}
+		want   string
+	}{
+		{[]interface{}{map[string]interface{}{"x_mm": 0.0, "y_mm": 0.0, "width_mm": 50.0, "height_mm": 50.0}}, "at least 2 frames"},
+		{[]interface{}{
+			map[string]interface{}{"x_mm": 0.0, "y_mm": 0.0, "width_mm": 50.0},
+			map[string]interface{}{"x_mm": 0.0, "y_mm": 60.0, "width_mm": 50.0, "height_mm": 50.0},
+		}, "frames[0].height_mm: is required"},
+		{[]interface{}{
+			map[string]interface{}{"x_mm": 0.0, "y_mm": 0.0, "width_mm": 50.0, "height_mm": 50.0},
+			map[string]interface{}{"x_mm": 0.0, "y_mm": 60.0, "width_mm": 2.0, "height_mm": 50.0},
+		}, "frames[1]: size 2x50mm is too small"},
+	} {
+		if _, err := textFramesArgument(argumentRequest("frames", test.frames)); err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("textFramesArgument(%v) error = %v, want %q", test.frames, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"hwp-mcp-go/hwp"
+)
+
+// Official documents (공문서)
+//
+// The "official" document type follows the 기안문 layout of the 행정업무의
+// 운영 및 혁신에 관한 규정: the agency name on top, then 수신, (경유) and 제목,
+// numbered body clauses closed with 끝, attachments (붙임), the sender name
+// with the seal position, and the 결문 lines with the document number and
+// contact details at the bottom.
+
+// officialTextFields are the text fields of an official document
+var officialTextFields = []string{
+	"organization", "receiver", "via", "title", "sender",
+	"document_number", "date", "received",
+	"postal_code", "address", "homepage", "phone", "fax", "email", "disclosure",
+}
+
+// officialClauseDepth is the number of clause levels the rules define
+const officialClauseDepth = 8
+
+// hangulSequence numbers the 가., 가), (가) clause levels
+var hangulSequence = []string{"가", "나", "다", "라", "마", "바", "사", "아", "자", "차", "카", "타", "파", "하"}
+
+// officialClauseMark returns the mark of the n-th clause (1-based) at level
+// (0-based): 1. 가. 1) 가) (1) (가) ① ㉮
+func officialClauseMark(level, n int) string {
+	hangul := func() string {
+		if n <= len(hangulSequence) {
+			return hangulSequence[n-1]
+		}
+		return fmt.Sprint(n)
+	}
+	switch level {
+	case 0:
+		return fmt.Sprintf("%d.", n)
+	case 1:
+		return hangul() + "."
+	case 2:
+		return fmt.Sprintf("%d)", n)
+	case 3:
+		return hangul() + ")"
+	case 4:
+		return fmt.Sprintf("(%d)", n)
+	case 5:
+		return "(" + hangul() + ")"
+	case 6:
+		if n <= 20 {
+			return string(rune('①' + n - 1))
+		}
+		return fmt.Sprintf("(%d)", n)
+	default:
+		if n <= len(hangulSequence) {
+			return string(rune('㉮' + n - 1))
+		}
+		return "(" + hangul() + ")"
+	}
+}
+
+// officialClause is a numbered body clause with its sub-clauses
+type officialClause struct {
+	Text  string
+	Items []officialClause
+}
+
+// readOfficialClauses reads clauses given as strings or {text, items}
+// objects, reporting invalid ones on fields under key
+func readOfficialClauses(fields *fieldReader, key string, value interface{}, level int) []officialClause {
+	items, ok := value.([]interface{})
+	if !ok {
+		fields.fail(key, "must be an array of strings or {text, items} objects")
+		return nil
+	}
+	if level >= officialClauseDepth {
+		fields.fail(key, "clauses nest at most %d levels", officialClauseDepth)
+		return nil
+	}
+
+	clauses := make([]officialClause, 0, len(items))
+	for i, item := range items {
+		itemKey := fmt.Sprintf("%s[%d]", key, i)
+		switch v := item.(type) {
+		case string:
+			clauses = append(clauses, officialClause{Text: v})
+		case map[string]interface{}:
+			clauseFields := &fieldReader{path: fields.path + "." + itemKey, object: v}
+			clause := officialClause{Text: clauseFields.String("text")}
+			if sub, ok := clauseFields.value("items"); ok {
+				clause.Items = readOfficialClauses(fields, itemKey+".items", sub, level+1)
+			}
+			if clauseFields.err != nil && fields.err == nil {
+				fields.err = clauseFields.err
+			}
+			clauses = append(clauses, clause)
+		default:
+			fields.fail(itemKey, "must be a string or a {text, items} object")
+		}
+	}
+	return clauses
+}
+
+// readStringList reads an optional array of strings
+func readStringList(fields *fieldReader, key string) []string {
+	value, ok := fields.value(key)
+	if !ok {
+		return nil
+	}
+	items, isArray := value.([]interface{})
+	if !isArray {
+		fields.fail(key, "must be an array of strings")
+		return nil
+	}
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		text, isString := item.(string)
+		if !isString {
+			fields.fail(key, "must be an array of strings")
+			return nil
+		}
+		list = append(list, text)
+	}
+	return list
+}
+
+// officialDocument is a validated official document spec
+type officialDocument struct {
+	Text        map[string]string
+	Clauses     []officialClause
+	Attachments []string
+	Approvals   []string // 기안자, 검토자 and 결재권자, e.g. "주무관 홍길동"
+	Stamp       bool
+}
+
+// readOfficialDocument reads the fields of an official document spec
+func readOfficialDocument(fields *fieldReader) officialDocument {
+	document := officialDocument{Text: map[string]string{}, Stamp: true}
+	for _, name := range officialTextFields {
+		document.Text[name] = fields.String(name)
+	}
+	if clauses, ok := fields.value("clauses"); ok {
+		document.Clauses = readOfficialClauses(fields, "clauses", clauses, 0)
+	}
+	document.Attachments = readStringList(fields, "attachments")
+	document.Approvals = readStringList(fields, "approvals")
+	if _, set := fields.value("stamp"); set {
+		document.Stamp = fields.Bool("stamp")
+	}
+	return document
+}
+
+// officialBodyLines returns the body clauses as lines, each level indented
+// two more spaces than its parent
+func officialBodyLines(clauses []officialClause, level int) []string {
+	var lines []string
+	for i, clause := range clauses {
+		indent := strings.Repeat("  ", level)
+		lines = append(lines, indent+officialClauseMark(level, i+1)+" "+clause.Text)
+		lines = append(lines, officialBodyLines(clause.Items, level+1)...)
+	}
+	return lines
+}
+
+// officialAttachmentLines returns the 붙임 lines: a single attachment on the
+// 붙임 line, several numbered under it
+func officialAttachmentLines(attachments []string) []string {
+	if len(attachments) == 1 {
+		return []string{"붙임  " + attachments[0]}
+	}
+	lines := make([]string, len(attachments))
+	for i, attachment := range attachments {
+		prefix := "      "
+		if i == 0 {
+			prefix = "붙임  "
+		}
+		lines[i] = fmt.Sprintf("%s%d. %s", prefix, i+1, attachment)
+	}
+	return lines
+}
+
+// joinNonEmpty joins the non-empty values with sep
+func joinNonEmpty(sep string, values ...string) string {
+	var kept []string
+	for _, value := range values {
+		if value != "" {
+			kept = append(kept, value)
+		}
+	}
+	return strings.Join(kept, sep)
+}
+
+func createOfficialDocument(controller *hwp.Controller, spec map[string]interface{}, styles stylePresetSet) error {
+	document := readOfficialDocument(&fieldReader{path: "spec", object: spec})
+	text := document.Text
+
+	writeLines := func(lines ...string) error {
+		for _, line := range lines {
+			if err := controller.InsertText(line, false); err != nil {
+				return err
+			}
+			if err := controller.InsertParagraph(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// 두문: agency name, receiver and routing
+	if text["organization"] != "" {
+		if err := controller.SetParagraphAlignment("center"); err != nil {
+			return err
+		}
+		if err := styles.apply(controller, "제목2"); err != nil {
+			return err
+		}
+		if err := writeLines(text["organization"], ""); err != nil {
+			return err
+		}
+		if err := controller.SetParagraphAlignment("justify"); err != nil {
+			return err
+		}
+	}
+	if err := styles.apply(controller, "본문"); err != nil {
+		return err
+	}
+	receiver := text["receiver"]
+	if receiver == "" {
+		receiver = "내부결재"
+	}
+	if err := writeLines("수신  " + receiver); err != nil {
+		return err
+	}
+	if text["via"] != "" {
+		if err := writeLines("(경유)  " + text["via"]); err != nil {
+			return err
+		}
+	}
+	if err := writeLines("제목  "+text["title"], ""); err != nil {
+		return err
+	}
+
+	// 본문: numbered clauses, then attachments; 끝 follows the last line
+	lines := officialBodyLines(document.Clauses, 0)
+	if len(document.Attachments) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, officialAttachmentLines(document.Attachments)...)
+	}
+	if len(lines) > 0 {
+		lines[len(lines)-1] += "  끝."
+	}
+	if err := writeLines(lines...); err != nil {
+		return err
+	}
+
+	// 발신명의 with the seal position after it
+	if text["sender"] != "" {
+		if err := insertBlankLines(controller, 2); err != nil {
+			return err
+		}
+		if err := controller.SetParagraphAlignment("center"); err != nil {
+			return err
+		}
+		if err := styles.apply(controller, "제목1"); err != nil {
+			return err
+		}
+		sender := text["sender"]
+		if document.Stamp {
+			sender += "  (직인)"
+		}
+		if err := writeLines(sender, ""); err != nil {
+			return err
+		}
+		if err := controller.SetParagraphAlignment("justify"); err != nil {
+			return err
+		}
+	}
+
+	// 결문: approvals, document number and contact details
+	if err := styles.apply(controller, "결문"); err != nil {
+		return err
+	}
+	enforcement := text["document_number"]
+	if text["date"] != "" {
+		enforcement = joinNonEmpty(" ", enforcement, "("+text["date"]+")")
+	}
+	footer := []string{
+		strings.Join(document.Approvals, "    "),
+		joinNonEmpty("    ", prefixed("시행  ", enforcement), prefixed("접수  ", text["received"])),
+		joinNonEmpty("  ", prefixed("우 ", text["postal_code"]), text["address"], prefixed("/ ", text["homepage"])),
+		joinNonEmpty("  ", prefixed("전화번호 ", text["phone"]), prefixed("팩스번호 ", text["fax"]), prefixed("/ ", text["email"]), prefixed("/ ", text["disclosure"])),
+	}
+	for _, line := range footer {
+		if line == "" {
+			continue
+		}
+		if err := writeLines(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prefixed returns label+value, or empty for an empty value
+func prefixed(label, value string) string {
+	if value == "" {
+		return ""
+	}
+	return label + value
+}
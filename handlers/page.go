@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Tool names for page layout
+const (
+	HWP_SET_PAGE_SETUP      = "hwp_set_page_setup"
+	HWP_INSERT_SECTION      = "hwp_insert_section"
+	HWP_SET_PAGE_BORDER     = "hwp_set_page_border"
+	HWP_SET_PAGE_BACKGROUND = "hwp_set_page_background"
+	HWP_SET_LINE_NUMBERING  = "hwp_set_line_numbering"
+)
+
+// Page layout tool handlers
+
+func HandleHwpSetPageSetup(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	setup := pageSetupFromRequest(request)
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		err := controller.SetPageSetup(setup)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult("Page setup applied to current section")
+	})
+
+	return result, nil
+}
+
+func HandleHwpInsertSection(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	setup := pageSetupFromRequest(request)
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		err := controller.InsertSection(setup)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		if setup.Orientation != "" {
+			result = hwp.CreateTextResult(fmt.Sprintf("New %s section inserted", setup.Orientation))
+		} else {
+			result = hwp.CreateTextResult("New section inserted")
+		}
+	})
+
+	return result, nil
+}
+
+func HandleHwpSetPageBorder(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	border := hwp.PageBorder{
+		Style:   request.GetString("style", "solid"),
+		WidthMM: request.GetFloat("width", 0.4),
+		Color:   request.GetString("color", "black"),
+		Edges:   request.GetStringSlice("edges", nil),
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		err := controller.SetPageBorder(border)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		edges := "all edges"
+		if len(border.Edges) > 0 {
+			edges = strings.Join(border.Edges, ", ")
+		}
+		result = hwp.CreateTextResult(fmt.Sprintf("Page border set (%s %gmm %s, %s)", border.Style, border.WidthMM, border.Color, edges))
+	})
+
+	return result, nil
+}
+
+func HandleHwpSetPageBackground(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	color := request.GetString("color", "")
+	imagePath := request.GetString("image_path", "")
+	if color == "" && imagePath == "" {
+		return hwp.CreateTextResult("Error: Either color or image_path is required"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		err := controller.SetPageBackground(color, imagePath)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		if imagePath != "" {
+			result = hwp.CreateTextResult(fmt.Sprintf("Page background set to image: %s", imagePath))
+		} else {
+			result = hwp.CreateTextResult(fmt.Sprintf("Page background set to color: %s", color))
+		}
+	})
+
+	return result, nil
+}
+
+func HandleHwpSetLineNumbering(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	numbering := hwp.LineNumbering{
+		Enabled:     request.GetBool("enabled", true),
+		Interval:    request.GetInt("interval", 1),
+		StartNumber: request.GetInt("start", 1),
+		DistanceMM:  request.GetFloat("distance", 5),
+		Restart:     request.GetString("restart", "page"),
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		err := controller.SetLineNumbering(numbering)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		if !numbering.Enabled {
+			result = hwp.CreateTextResult("Line numbering turned off for current section")
+			return
+		}
+		result = hwp.CreateTextResult(fmt.Sprintf("Line numbering enabled (every %d lines from %d, restart per %s)",
+			numbering.Interval, numbering.StartNumber, numbering.Restart))
+	})
+
+	return result, nil
+}
+
+// pageSetupFromRequest reads the shared page setup arguments
+func pageSetupFromRequest(request mcp.CallToolRequest) hwp.PageSetup {
+	return hwp.PageSetup{
+		Orientation:  request.GetString("orientation", ""),
+		LeftMargin:   optionalFloat(request, "margin_left"),
+		RightMargin:  optionalFloat(request, "margin_right"),
+		TopMargin:    optionalFloat(request, "margin_top"),
+		BottomMargin: optionalFloat(request, "margin_bottom"),
+		HeaderMargin: optionalFloat(request, "margin_header"),
+		FooterMargin: optionalFloat(request, "margin_footer"),
+		GutterMargin: optionalFloat(request, "margin_gutter"),
+		Binding:      request.GetString("binding", ""),
+	}
+}
+
+// optionalFloat returns a pointer to a numeric argument, or nil when it is absent
+func optionalFloat(request mcp.CallToolRequest, key string) *float64 {
+	if _, ok := request.GetArguments()[key]; !ok {
+		return nil
+	}
+	value := request.GetFloat(key, 0)
+	return &value
+}
+
+// optionalBool returns a pointer to a boolean argument, or nil when it is absent
+func optionalBool(request mcp.CallToolRequest, key string) *bool {
+	if _, ok := request.GetArguments()[key]; !ok {
+		return nil
+	}
+	value := request.GetBool(key, false)
+	return &value
+}
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Tool names for style presets
+const (
+	HWP_APPLY_PRESET = "hwp_apply_preset"
+)
+
+// StylePreset is a named bundle of character and paragraph formatting. Nil
+// spacing fields leave the paragraph spacing unchanged; presets with spacing
+// need the COM backend.
+type StylePreset struct {
+	Font        string   `json:"font"`
+	Size        int      `json:"size"`
+	Bold        bool     `json:"bold,omitempty"`
+	Italic      bool     `json:"italic,omitempty"`
+	Underline   bool     `json:"underline,omitempty"`
+	Color       string   `json:"color,omitempty"` // color name or #RRGGBB; empty keeps the color
+	LineSpacing *int     `json:"line_spacing,omitempty"`
+	Before      *float64 `json:"before,omitempty"` // pt
+	After       *float64 `json:"after,omitempty"`  // pt
+}
+
+// stylePresetSet maps preset names to presets. A set in use is never
+// changed; themes and presets files build a new one.
+type stylePresetSet map[string]StylePreset
+
+// defaultStylePresets are the presets the built-in document types use; a
+// presets file overrides them by name
+var defaultStylePresets = stylePresetSet{
+	"표지제목":  {Font: "맑은 고딕", Size: 26, Bold: true},
+	"표지부제목": {Font: "맑은 고딕", Size: 16},
+	"표지정보":  {Font: "맑은 고딕", Size: 14},
+	"제목1":   {Font: "맑은 고딕", Size: 18, Bold: true},
+	"제목2":   {Font: "맑은 고딕", Size: 16, Bold: true},
+	"제목3":   {Font: "맑은 고딕", Size: 14, Bold: true},
+	"강조":    {Font: "맑은 고딕", Size: 12, Bold: true},
+	"본문":    {Font: "맑은 고딕", Size: 11},
+	"머리말기관": {Font: "맑은 고딕", Size: 14, Bold: true},
+	"머리말주소": {Font: "맑은 고딕", Size: 9, Color: "#595959"},
+	"결문":    {Font: "맑은 고딕", Size: 10},
+}
+
+// stylePresets are the presets in use
+var stylePresets = struct {
+	sync.RWMutex
+	presets stylePresetSet
+}{presets: defaultStylePresets}
+
+// SetPresetsFile loads style presets from a JSON object of name to preset,
+// e.g. {"본문": {"font": "나눔고딕", "size": 10}}. Its presets replace the
+// defaults of the same name and add new ones. An empty path keeps the defaults.
+func SetPresetsFile(path string) error {
+	presets := defaultStylePresets.clone()
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read presets file: %v", err)
+		}
+		var loaded map[string]StylePreset
+		if err := json.Unmarshal(data, &loaded); err != nil {
+			return fmt.Errorf("invalid presets file %s: %v", path, err)
+		}
+		for name, preset := range loaded {
+			if err := preset.validate(); err != nil {
+				return fmt.Errorf("invalid preset %q in %s: %v", name, path, err)
+			}
+			presets[name] = preset
+		}
+	}
+
+	stylePresets.Lock()
+	stylePresets.presets = presets
+	stylePresets.Unlock()
+	return nil
+}
+
+// validate checks the values of a preset from a presets file
+func (p StylePreset) validate() error {
+	if strings.TrimSpace(p.Font) == "" {
+		return fmt.Errorf("font is required")
+	}
+	if p.Size < 1 {
+		return fmt.Errorf("size must be at least 1")
+	}
+	if p.Color != "" {
+		if _, err := hwp.ParseColor(p.Color); err != nil {
+			return err
+		}
+	}
+	if p.LineSpacing != nil && *p.LineSpacing < 1 {
+		return fmt.Errorf("line_spacing must be a positive percentage")
+	}
+	if (p.Before != nil && *p.Before < 0) || (p.After != nil && *p.After < 0) {
+		return fmt.Errorf("before and after must not be negative")
+	}
+	return nil
+}
+
+// currentPresets returns the presets in use
+func currentPresets() stylePresetSet {
+	stylePresets.RLock()
+	defer stylePresets.RUnlock()
+	return stylePresets.presets
+}
+
+// clone returns a copy of s that can be changed
+func (s stylePresetSet) clone() stylePresetSet {
+	copied := make(stylePresetSet, len(s))
+	for name, preset := range s {
+		copied[name] = preset
+	}
+	return copied
+}
+
+// names returns the preset names, sorted
+func (s stylePresetSet) names() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookup returns the preset called name
+func (s stylePresetSet) lookup(name string) (StylePreset, error) {
+	preset, ok := s[name]
+	if !ok {
+		return StylePreset{}, fmt.Errorf("unknown preset %q (use %s)", name, strings.Join(s.names(), ", "))
+	}
+	return preset, nil
+}
+
+// apply sets the character style, and the paragraph spacing if the preset
+// has any, of the preset called name for following text
+func (s stylePresetSet) apply(controller *hwp.Controller, name string) error {
+	preset, err := s.lookup(name)
+	if err != nil {
+		return err
+	}
+
+	if preset.Color != "" {
+		err = controller.SetFontStyle(preset.Font, preset.Size, preset.Bold, preset.Italic, preset.Underline, preset.Color)
+	} else {
+		err = controller.SetFontStyle(preset.Font, preset.Size, preset.Bold, preset.Italic, preset.Underline)
+	}
+	if err != nil {
+		return err
+	}
+	if preset.LineSpacing == nil && preset.Before == nil && preset.After == nil {
+		return nil
+	}
+	return controller.SetParagraphSpacing(hwp.ParagraphSpacing{
+		LineSpacingPercent: preset.LineSpacing,
+		BeforePt:           preset.Before,
+		AfterPt:            preset.After,
+	})
+}
+
+func HandleHwpApplyPreset(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name := strings.TrimSpace(request.GetString("name", ""))
+	presets := currentPresets()
+	if name == "" {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: Name is required (use %s)", strings.Join(presets.names(), ", "))), nil
+	}
+	preset, err := presets.lookup(name)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := presets.apply(controller, name); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		description := fmt.Sprintf("%s %dpt", preset.Font, preset.Size)
+		if preset.Bold {
+			description += " bold"
+		}
+		result = hwp.CreateTextResult(fmt.Sprintf("Preset %s applied (%s)", name, description))
+	})
+
+	return result, nil
+}
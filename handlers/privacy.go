@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Personal data tools
+//
+// hwp_scan_pii reports where personal data (resident registration numbers,
+// phone numbers, email addresses and custom patterns) appears, for review;
+// hwp_redact blacks it out or masks it so a document can be shared. Both take
+// the same patterns, which are in hwp/pii.go.
+
+// Tool names for personal data
+const (
+	HWP_SCAN_PII = "hwp_scan_pii"
+	HWP_REDACT   = "hwp_redact"
+)
+
+// piiPatternsArgument reads the patterns argument (default: every built-in
+// pattern) and the custom_patterns object of name → regular expression
+func piiPatternsArgument(request mcp.CallToolRequest) ([]hwp.PIIPattern, error) {
+	names := request.GetStringSlice("patterns", hwp.PIIPatternNames())
+	custom, _, err := objectArgument(request, "custom_patterns")
+	if err != nil {
+		return nil, err
+	}
+
+	var patterns []hwp.PIIPattern
+	for _, name := range names {
+		pattern, ok := hwp.LookupPIIPattern(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown pattern %q (use %s)", name, strings.Join(hwp.PIIPatternNames(), ", "))
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	// Custom patterns in name order, so the counts come out the same each call
+	customNames := make([]string, 0, len(custom))
+	for name := range custom {
+		customNames = append(customNames, name)
+	}
+	sort.Strings(customNames)
+	for _, name := range customNames {
+		expression, isString := custom[name].(string)
+		if !isString || expression == "" {
+			return nil, fmt.Errorf("custom_patterns.%s: must be a regular expression", name)
+		}
+		if _, builtIn := hwp.LookupPIIPattern(name); builtIn {
+			return nil, fmt.Errorf("custom_patterns.%s: the name is taken by a built-in pattern", name)
+		}
+		pattern, err := hwp.NewPIIPattern(name, expression)
+		if err != nil {
+			return nil, fmt.Errorf("custom_patterns.%s: %v", name, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("no patterns given; leave out patterns for the built-in ones or add custom_patterns")
+	}
+	return patterns, nil
+}
+
+// redactModeArgument reads the mode argument (default: box)
+func redactModeArgument(request mcp.CallToolRequest) (string, error) {
+	mode := request.GetString("mode", hwp.RedactBox)
+	for _, known := range hwp.RedactModes {
+		if mode == known {
+			return mode, nil
+		}
+	}
+	return "", fmt.Errorf("unknown mode %q (use %s)", mode, strings.Join(hwp.RedactModes, " or "))
+}
+
+// redactionReport is the result of hwp_redact: the number of matches by
+// pattern, every requested pattern included
+type redactionReport struct {
+	Counts map[string]int `json:"counts"`
+	Total  int            `json:"total"`
+}
+
+// countRedactions sums the redactions by pattern
+func countRedactions(patterns []hwp.PIIPattern, redactions []hwp.Redaction) redactionReport {
+	report := redactionReport{Counts: map[string]int{}}
+	for _, pattern := range patterns {
+		report.Counts[pattern.Name] = 0
+	}
+	for _, redaction := range redactions {
+		report.Counts[redaction.Pattern] += redaction.Count
+		report.Total += redaction.Count
+	}
+	return report
+}
+
+// piiScanReport is the result of hwp_scan_pii: the number of matches by
+// pattern, every requested pattern included, and the matches
+type piiScanReport struct {
+	Counts  map[string]int `json:"counts"`
+	Total   int            `json:"total"`
+	Matches []hwp.PIIMatch `json:"matches"`
+}
+
+func HandleHwpScanPII(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	patterns, err := piiPatternsArgument(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		text, err := controller.GetText()
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		report := piiScanReport{Counts: map[string]int{}, Matches: hwp.ScanPII(text, patterns)}
+		for _, pattern := range patterns {
+			report.Counts[pattern.Name] = 0
+		}
+		for _, match := range report.Matches {
+			report.Counts[match.Type]++
+		}
+		report.Total = len(report.Matches)
+		if report.Matches == nil {
+			report.Matches = []hwp.PIIMatch{}
+		}
+
+		resultJSON, _ := json.Marshal(report)
+		result = hwp.CreateTextResult(string(resultJSON))
+	})
+
+	return result, nil
+}
+
+func HandleHwpRedact(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	patterns, err := piiPatternsArgument(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	mode, err := redactModeArgument(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		redactions, err := controller.Redact(patterns, mode)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		resultJSON, _ := json.Marshal(countRedactions(patterns, redactions))
+		result = hwp.CreateTextResult(string(resultJSON))
+	})
+
+	return result, nil
+}
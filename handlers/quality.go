@@ -0,0 +1,350 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Document quality checks
+//
+// hwp_validate_document checks the open document against a rule set so a
+// pipeline can hold back a generated document before it goes out. Every
+// failed rule is reported; rules the backend cannot check are listed as
+// skipped rather than failed. hwp_find_duplicates reports paragraphs that
+// repeat earlier ones, as generated text sometimes does.
+
+// Tool names for document checks
+const (
+	HWP_VALIDATE_DOCUMENT = "hwp_validate_document"
+	HWP_FIND_DUPLICATES   = "hwp_find_duplicates"
+)
+
+// Defaults of hwp_find_duplicates
+const (
+	defaultDuplicateThreshold = 0.9
+	defaultDuplicateMinLength = 10
+)
+
+// documentRules is a validated rule set of hwp_validate_document
+type documentRules struct {
+	RequiredSections []string
+	Placeholders     bool // fail on {{placeholders}} left in the text
+	MinPages         int
+	MaxPages         int
+	AllowedFonts     []string
+}
+
+// readDocumentRules reads the rules argument
+func readDocumentRules(fields *fieldReader) documentRules {
+	rules := documentRules{Placeholders: true}
+	rules.RequiredSections = readStringList(fields, "required_sections")
+	if _, set := fields.value("no_placeholders"); set {
+		rules.Placeholders = fields.Bool("no_placeholders")
+	}
+	rules.MinPages = fields.Int("min_pages")
+	rules.MaxPages = fields.Int("max_pages")
+	if rules.MinPages < 0 || rules.MaxPages < 0 {
+		fields.fail("min_pages", "page limits must not be negative")
+	}
+	if rules.MaxPages > 0 && rules.MinPages > rules.MaxPages {
+		fields.fail("max_pages", "must not be less than min_pages")
+	}
+	rules.AllowedFonts = readStringList(fields, "allowed_fonts")
+	return rules
+}
+
+// documentViolation is one failed rule
+type documentViolation struct {
+	Rule    string   `json:"rule"`
+	Message string   `json:"message"`
+	Items   []string `json:"items,omitempty"`
+}
+
+// headingNumbering matches the numbering and bullets in front of a heading,
+// such as 1. Ⅱ. 가. (1) □
+var headingNumbering = regexp.MustCompile(`^\s*(?:[0-9]+[.)]|[IVXⅠ-Ⅻ]+\.|[가-힣][.)]|\([0-9가-힣]+\)|[□■○●◦·※\-])\s*`)
+
+// headingText returns line without its leading numbering and bullets
+func headingText(line string) string {
+	for {
+		stripped := headingNumbering.ReplaceAllString(line, "")
+		if stripped == line {
+			return strings.TrimSpace(line)
+		}
+		line = stripped
+	}
+}
+
+// missingSections returns the required sections that no line of text names
+// as its heading
+func missingSections(text string, sections []string) []string {
+	headings := map[string]bool{}
+	for _, line := range strings.Split(text, "\n") {
+		headings[headingText(line)] = true
+	}
+	var missing []string
+	for _, section := range sections {
+		if !headings[headingText(section)] {
+			missing = append(missing, section)
+		}
+	}
+	return missing
+}
+
+// leftoverPlaceholders returns the distinct {{placeholders}} in text
+func leftoverPlaceholders(text string) []string {
+	var found []string
+	seen := map[string]bool{}
+	for _, match := range templateVariable.FindAllString(text, -1) {
+		if !seen[match] {
+			seen[match] = true
+			found = append(found, match)
+		}
+	}
+	return found
+}
+
+// disallowedFonts returns the fonts not in allowed, compared without case
+func disallowedFonts(fonts, allowed []string) []string {
+	permitted := map[string]bool{}
+	for _, font := range allowed {
+		permitted[strings.ToLower(strings.TrimSpace(font))] = true
+	}
+	var disallowed []string
+	for _, font := range fonts {
+		if !permitted[strings.ToLower(font)] {
+			disallowed = append(disallowed, font)
+		}
+	}
+	return disallowed
+}
+
+// validateDocument checks the open document against rules and returns the
+// violations and the rules it had to skip
+func validateDocument(controller *hwp.Controller, rules documentRules) ([]documentViolation, []string, error) {
+	violations := []documentViolation{}
+	var skipped []string
+
+	if len(rules.RequiredSections) > 0 || rules.Placeholders {
+		text, err := controller.GetText()
+		if err != nil {
+			return nil, nil, err
+		}
+		if missing := missingSections(text, rules.RequiredSections); len(missing) > 0 {
+			violations = append(violations, documentViolation{
+				Rule:    "required_sections",
+				Message: fmt.Sprintf("missing %d of %d required sections", len(missing), len(rules.RequiredSections)),
+				Items:   missing,
+			})
+		}
+		if rules.Placeholders {
+			if left := leftoverPlaceholders(text); len(left) > 0 {
+				violations = append(violations, documentViolation{
+					Rule:    "no_placeholders",
+					Message: "placeholders were left unfilled",
+					Items:   left,
+				})
+			}
+		}
+	}
+
+	if rules.MinPages > 0 || rules.MaxPages > 0 {
+		pages, err := controller.PageCount()
+		switch {
+		case errors.Is(err, hwp.ErrCOMRequired):
+			skipped = append(skipped, "min_pages/max_pages: counting pages needs the com backend")
+		case err != nil:
+			return nil, nil, err
+		case pages < rules.MinPages:
+			violations = append(violations, documentViolation{
+				Rule:    "min_pages",
+				Message: fmt.Sprintf("the document has %d pages, fewer than %d", pages, rules.MinPages),
+			})
+		case rules.MaxPages > 0 && pages > rules.MaxPages:
+			violations = append(violations, documentViolation{
+				Rule:    "max_pages",
+				Message: fmt.Sprintf("the document has %d pages, more than %d", pages, rules.MaxPages),
+			})
+		}
+	}
+
+	if len(rules.AllowedFonts) > 0 {
+		fonts, err := controller.UsedFonts()
+		if err != nil {
+			return nil, nil, err
+		}
+		if disallowed := disallowedFonts(fonts, rules.AllowedFonts); len(disallowed) > 0 {
+			violations = append(violations, documentViolation{
+				Rule:    "allowed_fonts",
+				Message: "fonts outside allowed_fonts are used",
+				Items:   disallowed,
+			})
+		}
+	}
+	return violations, skipped, nil
+}
+
+func HandleHwpValidateDocument(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	object, _, err := objectArgument(request, "rules")
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	fields := &fieldReader{path: "rules", object: object}
+	rules := readDocumentRules(fields)
+	if fields.err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", fields.err)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		violations, skipped, err := validateDocument(controller, rules)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		report := map[string]interface{}{
+			"valid":      len(violations) == 0,
+			"violations": violations,
+		}
+		if len(skipped) > 0 {
+			report["skipped"] = skipped
+		}
+		resultJSON, _ := json.Marshal(report)
+		result = hwp.CreateTextResult(string(resultJSON))
+	})
+
+	return result, nil
+}
+
+// duplicateParagraph is a paragraph that repeats an earlier one
+type duplicateParagraph struct {
+	Index       int     `json:"index"`
+	DuplicateOf int     `json:"duplicate_of"`
+	Similarity  float64 `json:"similarity"`
+	Text        string  `json:"text"`
+}
+
+// bigrams counts the pairs of adjacent characters of text, ignoring case and
+// runs of spaces
+func bigrams(text string) (map[string]int, int) {
+	runes := []rune(strings.ToLower(strings.Join(strings.Fields(text), " ")))
+	counts := map[string]int{}
+	for i := 0; i+1 < len(runes); i++ {
+		counts[string(runes[i:i+2])]++
+	}
+	return counts, len(runes) - 1
+}
+
+// similarity is the Dice coefficient of two bigram counts: 1 for the same
+// text, 0 for texts with no pair of characters in common
+func similarity(a map[string]int, aTotal int, b map[string]int, bTotal int) float64 {
+	if aTotal <= 0 || bTotal <= 0 {
+		return 0
+	}
+	shared := 0
+	for pair, count := range a {
+		if other := b[pair]; other < count {
+			shared += other
+		} else {
+			shared += count
+		}
+	}
+	return 2 * float64(shared) / float64(aTotal+bTotal)
+}
+
+// findDuplicates returns the paragraphs at least threshold similar to an
+// earlier paragraph, each matched to the most similar earlier paragraph that
+// is not itself a duplicate. Paragraphs shorter than minLength characters,
+// such as blank lines and short headings, are ignored.
+func findDuplicates(paragraphs []hwp.Paragraph, threshold float64, minLength int) []duplicateParagraph {
+	type original struct {
+		index  int
+		counts map[string]int
+		total  int
+	}
+	var originals []original
+	duplicates := []duplicateParagraph{}
+
+	for _, paragraph := range paragraphs {
+		if utf8.RuneCountInString(strings.TrimSpace(paragraph.Text)) < minLength {
+			continue
+		}
+		counts, total := bigrams(paragraph.Text)
+
+		best, bestScore := -1, 0.0
+		for _, earlier := range originals {
+			// The score can't reach the threshold when the lengths differ too much
+			if 2*math.Min(float64(total), float64(earlier.total))/float64(total+earlier.total) < threshold {
+				continue
+			}
+			if score := similarity(counts, total, earlier.counts, earlier.total); score >= threshold && score > bestScore {
+				best, bestScore = earlier.index, score
+			}
+		}
+		if best < 0 {
+			originals = append(originals, original{paragraph.Index, counts, total})
+			continue
+		}
+		duplicates = append(duplicates, duplicateParagraph{
+			Index:       paragraph.Index,
+			DuplicateOf: best,
+			Similarity:  math.Round(bestScore*100) / 100,
+			Text:        paragraph.Text,
+		})
+	}
+	return duplicates
+}
+
+func HandleHwpFindDuplicates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	threshold := request.GetFloat("threshold", defaultDuplicateThreshold)
+	if threshold <= 0 || threshold > 1 {
+		return hwp.CreateTextResult("Error: Threshold must be greater than 0 and at most 1"), nil
+	}
+	minLength := request.GetInt("min_length", defaultDuplicateMinLength)
+	if minLength < 2 {
+		return hwp.CreateTextResult("Error: min_length must be at least 2"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		paragraphs, total, err := controller.Paragraphs(0, 0)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		resultJSON, _ := json.Marshal(map[string]interface{}{
+			"paragraphs": total,
+			"threshold":  threshold,
+			"duplicates": findDuplicates(paragraphs, threshold, minLength),
+		})
+		result = hwp.CreateTextResult(string(resultJSON))
+	})
+
+	return result, nil
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"testing"
+
+	"hwp-mcp-go/hwp"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	paragraphs := []hwp.Paragraph{
+		{Index: 0, Text: "1. 개요"},
+		{Index: 1, Text: "올해 매출은 전년 대비 12% 증가하였다."},
+		{Index: 2, Text: ""},
+		{Index: 3, Text: "신규 고객은 주로 수도권에서 유입되었다."},
+		{Index: 4, Text: "올해  매출은 전년 대비 12% 증가하였다."},
+		{Index: 5, Text: "올해 매출은 전년 대비 12% 증가했다."},
+		{Index: 6, Text: "1. 개요"},
+	}
+
+	duplicates := findDuplicates(paragraphs, 0.8, defaultDuplicateMinLength)
+	if len(duplicates) != 2 {
+		t.Fatalf("findDuplicates() = %+v, want paragraphs 4 and 5", duplicates)
+	}
+	if got := duplicates[0]; got.Index != 4 || got.DuplicateOf != 1 || got.Similarity != 1 {
+		t.Errorf("duplicates[0] = %+v, want an exact repeat of paragraph 1", got)
+	}
+	if got := duplicates[1]; got.Index != 5 || got.DuplicateOf != 1 || got.Similarity >= 1 {
+		t.Errorf("duplicates[1] = %+v, want a near repeat of paragraph 1", got)
+	}
+
+	if exact := findDuplicates(paragraphs, 1, defaultDuplicateMinLength); len(exact) != 1 || exact[0].Index != 4 {
+		t.Errorf("findDuplicates() with threshold 1 = %+v, want paragraph 4 only", exact)
+	}
+}
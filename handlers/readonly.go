@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// readOnlySafeTools are the tools allowed while a document is open read-only:
+// they only read the document, move the cursor, replace it with a new one,
+// or work on other files
+var readOnlySafeTools = map[string]bool{
+	HWP_CREATE:                   true,
+	HWP_OPEN:                     true,
+	HWP_CLOSE:                    true,
+	HWP_REVERT:                   true,
+	HWP_GET_TEXT:                 true,
+	HWP_GET_PARAGRAPHS:           true,
+	HWP_DIAGNOSTICS:              true,
+	HWP_METRICS:                  true,
+	HWP_LIST_RECENT:              true,
+	HWP_LIST_FILES:               true,
+	HWP_CONVERT_FILE:             true,
+	HWP_COMPRESS_IMAGES:          true,
+	HWP_PRINT_TO_PDF:             true,
+	HWP_LIST_HYPERLINKS:          true,
+	HWP_VALIDATE_DOCUMENT:        true,
+	HWP_FIND_DUPLICATES:          true,
+	HWP_LIST_IMAGES:              true,
+	HWP_LIST_OBJECTS:             true,
+	HWP_SELECT_OBJECT:            true,
+	HWP_SCAN_PII:                 true,
+	HWP_EXPORT_MODEL:             true,
+	HWP_GET_DOCUMENT_STATUS:      true,
+	HWP_GET_PAGE_COUNT:           true,
+	HWP_RENDER_CURRENT_PAGE:      true,
+	HWP_EXPORT_PAGE_IMAGE:        true,
+	HWP_GET_METADATA:             true,
+	HWP_FIND:                     true,
+	HWP_GOTO_MATCH:               true,
+	HWP_GET_SELECTION_TEXT:       true,
+	HWP_MOVE_TO_LEFT_CELL:        true,
+	HWP_MOVE_TO_RIGHT_CELL:       true,
+	HWP_MOVE_TO_UPPER_CELL:       true,
+	HWP_MOVE_TO_LOWER_CELL:       true,
+	HWP_CREATE_COMPLETE_DOCUMENT: true,
+	HWP_GENERATE_DOCUMENTS:       true,
+}
+
+// ReadOnlyGuard refuses tools that would change or save the document while it
+// is open read-only
+func ReadOnlyGuard(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := request.Params.Name
+		if readOnlySafeTools[name] {
+			return next(ctx, request)
+		}
+
+		readOnly := hwp.ExecuteHWPOperationWithResult(func() bool {
+			controller := hwp.GetGlobalController()
+			return controller != nil && controller.HasDocument() && controller.IsReadOnly()
+		})
+		if readOnly {
+			return hwp.CreateTextResult(fmt.Sprintf("Error: %s is not allowed while the document is open read-only. Reopen it without read_only to edit.", name)), nil
+		}
+
+		return next(ctx, request)
+	}
+}
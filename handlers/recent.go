@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Tool names for recent files
+const (
+	HWP_LIST_RECENT = "hwp_list_recent"
+)
+
+// recentFilesLimit is the number of files the recent files store keeps
+const recentFilesLimit = 50
+
+// RecentFile is a file the server opened or saved
+type RecentFile struct {
+	Path   string    `json:"path"`
+	Action string    `json:"action"` // opened or saved, whichever was last
+	Time   time.Time `json:"time"`
+}
+
+// recentFiles is the recent files store, a JSON file shared by every server
+// run of the user. An empty path turns tracking off.
+var recentFiles struct {
+	mu   sync.Mutex
+	path string
+}
+
+// SetRecentFilesPath sets the recent files store; empty turns tracking off
+func SetRecentFilesPath(path string) {
+	recentFiles.mu.Lock()
+	defer recentFiles.mu.Unlock()
+	recentFiles.path = path
+}
+
+// DefaultRecentFilesPath returns the recent files store under the user config directory
+func DefaultRecentFilesPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = "."
+	}
+	return filepath.Join(dir, "hwp-mcp-go", "recent.json")
+}
+
+// loadRecentFiles reads the store, most recent first. A missing store is empty.
+func loadRecentFiles(path string) ([]RecentFile, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var files []RecentFile
+	if err := json.Unmarshal(data, &files); err != nil {
+		return nil, fmt.Errorf("invalid recent files store %s: %v", path, err)
+	}
+	return files, nil
+}
+
+// recordRecentFile moves path to the top of the recent files. Tracking is
+// best effort: the open or save already succeeded, so a store that can't be
+// written is ignored.
+func recordRecentFile(path, action string) {
+	recentFiles.mu.Lock()
+	defer recentFiles.mu.Unlock()
+	if recentFiles.path == "" || path == "" {
+		return
+	}
+	if absolute, err := filepath.Abs(path); err == nil {
+		path = absolute
+	}
+
+	files, err := loadRecentFiles(recentFiles.path)
+	if err != nil {
+		// Start over rather than keep failing on a corrupt store
+		files = nil
+	}
+	updated := []RecentFile{{Path: path, Action: action, Time: time.Now()}}
+	for _, file := range files {
+		if !samePath(file.Path, path) && len(updated) < recentFilesLimit {
+			updated = append(updated, file)
+		}
+	}
+
+	data, err := json.MarshalIndent(updated, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(recentFiles.path), 0o755); err != nil {
+		return
+	}
+	// Write and rename so a concurrent server never reads half a store
+	temp := recentFiles.path + ".tmp"
+	if err := os.WriteFile(temp, data, 0o644); err != nil {
+		return
+	}
+	if err := os.Rename(temp, recentFiles.path); err != nil {
+		os.Remove(temp)
+	}
+}
+
+// samePath compares file paths, ignoring case on Windows
+func samePath(a, b string) bool {
+	a, b = filepath.Clean(a), filepath.Clean(b)
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+func HandleHwpListRecent(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	limit := request.GetInt("limit", 10)
+	if limit < 1 {
+		return hwp.CreateTextResult("Error: limit must be at least 1"), nil
+	}
+	query := strings.ToLower(strings.TrimSpace(request.GetString("query", "")))
+	action := request.GetString("action", "")
+	if action != "" && action != "opened" && action != "saved" {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: invalid action %q (use opened or saved)", action)), nil
+	}
+
+	recentFiles.mu.Lock()
+	path := recentFiles.path
+	var files []RecentFile
+	var err error
+	if path != "" {
+		files, err = loadRecentFiles(path)
+	}
+	recentFiles.mu.Unlock()
+	if path == "" {
+		return hwp.CreateTextResult("Error: recent files tracking is turned off for this server"), nil
+	}
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	type listedFile struct {
+		RecentFile
+		Name   string `json:"name"`
+		Exists bool   `json:"exists"`
+	}
+	listed := []listedFile{}
+	for _, file := range files {
+		if len(listed) == limit {
+			break
+		}
+		if action != "" && file.Action != action {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(filepath.Base(file.Path)), query) {
+			continue
+		}
+		_, statErr := os.Stat(file.Path)
+		listed = append(listed, listedFile{RecentFile: file, Name: filepath.Base(file.Path), Exists: statErr == nil})
+	}
+
+	resultJSON, _ := json.Marshal(map[string]interface{}{
+		"total_files": len(listed),
+		"files":       listed,
+	})
+	return hwp.CreateTextResult(string(resultJSON)), nil
+}
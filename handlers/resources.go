@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Resource URIs
+const (
+	RESOURCE_CURRENT_TEXT = "hwp://current/text"
+)
+
+// Document resource handlers
+
+func HandleCurrentTextResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	var text string
+	var err error
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			return
+		}
+		text, err = controller.GetText()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      RESOURCE_CURRENT_TEXT,
+			MIMEType: "text/plain",
+			Text:     text,
+		},
+	}, nil
+}
+
+// Resource subscriptions
+//
+// Clients ask for resources/updated notifications with resources/subscribe
+// and stop them with resources/unsubscribe. mcp-go doesn't route these
+// methods, so the transports pass each incoming message to
+// ResourceSubscriptions.HandleMessage first, which answers them. Sessions
+// that end drop their subscriptions through the server's unregister hook.
+
+// ResourceSubscriptions records which sessions subscribed to which resources
+type ResourceSubscriptions struct {
+	mu       sync.Mutex
+	sessions map[string]map[string]bool // session ID -> subscribed URIs
+	changed  chan struct{}              // signalled when a first subscription is made
+}
+
+// NewResourceSubscriptions returns an empty subscription record
+func NewResourceSubscriptions() *ResourceSubscriptions {
+	return &ResourceSubscriptions{
+		sessions: map[string]map[string]bool{},
+		changed:  make(chan struct{}, 1),
+	}
+}
+
+// subscribableResources are the resources that send updates
+var subscribableResources = map[string]bool{
+	RESOURCE_CURRENT_TEXT: true,
+}
+
+// Subscribe subscribes a session to a resource
+func (r *ResourceSubscriptions) Subscribe(sessionID, uri string) error {
+	if !subscribableResources[uri] {
+		return fmt.Errorf("unknown resource: %s", uri)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.sessions[sessionID] == nil {
+		r.sessions[sessionID] = map[string]bool{}
+	}
+	r.sessions[sessionID][uri] = true
+	select {
+	case r.changed <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Unsubscribe ends a session's subscription to a resource
+func (r *ResourceSubscriptions) Unsubscribe(sessionID, uri string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.sessions[sessionID], uri)
+	if len(r.sessions[sessionID]) == 0 {
+		delete(r.sessions, sessionID)
+	}
+}
+
+// RemoveSession ends all subscriptions of a session
+func (r *ResourceSubscriptions) RemoveSession(sessionID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.sessions, sessionID)
+}
+
+// Subscribers returns the sessions subscribed to a resource
+func (r *ResourceSubscriptions) Subscribers(uri string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var sessions []string
+	for sessionID, uris := range r.sessions {
+		if uris[uri] {
+			sessions = append(sessions, sessionID)
+		}
+	}
+	return sessions
+}
+
+// AddHooks drops the subscriptions of sessions as they end
+func (r *ResourceSubscriptions) AddHooks(hooks *server.Hooks) {
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		r.RemoveSession(session.SessionID())
+	})
+}
+
+// HandleMessage answers a resources/subscribe or resources/unsubscribe
+// request of a session. handled is false for any other message, which goes
+// on to the MCP server.
+func (r *ResourceSubscriptions) HandleMessage(sessionID string, message []byte) (response mcp.JSONRPCMessage, handled bool) {
+	var request struct {
+		ID     *mcp.RequestId `json:"id"`
+		Method string         `json:"method"`
+		Params struct {
+			URI string `json:"uri"`
+		} `json:"params"`
+	}
+	if json.Unmarshal(message, &request) != nil || request.ID == nil {
+		return nil, false
+	}
+
+	switch request.Method {
+	case methodResourcesSubscribe:
+		if request.Params.URI == "" {
+			return mcp.NewJSONRPCError(*request.ID, mcp.INVALID_PARAMS, "uri is required", nil), true
+		}
+		if err := r.Subscribe(sessionID, request.Params.URI); err != nil {
+			return mcp.NewJSONRPCError(*request.ID, mcp.INVALID_PARAMS, err.Error(), nil), true
+		}
+	case methodResourcesUnsubscribe:
+		r.Unsubscribe(sessionID, request.Params.URI)
+	default:
+		return nil, false
+	}
+	return mcp.NewJSONRPCResponse(*request.ID, mcp.Result{}), true
+}
+
+// Subscription methods, which mcp-go doesn't define
+const (
+	methodResourcesSubscribe   = "resources/subscribe"
+	methodResourcesUnsubscribe = "resources/unsubscribe"
+)
+
+// WatchDocument polls the document text every interval while a session is
+// subscribed to hwp://current/text, and notifies the subscribed sessions
+// whenever it changes, whether through tools or by the user editing the
+// attached HWP window. It returns when ctx is done.
+func WatchDocument(ctx context.Context, mcpServer *server.MCPServer, subscriptions *ResourceSubscriptions, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last [sha256.Size]byte
+	first := true
+
+	for {
+		// Nobody is listening: stop reading the document until a client
+		// subscribes, then start over from its current text
+		if len(subscriptions.Subscribers(RESOURCE_CURRENT_TEXT)) == 0 {
+			first = true
+			select {
+			case <-ctx.Done():
+				return
+			case <-subscriptions.changed:
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		var text string
+		var err error
+		hwp.ExecuteHWPOperation(func() {
+			controller := hwp.GetGlobalController()
+			if controller == nil || !controller.HasDocument() {
+				return
+			}
+			text, err = controller.GetText()
+		})
+		if err != nil {
+			// The user may be in the middle of a modal dialog; try again later
+			continue
+		}
+
+		sum := sha256.Sum256([]byte(text))
+		if !first && sum != last {
+			for _, sessionID := range subscriptions.Subscribers(RESOURCE_CURRENT_TEXT) {
+				err := mcpServer.SendNotificationToSpecificClient(sessionID, mcp.MethodNotificationResourceUpdated, map[string]any{
+					"uri": RESOURCE_CURRENT_TEXT,
+				})
+				// A session the server no longer knows can't be notified again
+				if errors.Is(err, server.ErrSessionNotFound) {
+					subscriptions.RemoveSession(sessionID)
+				}
+			}
+		}
+		last = sum
+		first = false
+	}
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestResourceSubscriptions(t *testing.T) {
+	subscriptions := NewResourceSubscriptions()
+
+	response, handled := subscriptions.HandleMessage("a", []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/subscribe","params":{"uri":"hwp://current/text"}}`))
+	if !handled {
+		t.Fatal("resources/subscribe was not handled")
+	}
+	if encoded, _ := json.Marshal(response); !strings.Contains(string(encoded), `"result":{}`) {
+		t.Errorf("subscribe response = %s, want an empty result", encoded)
+	}
+	if got := subscriptions.Subscribers(RESOURCE_CURRENT_TEXT); len(got) != 1 || got[0] != "a" {
+		t.Errorf("subscribers = %v, want [a]", got)
+	}
+
+	response, _ = subscriptions.HandleMessage("b", []byte(`{"jsonrpc":"2.0","id":2,"method":"resources/subscribe","params":{"uri":"hwp://other"}}`))
+	if encoded, _ := json.Marshal(response); !strings.Contains(string(encoded), "unknown resource") {
+		t.Errorf("subscribing to an unknown resource: response = %s, want an error", encoded)
+	}
+
+	if _, handled := subscriptions.HandleMessage("a", []byte(`{"jsonrpc":"2.0","id":3,"method":"tools/list"}`)); handled {
+		t.Error("tools/list was handled, want it passed on")
+	}
+	if _, handled := subscriptions.HandleMessage("a", []byte(`{"jsonrpc":"2.0","method":"resources/subscribe","params":{"uri":"hwp://current/text"}}`)); handled {
+		t.Error("a notification was handled, want it passed on")
+	}
+
+	subscriptions.HandleMessage("a", []byte(`{"jsonrpc":"2.0","id":4,"method":"resources/unsubscribe","params":{"uri":"hwp://current/text"}}`))
+	if got := subscriptions.Subscribers(RESOURCE_CURRENT_TEXT); len(got) != 0 {
+		t.Errorf("subscribers after unsubscribe = %v, want none", got)
+	}
+}
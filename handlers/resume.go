@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"strings"
+
+	"hwp-mcp-go/hwp"
+)
+
+// Resumes (이력서) and self-introductions (자기소개서)
+//
+// The "resume" document type follows the standard Korean resume form: the
+// title, a personal details table with a photo cell spanning its rows, the
+// education, career and certification tables, and the closing declaration
+// with the date and name. Self-introduction sections (성장과정, 지원동기, ...)
+// follow on a new page under 자기소개서. On HWPX the photo cell is not merged
+// and holds the word 사진 instead of a photo.
+
+// resumeTextFields are the text fields of a resume
+var resumeTextFields = []string{
+	"title", "name", "name_hanja", "name_english", "birth_date", "phone", "email", "address",
+	"photo_path", "date",
+}
+
+// resumePersonalFields are the personal details rows in order, with their labels
+var resumePersonalFields = []struct{ key, label string }{
+	{"name", "성명"},
+	{"name_hanja", "한자"},
+	{"name_english", "영문"},
+	{"birth_date", "생년월일"},
+	{"phone", "연락처"},
+	{"email", "이메일"},
+	{"address", "주소"},
+}
+
+// resumeTables are the history tables of a resume: the spec key, the heading
+// and the object fields with their column labels
+var resumeTables = []struct {
+	key     string
+	heading string
+	columns []struct{ key, label string }
+}{
+	{"education", "학력사항", []struct{ key, label string }{
+		{"period", "기간"}, {"school", "학교명"}, {"major", "전공"}, {"status", "구분"},
+	}},
+	{"career", "경력사항", []struct{ key, label string }{
+		{"period", "기간"}, {"company", "회사명"}, {"position", "직위"}, {"duties", "담당 업무"},
+	}},
+	{"certifications", "자격·면허", []struct{ key, label string }{
+		{"date", "취득일"}, {"name", "자격/면허"}, {"issuer", "발행처"},
+	}},
+}
+
+// Resume photo bounds (hwpunit): a 3×4cm photo
+const (
+	resumePhotoMaxWidth  = 8504
+	resumePhotoMaxHeight = 11339
+)
+
+// resumeDeclaration closes the resume above the date and signature
+const resumeDeclaration = "위의 기재 사항은 사실과 틀림이 없습니다."
+
+// resumeDocument is a validated resume spec
+type resumeDocument struct {
+	Text         map[string]string
+	Tables       map[string][][]string // history table rows with the header row, by key
+	Introduction []interface{}         // {title, content} sections
+}
+
+// readResumeDocument reads the fields of a resume spec
+func readResumeDocument(fields *fieldReader) resumeDocument {
+	document := resumeDocument{Text: map[string]string{}, Tables: map[string][][]string{}}
+	for _, name := range resumeTextFields {
+		document.Text[name] = fields.String(name)
+	}
+	if document.Text["name"] == "" {
+		fields.fail("name", "is required")
+	}
+
+	for _, table := range resumeTables {
+		header := make([]string, len(table.columns))
+		keys := make([]string, len(table.columns))
+		for i, column := range table.columns {
+			header[i], keys[i] = column.label, column.key
+		}
+		rows := [][]string{header}
+		shape := "{" + strings.Join(keys, ", ") + "}"
+		readObjects(fields, table.key, shape, func(item *fieldReader) {
+			row := make([]string, len(table.columns))
+			for i, column := range table.columns {
+				row[i] = item.String(column.key)
+			}
+			rows = append(rows, row)
+		})
+		if len(rows) > 1 {
+			document.Tables[table.key] = rows
+		}
+	}
+
+	readObjects(fields, "introduction", "{title, content}", func(item *fieldReader) {
+		item.String("title")
+		item.String("content")
+		document.Introduction = append(document.Introduction, item.object)
+	})
+	return document
+}
+
+// resumePersonalRows returns the label/value rows of the personal details
+// that are set
+func (d resumeDocument) resumePersonalRows() [][]string {
+	var rows [][]string
+	for _, field := range resumePersonalFields {
+		if value := d.Text[field.key]; value != "" {
+			rows = append(rows, []string{field.label, value})
+		}
+	}
+	return rows
+}
+
+func createResumeDocument(controller *hwp.Controller, spec map[string]interface{}, styles stylePresetSet) error {
+	document := readResumeDocument(&fieldReader{path: "spec", object: spec})
+	text := document.Text
+
+	writeLines := func(lines ...string) error {
+		for _, line := range lines {
+			if err := controller.InsertText(line, false); err != nil {
+				return err
+			}
+			if err := controller.InsertParagraph(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	centeredTitle := func(title string) error {
+		if err := controller.SetParagraphAlignment("center"); err != nil {
+			return err
+		}
+		if err := styles.apply(controller, "제목1"); err != nil {
+			return err
+		}
+		if err := writeLines(title, ""); err != nil {
+			return err
+		}
+		return controller.SetParagraphAlignment("justify")
+	}
+
+	title := text["title"]
+	if title == "" {
+		title = "이 력 서"
+	}
+	if err := centeredTitle(title); err != nil {
+		return err
+	}
+	if err := styles.apply(controller, "본문"); err != nil {
+		return err
+	}
+	if err := insertResumePersonalTable(controller, document); err != nil {
+		return err
+	}
+	if err := insertBlankLines(controller, 2); err != nil {
+		return err
+	}
+
+	for _, table := range resumeTables {
+		rows, ok := document.Tables[table.key]
+		if !ok {
+			continue
+		}
+		if err := styles.apply(controller, "강조"); err != nil {
+			return err
+		}
+		if err := writeLines(table.heading); err != nil {
+			return err
+		}
+		if err := styles.apply(controller, "본문"); err != nil {
+			return err
+		}
+		if err := controller.InsertTable(len(rows), len(rows[0])); err != nil {
+			return err
+		}
+		if err := controller.FillTableWithData(rows, 1, 1, true); err != nil {
+			return err
+		}
+		if err := insertBlankLines(controller, 2); err != nil {
+			return err
+		}
+	}
+
+	if text["date"] != "" {
+		if err := controller.SetParagraphAlignment("center"); err != nil {
+			return err
+		}
+		if err := writeLines(resumeDeclaration, "", text["date"], "", "성명  "+text["name"]+"  (인)"); err != nil {
+			return err
+		}
+		if err := controller.SetParagraphAlignment("justify"); err != nil {
+			return err
+		}
+	}
+
+	if len(document.Introduction) > 0 {
+		if err := controller.InsertPageBreak(); err != nil {
+			return err
+		}
+		if err := centeredTitle("자 기 소 개 서"); err != nil {
+			return err
+		}
+		if err := createReportSections(controller, document.Introduction, styles); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertResumePersonalTable writes the personal details table with the photo
+// cell in its first column. With COM the photo cell spans every row and holds
+// the photo; HWPX cannot merge cells, so the first cell reads 사진.
+func insertResumePersonalTable(controller *hwp.Controller, document resumeDocument) error {
+	rows := document.resumePersonalRows()
+	if err := controller.InsertTable(len(rows), 3); err != nil {
+		return err
+	}
+
+	if controller.Backend() == hwp.BackendHWPX {
+		data := make([][]string, len(rows))
+		for i, row := range rows {
+			data[i] = append([]string{""}, row...)
+		}
+		data[0][0] = "사진"
+		return controller.FillTableWithData(data, 1, 1, false)
+	}
+
+	// The cursor is in the first cell after the table is created
+	if err := controller.MergeCellsDown(len(rows)); err != nil {
+		return err
+	}
+	if photo := document.Text["photo_path"]; photo != "" {
+		maxWidth, maxHeight := resumePhotoMaxWidth, resumePhotoMaxHeight
+		if err := controller.InsertImage(photo, nil, nil, false, &maxWidth, &maxHeight, nil, true, true, false, false, 0, "증명사진", nil, nil, nil); err != nil {
+			return err
+		}
+	} else if err := controller.InsertText("사진", false); err != nil {
+		return err
+	}
+	return controller.FillTableWithData(rows, 1, 2, false)
+}
@@ -0,0 +1,1030 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Tool names for table operations
+const (
+	HWP_INSERT_TABLE           = "hwp_insert_table"
+	HWP_FILL_TABLE_WITH_DATA   = "hwp_fill_table_with_data"
+	HWP_FILL_COLUMN_NUMBERS    = "hwp_fill_column_numbers"
+	HWP_CREATE_TABLE_WITH_DATA = "hwp_create_table_with_data"
+	// Table manipulation tools
+	HWP_INSERT_LEFT_COLUMN     = "hwp_insert_left_column"
+	HWP_INSERT_RIGHT_COLUMN    = "hwp_insert_right_column"
+	HWP_INSERT_UPPER_ROW       = "hwp_insert_upper_row"
+	HWP_INSERT_LOWER_ROW       = "hwp_insert_lower_row"
+	HWP_MOVE_TO_LEFT_CELL      = "hwp_move_to_left_cell"
+	HWP_MOVE_TO_RIGHT_CELL     = "hwp_move_to_right_cell"
+	HWP_MOVE_TO_UPPER_CELL     = "hwp_move_to_upper_cell"
+	HWP_MOVE_TO_LOWER_CELL     = "hwp_move_to_lower_cell"
+	HWP_MERGE_TABLE_CELLS      = "hwp_merge_table_cells"
+	HWP_MERGE_TABLES           = "hwp_merge_tables"
+	HWP_SET_CELL_STYLE         = "hwp_set_cell_style"
+	// Table conversion tools
+	HWP_CONVERT_TEXT_TO_TABLE  = "hwp_convert_text_to_table"
+	HWP_CONVERT_TABLE_TO_TEXT  = "hwp_convert_table_to_text"
+	HWP_CONVERT_TABLE_TO_CHART = "hwp_convert_table_to_chart"
+	// Chunked table fill tools
+	HWP_BEGIN_TABLE_FILL    = "hwp_begin_table_fill"
+	HWP_APPEND_TABLE_ROWS   = "hwp_append_table_rows"
+	HWP_END_TABLE_FILL      = "hwp_end_table_fill"
+	HWP_FILL_TABLE_FROM_CSV = "hwp_fill_table_from_csv"
+	// Row templates
+	HWP_EXPAND_ROW_TEMPLATE = "hwp_expand_row_template"
+)
+
+// Table operation tool handlers
+
+func HandleHwpInsertTable(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	rows := request.GetInt("rows", 0)
+	cols := request.GetInt("cols", 0)
+
+	if rows <= 0 || cols <= 0 {
+		return hwp.CreateTextResult("Error: Valid rows and cols are required"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		err := controller.InsertTable(rows, cols)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Table created (%dx%d)", rows, cols))
+	})
+
+	return result, nil
+}
+
+func HandleHwpFillTableWithData(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tableData, ok, err := tableDataArgument(request, "data")
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	if !ok {
+		return hwp.CreateTextResult("Error: Data is required"), nil
+	}
+
+	startRow := request.GetInt("start_row", 1)
+	startCol := request.GetInt("start_col", 1)
+	hasHeader := request.GetBool("has_header", false)
+
+	formatter, err := cellFormatterArgument(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	formatter.apply(tableData, hasHeader)
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		err := controller.FillTableWithData(tableData, startRow, startCol, hasHeader)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult("Table data filled successfully")
+	})
+
+	return result, nil
+}
+
+func HandleHwpFillColumnNumbers(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	start := request.GetInt("start", 1)
+	end := request.GetInt("end", 10)
+	column := request.GetInt("column", 1)
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		// Move to table beginning
+		controller.MoveToTableCell("col_begin")
+
+		// Move to specified column
+		for i := 0; i < column-1; i++ {
+			controller.MoveToTableCell("right")
+		}
+
+		// Fill numbers
+		for num := start; num <= end; num++ {
+			controller.SelectTableCell()
+			controller.DeleteTableCellContent()
+
+			// Insert text directly using controller's method
+			controller.InsertText(strconv.Itoa(num), false)
+
+			if num < end {
+				controller.MoveToTableCell("lower")
+			}
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Column %d filled with numbers %d~%d", column, start, end))
+	})
+
+	return result, nil
+}
+
+func HandleHwpCreateTableWithData(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	rows := request.GetInt("rows", 0)
+	cols := request.GetInt("cols", 0)
+	hasHeader := request.GetBool("has_header", false)
+
+	if rows <= 0 || cols <= 0 {
+		return hwp.CreateTextResult("Error: Valid rows and cols are required"), nil
+	}
+
+	tableData, hasData, err := tableDataArgument(request, "data")
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	formatter, err := cellFormatterArgument(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	formatter.apply(tableData, hasHeader)
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		// Create table first
+		err := controller.InsertTable(rows, cols)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error creating table: %v", err))
+			return
+		}
+
+		// Fill with data if provided
+		if hasData {
+			err = controller.FillTableWithData(tableData, 1, 1, hasHeader)
+			if err != nil {
+				result = hwp.CreateTextResult(fmt.Sprintf("Error filling table: %v", err))
+				return
+			}
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Table created (%dx%d) and filled with data", rows, cols))
+	})
+
+	return result, nil
+}
+
+// Table manipulation handlers
+
+func HandleHwpInsertLeftColumn(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return insertTableLines(request, "column", "left", "to the left")
+}
+
+func HandleHwpInsertRightColumn(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return insertTableLines(request, "column", "right", "to the right")
+}
+
+func HandleHwpInsertUpperRow(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return insertTableLines(request, "row", "upper", "above")
+}
+
+func HandleHwpInsertLowerRow(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return insertTableLines(request, "row", "lower", "below")
+}
+
+// insertTableLines inserts the count rows or columns requested beside the
+// current cell; where describes direction in the result message
+func insertTableLines(request mcp.CallToolRequest, line, direction, where string) (*mcp.CallToolResult, error) {
+	count := request.GetInt("count", 1)
+	if count < 1 {
+		return hwp.CreateTextResult("Error: count must be at least 1"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		var err error
+		if line == "row" {
+			err = controller.InsertTableRow(direction, count)
+		} else {
+			err = controller.InsertTableColumn(direction, count)
+		}
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		if count == 1 {
+			result = hwp.CreateTextResult(fmt.Sprintf("Inserted 1 %s %s", line, where))
+		} else {
+			result = hwp.CreateTextResult(fmt.Sprintf("Inserted %d %ss %s", count, line, where))
+		}
+	})
+
+	return result, nil
+}
+
+func HandleHwpMoveToLeftCell(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		// Move to left cell
+		err := controller.MoveToTableCell("left")
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult("Moved to left cell")
+	})
+
+	return result, nil
+}
+
+func HandleHwpMoveToRightCell(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		// Move to right cell
+		err := controller.MoveToTableCell("right")
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult("Moved to right cell")
+	})
+
+	return result, nil
+}
+
+func HandleHwpMoveToUpperCell(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		// Move to upper cell
+		err := controller.MoveToTableCell("upper")
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult("Moved to upper cell")
+	})
+
+	return result, nil
+}
+
+func HandleHwpMoveToLowerCell(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		// Move to lower cell
+		err := controller.MoveToTableCell("lower")
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult("Moved to lower cell")
+	})
+
+	return result, nil
+}
+
+func HandleHwpMergeTableCells(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		// Merge selected cells
+		err := controller.MergeTableCells()
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult("Table cells merged successfully")
+	})
+
+	return result, nil
+}
+
+func HandleHwpMergeTables(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		// Merge adjacent tables
+		err := controller.MergeTables()
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult("Tables merged successfully")
+	})
+
+	return result, nil
+}
+
+// Table conversion handlers
+
+func HandleHwpSetCellStyle(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	style := hwp.CellStyle{
+		PaddingLeft:     optionalFloat(request, "padding_left"),
+		PaddingRight:    optionalFloat(request, "padding_right"),
+		PaddingTop:      optionalFloat(request, "padding_top"),
+		PaddingBottom:   optionalFloat(request, "padding_bottom"),
+		Diagonal:        request.GetString("diagonal", ""),
+		DiagonalStyle:   request.GetString("diagonal_style", "solid"),
+		DiagonalWidthMM: request.GetFloat("diagonal_width", 0.12),
+		DiagonalColor:   request.GetString("diagonal_color", "black"),
+	}
+	// padding sets all four sides unless a side is given on its own
+	if all := optionalFloat(request, "padding"); all != nil {
+		for _, side := range []**float64{&style.PaddingLeft, &style.PaddingRight, &style.PaddingTop, &style.PaddingBottom} {
+			if *side == nil {
+				*side = all
+			}
+		}
+	}
+	if !style.HasPadding() && style.Diagonal == "" {
+		return hwp.CreateTextResult("Error: Set padding (padding or padding_left/right/top/bottom) or diagonal"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		err := controller.SetCellStyle(style)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		var changes []string
+		if style.HasPadding() {
+			var sides []string
+			for _, side := range []struct {
+				name string
+				mm   *float64
+			}{
+				{"left", style.PaddingLeft}, {"right", style.PaddingRight},
+				{"top", style.PaddingTop}, {"bottom", style.PaddingBottom},
+			} {
+				if side.mm != nil {
+					sides = append(sides, fmt.Sprintf("%s %gmm", side.name, *side.mm))
+				}
+			}
+			changes = append(changes, "padding "+strings.Join(sides, ", "))
+		}
+		if style.Diagonal != "" {
+			changes = append(changes, "diagonal "+style.Diagonal)
+		}
+		result = hwp.CreateTextResult(fmt.Sprintf("Cell style applied (%s)", strings.Join(changes, "; ")))
+	})
+
+	return result, nil
+}
+
+func HandleHwpConvertTextToTable(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	delimiter := request.GetString("delimiter", "tab")
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		// Convert the selected text
+		err := controller.ConvertTextToTable(delimiter)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Selected text converted to table (delimiter: %s)", delimiter))
+	})
+
+	return result, nil
+}
+
+func HandleHwpConvertTableToText(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	delimiter := request.GetString("delimiter", "tab")
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		// Flatten the table under the cursor
+		err := controller.ConvertTableToText(delimiter)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Table converted to text (delimiter: %s)", delimiter))
+	})
+
+	return result, nil
+}
+
+// templateRowNumber is the 1-based record number a row template can use as
+// {{row_number}}
+const templateRowNumber = "row_number"
+
+// findTemplateRow returns the 1-based number of the first row with a
+// {{placeholder}}, the mark of a row template
+func findTemplateRow(rows [][]string) (int, bool) {
+	for r, cells := range rows {
+		for _, cell := range cells {
+			if templateVariable.MatchString(cell) {
+				return r + 1, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// expandTemplateRow fills the placeholders of template once per record
+func expandTemplateRow(template []string, records []map[string]interface{}) ([][]string, error) {
+	rows := make([][]string, len(records))
+	for i, record := range records {
+		scope := make(map[string]interface{}, len(record)+1)
+		for key, value := range record {
+			scope[key] = value
+		}
+		scope[templateRowNumber] = float64(i + 1)
+
+		rows[i] = make([]string, len(template))
+		for c, cell := range template {
+			text, err := interpolate(cell, scope)
+			if err != nil {
+				return nil, fmt.Errorf("data[%d]: %v", i, err)
+			}
+			rows[i][c] = text
+		}
+	}
+	return rows, nil
+}
+
+func HandleHwpExpandRowTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	records, ok, err := objectArrayArgument(request, "data")
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	if !ok {
+		return hwp.CreateTextResult("Error: Data is required"), nil
+	}
+	row := request.GetInt("row", 0)
+	if row < 0 {
+		return hwp.CreateTextResult("Error: row must be at least 1"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		table, err := controller.ReadTable(0)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		if row == 0 {
+			if row, ok = findTemplateRow(table); !ok {
+				result = hwp.CreateTextResult("Error: No row of the table has {{placeholders}}; put them in the row to repeat or pass row")
+				return
+			}
+		} else if row > len(table) {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: Row %d not found; the table has %d rows", row, len(table)))
+			return
+		}
+
+		rows, err := expandTemplateRow(table[row-1], records)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		if err := controller.ExpandTableRow(row, rows); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		noun := "rows"
+		if len(rows) == 1 {
+			noun = "row"
+		}
+		result = hwp.CreateTextResult(fmt.Sprintf("Expanded template row %d into %d %s", row, len(rows), noun))
+	})
+
+	return result, nil
+}
+
+func HandleHwpConvertTableToChart(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	index := request.GetInt("index", 0)
+	options := hwp.ChartOptions{
+		Type:    request.GetString("type", "bar"),
+		Title:   request.GetString("title", ""),
+		Series:  request.GetStringSlice("series", nil),
+		WidthMM: request.GetInt("width", 0),
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		data, err := controller.InsertTableChart(index, options)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		source := "the table under the cursor"
+		if index > 0 {
+			source = fmt.Sprintf("table %d", index)
+		}
+		names := make([]string, len(data.Series))
+		for i, series := range data.Series {
+			names[i] = series.Name
+		}
+		message := fmt.Sprintf("Inserted a %s chart of %s (%d categories; series: %s)",
+			options.Type, source, len(data.Categories), strings.Join(names, ", "))
+		// Show the chart too; it was drawn already, so drawing it again can't fail
+		if image, err := hwp.RenderChart(data, options.Type); err == nil {
+			result = hwp.CreateImageResult(message, image, "image/png")
+			return
+		}
+		result = hwp.CreateTextResult(message)
+	})
+
+	return result, nil
+}
+
+// cellFormatter formats table data by the number_format and column_formats
+// arguments before it is written
+type cellFormatter struct {
+	fallback hwp.CellFormat
+	columns  []hwp.CellFormat
+}
+
+// cellFormatterArgument reads number_format and column_formats; the
+// formatter is nil when neither is given
+func cellFormatterArgument(request mcp.CallToolRequest) (*cellFormatter, error) {
+	numberFormat := request.GetString("number_format", "")
+	columnFormats := request.GetStringSlice("column_formats", nil)
+	if numberFormat == "" && len(columnFormats) == 0 {
+		return nil, nil
+	}
+
+	fallback, err := hwp.ParseCellFormat(numberFormat)
+	if err != nil {
+		return nil, fmt.Errorf("number_format: %v", err)
+	}
+	formatter := &cellFormatter{fallback: fallback}
+	for i, spec := range columnFormats {
+		format := fallback
+		if strings.TrimSpace(spec) != "" {
+			if format, err = hwp.ParseCellFormat(spec); err != nil {
+				return nil, fmt.Errorf("column_formats[%d]: %v", i, err)
+			}
+		}
+		formatter.columns = append(formatter.columns, format)
+	}
+	return formatter, nil
+}
+
+// apply formats data in place, leaving the header row as it is
+func (f *cellFormatter) apply(data [][]string, skipHeader bool) {
+	if f != nil {
+		hwp.FormatTableData(data, f.fallback, f.columns, skipHeader)
+	}
+}
+
+// Chunked table fill
+
+// tableFillSession tracks a chunked table fill between tool calls
+type tableFillSession struct {
+	hasHeader   bool
+	grow        bool
+	formatter   *cellFormatter
+	totalRows   int
+	rowsWritten int
+	startedAt   time.Time
+	// document is the controller's DocumentSerial when the fill began
+	document int
+}
+
+var (
+	tableFillSessions   = map[string]*tableFillSession{}
+	tableFillSessionsMu sync.Mutex
+)
+
+// tableFillTTL is how long after it began a fill session is given up
+const tableFillTTL = time.Hour
+
+// csvProgressInterval is how many rows are written between progress notifications
+const csvProgressInterval = 50
+
+func newFillToken() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to create a fill session token: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// pruneTableFillSessions drops the sessions that have expired or that began
+// on a document that has since been closed or replaced. The caller holds
+// tableFillSessionsMu.
+func pruneTableFillSessions(document int, now time.Time) {
+	for token, session := range tableFillSessions {
+		if session.document != document || now.Sub(session.startedAt) > tableFillTTL {
+			delete(tableFillSessions, token)
+		}
+	}
+}
+
+// lookupTableFillSession returns the live session of token on the document
+// with the given serial, removing it if remove is set
+func lookupTableFillSession(token string, document int, remove bool) (*tableFillSession, error) {
+	tableFillSessionsMu.Lock()
+	defer tableFillSessionsMu.Unlock()
+
+	session, ok := tableFillSessions[token]
+	if !ok {
+		return nil, fmt.Errorf("Unknown fill session: %s", token)
+	}
+	now := time.Now()
+	pruneTableFillSessions(document, now)
+	switch {
+	case session.document != document:
+		return nil, fmt.Errorf("Fill session %s ended: its document was closed or replaced", token)
+	case now.Sub(session.startedAt) > tableFillTTL:
+		return nil, fmt.Errorf("Fill session %s expired %s after it began; start a new one", token, tableFillTTL)
+	}
+	if remove {
+		delete(tableFillSessions, token)
+	}
+	return session, nil
+}
+
+// sendProgress reports progress to the client if the request carried a progress token
+func sendProgress(ctx context.Context, request mcp.CallToolRequest, progress, total int, message string) {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return
+	}
+	mcpServer := server.ServerFromContext(ctx)
+	if mcpServer == nil {
+		return
+	}
+
+	params := map[string]any{
+		"progressToken": request.Params.Meta.ProgressToken,
+		"progress":      progress,
+		"message":       message,
+	}
+	if total > 0 {
+		params["total"] = total
+	}
+	mcpServer.SendNotificationToClient(ctx, "notifications/progress", params)
+}
+
+func HandleHwpBeginTableFill(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	startRow := request.GetInt("start_row", 1)
+	startCol := request.GetInt("start_col", 1)
+	hasHeader := request.GetBool("has_header", false)
+	grow := request.GetBool("grow", false)
+	totalRows := request.GetInt("total_rows", 0)
+
+	formatter, err := cellFormatterArgument(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		token, err := newFillToken()
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		err = controller.BeginTableFill(startRow, startCol)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		document := controller.DocumentSerial()
+		tableFillSessionsMu.Lock()
+		pruneTableFillSessions(document, time.Now())
+		tableFillSessions[token] = &tableFillSession{
+			hasHeader: hasHeader,
+			grow:      grow,
+			formatter: formatter,
+			totalRows: totalRows,
+			startedAt: time.Now(),
+			document:  document,
+		}
+		tableFillSessionsMu.Unlock()
+
+		resultJSON, _ := json.Marshal(map[string]interface{}{
+			"token":     token,
+			"start_row": startRow,
+			"start_col": startCol,
+		})
+		result = hwp.CreateTextResult(string(resultJSON))
+	})
+
+	return result, nil
+}
+
+func HandleHwpAppendTableRows(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	token := request.GetString("token", "")
+	if token == "" {
+		return hwp.CreateTextResult("Error: Fill session token is required"), nil
+	}
+	tableData, ok, err := tableDataArgument(request, "data")
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	if !ok {
+		return hwp.CreateTextResult("Error: Data is required"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+		session, err := lookupTableFillSession(token, controller.DocumentSerial(), false)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		if !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		continued := session.rowsWritten > 0
+		boldFirst := session.hasHeader && !continued
+		session.formatter.apply(tableData, boldFirst)
+		err = controller.AppendTableRows(tableData, continued, session.grow, boldFirst, nil)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		session.rowsWritten += len(tableData)
+		sendProgress(ctx, request, session.rowsWritten, session.totalRows, fmt.Sprintf("%d rows written", session.rowsWritten))
+
+		resultJSON, _ := json.Marshal(map[string]interface{}{
+			"token":        token,
+			"rows_added":   len(tableData),
+			"rows_written": session.rowsWritten,
+			"total_rows":   session.totalRows,
+		})
+		result = hwp.CreateTextResult(string(resultJSON))
+	})
+
+	return result, nil
+}
+
+func HandleHwpEndTableFill(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	token := request.GetString("token", "")
+	if token == "" {
+		return hwp.CreateTextResult("Error: Fill session token is required"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+		session, err := lookupTableFillSession(token, controller.DocumentSerial(), true)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		if !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		err = controller.EndTableFill()
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Table fill completed: %d rows written in %s",
+			session.rowsWritten, time.Since(session.startedAt).Round(time.Millisecond)))
+	})
+
+	return result, nil
+}
+
+func HandleHwpFillTableFromCsv(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	path := request.GetString("path", "")
+	if path == "" {
+		return hwp.CreateTextResult("Error: CSV file path is required"), nil
+	}
+
+	startRow := request.GetInt("start_row", 1)
+	startCol := request.GetInt("start_col", 1)
+	hasHeader := request.GetBool("has_header", false)
+	grow := request.GetBool("grow", false)
+	delimiter := request.GetString("delimiter", ",")
+	comma := '\t'
+	if delimiter != "tab" {
+		runes := []rune(delimiter)
+		if len(runes) != 1 {
+			return hwp.CreateTextResult(fmt.Sprintf("Error: Delimiter must be a single character or \"tab\", got %q", delimiter)), nil
+		}
+		comma = runes[0]
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: Failed to open CSV file - %v", err)), nil
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.Comma = comma
+
+	var tableData [][]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return hwp.CreateTextResult(fmt.Sprintf("Error: Failed to read CSV file - %v", err)), nil
+		}
+		tableData = append(tableData, record)
+	}
+	if len(tableData) == 0 {
+		return hwp.CreateTextResult("Error: CSV file is empty"), nil
+	}
+
+	formatter, err := cellFormatterArgument(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	formatter.apply(tableData, hasHeader)
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		err := controller.BeginTableFill(startRow, startCol)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		total := len(tableData)
+		err = controller.AppendTableRows(tableData, false, grow, hasHeader, func(written int) {
+			if written%csvProgressInterval == 0 || written == total {
+				sendProgress(ctx, request, written, total, fmt.Sprintf("%d/%d rows written", written, total))
+			}
+		})
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		if err := controller.EndTableFill(); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %d rows written, but leaving the table failed: %v", total, err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Table filled with %d rows from %s", total, path))
+	})
+
+	return result, nil
+}
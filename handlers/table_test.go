@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// setTableFillSessions replaces the fill sessions with a live, an expired and
+// a closed one, all but the closed one on document 2
+func setTableFillSessions() {
+	tableFillSessionsMu.Lock()
+	defer tableFillSessionsMu.Unlock()
+	tableFillSessions = map[string]*tableFillSession{
+		"live":    {startedAt: time.Now(), document: 2},
+		"expired": {startedAt: time.Now().Add(-2 * tableFillTTL), document: 2},
+		"closed":  {startedAt: time.Now(), document: 1},
+	}
+}
+
+func TestLookupTableFillSession(t *testing.T) {
+	defer func() {
+		tableFillSessionsMu.Lock()
+		tableFillSessions = map[string]*tableFillSession{}
+		tableFillSessionsMu.Unlock()
+	}()
+
+	tests := []struct {
+		token   string
+		wantErr string
+	}{
+		{token: "expired", wantErr: "expired"},
+		{token: "closed", wantErr: "closed or replaced"},
+		{token: "missing", wantErr: "Unknown fill session"},
+		{token: "live"},
+	}
+	for _, tt := range tests {
+		setTableFillSessions()
+		_, err := lookupTableFillSession(tt.token, 2, false)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("lookup(%s): unexpected error %v", tt.token, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("lookup(%s) error = %v, want one containing %q", tt.token, err, tt.wantErr)
+		}
+	}
+
+	// Looking up any session drops the dead ones
+	tableFillSessionsMu.Lock()
+	remaining := len(tableFillSessions)
+	tableFillSessionsMu.Unlock()
+	if remaining != 1 {
+		t.Errorf("%d sessions left after lookups, want only the live one", remaining)
+	}
+
+	// Once the document is replaced the live session ends too
+	if _, err := lookupTableFillSession("live", 3, false); err == nil {
+		t.Error("session outlived its document")
+	}
+	if _, err := lookupTableFillSession("live", 3, false); err == nil || !strings.Contains(err.Error(), "Unknown") {
+		t.Errorf("session not cleared after its document was replaced: %v", err)
+	}
+}
+
+func TestNewFillTokenIsRandom(t *testing.T) {
+	first, err := newFillToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := newFillToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != 16 || first == second {
+		t.Errorf("tokens %q and %q are not 16 distinct hex digits", first, second)
+	}
+}
@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Document spec templating
+//
+// Specs may carry a top-level "data" object and use these constructs anywhere
+// below it; they are expanded before the document is built:
+//
+//	"{{project.name}}"                                  value interpolation
+//	{"if": "cond", "then": ..., "else": ...}            conditional value or block
+//	{"for_each": "projects", "as": "p", "do": ...}      repeat per array element
+//	{"when": "cond", ...}                               keep an object only if cond holds
+//
+// Conditions are a variable path ("approved"), its negation ("!approved") or a
+// comparison with a literal ("status == 'done'", "count != 0"). Inside a loop
+// the element is bound to the "as" name (default "item") and its 1-based
+// position to "<as>_index". In arrays, blocks that produce arrays are spliced
+// in place; a loop in a text field joins its results with newlines.
+
+// templateVariable matches {{path}} placeholders
+var templateVariable = regexp.MustCompile(`\{\{\s*([^{}]+?)\s*\}\}`)
+
+// expandSpec returns spec with its templating constructs expanded
+func expandSpec(spec map[string]interface{}) (map[string]interface{}, error) {
+	scope := map[string]interface{}{}
+	if data, ok := spec["data"].(map[string]interface{}); ok {
+		for key, value := range data {
+			scope[key] = value
+		}
+	}
+
+	expanded := make(map[string]interface{}, len(spec))
+	for key, value := range spec {
+		if key == "data" {
+			expanded[key] = value
+			continue
+		}
+		result, _, err := expandValue(value, scope)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", key, err)
+		}
+		expanded[key] = result
+	}
+	return expanded, nil
+}
+
+// expandValue expands one spec value. keep is false when a conditional or
+// "when" guard removed the value.
+func expandValue(value interface{}, scope map[string]interface{}) (interface{}, bool, error) {
+	switch v := value.(type) {
+	case string:
+		text, err := interpolate(v, scope)
+		return text, true, err
+
+	case []interface{}:
+		items, err := expandArray(v, scope)
+		return items, true, err
+
+	case map[string]interface{}:
+		if condition, ok := v["if"]; ok {
+			holds, err := evaluateCondition(condition, scope)
+			if err != nil {
+				return nil, false, err
+			}
+			branch, ok := v["then"]
+			if !holds {
+				branch, ok = v["else"]
+			}
+			if !ok {
+				return nil, false, nil
+			}
+			return expandValue(branch, scope)
+		}
+
+		if _, ok := v["for_each"]; ok {
+			items, err := expandLoop(v, scope)
+			if err != nil {
+				return nil, false, err
+			}
+			// A loop producing only text fills a text field
+			lines := make([]string, 0, len(items))
+			for _, item := range items {
+				line, ok := item.(string)
+				if !ok {
+					return items, true, nil
+				}
+				lines = append(lines, line)
+			}
+			return strings.Join(lines, "\n"), true, nil
+		}
+
+		if condition, ok := v["when"]; ok {
+			holds, err := evaluateCondition(condition, scope)
+			if err != nil || !holds {
+				return nil, false, err
+			}
+		}
+
+		object := make(map[string]interface{}, len(v))
+		for key, field := range v {
+			if key == "when" {
+				continue
+			}
+			result, keep, err := expandValue(field, scope)
+			if err != nil {
+				return nil, false, fmt.Errorf("%s: %v", key, err)
+			}
+			if keep {
+				object[key] = result
+			}
+		}
+		return object, true, nil
+	}
+
+	return value, true, nil
+}
+
+// expandArray expands array elements, splicing in the results of blocks
+func expandArray(items []interface{}, scope map[string]interface{}) ([]interface{}, error) {
+	expanded := make([]interface{}, 0, len(items))
+	for i, item := range items {
+		if block, ok := item.(map[string]interface{}); ok {
+			if _, isLoop := block["for_each"]; isLoop {
+				results, err := expandLoop(block, scope)
+				if err != nil {
+					return nil, fmt.Errorf("[%d]: %v", i, err)
+				}
+				expanded = append(expanded, results...)
+				continue
+			}
+		}
+
+		result, keep, err := expandValue(item, scope)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %v", i, err)
+		}
+		if !keep {
+			continue
+		}
+		// Conditional blocks may yield several elements
+		if block, ok := item.(map[string]interface{}); ok && block["if"] != nil {
+			if results, ok := result.([]interface{}); ok {
+				expanded = append(expanded, results...)
+				continue
+			}
+		}
+		expanded = append(expanded, result)
+	}
+	return expanded, nil
+}
+
+// expandLoop renders a for_each block once per element of its array
+func expandLoop(block map[string]interface{}, scope map[string]interface{}) ([]interface{}, error) {
+	path, _ := block["for_each"].(string)
+	source, ok := lookupVariable(path, scope)
+	if !ok {
+		return nil, fmt.Errorf("for_each: undefined variable %q", path)
+	}
+	elements, ok := source.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("for_each: %q is not an array", path)
+	}
+	name, _ := block["as"].(string)
+	if name == "" {
+		name = "item"
+	}
+	body, ok := block["do"]
+	if !ok {
+		return nil, fmt.Errorf("for_each: missing do")
+	}
+
+	var results []interface{}
+	for i, element := range elements {
+		inner := make(map[string]interface{}, len(scope)+2)
+		for key, value := range scope {
+			inner[key] = value
+		}
+		inner[name] = element
+		inner[name+"_index"] = float64(i + 1)
+
+		result, keep, err := expandValue(body, inner)
+		if err != nil {
+			return nil, fmt.Errorf("%s #%d: %v", path, i+1, err)
+		}
+		if !keep {
+			continue
+		}
+		if items, ok := result.([]interface{}); ok {
+			results = append(results, items...)
+		} else {
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+// interpolate replaces {{path}} placeholders in text with variable values
+func interpolate(text string, scope map[string]interface{}) (string, error) {
+	var missing string
+	result := templateVariable.ReplaceAllStringFunc(text, func(match string) string {
+		path := templateVariable.FindStringSubmatch(match)[1]
+		value, ok := lookupVariable(path, scope)
+		if !ok {
+			if missing == "" {
+				missing = path
+			}
+			return match
+		}
+		return formatTemplateValue(value)
+	})
+	if missing != "" {
+		return "", fmt.Errorf("undefined variable %q", missing)
+	}
+	return result, nil
+}
+
+// lookupVariable resolves a dotted path such as "project.members.0.name"
+func lookupVariable(path string, scope map[string]interface{}) (interface{}, bool) {
+	var current interface{} = scope
+	for _, part := range strings.Split(strings.TrimSpace(path), ".") {
+		switch container := current.(type) {
+		case map[string]interface{}:
+			value, ok := container[part]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(part)
+			if err != nil || index < 0 || index >= len(container) {
+				return nil, false
+			}
+			current = container[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// evaluateCondition evaluates an if/when condition. Booleans are used as is.
+func evaluateCondition(condition interface{}, scope map[string]interface{}) (bool, error) {
+	expression, ok := condition.(string)
+	if !ok {
+		if value, isBool := condition.(bool); isBool {
+			return value, nil
+		}
+		return false, fmt.Errorf("condition must be a string or boolean")
+	}
+	expression = strings.TrimSpace(expression)
+
+	for _, operator := range []string{"==", "!="} {
+		left, right, found := strings.Cut(expression, operator)
+		if !found {
+			continue
+		}
+		value, _ := lookupVariable(left, scope)
+		literal := strings.Trim(strings.TrimSpace(right), `'"`)
+		equal := value != nil && formatTemplateValue(value) == literal
+		if operator == "==" {
+			return equal, nil
+		}
+		return !equal, nil
+	}
+
+	if strings.HasPrefix(expression, "!") {
+		value, _ := lookupVariable(expression[1:], scope)
+		return !truthy(value), nil
+	}
+	value, _ := lookupVariable(expression, scope)
+	return truthy(value), nil
+}
+
+// truthy reports whether a JSON value counts as true in a condition
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case float64:
+		return v != 0
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	}
+	return true
+}
+
+// formatTemplateValue renders a JSON value for interpolation
+func formatTemplateValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,912 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Tool names for text operations
+const (
+	HWP_INSERT_TEXT               = "hwp_insert_text"
+	HWP_SET_FONT                  = "hwp_set_font"
+	HWP_INSERT_PARAGRAPH          = "hwp_insert_paragraph"
+	HWP_BATCH_OPERATIONS          = "hwp_batch_operations"
+	HWP_CREATE_DOCUMENT_FROM_TEXT = "hwp_create_document_from_text"
+	HWP_INSERT_IMAGE              = "hwp_insert_image"
+	HWP_INSERT_DATE_STAMP         = "hwp_insert_date_stamp"
+	HWP_INSERT_SYMBOL             = "hwp_insert_symbol"
+	HWP_FIND                      = "hwp_find"
+	HWP_GOTO_MATCH                = "hwp_goto_match"
+	HWP_GET_SELECTION_TEXT        = "hwp_get_selection_text"
+	HWP_IMPORT_TEXT_FILE          = "hwp_import_text_file"
+	HWP_INSERT_CODE_BLOCK         = "hwp_insert_code_block"
+	HWP_INSERT_CALLOUT            = "hwp_insert_callout"
+	HWP_FORMAT_NUMBER             = "hwp_format_number"
+)
+
+// maxImportTextSize is the largest text file hwp_import_text_file reads
+const maxImportTextSize = 32 << 20
+
+// Text manipulation tool handlers
+
+func HandleHwpInsertText(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	text := request.GetString("text", "")
+	if text == "" {
+		return hwp.CreateTextResult("Error: Text is required"), nil
+	}
+
+	preserveLinebreaks := request.GetBool("preserve_linebreaks", true)
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		err := controller.InsertText(text, preserveLinebreaks)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult("Text inserted successfully")
+	})
+
+	return result, nil
+}
+
+func HandleHwpSetFont(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name := request.GetString("name", "")
+	size := request.GetInt("size", 0)
+	bold := request.GetBool("bold", false)
+	italic := request.GetBool("italic", false)
+	underline := request.GetBool("underline", false)
+	color := request.GetString("color", "")
+	target, err := formatTargetArgument(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		var err error
+		formatted := 1
+		if target.Scope != hwp.ScopeCursor {
+			// Existing text keeps the settings that aren't given
+			format := hwp.CharFormat{
+				Font:      name,
+				Size:      size,
+				Bold:      optionalBool(request, "bold"),
+				Italic:    optionalBool(request, "italic"),
+				Underline: optionalBool(request, "underline"),
+				Color:     color,
+			}
+			formatted, err = controller.ApplyToScope(target, func() error {
+				return controller.SetCharFormat(format)
+			})
+		} else if color != "" {
+			err = controller.SetFontStyle(name, size, bold, italic, underline, color)
+		} else {
+			err = controller.SetFontStyle(name, size, bold, italic, underline)
+		}
+		
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		// Generate response message with applied settings
+		formatInfo := "Font set successfully"
+		if name != "" || size > 0 {
+			if name != "" && size > 0 {
+				formatInfo = fmt.Sprintf("Font set to %s %dpt", name, size)
+			} else if name != "" {
+				formatInfo = fmt.Sprintf("Font set to %s", name)
+			} else {
+				formatInfo = fmt.Sprintf("Font size set to %dpt", size)
+			}
+		}
+		
+		var attributes []string
+		if bold {
+			attributes = append(attributes, "bold")
+		}
+		if italic {
+			attributes = append(attributes, "italic")
+		}
+		if underline {
+			attributes = append(attributes, "underline")
+		}
+		if color != "" {
+			attributes = append(attributes, fmt.Sprintf("color: %s", color))
+		}
+		
+		if len(attributes) > 0 {
+			formatInfo += fmt.Sprintf(" (%s)", strings.Join(attributes, ", "))
+		}
+		formatInfo += describeScope(target, formatted)
+
+		result = hwp.CreateTextResult(formatInfo)
+	})
+
+	return result, nil
+}
+
+func HandleHwpImportTextFile(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	path := request.GetString("path", "")
+	if path == "" {
+		return hwp.CreateTextResult("Error: File path is required"), nil
+	}
+	preserveLinebreaks := request.GetBool("preserve_linebreaks", true)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: File not found: %s", path)), nil
+	}
+	if info.Size() > maxImportTextSize {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %s is %d bytes; text files over %d MB are not imported", path, info.Size(), maxImportTextSize>>20)), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: Failed to read %s - %v", path, err)), nil
+	}
+	text, encoding, err := hwp.DecodeText(data, request.GetString("encoding", "auto"))
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	text = strings.TrimSuffix(text, "\n")
+	if strings.TrimSpace(text) == "" {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %s has no text", path)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := controller.InsertText(text, preserveLinebreaks); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Inserted %d characters in %d lines from %s (%s)",
+			utf8.RuneCountInString(text), strings.Count(text, "\n")+1, path, strings.ToUpper(encoding)))
+	})
+
+	return result, nil
+}
+
+func HandleHwpInsertCodeBlock(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	block := hwp.CodeBlock{
+		Code:        request.GetString("code", ""),
+		Font:        request.GetString("font", ""),
+		Size:        request.GetInt("size", 0),
+		Background:  request.GetString("background", ""),
+		LineNumbers: request.GetBool("line_numbers", false),
+		TabWidth:    request.GetInt("tab_width", 0),
+	}
+	if strings.TrimSpace(block.Code) == "" {
+		return hwp.CreateTextResult("Error: Code is required"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := controller.InsertCodeBlock(block); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Code block with %d lines inserted", len(block.CodeLines())))
+	})
+
+	return result, nil
+}
+
+func HandleHwpInsertCallout(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	callout := hwp.Callout{
+		Text:     request.GetString("text", ""),
+		Variant:  request.GetString("variant", "info"),
+		Title:    request.GetString("title", ""),
+		IndentMM: request.GetFloat("indent", 5),
+	}
+	if strings.TrimSpace(callout.Text) == "" {
+		return hwp.CreateTextResult("Error: Text is required"), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := controller.InsertCallout(callout); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("%s callout inserted", callout.Variant))
+	})
+
+	return result, nil
+}
+
+func HandleHwpInsertParagraph(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		err := controller.InsertParagraph()
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult("Paragraph inserted successfully")
+	})
+
+	return result, nil
+}
+
+// batchOperation is one validated step of hwp_batch_operations
+type batchOperation struct {
+	Type               string
+	Text               string
+	PreserveLinebreaks bool
+	Name               string
+	Size               int
+	Bold               bool
+	Italic             bool
+	Underline          bool
+	Color              string
+	Rows               int
+	Cols               int
+}
+
+// batchOperationTypes are the operation types of hwp_batch_operations
+var batchOperationTypes = []string{"insert_text", "insert_paragraph", "set_font", "insert_table"}
+
+// parseBatchOperations validates the operations argument
+func parseBatchOperations(request mcp.CallToolRequest) ([]batchOperation, error) {
+	items, ok, err := objectArrayArgument(request, "operations")
+	if err != nil {
+		return nil, err
+	}
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("Operations list is required")
+	}
+
+	operations := make([]batchOperation, 0, len(items))
+	for i, item := range items {
+		fields := &fieldReader{path: fmt.Sprintf("operations[%d]", i), object: item}
+		// Models write "Insert_Text" or "insert-text" as often as insert_text
+		op := batchOperation{Type: normalizeOperationType(fields.RequiredString("type"))}
+
+		switch op.Type {
+		case "insert_text":
+			op.Text = fields.RequiredString("text")
+			op.PreserveLinebreaks = fields.Bool("preserve_linebreaks")
+		case "insert_paragraph":
+		case "set_font":
+			op.Name = fields.String("name")
+			op.Size = fields.Int("size")
+			op.Bold = fields.Bool("bold")
+			op.Italic = fields.Bool("italic")
+			op.Underline = fields.Bool("underline")
+			op.Color = fields.String("color")
+		case "insert_table":
+			op.Rows = fields.PositiveInt("rows")
+			op.Cols = fields.PositiveInt("cols")
+		case "":
+		default:
+			fields.fail("type", "unknown operation type %q (use %s)", op.Type, strings.Join(batchOperationTypes, ", "))
+		}
+
+		if fields.err != nil {
+			return nil, fields.err
+		}
+		operations = append(operations, op)
+	}
+	return operations, nil
+}
+
+// normalizeOperationType lower-cases an operation type and turns spaces and
+// dashes into underscores
+func normalizeOperationType(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer("-", "_", " ", "_").Replace(name)
+}
+
+func HandleHwpBatchOperations(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Validate every operation before running any of them
+	operations, err := parseBatchOperations(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		var results []string
+		for i, op := range operations {
+			var err error
+			switch op.Type {
+			case "insert_text":
+				err = controller.InsertText(op.Text, op.PreserveLinebreaks)
+			case "insert_paragraph":
+				err = controller.InsertParagraph()
+			case "set_font":
+				if op.Color != "" {
+					err = controller.SetFontStyle(op.Name, op.Size, op.Bold, op.Italic, op.Underline, op.Color)
+				} else {
+					err = controller.SetFontStyle(op.Name, op.Size, op.Bold, op.Italic, op.Underline)
+				}
+			case "insert_table":
+				err = controller.InsertTable(op.Rows, op.Cols)
+			}
+
+			if err != nil {
+				results = append(results, fmt.Sprintf("Operation %d (%s): Error - %v", i+1, op.Type, err))
+			} else {
+				results = append(results, fmt.Sprintf("Operation %d (%s): Success", i+1, op.Type))
+			}
+		}
+
+		resultJSON, _ := json.Marshal(map[string]interface{}{
+			"total_operations": len(operations),
+			"results":          results,
+		})
+		result = hwp.CreateTextResult(string(resultJSON))
+	})
+
+	return result, nil
+}
+
+func HandleHwpCreateDocumentFromText(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	content := request.GetString("content", "")
+	if content == "" {
+		return hwp.CreateTextResult("Error: Content is required"), nil
+	}
+
+	title := request.GetString("title", "")
+	fontName := request.GetString("font_name", "맑은 고딕")
+	fontSize := request.GetInt("font_size", 11)
+	preserveFormatting := request.GetBool("preserve_formatting", true)
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil {
+			controller = hwp.NewController()
+			hwp.SetGlobalController(controller)
+		}
+
+		// Create new document
+		err := controller.CreateNewDocument()
+		if err != nil {
+			hwp.SetGlobalController(nil)
+			result = hwp.CreateTextResult(fmt.Sprintf("Error creating document: %v", err))
+			return
+		}
+
+		// Set default font
+		err = controller.SetFontStyle(fontName, fontSize, false, false, false)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error setting font: %v", err))
+			return
+		}
+
+		// Insert title if provided
+		if title != "" {
+			err = controller.SetFontStyle(fontName, fontSize+4, true, false, false)
+			if err != nil {
+				result = hwp.CreateTextResult(fmt.Sprintf("Error setting title font: %v", err))
+				return
+			}
+
+			err = controller.InsertText(title, false)
+			if err != nil {
+				result = hwp.CreateTextResult(fmt.Sprintf("Error inserting title: %v", err))
+				return
+			}
+
+			err = controller.InsertParagraph()
+			if err != nil {
+				result = hwp.CreateTextResult(fmt.Sprintf("Error inserting paragraph: %v", err))
+				return
+			}
+
+			err = controller.InsertParagraph()
+			if err != nil {
+				result = hwp.CreateTextResult(fmt.Sprintf("Error inserting paragraph: %v", err))
+				return
+			}
+
+			// Reset font to normal
+			err = controller.SetFontStyle(fontName, fontSize, false, false, false)
+			if err != nil {
+				result = hwp.CreateTextResult(fmt.Sprintf("Error resetting font: %v", err))
+				return
+			}
+		}
+
+		// Insert content
+		err = controller.InsertText(content, preserveFormatting)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error inserting content: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult("Document created successfully from text")
+	})
+
+	return result, nil
+}
+
+func HandleHwpInsertImage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	path := request.GetString("path", "")
+	if path == "" {
+		return hwp.CreateTextResult("Error: Image path is required"), nil
+	}
+
+	// Extract parameters with Python-like defaults
+	var width, height, maxWidth, maxHeight *int
+	var scale *float64
+	
+	if w := request.GetInt("width", 0); w > 0 {
+		width = &w
+	}
+	if h := request.GetInt("height", 0); h > 0 {
+		height = &h
+	}
+	if mw := request.GetInt("max_width", 0); mw > 0 {
+		maxWidth = &mw
+	}
+	if mh := request.GetInt("max_height", 0); mh > 0 {
+		maxHeight = &mh
+	}
+	if s := request.GetInt("scale", 0); s > 0 {
+		scaleFloat := float64(s)
+		scale = &scaleFloat
+	}
+	
+	useOriginalSize := request.GetBool("use_original_size", true)  // Default to original size
+	keepAspectRatio := request.GetBool("keep_aspect_ratio", false)
+	embedded := request.GetBool("embedded", true)
+	reverse := request.GetBool("reverse", false)
+	watermark := request.GetBool("watermark", false)
+	effect := request.GetInt("effect", 0)
+	altText := request.GetString("alt_text", "")
+	placement, err := imagePlacementArgument(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	transform, err := imageTransformArgument(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	compression, err := imageCompressionArgument(request)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		err := controller.InsertImage(path, width, height, useOriginalSize, maxWidth, maxHeight, scale, keepAspectRatio, embedded, reverse, watermark, effect, altText, placement, transform, compression)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		// Generate size info string (like Python version)
+		var sizeInfo string
+		if useOriginalSize {
+			sizeInfo = "original size"
+		} else if keepAspectRatio {
+			if scale != nil {
+				sizeInfo = fmt.Sprintf("scale %.2fx (aspect ratio maintained)", *scale)
+			} else if maxWidth != nil || maxHeight != nil {
+				var maxInfo []string
+				if maxWidth != nil {
+					maxInfo = append(maxInfo, fmt.Sprintf("max_width=%d", *maxWidth))
+				}
+				if maxHeight != nil {
+					maxInfo = append(maxInfo, fmt.Sprintf("max_height=%d", *maxHeight))
+				}
+				sizeInfo = fmt.Sprintf("%s (aspect ratio maintained)", strings.Join(maxInfo, ", "))
+			} else {
+				sizeInfo = "aspect ratio maintained"
+			}
+		} else {
+			var actualWidth, actualHeight string
+			if width != nil {
+				actualWidth = fmt.Sprintf("%d", *width)
+			} else {
+				actualWidth = "auto"
+			}
+			if height != nil {
+				actualHeight = fmt.Sprintf("%d", *height)
+			} else {
+				actualHeight = "auto"
+			}
+			sizeInfo = fmt.Sprintf("%sx%s", actualWidth, actualHeight)
+		}
+
+		// Generate effect info
+		effectNames := []string{"normal", "grayscale", "black&white"}
+		var effectInfo string
+		if effect >= 0 && effect < len(effectNames) {
+			effectInfo = effectNames[effect]
+		} else {
+			effectInfo = "unknown"
+		}
+
+		// Generate options info
+		var options []string
+		if reverse {
+			options = append(options, "reversed")
+		}
+		if watermark {
+			options = append(options, "watermark")
+		}
+		if !embedded {
+			options = append(options, "linked")
+		}
+		if altText != "" {
+			options = append(options, fmt.Sprintf("alt text %q", altText))
+		}
+		if placement != nil {
+			options = append(options, describePlacement(*placement))
+		}
+		if transform != nil {
+			options = append(options, describeTransform(*transform))
+		}
+		if compression != nil {
+			options = append(options, "downscaled if larger than "+describeCompression(*compression))
+		}
+
+		var optionsInfo string
+		if len(options) > 0 {
+			optionsInfo = fmt.Sprintf(", %s", strings.Join(options, ", "))
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Image inserted successfully: %s (%s, %s effect%s)",
+			path, sizeInfo, effectInfo, optionsInfo))
+	})
+
+	return result, nil
+}
+
+func HandleHwpInsertDateStamp(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	style := request.GetString("format", "long")
+	era := request.GetString("era", "none")
+	withWeekday := request.GetBool("weekday", false)
+	withTime := request.GetBool("time", false)
+	live := request.GetBool("live", false)
+	dateStr := request.GetString("date", "")
+
+	// A live field is formatted by HWP's date code, which these options
+	// can't reach, so they are refused rather than silently dropped
+	if live {
+		var given []string
+		if request.GetString("format", "") != "" {
+			given = append(given, "format")
+		}
+		if era != "none" {
+			given = append(given, "era")
+		}
+		if withWeekday {
+			given = append(given, "weekday")
+		}
+		if withTime {
+			given = append(given, "time")
+		}
+		if dateStr != "" {
+			given = append(given, "date")
+		}
+		if len(given) > 0 {
+			return hwp.CreateTextResult(fmt.Sprintf("Error: live can't be combined with %s; a live date field always shows today in HWP's date code format. Leave them out or set live to false",
+				strings.Join(given, ", "))), nil
+		}
+	}
+
+	stamp := time.Now()
+	if dateStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", dateStr, time.Local)
+		if err != nil {
+			return hwp.CreateTextResult(fmt.Sprintf("Error: Invalid date %q (use YYYY-MM-DD)", dateStr)), nil
+		}
+		stamp = parsed
+	}
+
+	text, err := hwp.FormatKoreanDate(stamp, style, era, withWeekday, withTime)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if live {
+			if err := controller.InsertDateCode(); err != nil {
+				result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+				return
+			}
+			result = hwp.CreateTextResult("Live date field inserted (uses HWP's date code format)")
+			return
+		}
+
+		if err := controller.InsertText(text, false); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Date inserted: %s", text))
+	})
+
+	return result, nil
+}
+
+func HandleHwpInsertSymbol(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	codePoint := request.GetString("code_point", "")
+	category := request.GetString("category", "")
+	name := request.GetString("name", "")
+	count := request.GetInt("count", 1)
+
+	var symbol string
+	var err error
+	switch {
+	case codePoint != "":
+		symbol, err = hwp.ParseCodePoint(codePoint)
+	case category != "":
+		symbol, err = hwp.LookupSymbol(category, name)
+	default:
+		return hwp.CreateTextResult("Error: Either code_point or category is required"), nil
+	}
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	if count < 1 {
+		count = 1
+	}
+	text := strings.Repeat(symbol, count)
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := controller.InsertText(text, false); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Symbol inserted: %s (U+%04X)", text, []rune(symbol)[0]))
+	})
+
+	return result, nil
+}
+
+// numberStyles are the styles hwp_format_number writes
+var numberStyles = []string{"comma", "korean", "amount", "mixed"}
+
+// formatNumberStyle formats value in style: comma (1,234,000), korean
+// (백이십삼만사천), amount (금 일백이십삼만사천원정) or mixed (123만 4,000).
+// The Korean styles need a whole number that fits an int64, i.e. below 2⁶³
+// (about 9.2×10¹⁸) either way; decimals applies to comma only, negative
+// keeping the digits as given.
+func formatNumberStyle(value float64, style string, decimals int) (string, error) {
+	if style == "" || style == "comma" {
+		return hwp.FormatNumber(value, decimals), nil
+	}
+	if value != math.Trunc(value) || math.Abs(value) >= math.MaxInt64 {
+		return "", fmt.Errorf("the %s style needs a whole number", style)
+	}
+	n := int64(value)
+	switch style {
+	case "korean":
+		return hwp.KoreanNumberWords(n, false), nil
+	case "amount":
+		if n < 0 {
+			return "", fmt.Errorf("an amount must not be negative")
+		}
+		return hwp.KoreanAmount(n), nil
+	case "mixed":
+		return hwp.KoreanMixedNumber(n), nil
+	}
+	return "", fmt.Errorf("invalid style %q (use %s)", style, strings.Join(numberStyles, ", "))
+}
+
+func HandleHwpFormatNumber(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	value, err := request.RequireFloat("value")
+	if err != nil || math.IsInf(value, 0) || math.IsNaN(value) {
+		return hwp.CreateTextResult("Error: Value must be a number"), nil
+	}
+	style := strings.ToLower(request.GetString("style", "comma"))
+	decimals := request.GetInt("decimals", -1)
+	figures := request.GetBool("with_figures", false)
+	insert := request.GetBool("insert", false)
+
+	text, err := formatNumberStyle(value, style, decimals)
+	if err != nil {
+		return hwp.CreateTextResult(fmt.Sprintf("Error: %v", err)), nil
+	}
+	if figures && style == "amount" {
+		text += fmt.Sprintf(" (₩%s)", hwp.FormatNumber(value, 0))
+	} else if figures && style != "comma" {
+		text += fmt.Sprintf(" (%s)", hwp.FormatNumber(value, -1))
+	}
+
+	if !insert {
+		return hwp.CreateTextResult(text), nil
+	}
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		if err := controller.InsertText(text, false); err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Number inserted: %s", text))
+	})
+
+	return result, nil
+}
+
+func HandleHwpFind(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	text := request.GetString("text", "")
+	if text == "" {
+		return hwp.CreateTextResult("Error: Text is required"), nil
+	}
+	regex := request.GetBool("regex", false)
+	matchCase := request.GetBool("match_case", false)
+	maxResults := request.GetInt("max_results", 100)
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		matches, err := controller.Find(text, regex, matchCase, maxResults)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		resultJSON, _ := json.Marshal(map[string]interface{}{
+			"query":         text,
+			"regex":         regex,
+			"total_matches": len(matches),
+			"truncated":     maxResults > 0 && len(matches) == maxResults,
+			"matches":       matches,
+		})
+		result = hwp.CreateTextResult(string(resultJSON))
+	})
+
+	return result, nil
+}
+
+func HandleHwpGotoMatch(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	index := request.GetInt("index", -1)
+
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		match, err := controller.GotoMatch(index)
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+
+		result = hwp.CreateTextResult(fmt.Sprintf("Match %d selected: %q (paragraph %d, position %d)", match.Index, match.Text, match.Para, match.Pos))
+	})
+
+	return result, nil
+}
+
+func HandleHwpGetSelectionText(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var result *mcp.CallToolResult
+
+	hwp.ExecuteHWPOperation(func() {
+		controller := hwp.GetGlobalController()
+		if controller == nil || !controller.HasDocument() {
+			result = hwp.CreateTextResult("Error: No HWP document is open. Please create or open a document first.")
+			return
+		}
+
+		selection, ok, err := controller.GetSelection()
+		if err != nil {
+			result = hwp.CreateTextResult(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		if !ok {
+			result = hwp.CreateTextResult("Error: Nothing is selected")
+			return
+		}
+
+		resultJSON, _ := json.Marshal(selection)
+		result = hwp.CreateTextResult(string(resultJSON))
+	})
+
+	return result, nil
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// Payloads below are shaped like real model output: numbers quoted, types in
+// the wrong case, optional fields blank or null.
+
+func TestParseBatchOperationsCoercesMangledFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		operations string
+		want       batchOperation
+	}{
+		{
+			name:       "quoted size",
+			operations: `[{"type": "set_font", "size": "12"}]`,
+			want:       batchOperation{Type: "set_font", Size: 12},
+		},
+		{
+			name:       "padded size and whole float",
+			operations: `[{"type": "set_font", "size": " 14.0 "}]`,
+			want:       batchOperation{Type: "set_font", Size: 14},
+		},
+		{
+			name:       "quoted booleans",
+			operations: `[{"type": "set_font", "bold": "true", "italic": "False", "underline": "1"}]`,
+			want:       batchOperation{Type: "set_font", Bold: true, Underline: true},
+		},
+		{
+			name:       "blank and null optional fields",
+			operations: `[{"type": "set_font", "name": "바탕", "size": "", "bold": null}]`,
+			want:       batchOperation{Type: "set_font", Name: "바탕"},
+		},
+		{
+			name:       "quoted table size",
+			operations: `[{"type": "insert_table", "rows": "3", "cols": "4"}]`,
+			want:       batchOperation{Type: "insert_table", Rows: 3, Cols: 4},
+		},
+		{
+			name:       "type in other case and separators",
+			operations: `[{"type": " Insert-Table ", "rows": 2, "cols": 2.0}]`,
+			want:       batchOperation{Type: "insert_table", Rows: 2, Cols: 2},
+		},
+		{
+			name:       "quoted linebreak flag",
+			operations: `[{"type": "INSERT_TEXT", "text": "a\nb", "preserve_linebreaks": "true"}]`,
+			want:       batchOperation{Type: "insert_text", Text: "a\nb", PreserveLinebreaks: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var decoded interface{}
+			if err := json.Unmarshal([]byte(test.operations), &decoded); err != nil {
+				t.Fatal(err)
+			}
+			// Both the structured and the JSON-in-string form must parse alike
+			for _, value := range []interface{}{decoded, test.operations} {
+				operations, err := parseBatchOperations(argumentRequest("operations", value))
+				if err != nil {
+					t.Fatalf("parseBatchOperations(%T): %v", value, err)
+				}
+				if len(operations) != 1 || operations[0] != test.want {
+					t.Errorf("parseBatchOperations(%T) = %+v, want %+v", value, operations, test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseBatchOperationsReportsFieldErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		operations string
+		want       []string
+	}{
+		{
+			name:       "missing table size",
+			operations: `[{"type": "insert_table"}]`,
+			want:       []string{"operations[0].rows: is required", "operations[0].cols: is required"},
+		},
+		{
+			name:       "blank row count",
+			operations: `[{"type": "insert_table", "rows": " ", "cols": 2}]`,
+			want:       []string{"operations[0].rows: is required"},
+		},
+		{
+			name:       "size in words",
+			operations: `[{"type": "insert_table", "rows": "three", "cols": "0"}]`,
+			want:       []string{`operations[0].rows: must be a whole number, got "three"`, "operations[0].cols: must be greater than 0"},
+		},
+		{
+			name:       "size with unit",
+			operations: `[{"type": "insert_text", "text": "x"}, {"type": "set_font", "size": "12pt"}]`,
+			want:       []string{`operations[1].size: must be a whole number, got "12pt"`},
+		},
+		{
+			name:       "fractional size",
+			operations: `[{"type": "set_font", "size": 10.5}]`,
+			want:       []string{"operations[0].size: must be a whole number, got 10.5"},
+		},
+		{
+			name:       "huge size",
+			operations: `[{"type": "insert_table", "rows": 1e300, "cols": 1}]`,
+			want:       []string{"operations[0].rows: must be a whole number"},
+		},
+		{
+			name:       "size as object",
+			operations: `[{"type": "insert_table", "rows": {"value": 3}, "cols": [2]}]`,
+			want:       []string{"operations[0].rows: must be a whole number, got an object", "operations[0].cols: must be a whole number, got an array"},
+		},
+		{
+			name:       "boolean in words",
+			operations: `[{"type": "set_font", "bold": "yes"}]`,
+			want:       []string{`operations[0].bold: must be a boolean, got "yes"`},
+		},
+		{
+			name:       "numeric type",
+			operations: `[{"type": 5}]`,
+			want:       []string{"operations[0].type: must be a string"},
+		},
+		{
+			name:       "unknown type",
+			operations: `[{"type": "insert_image"}]`,
+			want:       []string{`operations[0].type: unknown operation type "insert_image"`},
+		},
+		{
+			name:       "operation that is not an object",
+			operations: `[{"type": "insert_paragraph"}, "insert_paragraph"]`,
+			want:       []string{"operations[1]"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var decoded interface{}
+			if err := json.Unmarshal([]byte(test.operations), &decoded); err != nil {
+				t.Fatal(err)
+			}
+			operations, err := parseBatchOperations(argumentRequest("operations", decoded))
+			if err == nil {
+				t.Fatalf("parseBatchOperations accepted %s as %+v", test.operations, operations)
+			}
+			for _, want := range test.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %q", err, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"fmt"
+
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// themeHeadingPresets are the presets a theme's heading font and accent
+// color apply to
+var themeHeadingPresets = []string{"표지제목", "제목1", "제목2", "제목3", "강조", "머리말기관"}
+
+// documentTheme restyles the built-in document types for one call, so the
+// same spec can render in an organization's house style
+type documentTheme struct {
+	Font        string         // font of every preset
+	HeadingFont string         // font of the heading presets, over Font
+	Sizes       map[string]int // point sizes by preset name
+	AccentColor string         // color of the heading presets
+	Margins     hwp.PageSetup  // page margins in mm; nil margins are kept
+}
+
+// themeArgument reads the theme object argument; it is nil when absent
+func themeArgument(request mcp.CallToolRequest, name string) (*documentTheme, error) {
+	object, ok, err := objectArgument(request, name)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	fields := &fieldReader{path: name, object: object}
+	theme := &documentTheme{
+		Font:        fields.String("font"),
+		HeadingFont: fields.String("heading_font"),
+		AccentColor: fields.String("accent_color"),
+	}
+	if theme.AccentColor != "" {
+		if _, err := hwp.ParseColor(theme.AccentColor); err != nil {
+			fields.fail("accent_color", "%v", err)
+		}
+	}
+
+	presets := currentPresets()
+	if sizes := fields.Object("sizes"); sizes != nil {
+		theme.Sizes = map[string]int{}
+		sizeFields := &fieldReader{path: name + ".sizes", object: sizes}
+		for preset := range sizes {
+			if _, err := presets.lookup(preset); err != nil {
+				sizeFields.fail(preset, "%v", err)
+				continue
+			}
+			if size := sizeFields.Int(preset); size < 1 || size > 4096 {
+				sizeFields.fail(preset, "must be between 1 and 4096")
+			} else {
+				theme.Sizes[preset] = size
+			}
+		}
+		if sizeFields.err != nil && fields.err == nil {
+			fields.err = sizeFields.err
+		}
+	}
+
+	if margins := fields.Object("margins"); margins != nil {
+		marginFields := &fieldReader{path: name + ".margins", object: margins}
+		for _, side := range []struct {
+			key   string
+			value **float64
+		}{
+			{"left", &theme.Margins.LeftMargin},
+			{"right", &theme.Margins.RightMargin},
+			{"top", &theme.Margins.TopMargin},
+			{"bottom", &theme.Margins.BottomMargin},
+		} {
+			if mm, ok := marginFields.Float(side.key); ok {
+				if mm < 0 || mm > 100 {
+					marginFields.fail(side.key, "must be between 0 and 100 mm")
+					continue
+				}
+				*side.value = &mm
+			}
+		}
+		if marginFields.err != nil && fields.err == nil {
+			fields.err = marginFields.err
+		}
+	}
+
+	if fields.err != nil {
+		return nil, fields.err
+	}
+	return theme, nil
+}
+
+// presets returns base with the theme's fonts, sizes and accent color applied
+func (t *documentTheme) presets(base stylePresetSet) stylePresetSet {
+	themed := base.clone()
+	isHeading := map[string]bool{}
+	for _, name := range themeHeadingPresets {
+		isHeading[name] = true
+	}
+
+	for name, preset := range themed {
+		if t.Font != "" {
+			preset.Font = t.Font
+		}
+		if isHeading[name] {
+			if t.HeadingFont != "" {
+				preset.Font = t.HeadingFont
+			}
+			if t.AccentColor != "" {
+				preset.Color = t.AccentColor
+			}
+		}
+		if size, ok := t.Sizes[name]; ok {
+			preset.Size = size
+		}
+		themed[name] = preset
+	}
+	return themed
+}
+
+// applyPage sets the theme's page margins on the current document
+func (t *documentTheme) applyPage(controller *hwp.Controller) error {
+	margins := t.Margins
+	if margins.LeftMargin == nil && margins.RightMargin == nil && margins.TopMargin == nil && margins.BottomMargin == nil {
+		return nil
+	}
+	if err := controller.SetPageSetup(margins); err != nil {
+		return fmt.Errorf("failed to set theme margins: %v", err)
+	}
+	return nil
+}
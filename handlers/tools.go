@@ -0,0 +1,1750 @@
+package handlers
+
+import (
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterTools adds all HWP tools to mcpServer. Each tool is wrapped in the
+// metrics, argument validation, dry-run and read-only middleware.
+func RegisterTools(mcpServer *server.MCPServer) {
+	// Server introspection tools
+	addTool(mcpServer, mcp.NewTool(HWP_METRICS,
+		mcp.WithDescription("Get server metrics: HWP operation queue depth, calls in progress, and per-tool call counts, error rates and average/p50/p95/p99 durations"),
+		mcp.WithReadOnlyHintAnnotation(true),
+	), HandleHwpMetrics)
+
+	// Document management tools
+	addTool(mcpServer, mcp.NewTool(HWP_CREATE,
+		mcp.WithDescription("Create a new HWP document"),
+	), HandleHwpCreate)
+
+	addTool(mcpServer, mcp.NewTool(HWP_OPEN,
+		mcp.WithDescription("Open an existing HWP document"),
+		mcp.WithString("path",
+			mcp.Description("File path to open"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean("read_only",
+			mcp.Description("Open read-only; tools that edit or save the document are refused (default: false)"),
+		),
+	), HandleHwpOpen)
+
+	addTool(mcpServer, mcp.NewTool(HWP_LIST_RECENT,
+		mcp.WithDescription("List the documents recently opened or saved through this server, most recent first, with when and whether the file still exists. Use it to reopen a document without asking for its path"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of files (default: 10)"),
+			mcp.Min(1),
+		),
+		mcp.WithString("query",
+			mcp.Description("Only files whose name contains this text, ignoring case (e.g. \"보고서\")"),
+		),
+		mcp.WithString("action",
+			mcp.Description("Only files last opened or last saved"),
+			mcp.Enum("opened", "saved"),
+		),
+	), HandleHwpListRecent)
+
+	addTool(mcpServer, mcp.NewTool(HWP_LIST_FILES,
+		mcp.WithDescription("List documents in the directories the server allows (-allowed-dirs), with size and modified time, plus the subdirectories to browse into. Use it to find a file's path before hwp_open instead of guessing"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("dir",
+			mcp.Description("Directory to list, absolute or relative to the first allowed directory (default: the first allowed directory)"),
+		),
+		mcp.WithString("pattern",
+			mcp.Description("Comma-separated file name patterns, ignoring case (default: \"*.hwp, *.hwpx\"), e.g. \"*보고서*.hwp\""),
+		),
+		mcp.WithBoolean("recursive",
+			mcp.Description("Also search subdirectories (default: false)"),
+		),
+	), HandleHwpListFiles)
+
+	addTool(mcpServer, mcp.NewTool(HWP_CONVERT_FILE,
+		mcp.WithDescription("Convert a file on disk to another format without opening it as the current document: a separate hidden HWP instance opens src, saves it to dst and closes. The open document is not touched, so it suits batch conversion. Needs the com backend"),
+		mcp.WithString("src",
+			mcp.Description("File to convert (any format HWP opens: .hwp, .hwpx, .docx, .txt, ...)"),
+			mcp.Required(),
+		),
+		mcp.WithString("dst",
+			mcp.Description("Target file path"),
+			mcp.Required(),
+		),
+		mcp.WithString("format",
+			mcp.Description("Target format (default: the extension of dst)"),
+			mcp.Enum(hwp.ConvertFormats()...),
+		),
+		mcp.WithBoolean("overwrite",
+			mcp.Description("Replace dst if it exists (default: false)"),
+		),
+		DryRunOption(),
+	), HandleHwpConvertFile)
+
+	addTool(mcpServer, mcp.NewTool(HWP_PRINT_TO_PDF,
+		mcp.WithDescription("Print the current document to a PDF printer driver (Microsoft Print to PDF or Hancom PDF) that writes the given file. Use it when the PDF must match a printout: hwp_convert_file and hwp_save write PDFs with HWP's own renderer, which can lay out some documents differently. Waits until the driver has written the file. Needs the com backend"),
+		mcp.WithString("path",
+			mcp.Description("PDF file to write"),
+			mcp.Required(),
+			FilePattern(false, "pdf"),
+		),
+		mcp.WithString("printer",
+			mcp.Description("microsoft (Microsoft Print to PDF), hancom (Hancom PDF) or the name of another installed PDF printer (default: microsoft)"),
+		),
+		mcp.WithString("pages",
+			mcp.Description("Pages to print, e.g. 1-3,5 (default: all pages)"),
+		),
+		mcp.WithBoolean("overwrite",
+			mcp.Description("Replace the file if it exists (default: false)"),
+		),
+		DryRunOption(),
+	), HandleHwpPrintToPDF)
+
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_PAGE_OF_DOCUMENT,
+		mcp.WithDescription("Insert a page or page range of another HWP file at the cursor, keeping its formatting, to compose documents from a library of boilerplate pages such as terms and conditions. A separate hidden HWP instance extracts the pages, so the clipboard is not used. Needs the com backend"),
+		mcp.WithString("path",
+			mcp.Description("File to take the pages from"),
+			mcp.Required(),
+		),
+		mcp.WithNumber("from",
+			mcp.Description("First page to insert, 1-based (default: 1)"),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("to",
+			mcp.Description("Last page to insert; 0 for the last page of the file (default: from, a single page)"),
+			mcp.Min(0),
+		),
+	), HandleHwpInsertPageOfDocument)
+
+	addTool(mcpServer, mcp.NewTool(HWP_SAVE,
+		mcp.WithDescription("Save the current HWP document"),
+		mcp.WithString("path",
+			mcp.Description("File path to save (optional)"),
+		),
+		mcp.WithNumber("backups",
+			mcp.Description("Copy the file being overwritten to a backups directory beside it as name-YYYYMMDD-HHMMSS.ext, keeping this many most recent copies (default: 0, no backup)"),
+			mcp.Min(0),
+		),
+		DryRunOption(),
+	), HandleHwpSave)
+
+	addTool(mcpServer, mcp.NewTool(HWP_GET_TEXT,
+		mcp.WithDescription("Get the text content of the current document"),
+	), HandleHwpGetText)
+
+	addTool(mcpServer, mcp.NewTool(HWP_GET_PARAGRAPHS,
+		mcp.WithDescription("Get paragraphs of the body text as {\"total\": n, \"paragraphs\": [{\"index\", \"style\", \"text\"}]}. Indices count from 0 and are the para of hwp_find matches and hwp_apply_patch positions, so paragraphs can be addressed structurally; a table counts as one paragraph. Page through long documents with start_index and count"),
+		mcp.WithNumber("start_index",
+			mcp.Description("Index of the first paragraph to return (default: 0)"),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("count",
+			mcp.Description("Number of paragraphs to return (default: 50)"),
+			mcp.Min(1),
+		),
+	), HandleHwpGetParagraphs)
+
+	addTool(mcpServer, mcp.NewTool(HWP_CLOSE,
+		mcp.WithDescription("Close the HWP document and connection. Refuses to close a document with unsaved changes unless discard_changes is set"),
+		mcp.WithBoolean("discard_changes",
+			mcp.Description("Close even if the document has unsaved changes (default: false)"),
+		),
+		DryRunOption(),
+	), HandleHwpClose)
+
+	addTool(mcpServer, mcp.NewTool(HWP_REVERT,
+		mcp.WithDescription("Discard all unsaved changes by reopening the document from its last saved file. Use it to recover from a failed multi-step edit"),
+		DryRunOption(),
+	), HandleHwpRevert)
+
+	addTool(mcpServer, mcp.NewTool(HWP_GET_DOCUMENT_STATUS,
+		mcp.WithDescription("Get the current document's path, backend, read-only mode and whether it has unsaved changes"),
+	), HandleHwpGetDocumentStatus)
+
+	addTool(mcpServer, mcp.NewTool(HWP_GET_PAGE_COUNT,
+		mcp.WithDescription("Get the number of pages of the current document as {\"pages\": n}. It only reads HWP's page count, so it is cheap enough to call in pagination loops. Needs the com backend (HWPX documents are not laid out into pages)"),
+	), HandleHwpGetPageCount)
+
+	addTool(mcpServer, mcp.NewTool(HWP_RENDER_CURRENT_PAGE,
+		mcp.WithDescription("Render the page at the cursor, or another page, as HWP lays it out and return it as a PNG image, to show what the document looks like after a series of edits. Needs the com backend"),
+		mcp.WithNumber("page",
+			mcp.Description("1-based page to render (default: the page at the cursor)"),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("dpi",
+			mcp.Description("Resolution in dots per inch (default: 96; higher is sharper but larger)"),
+			mcp.Min(hwp.MinRenderDPI),
+			mcp.Max(hwp.MaxRenderDPI),
+		),
+	), HandleHwpRenderCurrentPage)
+
+	addTool(mcpServer, mcp.NewTool(HWP_EXPORT_PAGE_IMAGE,
+		mcp.WithDescription("Save the page at the cursor, or another page, as a PNG file, as HWP lays it out. The image is also returned in the result, so clients without access to the file can show it. Needs the com backend"),
+		mcp.WithString("path",
+			mcp.Description("PNG file to write"),
+			mcp.Required(),
+			FilePattern(false, "png"),
+		),
+		mcp.WithNumber("page",
+			mcp.Description("1-based page to save (default: the page at the cursor)"),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("dpi",
+			mcp.Description("Resolution in dots per inch (default: 96)"),
+			mcp.Min(hwp.MinRenderDPI),
+			mcp.Max(hwp.MaxRenderDPI),
+		),
+		mcp.WithBoolean("overwrite",
+			mcp.Description("Replace the file if it exists (default: false)"),
+		),
+		DryRunOption(),
+	), HandleHwpExportPageImage)
+
+	addTool(mcpServer, mcp.NewTool(HWP_GET_METADATA,
+		mcp.WithDescription("Get the document summary information: title, subject, author, keywords, comments, the created date stored in the summary and the file's last modified time"),
+	), HandleHwpGetMetadata)
+
+	addTool(mcpServer, mcp.NewTool(HWP_SET_METADATA,
+		mcp.WithDescription("Set document summary information (파일 > 문서 정보). Only the given fields change; the summary is stored with the document on the next save"),
+		mcp.WithString("title", mcp.Description("Document title")),
+		mcp.WithString("subject", mcp.Description("Document subject")),
+		mcp.WithString("author", mcp.Description("Author")),
+		mcp.WithString("keywords", mcp.Description("Keywords, e.g. \"보고서, 2024, 예산\"")),
+		mcp.WithString("comments", mcp.Description("Comments / description")),
+		mcp.WithString("created", mcp.Description("Created date stored in the summary, e.g. 2024-03-01")),
+	), HandleHwpSetMetadata)
+
+	addTool(mcpServer, mcp.NewTool(HWP_DIAGNOSTICS,
+		mcp.WithDescription("Run a self-test on a separate hidden instance: COM object creation, then creating a document, inserting and reading back text, saving it to a temporary directory and deleting it. Returns pass/fail and timing for each step; the open document is not touched"),
+	), HandleHwpDiagnostics)
+
+	addTool(mcpServer, mcp.NewTool(HWP_PROTECT_DOCUMENT,
+		mcp.WithDescription("Restrict editing of the current document and set or remove its open password"),
+		mcp.WithString("mode",
+			mcp.Description("Edit mode: normal, read_only, or form (only form fields are editable)"),
+			mcp.Enum("normal", "read_only", "form"),
+		),
+		mcp.WithString("password",
+			mcp.Description("Password required to open the document after it is saved"),
+		),
+		mcp.WithBoolean("remove_password",
+			mcp.Description("Remove the document password (default: false)"),
+		),
+		DryRunOption(),
+	), HandleHwpProtectDocument)
+
+	addTool(mcpServer, mcp.NewTool(HWP_CHANGE_PASSWORD,
+		mcp.WithDescription("Change or remove the open password of the current document, given its current password, e.g. to archive protected documents received from outside. Open the document first (HWP asks for the password when opening it); the change applies when the document is saved. Needs the com backend"),
+		mcp.WithString("current_password",
+			mcp.Description("The document's current password"),
+			mcp.Required(),
+		),
+		mcp.WithString("new_password",
+			mcp.Description("New password; leave out to remove the password"),
+		),
+		DryRunOption(),
+	), HandleHwpChangePassword)
+
+	addTool(mcpServer, mcp.NewTool(HWP_LIST_HYPERLINKS,
+		mcp.WithDescription("List all hyperlinks in the current document with their display text and targets"),
+	), HandleHwpListHyperlinks)
+
+	addTool(mcpServer, mcp.NewTool(HWP_VALIDATE_DOCUMENT,
+		mcp.WithDescription("Check the current document against a rule set and return {valid, violations: [{rule, message, items}], skipped}, so a pipeline can hold back a document before sending it out. Rules the backend cannot check (page counts on HWPX) are listed under skipped"),
+		mcp.WithObject("rules",
+			mcp.Description("Rules to check; each is optional"),
+			mcp.Properties(map[string]any{
+				"required_sections": map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Headings that must appear as a line of their own; numbering such as 1. 가. (1) □ is ignored"},
+				"no_placeholders":   map[string]any{"type": "boolean", "description": "Fail on {{placeholders}} left in the text (default: true)"},
+				"min_pages":         map[string]any{"type": "number", "minimum": 0},
+				"max_pages":         map[string]any{"type": "number", "minimum": 0},
+				"allowed_fonts":     map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Fonts the text may use"},
+			}),
+			mcp.Required(),
+		),
+	), HandleHwpValidateDocument)
+
+	addTool(mcpServer, mcp.NewTool(HWP_FIND_DUPLICATES,
+		mcp.WithDescription("Find paragraphs that repeat or nearly repeat an earlier paragraph, e.g. content a generator wrote twice. Returns {paragraphs, threshold, duplicates: [{index, duplicate_of, similarity, text}]} with hwp_get_paragraphs indices, so the repeats can be removed with hwp_delete_paragraphs"),
+		mcp.WithNumber("threshold",
+			mcp.Description("Similarity from 0 to 1 at which a paragraph counts as a duplicate; 1 finds exact repeats only, ignoring case and spacing (default: 0.9)"),
+			mcp.Min(0),
+			mcp.Max(1),
+		),
+		mcp.WithNumber("min_length",
+			mcp.Description("Ignore paragraphs shorter than this many characters, such as blank lines and short headings (default: 10)"),
+			mcp.Min(2),
+		),
+	), HandleHwpFindDuplicates)
+
+	addTool(mcpServer, mcp.NewTool(HWP_EXPORT_MODEL,
+		mcp.WithDescription("Export the current document as a structured JSON model: paragraphs with styled runs (font, size, bold, italic, underline, color), tables, images and page breaks. Edit it offline and rebuild with hwp_import_model"),
+		mcp.WithString("path",
+			mcp.Description("File to write the model to; the model is returned directly if omitted"),
+			FilePattern(false, "json"),
+		),
+	), HandleHwpExportModel)
+
+	addTool(mcpServer, mcp.NewTool(HWP_IMPORT_MODEL,
+		mcp.WithDescription("Build a document from a JSON model produced by hwp_export_model. Section layout, cell merges and image placement are not part of the model"),
+		mcp.WithString("model",
+			mcp.Description("Document model JSON: {\"version\": 1, \"blocks\": [{\"type\": \"paragraph\", \"align\": \"center\", \"runs\": [{\"text\": \"...\", \"bold\": true}]}, {\"type\": \"table\", \"rows\": [[\"a\", \"b\"]]}, {\"type\": \"image\", \"source\": \"path\", \"alt\": \"description\"}, {\"type\": \"page_break\"}]}"),
+		),
+		mcp.WithString("path",
+			mcp.Description("File to read the model from when model is omitted"),
+			FilePattern(false, "json"),
+		),
+		mcp.WithBoolean("new_document",
+			mcp.Description("Create a new document for the model; false inserts it at the cursor (default: true)"),
+		),
+		DryRunOption(),
+	), HandleHwpImportModel)
+
+	// Text manipulation tools
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_TEXT,
+		mcp.WithDescription("Insert text at the current cursor position"),
+		mcp.WithString("text",
+			mcp.Description("Text to insert"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean("preserve_linebreaks",
+			mcp.Description("Preserve line breaks in text"),
+		),
+	), HandleHwpInsertText)
+
+	addTool(mcpServer, mcp.NewTool(HWP_IMPORT_TEXT_FILE,
+		mcp.WithDescription("Insert the contents of a local text file at the cursor. Use it for long text instead of passing it through hwp_insert_text. The encoding is detected (byte order mark, UTF-8, else CP949) unless given; bytes that aren't valid in the encoding are an error rather than garbled Hangul"),
+		mcp.WithString("path",
+			mcp.Description("Text file to insert"),
+			mcp.Required(),
+		),
+		mcp.WithString("encoding",
+			mcp.Description("File encoding (default: auto). cp949 and euc-kr need Windows"),
+			mcp.Enum(hwp.TextEncodings...),
+		),
+		mcp.WithBoolean("preserve_linebreaks",
+			mcp.Description("Start a paragraph at each line break (default: true)"),
+		),
+	), HandleHwpImportTextFile)
+
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_CODE_BLOCK,
+		mcp.WithDescription("Insert preformatted code as a block: monospace font on a light shaded background, indentation and blank lines kept, tabs expanded to spaces, optional line numbers. The cursor ends after the block and the body font is unchanged"),
+		mcp.WithString("code",
+			mcp.Description("Code to insert; each line break starts a new line of code"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean("line_numbers",
+			mcp.Description("Number the lines (default: false)"),
+		),
+		mcp.WithString("font",
+			mcp.Description("Monospace font (default: 굴림체; e.g. D2Coding, Consolas)"),
+		),
+		mcp.WithNumber("size",
+			mcp.Description("Font size in points (default: 9)"),
+			mcp.Min(1),
+			mcp.Max(100),
+		),
+		mcp.WithString("background",
+			mcp.Description("Background color name or #RRGGBB (default: #F2F2F2)"),
+		),
+		mcp.WithNumber("tab_width",
+			mcp.Description("Spaces per tab stop (default: 4)"),
+			mcp.Min(1),
+			mcp.Max(16),
+		),
+	), HandleHwpInsertCodeBlock)
+
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_CALLOUT,
+		mcp.WithDescription("Insert a callout: an indented box with a colored border and shaded background, headed by an icon and a bold title, for notes and warnings in manuals. The cursor ends after the box and the body font is unchanged"),
+		mcp.WithString("text",
+			mcp.Description("Callout text; each line break starts a new paragraph"),
+			mcp.Required(),
+		),
+		mcp.WithString("variant",
+			mcp.Description("Look of the callout: info (blue, 참고), warning (orange, 주의) or note (gray, 메모) (default: info)"),
+			mcp.Enum("info", "warning", "note"),
+		),
+		mcp.WithString("title",
+			mcp.Description("Title replacing the variant label, e.g. 경고 or 팁"),
+		),
+		mcp.WithNumber("indent",
+			mcp.Description("Left indent of the box in mm (default: 5)"),
+			mcp.Min(0),
+			mcp.Max(50),
+		),
+	), HandleHwpInsertCallout)
+
+	addTool(mcpServer, mcp.NewTool(HWP_SET_FONT, append([]mcp.ToolOption{
+		mcp.WithDescription("Set font properties with color support. By default they apply at the cursor, to text typed next (or to the selection); use scope to format text already in the document"),
+		mcp.WithString("name",
+			mcp.Description("Font name"),
+		),
+		mcp.WithNumber("size",
+			mcp.Description("Font size"),
+			mcp.Min(1),
+		),
+		mcp.WithBoolean("bold",
+			mcp.Description("Bold font"),
+		),
+		mcp.WithBoolean("italic",
+			mcp.Description("Italic font"),
+		),
+		mcp.WithBoolean("underline",
+			mcp.Description("Underline font"),
+		),
+		mcp.WithString("color",
+			mcp.Description("Text color (black, white, gray, red, blue, green, yellow, purple, cyan, or #RRGGBB)"),
+		),
+	}, formatScopeOptions("Bold, italic and underline left out keep their current setting outside the cursor scope")...)...), HandleHwpSetFont)
+
+	addTool(mcpServer, mcp.NewTool(HWP_FORMAT_MATCHES,
+		mcp.WithDescription("Find every occurrence of a text or regular expression in the document, tables included, and apply character formatting to each, e.g. bold every mention of a product name. Settings left out keep their current value. Returns how many occurrences were formatted; the cursor is put back afterwards"),
+		mcp.WithString("text",
+			mcp.Description("Text to find"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean("regex",
+			mcp.Description("Treat text as an HWP regular expression (default: false)"),
+		),
+		mcp.WithBoolean("match_case",
+			mcp.Description("Match upper/lower case exactly (default: false)"),
+		),
+		mcp.WithBoolean("bold",
+			mcp.Description("Make the matches bold (true) or not bold (false)"),
+		),
+		mcp.WithBoolean("italic",
+			mcp.Description("Make the matches italic (true) or not italic (false)"),
+		),
+		mcp.WithBoolean("underline",
+			mcp.Description("Underline the matches (true) or remove the underline (false)"),
+		),
+		mcp.WithString("color",
+			mcp.Description("Text color (black, white, gray, red, blue, green, yellow, purple, cyan, or #RRGGBB)"),
+		),
+		mcp.WithString("highlight",
+			mcp.Description("Highlight (background) color, same names as color"),
+		),
+		mcp.WithString("font",
+			mcp.Description("Font name"),
+		),
+		mcp.WithNumber("size",
+			mcp.Description("Font size"),
+			mcp.Min(1),
+		),
+	), HandleHwpFormatMatches)
+
+	addTool(mcpServer, mcp.NewTool(HWP_APPLY_PRESET,
+		mcp.WithDescription("Apply a named style preset (font, size, bold, color and paragraph spacing) for following text. Built-in presets: 표지제목, 표지부제목, 표지정보, 제목1, 제목2, 제목3, 강조, 본문, 머리말기관, 머리말주소, 결문; the server's presets file can change them and add more. The built-in document types use the same presets"),
+		mcp.WithString("name",
+			mcp.Description("Preset name, e.g. 제목1, 본문 or 강조"),
+			mcp.Required(),
+		),
+	), HandleHwpApplyPreset)
+
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_PARAGRAPH,
+		mcp.WithDescription("Insert a new paragraph"),
+	), HandleHwpInsertParagraph)
+
+	addTool(mcpServer, mcp.NewTool(HWP_BATCH_OPERATIONS,
+		mcp.WithDescription("Execute multiple HWP operations in sequence"),
+		mcp.WithArray("operations",
+			mcp.Description("Operations to execute in order. All operations are validated before any runs"),
+			mcp.Required(),
+			mcp.Items(batchOperationSchema),
+		),
+	), HandleHwpBatchOperations)
+
+	addTool(mcpServer, mcp.NewTool(HWP_CREATE_DOCUMENT_FROM_TEXT,
+		mcp.WithDescription("Create a new document from text content"),
+		mcp.WithString("content",
+			mcp.Description("Text content for the document"),
+			mcp.Required(),
+		),
+		mcp.WithString("title",
+			mcp.Description("Document title (optional)"),
+		),
+		mcp.WithString("font_name",
+			mcp.Description("Font name (default: 맑은 고딕)"),
+		),
+		mcp.WithNumber("font_size",
+			mcp.Description("Font size (default: 11)"),
+			mcp.Min(1),
+		),
+		mcp.WithBoolean("preserve_formatting",
+			mcp.Description("Preserve line breaks and formatting"),
+		),
+	), HandleHwpCreateDocumentFromText)
+
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_DATE_STAMP,
+		mcp.WithDescription("Insert the current (or given) date formatted per Korean conventions, as text or a live date field"),
+		mcp.WithString("format",
+			mcp.Description("Date style: long (2025년 1월 15일), dot (2025. 1. 15.), iso (2025-01-15) (default: long)"),
+			mcp.Enum("long", "dot", "iso"),
+		),
+		mcp.WithString("era",
+			mcp.Description("Year era prefix: none, seogi (서기), dangi (단기) (default: none)"),
+			mcp.Enum("none", "seogi", "dangi"),
+		),
+		mcp.WithBoolean("weekday",
+			mcp.Description("Append the weekday, e.g. (수) (default: false)"),
+		),
+		mcp.WithBoolean("time",
+			mcp.Description("Append the time, e.g. 오후 3시 20분 (default: false)"),
+		),
+		mcp.WithString("date",
+			mcp.Description("Date to format as YYYY-MM-DD instead of today (optional)"),
+		),
+		mcp.WithBoolean("live",
+			mcp.Description("Insert an auto-updating date field instead of static text, shown in HWP's date code format; it can't be combined with format, era, weekday, time or date (default: false)"),
+		),
+	), HandleHwpInsertDateStamp)
+
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_SYMBOL,
+		mcp.WithDescription("Insert a special symbol by Unicode code point or by category, e.g. circled numbers (①②), box drawing, currency, reference marks (※)"),
+		mcp.WithString("code_point",
+			mcp.Description("Unicode code point such as U+2460; takes precedence over category"),
+		),
+		mcp.WithString("category",
+			mcp.Description("Symbol category. Numbered: circled_number, parenthesized_number, number_period, circled_hangul, parenthesized_hangul, circled_latin, roman_upper, roman_lower. Named: box, currency, mark, arrow"),
+			mcp.Enum(hwp.SymbolCategories()...),
+		),
+		mcp.WithString("name",
+			mcp.Description("Number for numbered categories (e.g. 3 for ③) or symbol name for named ones (e.g. won, reference, top_left, right)"),
+		),
+		mcp.WithNumber("count",
+			mcp.Description("Number of times to repeat the symbol (default: 1)"),
+			mcp.Min(1),
+		),
+	), HandleHwpInsertSymbol)
+
+	addTool(mcpServer, mcp.NewTool(HWP_FORMAT_NUMBER,
+		mcp.WithDescription("Format a number per Korean conventions and return it, optionally inserting it at the cursor: comma grouping, 한글 숫자, amounts in words for invoices and contracts (금 일백만원정), or digits grouped by 만/억"),
+		mcp.WithNumber("value",
+			mcp.Required(),
+			mcp.Description("Number to format"),
+		),
+		mcp.WithString("style",
+			mcp.Description("comma (1,200,000), korean (백이십만), amount (금 일백이십만원정) or mixed (120만) (default: comma)"),
+			mcp.Enum(numberStyles...),
+		),
+		mcp.WithNumber("decimals",
+			mcp.Description("Decimal places for the comma style; omit to keep the digits as given"),
+			mcp.Min(0),
+		),
+		mcp.WithBoolean("with_figures",
+			mcp.Description("Append the number in digits, e.g. 금 일백이십만원정 (₩1,200,000) (default: false)"),
+		),
+		mcp.WithBoolean("insert",
+			mcp.Description("Insert the formatted text at the cursor instead of only returning it (default: false)"),
+		),
+	), HandleHwpFormatNumber)
+
+	addTool(mcpServer, mcp.NewTool(HWP_FIND,
+		mcp.WithDescription("Find all occurrences of text without changing the document. Returns each match's position (para/pos), page and surrounding context; use hwp_goto_match to select one"),
+		mcp.WithString("text",
+			mcp.Description("Text or regular expression to search for"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean("regex",
+			mcp.Description("Treat text as an HWP regular expression (default: false)"),
+		),
+		mcp.WithBoolean("match_case",
+			mcp.Description("Match upper/lower case exactly (default: false)"),
+		),
+		mcp.WithNumber("max_results",
+			mcp.Description("Maximum number of matches to return, 0 for no limit (default: 100)"),
+			mcp.Min(0),
+		),
+	), HandleHwpFind)
+
+	addTool(mcpServer, mcp.NewTool(HWP_GOTO_MATCH,
+		mcp.WithDescription("Move the cursor to a match from the last hwp_find and select it, so following edits apply to it. Positions go stale once the document is edited; run hwp_find again after changes"),
+		mcp.WithNumber("index",
+			mcp.Description("Match index from hwp_find (0-based)"),
+			mcp.Required(),
+			mcp.Min(0),
+		),
+	), HandleHwpGotoMatch)
+
+	addTool(mcpServer, mcp.NewTool(HWP_GET_SELECTION_TEXT,
+		mcp.WithDescription("Get the currently selected text with its position (list, paragraph, character offset) and page, e.g. to work with what the user highlighted in HWP"),
+	), HandleHwpGetSelectionText)
+
+	// Formatting tools
+	addTool(mcpServer, mcp.NewTool(HWP_SET_OUTLINE_NUMBERING,
+		mcp.WithDescription("Configure outline (heading) numbering for the current section"),
+		mcp.WithString("scheme",
+			mcp.Description("Numbering scheme: decimal (1. / 1.1 / 1.1.1), korean (1. / 가. / 1) / 가) ...), hangul (가. / 1) / 가) ...) (default: decimal)"),
+			mcp.Enum("decimal", "korean", "hangul"),
+		),
+		mcp.WithString("levels",
+			mcp.Description("Custom JSON array of up to 7 levels, e.g. [{\"format\":\"^1.\",\"type\":\"digit\"},{\"format\":\"^2.\",\"type\":\"hangul\"}]. Types: digit, circled_digit, roman_upper, roman_lower, latin_upper, latin_lower, hangul, circled_hangul, hangul_jamo, hangul_number, hanja_number"),
+		),
+	), HandleHwpSetOutlineNumbering)
+
+	addTool(mcpServer, mcp.NewTool(HWP_APPLY_HEADING,
+		mcp.WithDescription("Apply the outline heading style (개요 1-7) to the current paragraph so it is numbered automatically"),
+		mcp.WithNumber("level",
+			mcp.Description("Heading level (1-7)"),
+			mcp.Required(),
+			mcp.Min(1),
+			mcp.Max(7),
+		),
+	), HandleHwpApplyHeading)
+
+	addTool(mcpServer, mcp.NewTool(HWP_SET_SPACING, append([]mcp.ToolOption{
+		mcp.WithDescription("Set line spacing, paragraph spacing, first-line indent and drop cap of the current paragraph or selection, or of the paragraphs a scope names"),
+		mcp.WithString("preset",
+			mcp.Description("Line spacing preset: single (100%), 1.15, 1.5, 160% (HWP default), double (200%)"),
+			mcp.Enum("single", "1.15", "1.5", "160%", "double"),
+		),
+		mcp.WithNumber("line_spacing",
+			mcp.Description("Line spacing in percent; overrides preset (optional)"),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("before",
+			mcp.Description("Spacing before the paragraph (pt)"),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("after",
+			mcp.Description("Spacing after the paragraph (pt)"),
+			mcp.Min(0),
+		),
+		mcp.WithString("first_line",
+			mcp.Description("First-line indent preset: none, indent (10pt, one character), wide (20pt), hanging (first line 10pt out)"),
+			mcp.Enum("none", "indent", "wide", "hanging"),
+		),
+		mcp.WithNumber("first_line_indent",
+			mcp.Description("First-line indent in pt; negative for a hanging indent; overrides first_line (optional)"),
+		),
+		mcp.WithString("drop_cap",
+			mcp.Description("Drop cap (첫 글자 장식) for newsletter-style openings: none, 2_lines, 3_lines (first letter set into that many lines), margin (first letter in the left margin)"),
+			mcp.Enum("none", "2_lines", "3_lines", "margin"),
+		),
+		mcp.WithString("drop_cap_font",
+			mcp.Description("Font of the drop cap letter (default: paragraph font)"),
+		),
+	}, formatScopeOptions("")...)...), HandleHwpSetSpacing)
+
+	addTool(mcpServer, mcp.NewTool(HWP_CLEAN_FORMATTING,
+		mcp.WithDescription("Clear direct character formatting (bold, italic, underline, color, mixed fonts) and reapply a baseline font and spacing"),
+		mcp.WithString("scope",
+			mcp.Description("Apply to the whole document or the current selection (default: document)"),
+			mcp.Enum("document", "selection"),
+		),
+		mcp.WithString("font_name",
+			mcp.Description("Baseline font name (default: 맑은 고딕)"),
+		),
+		mcp.WithNumber("font_size",
+			mcp.Description("Baseline font size (default: 11)"),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("line_spacing",
+			mcp.Description("Line spacing in percent to reapply (optional)"),
+			mcp.Min(0),
+		),
+		DryRunOption(),
+	), HandleHwpCleanFormatting)
+
+	addTool(mcpServer, mcp.NewTool(HWP_TRANSFORM_TEXT,
+		mcp.WithDescription("Transform the selected text: upper/lower case, full-width/half-width characters, or Hangul/Hanja conversion"),
+		mcp.WithString("transform",
+			mcp.Description("Transformation to apply to the current selection"),
+			mcp.Required(),
+			mcp.Enum(hwp.TextTransforms...),
+		),
+		DryRunOption(),
+	), HandleHwpTransformText)
+
+	addTool(mcpServer, mcp.NewTool(HWP_SORT_LINES,
+		mcp.WithDescription("Sort the lines (paragraphs) of the selected text, like HWP's sort feature, e.g. for name lists and references. The sorted text takes the formatting of its first character"),
+		mcp.WithString("order",
+			mcp.Description("alphabetical ignores case and puts Hangul in 가나다 order; numeric compares the number each line starts with, e.g. \"12. ...\" (default: alphabetical)"),
+			mcp.Enum(hwp.SortOrders...),
+		),
+		mcp.WithBoolean("reverse",
+			mcp.Description("Sort in descending order (default: false)"),
+		),
+		DryRunOption(),
+	), HandleHwpSortLines)
+
+	addTool(mcpServer, mcp.NewTool(HWP_SCAN_PII,
+		mcp.WithDescription("Find personal data in the whole document, tables included, without changing it, so it can be reviewed before hwp_redact: resident registration numbers (주민등록번호), phone numbers and email addresses, plus custom regular expressions. Returns the number of matches per pattern and each match with its type, text, line (a paragraph or table row of hwp_get_text), column and surrounding text"),
+		mcp.WithArray("patterns",
+			mcp.Description("Built-in patterns to look for (default: all of them); pass [] to use only custom_patterns"),
+			mcp.WithStringEnumItems(hwp.PIIPatternNames()),
+		),
+		mcp.WithObject("custom_patterns",
+			mcp.Description("Additional patterns by name, as Go regular expressions, e.g. {\"account\": \"\\\\d{3}-\\\\d{2}-\\\\d{6}\"}"),
+		),
+	), HandleHwpScanPII)
+
+	addTool(mcpServer, mcp.NewTool(HWP_REDACT,
+		mcp.WithDescription("Redact personal data in the whole document, tables included, for sharing it: resident registration numbers (주민등록번호), phone numbers and email addresses, plus custom regular expressions. Matches become black boxes (■■■) or masked text (900101-1******, 010-****-5678, h*****@example.com); the formatting around them is kept. Returns the number of matches per pattern"),
+		mcp.WithArray("patterns",
+			mcp.Description("Built-in patterns to redact (default: all of them); pass [] to use only custom_patterns"),
+			mcp.WithStringEnumItems(hwp.PIIPatternNames()),
+		),
+		mcp.WithObject("custom_patterns",
+			mcp.Description("Additional patterns by name, as Go regular expressions, e.g. {\"account\": \"\\\\d{3}-\\\\d{2}-\\\\d{6}\"}; masking hides every letter and digit"),
+		),
+		mcp.WithString("mode",
+			mcp.Description("box replaces every character with ■; mask hides the identifying part with * (default: box)"),
+			mcp.Enum(hwp.RedactModes...),
+		),
+		DryRunOption(),
+	), HandleHwpRedact)
+
+	// Editing tools
+	addTool(mcpServer, mcp.NewTool(HWP_REPLACE_BETWEEN_BOOKMARKS,
+		mcp.WithDescription("Replace everything between two bookmarks (책갈피) with new text or blocks, leaving the rest of the document and the bookmarks themselves untouched. Use it to regenerate one section of a living document; running it again replaces the previous content"),
+		mcp.WithString("start_bookmark",
+			mcp.Description("Bookmark before the content to replace"),
+			mcp.Required(),
+		),
+		mcp.WithString("end_bookmark",
+			mcp.Description("Bookmark after the content to replace; must be in the same text as start_bookmark (both in the body, or both in one table cell)"),
+			mcp.Required(),
+		),
+		mcp.WithString("text",
+			mcp.Description("New content as text; line breaks start new paragraphs. Pass \"\" to empty the region"),
+		),
+		mcp.WithArray("blocks",
+			mcp.Description("New content as document model blocks, as in hwp_import_model: [{\"type\": \"paragraph\", \"runs\": [{\"text\": \"...\", \"bold\": true}]}, {\"type\": \"table\", \"rows\": [[\"a\", \"b\"]]}, {\"type\": \"image\", \"source\": \"path\", \"alt\": \"description\"}, {\"type\": \"page_break\"}]"),
+			mcp.Items(map[string]any{"type": "object"}),
+		),
+		DryRunOption(),
+	), HandleHwpReplaceBetweenBookmarks)
+
+	addTool(mcpServer, mcp.NewTool(HWP_APPLY_PATCH,
+		mcp.WithDescription("Apply a list of edits computed offline, e.g. from a diff: insert_at, delete_range, replace_range and format_range, each addressed by a bookmark or by paragraph and position (as returned by hwp_find). Edits run in order and positions refer to the document as the earlier edits left it, so apply a diff from the end of the document backwards. All edits are checked first, and if one fails the document is restored as it was"),
+		mcp.WithArray("operations",
+			mcp.Description("Edits to apply in order"),
+			mcp.Required(),
+			mcp.Items(patchOperationSchema),
+		),
+		DryRunOption(),
+	), HandleHwpApplyPatch)
+
+	addTool(mcpServer, mcp.NewTool(HWP_DELETE_PARAGRAPHS,
+		mcp.WithDescription("Delete a range of body paragraphs by index, as returned by hwp_get_paragraphs. The paragraphs after the range move up, so re-read indices before the next structural edit"),
+		mcp.WithNumber("start_index",
+			mcp.Description("Index of the first paragraph to delete"),
+			mcp.Required(),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("count",
+			mcp.Description("Number of paragraphs to delete (default: 1)"),
+			mcp.Min(1),
+		),
+		DryRunOption(),
+	), HandleHwpDeleteParagraphs)
+
+	addTool(mcpServer, mcp.NewTool(HWP_MOVE_PARAGRAPHS,
+		mcp.WithDescription("Move a range of body paragraphs, with their formatting, to another place, e.g. to reorder the sections of a report. Indices are those of hwp_get_paragraphs before the move; the paragraphs are placed before the paragraph at to_index, or at the end when to_index is the number of paragraphs. If a step fails the document is restored"),
+		mcp.WithNumber("start_index",
+			mcp.Description("Index of the first paragraph to move"),
+			mcp.Required(),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("count",
+			mcp.Description("Number of paragraphs to move (default: 1)"),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("to_index",
+			mcp.Description("Index of the paragraph to move them before, or the number of paragraphs to move them to the end"),
+			mcp.Required(),
+			mcp.Min(0),
+		),
+		DryRunOption(),
+	), HandleHwpMoveParagraphs)
+
+	// Citation tools
+	addTool(mcpServer, mcp.NewTool(HWP_SET_BIBLIOGRAPHY,
+		mcp.WithDescription("Load the sources the current document cites, from JSON, BibTeX or a .bib/.json file, and choose the citation style. Loading again replaces the sources and restarts citation numbers; the bibliography is dropped when the document is closed"),
+		mcp.WithArray("sources",
+			mcp.Description("Sources: [{\"key\": \"kim2023\", \"authors\": [\"김민수\"], \"title\": \"...\", \"year\": 2023, \"container\": \"journal or book\", \"publisher\", \"volume\", \"issue\", \"pages\", \"doi\", \"url\"}]"),
+			mcp.Items(map[string]any{"type": "object"}),
+		),
+		mcp.WithString("bibtex",
+			mcp.Description("BibTeX entries, instead of sources"),
+		),
+		mcp.WithString("path",
+			mcp.Description("BibTeX (.bib) or JSON (.json) file to read the sources from, instead of sources"),
+		),
+		mcp.WithString("style",
+			mcp.Description("Citation style: author_year gives (김민수, 2023) and (Kim et al., 2023), numbered gives [1] in the order sources are first cited (default: author_year)"),
+			mcp.Enum(hwp.CitationStyles...),
+		),
+	), HandleHwpSetBibliography)
+
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_CITATION,
+		mcp.WithDescription("Insert a citation of one or more sources of the bibliography at the cursor, e.g. (Kim, 2023; Lee & Park, 2021) or [1, 3]. Load the sources with hwp_set_bibliography first"),
+		mcp.WithArray("keys",
+			mcp.Description("Keys of the sources to cite"),
+			mcp.Required(),
+			mcp.WithStringItems(),
+		),
+		mcp.WithString("page",
+			mcp.Description("Cited page or page range, e.g. 12 or 12-15 (optional)"),
+		),
+	), HandleHwpInsertCitation)
+
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_BIBLIOGRAPHY,
+		mcp.WithDescription("Insert the references section in a new paragraph after the cursor: a heading and one formatted entry per source, in citation order for numbered citations or by author and year otherwise"),
+		mcp.WithString("title",
+			mcp.Description("Heading of the section; empty for none (default: 참고문헌)"),
+		),
+		mcp.WithBoolean("include_all",
+			mcp.Description("List every source of the bibliography, not just the cited ones (default: false)"),
+		),
+	), HandleHwpInsertBibliography)
+
+	// Page layout tools
+	addTool(mcpServer, mcp.NewTool(HWP_SET_PAGE_SETUP,
+		pageSetupOptions("Set page orientation and margins of the current section")...,
+	), HandleHwpSetPageSetup)
+
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_SECTION,
+		pageSetupOptions("Insert a new section at the cursor with its own page orientation and margins (e.g. a landscape section for a wide table)")...,
+	), HandleHwpInsertSection)
+
+	addTool(mcpServer, mcp.NewTool(HWP_SET_PAGE_BORDER,
+		mcp.WithDescription("Draw a border around the pages of the current section"),
+		mcp.WithString("style",
+			mcp.Description("Border line style (default: solid)"),
+			mcp.Enum("none", "solid", "dash", "dot", "dash_dot", "double", "thin_thick", "thick_thin", "triple"),
+		),
+		mcp.WithNumber("width",
+			mcp.Description("Line width in mm, rounded to the nearest HWP width (default: 0.4)"),
+			mcp.Min(0),
+		),
+		mcp.WithString("color",
+			mcp.Description("Line color name or #RRGGBB (default: black)"),
+		),
+		mcp.WithArray("edges",
+			mcp.Description("Edges to draw: left, right, top, bottom (default: all)"),
+			mcp.WithStringEnumItems([]string{"left", "right", "top", "bottom"}),
+		),
+	), HandleHwpSetPageBorder)
+
+	addTool(mcpServer, mcp.NewTool(HWP_SET_PAGE_BACKGROUND,
+		mcp.WithDescription("Fill the pages of the current section with a background color or image"),
+		mcp.WithString("color",
+			mcp.Description("Background color name or #RRGGBB"),
+		),
+		mcp.WithString("image_path",
+			mcp.Description("Background image file path, stretched over the page (takes precedence over color)"),
+			FilePattern(false, imageExtensions...),
+		),
+	), HandleHwpSetPageBackground)
+
+	addTool(mcpServer, mcp.NewTool(HWP_SET_LINE_NUMBERING,
+		mcp.WithDescription("Turn per-line numbering of the current section on or off (legal drafts, code listings)"),
+		mcp.WithBoolean("enabled",
+			mcp.Description("Enable line numbers (default: true)"),
+		),
+		mcp.WithNumber("interval",
+			mcp.Description("Show a number every N lines (default: 1)"),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("start",
+			mcp.Description("Starting line number (default: 1)"),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("distance",
+			mcp.Description("Distance between the numbers and the text in mm (default: 5)"),
+			mcp.Min(0),
+		),
+		mcp.WithString("restart",
+			mcp.Description("When numbering restarts (default: page)"),
+			mcp.Enum("page", "section", "continuous"),
+		),
+	), HandleHwpSetLineNumbering)
+
+	// Image insertion tools
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_IMAGE, append([]mcp.ToolOption{
+		mcp.WithDescription("Insert an image at the current cursor position with full Python functionality. Images are inline unless wrap is set"),
+		mcp.WithString("path",
+			mcp.Description("Image file path or URL"),
+			mcp.Required(),
+			FilePattern(true, imageExtensions...),
+		),
+		mcp.WithNumber("width",
+			mcp.Description("Image width (hwpunit). Used when keep_aspect_ratio=false"),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("height",
+			mcp.Description("Image height (hwpunit). Used when keep_aspect_ratio=false"),
+			mcp.Min(0),
+		),
+		mcp.WithBoolean("use_original_size",
+			mcp.Description("Use original image size (default: true)"),
+		),
+		mcp.WithNumber("max_width",
+			mcp.Description("Maximum width (hwpunit). Used when keep_aspect_ratio=true"),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("max_height",
+			mcp.Description("Maximum height (hwpunit). Used when keep_aspect_ratio=true"),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("scale",
+			mcp.Description("Scale factor (e.g., 0.5 = 50%, 2.0 = 200%). Used when keep_aspect_ratio=true"),
+			mcp.Min(0),
+		),
+		mcp.WithBoolean("keep_aspect_ratio",
+			mcp.Description("Keep original aspect ratio (default: false)"),
+		),
+		mcp.WithBoolean("embedded",
+			mcp.Description("Embed image in document (default: true)"),
+		),
+		mcp.WithBoolean("reverse",
+			mcp.Description("Flip image horizontally (default: false)"),
+		),
+		mcp.WithBoolean("watermark",
+			mcp.Description("Set as watermark (default: false)"),
+		),
+		mcp.WithNumber("effect",
+			mcp.Description("Image effect (0: normal, 1: grayscale, 2: black&white, default: 0)"),
+			mcp.Min(0),
+			mcp.Max(2),
+		),
+		mcp.WithString("alt_text",
+			mcp.Description("Alternative text describing the image for screen readers (개체 설명문), required by accessibility rules for public-sector documents"),
+		),
+		mcp.WithNumber("max_pixels",
+			mcp.Description("Downscale the image before inserting it so its longest edge is at most this many pixels, keeping the displayed size. Keeps generated files small enough to email"),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("max_dpi",
+			mcp.Description("Downscale the image before inserting it to at most this many pixels per inch at its displayed size (e.g. 150 for screen, 300 for print)"),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("jpeg_quality",
+			mcp.Description("JPEG quality of a downscaled image, 1-100 (default: 85). Needs max_pixels or max_dpi"),
+			mcp.Min(1),
+			mcp.Max(100),
+		),
+	}, append(imagePlacementOptions(), imageTransformOptions()...)...)...), HandleHwpInsertImage)
+
+	addTool(mcpServer, mcp.NewTool(HWP_LIST_IMAGES,
+		mcp.WithDescription("List the pictures of the document as [{index, para, description}] in document order, e.g. to find pictures without alternative text"),
+	), HandleHwpListImages)
+
+	addTool(mcpServer, mcp.NewTool(HWP_SET_IMAGE_DESCRIPTION,
+		mcp.WithDescription("Set the alternative text (개체 설명문) of an existing picture, for accessibility compliance. Find the index with hwp_list_images"),
+		mcp.WithNumber("index",
+			mcp.Description("Index of the picture as listed by hwp_list_images"),
+			mcp.Required(),
+			mcp.Min(0),
+		),
+		mcp.WithString("description",
+			mcp.Description("Alternative text describing the picture; empty removes it"),
+			mcp.Required(),
+		),
+	), HandleHwpSetImageDescription)
+
+	addTool(mcpServer, mcp.NewTool(HWP_SET_IMAGE_WRAP, append([]mcp.ToolOption{
+		mcp.WithDescription("Change an existing picture in place: how it sits in the text (inline, or floating with a text wrap and a position), its rotation and its crop, e.g. to straighten and trim a scanned stamp or photo. Arguments left out are kept. Find the index with hwp_list_images"),
+		mcp.WithNumber("index",
+			mcp.Description("Index of the picture as listed by hwp_list_images"),
+			mcp.Required(),
+			mcp.Min(0),
+		),
+	}, append(imagePlacementOptions(), imageTransformOptions()...)...)...), HandleHwpSetImageWrap)
+
+	addTool(mcpServer, mcp.NewTool(HWP_COMPRESS_IMAGES,
+		mcp.WithDescription("Write a copy of an .hwp or .hwpx file with its images downscaled and JPEGs saved again at a lower quality, to make it small enough to email. Images only change when that makes them smaller. .hwpx files are compressed directly; .hwp files go through HWPX in a hidden HWP instance and need the com backend. Save the open document first to compress it"),
+		mcp.WithString("src",
+			mcp.Description("Document to compress (.hwp or .hwpx)"),
+			mcp.Required(),
+			FilePattern(false, "hwp", "hwpx"),
+		),
+		mcp.WithString("dst",
+			mcp.Description("File to write (.hwp or .hwpx; default: src with _compressed added to the name)"),
+			FilePattern(false, "hwp", "hwpx"),
+		),
+		mcp.WithNumber("max_pixels",
+			mcp.Description("Longest image edge in pixels (default: 2000)"),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("jpeg_quality",
+			mcp.Description("JPEG quality, 1-100 (default: 85)"),
+			mcp.Min(1),
+			mcp.Max(100),
+		),
+		mcp.WithBoolean("overwrite",
+			mcp.Description("Replace dst if it exists (default: false)"),
+		),
+		DryRunOption(),
+	), HandleHwpCompressImages)
+
+	// Media link tools
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_VIDEO_LINK,
+		mcp.WithDescription("Insert a placeholder for a video or audio clip at the cursor, since HWP can't embed playable media: an optional thumbnail picture, the caption marked ▶ (video) or ♪ (audio) and the URL, both as hyperlinks to the clip. Suits training materials that reference media. Needs the com backend"),
+		mcp.WithString("url",
+			mcp.Description("Address of the clip, e.g. a YouTube or intranet video page"),
+			mcp.Required(),
+		),
+		mcp.WithString("kind",
+			mcp.Description("Kind of media (default: video)"),
+			mcp.Enum(hwp.MediaKinds...),
+		),
+		mcp.WithString("caption",
+			mcp.Description("Caption, e.g. the clip title (default: 동영상 or 음성 자료)"),
+		),
+		mcp.WithString("duration",
+			mcp.Description("Running time shown after the caption, e.g. 3:25"),
+		),
+		mcp.WithString("thumbnail",
+			mcp.Description("Thumbnail image file path or URL (default: no picture)"),
+			FilePattern(true, imageExtensions...),
+		),
+		mcp.WithNumber("width",
+			mcp.Description("Largest thumbnail width in mm (default: 80)"),
+			mcp.Min(1),
+		),
+	), HandleHwpInsertVideoLink)
+
+	// Object tools
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_OLE_OBJECT,
+		mcp.WithDescription("Embed an Excel, PowerPoint or Word file at the cursor as an editable OLE object, e.g. a financial appendix that stays editable in Excel when double-clicked in HWP. The page shows the file's first sheet or slide. Needs the com backend and the program that opens the file"),
+		mcp.WithString("path",
+			mcp.Description("File to embed"),
+			mcp.Required(),
+			FilePattern(false, hwp.OLEExtensions...),
+		),
+		mcp.WithBoolean("link",
+			mcp.Description("Link to the file instead of embedding a copy, so the object shows its current contents; the file must stay where it is (default: false)"),
+		),
+	), HandleHwpInsertOLEObject)
+
+	addTool(mcpServer, mcp.NewTool(HWP_LIST_OBJECTS,
+		mcp.WithDescription("List the drawing objects of the document (pictures, shapes, text boxes, OLE objects and groups) as [{id, kind, para, width_mm, height_mm, description}] in document order. The id is what hwp_select_object takes"),
+	), HandleHwpListObjects)
+
+	addTool(mcpServer, mcp.NewTool(HWP_SELECT_OBJECT,
+		mcp.WithDescription("Select a drawing object by the id hwp_list_objects gives it, so tools and actions that work on the selected object (moving, resizing, deleting, captions) apply to exactly that object"),
+		mcp.WithNumber("id",
+			mcp.Description("ID of the object as listed by hwp_list_objects"),
+			mcp.Required(),
+			mcp.Min(0),
+		),
+	), HandleHwpSelectObject)
+
+	addTool(mcpServer, mcp.NewTool(HWP_GROUP_OBJECTS,
+		mcp.WithDescription("Group drawing objects, such as the shapes of a diagram, into one object so they move and resize together. The group replaces them in hwp_list_objects, so IDs change; list the objects again afterwards"),
+		mcp.WithArray("ids",
+			mcp.Description("IDs of the objects to group, as listed by hwp_list_objects (at least 2)"),
+			mcp.Required(),
+			mcp.WithNumberItems(mcp.Min(0)),
+		),
+	), HandleHwpGroupObjects)
+
+	addTool(mcpServer, mcp.NewTool(HWP_UNGROUP_OBJECT,
+		mcp.WithDescription("Split a grouped object back into its objects. IDs change; list the objects again afterwards"),
+		mcp.WithNumber("id",
+			mcp.Description("ID of the group as listed by hwp_list_objects"),
+			mcp.Required(),
+			mcp.Min(0),
+		),
+	), HandleHwpUngroupObject)
+
+	addTool(mcpServer, mcp.NewTool(HWP_ARRANGE_OBJECT,
+		mcp.WithDescription("Change the stacking order of a drawing object where it overlaps others: bring it to the front, send it to the back, or move it one step forward or backward"),
+		mcp.WithNumber("id",
+			mcp.Description("ID of the object as listed by hwp_list_objects"),
+			mcp.Required(),
+			mcp.Min(0),
+		),
+		mcp.WithString("order",
+			mcp.Description("front, back, forward or backward"),
+			mcp.Required(),
+			mcp.Enum(hwp.ObjectOrders...),
+		),
+	), HandleHwpArrangeObject)
+
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_LINKED_TEXT_FRAMES,
+		mcp.WithDescription("Create a chain of linked text boxes on the page at the cursor, for brochure-style layouts: text typed into the first box flows on into the next when it is full. Each frame is placed in millimeters from the top left corner of the paper, in front of the body text"),
+		mcp.WithArray("frames",
+			mcp.Description("Frames in flow order (at least 2), e.g. [{\"x_mm\": 15, \"y_mm\": 20, \"width_mm\": 85, \"height_mm\": 250}, {\"x_mm\": 110, \"y_mm\": 20, \"width_mm\": 85, \"height_mm\": 250}]"),
+			mcp.Required(),
+			mcp.Items(textFrameSchema),
+		),
+		mcp.WithString("text",
+			mcp.Description("Text to flow through the frames; newlines start paragraphs"),
+		),
+	), HandleHwpInsertLinkedTextFrames)
+
+	// Diagram tools
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_ORG_CHART,
+		mcp.WithDescription("Draw an organization chart at the cursor from a tree of nodes, as text boxes joined by connectors and grouped into one editable object. Parents are centered over their children; the text continues below the chart"),
+		mcp.WithObject("root",
+			mcp.Description("Top node as {label, children: [nodes]}, e.g. {\"label\": \"대표이사\", \"children\": [{\"label\": \"경영지원본부\"}, {\"label\": \"사업본부\", \"children\": [{\"label\": \"영업팀\"}]}]}"),
+			mcp.Required(),
+			mcp.Properties(diagramNodeProperties),
+		),
+		mcp.WithNumber("width_mm",
+			mcp.Description("Width the chart may take in millimeters (default: 160)"),
+			mcp.Min(20),
+		),
+	), HandleHwpInsertOrgChart)
+
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_FLOW_DIAGRAM,
+		mcp.WithDescription("Draw a simple flow diagram at the cursor from nodes and the edges between them, as text boxes joined by connectors and grouped into one editable object. Each node goes one row below the nodes leading to it, so the flow reads from top to bottom; edges must not form a loop"),
+		mcp.WithArray("nodes",
+			mcp.Description("Nodes as {id, label}; nodes of a row keep this order"),
+			mcp.Required(),
+			mcp.Items(diagramFlowNodeSchema),
+		),
+		mcp.WithArray("edges",
+			mcp.Description("Edges as {from, to} node ids"),
+			mcp.Items(diagramEdgeSchema),
+		),
+		mcp.WithNumber("width_mm",
+			mcp.Description("Width the diagram may take in millimeters (default: 160)"),
+			mcp.Min(20),
+		),
+	), HandleHwpInsertFlowDiagram)
+
+	// Table operation tools
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_TABLE,
+		mcp.WithDescription("Insert a table at the current cursor position"),
+		mcp.WithNumber("rows",
+			mcp.Description("Number of rows"),
+			mcp.Required(),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("cols",
+			mcp.Description("Number of columns"),
+			mcp.Required(),
+			mcp.Min(1),
+		),
+	), HandleHwpInsertTable)
+
+	addTool(mcpServer, mcp.NewTool(HWP_FILL_TABLE_WITH_DATA,
+		mcp.WithDescription("Fill existing table with data"),
+		mcp.WithArray("data",
+			mcp.Description("Rows of cell values to fill, e.g. [[\"Name\", \"Score\"], [\"Kim\", 90]]"),
+			mcp.Required(),
+			mcp.Items(tableRowSchema),
+		),
+		mcp.WithNumber("start_row",
+			mcp.Description("Starting row number (1-based)"),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("start_col",
+			mcp.Description("Starting column number (1-based)"),
+			mcp.Min(1),
+		),
+		mcp.WithBoolean("has_header",
+			mcp.Description("Whether first row is header"),
+		),
+		numberFormatOption(),
+		columnFormatsOption(),
+	), HandleHwpFillTableWithData)
+
+	addTool(mcpServer, mcp.NewTool(HWP_FILL_COLUMN_NUMBERS,
+		mcp.WithDescription("Fill table column with sequential numbers"),
+		mcp.WithNumber("start",
+			mcp.Description("Starting number"),
+		),
+		mcp.WithNumber("end",
+			mcp.Description("Ending number"),
+		),
+		mcp.WithNumber("column",
+			mcp.Description("Column number to fill"),
+			mcp.Min(1),
+		),
+	), HandleHwpFillColumnNumbers)
+
+	addTool(mcpServer, mcp.NewTool(HWP_CREATE_TABLE_WITH_DATA,
+		mcp.WithDescription("Create a table and fill it with data"),
+		mcp.WithNumber("rows",
+			mcp.Description("Number of rows"),
+			mcp.Required(),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("cols",
+			mcp.Description("Number of columns"),
+			mcp.Required(),
+			mcp.Min(1),
+		),
+		mcp.WithArray("data",
+			mcp.Description("Rows of cell values to fill (optional)"),
+			mcp.Items(tableRowSchema),
+		),
+		mcp.WithBoolean("has_header",
+			mcp.Description("Whether first row is header"),
+		),
+		numberFormatOption(),
+		columnFormatsOption(),
+	), HandleHwpCreateTableWithData)
+
+	// Chunked table fill tools
+	addTool(mcpServer, mcp.NewTool(HWP_BEGIN_TABLE_FILL,
+		mcp.WithDescription("Begin a chunked fill of the table under the cursor and return a session token for hwp_append_table_rows"),
+		mcp.WithNumber("start_row",
+			mcp.Description("Starting row number (1-based)"),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("start_col",
+			mcp.Description("Starting column number (1-based)"),
+			mcp.Min(1),
+		),
+		mcp.WithBoolean("has_header",
+			mcp.Description("Whether the first appended row is a header"),
+		),
+		mcp.WithBoolean("grow",
+			mcp.Description("Insert a new table row for each appended row (use with a table that only has its header row)"),
+		),
+		mcp.WithNumber("total_rows",
+			mcp.Description("Expected total number of rows, used for progress reporting (optional)"),
+			mcp.Min(0),
+		),
+		numberFormatOption(),
+		columnFormatsOption(),
+	), HandleHwpBeginTableFill)
+
+	addTool(mcpServer, mcp.NewTool(HWP_APPEND_TABLE_ROWS,
+		mcp.WithDescription("Append a chunk of rows to a table fill session started with hwp_begin_table_fill"),
+		mcp.WithString("token",
+			mcp.Description("Fill session token"),
+			mcp.Required(),
+		),
+		mcp.WithArray("data",
+			mcp.Description("Rows of cell values to append"),
+			mcp.Required(),
+			mcp.Items(tableRowSchema),
+		),
+	), HandleHwpAppendTableRows)
+
+	addTool(mcpServer, mcp.NewTool(HWP_END_TABLE_FILL,
+		mcp.WithDescription("Finish a table fill session and move the cursor out of the table"),
+		mcp.WithString("token",
+			mcp.Description("Fill session token"),
+			mcp.Required(),
+		),
+	), HandleHwpEndTableFill)
+
+	addTool(mcpServer, mcp.NewTool(HWP_FILL_TABLE_FROM_CSV,
+		mcp.WithDescription("Fill the table under the cursor from a local CSV file, reporting progress"),
+		mcp.WithString("path",
+			mcp.Description("CSV file path"),
+			mcp.Required(),
+			FilePattern(false, "csv", "tsv", "txt"),
+		),
+		mcp.WithNumber("start_row",
+			mcp.Description("Starting row number (1-based)"),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("start_col",
+			mcp.Description("Starting column number (1-based)"),
+			mcp.Min(1),
+		),
+		mcp.WithBoolean("has_header",
+			mcp.Description("Whether the first CSV row is a header"),
+		),
+		mcp.WithBoolean("grow",
+			mcp.Description("Insert a new table row for each CSV row after the first"),
+		),
+		mcp.WithString("delimiter",
+			mcp.Description("Field delimiter: a single character or tab (default: ,)"),
+		),
+		numberFormatOption(),
+		columnFormatsOption(),
+	), HandleHwpFillTableFromCsv)
+
+	// Table manipulation tools
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_LEFT_COLUMN,
+		mcp.WithDescription("Insert columns to the left of the current position. Pass count to insert several columns in one call"),
+		mcp.WithNumber("count",
+			mcp.Description("Number of columns to insert (default: 1)"),
+			mcp.Min(1),
+			mcp.Max(500),
+		),
+	), HandleHwpInsertLeftColumn)
+
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_RIGHT_COLUMN,
+		mcp.WithDescription("Insert columns to the right of the current position. Pass count to insert several columns in one call"),
+		mcp.WithNumber("count",
+			mcp.Description("Number of columns to insert (default: 1)"),
+			mcp.Min(1),
+			mcp.Max(500),
+		),
+	), HandleHwpInsertRightColumn)
+
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_UPPER_ROW,
+		mcp.WithDescription("Insert rows above the current position. Pass count to insert several rows in one call"),
+		mcp.WithNumber("count",
+			mcp.Description("Number of rows to insert (default: 1)"),
+			mcp.Min(1),
+			mcp.Max(500),
+		),
+	), HandleHwpInsertUpperRow)
+
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_LOWER_ROW,
+		mcp.WithDescription("Insert rows below the current position. Pass count to insert several rows in one call"),
+		mcp.WithNumber("count",
+			mcp.Description("Number of rows to insert (default: 1)"),
+			mcp.Min(1),
+			mcp.Max(500),
+		),
+	), HandleHwpInsertLowerRow)
+
+	addTool(mcpServer, mcp.NewTool(HWP_MOVE_TO_LEFT_CELL,
+		mcp.WithDescription("Move cursor to the left cell"),
+	), HandleHwpMoveToLeftCell)
+
+	addTool(mcpServer, mcp.NewTool(HWP_MOVE_TO_RIGHT_CELL,
+		mcp.WithDescription("Move cursor to the right cell"),
+	), HandleHwpMoveToRightCell)
+
+	addTool(mcpServer, mcp.NewTool(HWP_MOVE_TO_UPPER_CELL,
+		mcp.WithDescription("Move cursor to the upper cell"),
+	), HandleHwpMoveToUpperCell)
+
+	addTool(mcpServer, mcp.NewTool(HWP_MOVE_TO_LOWER_CELL,
+		mcp.WithDescription("Move cursor to the lower cell"),
+	), HandleHwpMoveToLowerCell)
+
+	addTool(mcpServer, mcp.NewTool(HWP_MERGE_TABLE_CELLS,
+		mcp.WithDescription("Merge selected table cells"),
+	), HandleHwpMergeTableCells)
+
+	addTool(mcpServer, mcp.NewTool(HWP_MERGE_TABLES,
+		mcp.WithDescription("Merge adjacent tables into one table"),
+	), HandleHwpMergeTables)
+
+	addTool(mcpServer, mcp.NewTool(HWP_SET_CELL_STYLE,
+		mcp.WithDescription("Set inner padding and diagonal split lines of the current cell, or of every selected cell (e.g. the 구분 header cell of an administrative table)"),
+		mcp.WithNumber("padding",
+			mcp.Description("Padding in mm for all four sides; a side given on its own overrides it"),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("padding_left",
+			mcp.Description("Left padding in mm"),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("padding_right",
+			mcp.Description("Right padding in mm"),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("padding_top",
+			mcp.Description("Top padding in mm"),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("padding_bottom",
+			mcp.Description("Bottom padding in mm"),
+			mcp.Min(0),
+		),
+		mcp.WithString("diagonal",
+			mcp.Description("Diagonal split line: slash (bottom-left to top-right), backslash (top-left to bottom-right), cross, or none to remove"),
+			mcp.Enum(hwp.DiagonalLines...),
+		),
+		mcp.WithString("diagonal_style",
+			mcp.Description("Diagonal line style (default: solid)"),
+			mcp.Enum("solid", "dash", "dot", "dash_dot", "double"),
+		),
+		mcp.WithNumber("diagonal_width",
+			mcp.Description("Diagonal line width in mm, rounded to the nearest HWP width (default: 0.12)"),
+			mcp.Min(0),
+		),
+		mcp.WithString("diagonal_color",
+			mcp.Description("Diagonal line color name or #RRGGBB (default: black)"),
+		),
+	), HandleHwpSetCellStyle)
+
+	// Table conversion tools
+	addTool(mcpServer, mcp.NewTool(HWP_CONVERT_TEXT_TO_TABLE,
+		mcp.WithDescription("Convert the selected delimited text into a table"),
+		mcp.WithString("delimiter",
+			mcp.Description("Column delimiter: tab, comma, space, or any custom string (default: tab)"),
+		),
+	), HandleHwpConvertTextToTable)
+
+	addTool(mcpServer, mcp.NewTool(HWP_CONVERT_TABLE_TO_TEXT,
+		mcp.WithDescription("Convert the table under the cursor into delimited text"),
+		mcp.WithString("delimiter",
+			mcp.Description("Column delimiter: tab, comma, space, or any custom string (default: tab)"),
+		),
+	), HandleHwpConvertTableToText)
+
+	addTool(mcpServer, mcp.NewTool(HWP_CONVERT_TABLE_TO_CHART,
+		mcp.WithDescription("Draw a chart from a table and insert it as a picture. The first row is the header and the first column holds the categories; numeric columns (1,234, ₩5,000, 12%) become series. The title is written above the chart and a colored legend below it"),
+		mcp.WithNumber("index",
+			mcp.Description("1-based table number in the document; omit or 0 for the table under the cursor, in which case the chart goes below the table"),
+			mcp.Min(0),
+		),
+		mcp.WithString("type",
+			mcp.Description("Chart type (default: bar). pie plots the first series only"),
+			mcp.Enum(hwp.ChartTypes...),
+		),
+		mcp.WithString("title",
+			mcp.Description("Chart title written above the chart"),
+		),
+		mcp.WithArray("series",
+			mcp.Description("Header names of the columns to plot (default: every numeric column)"),
+			mcp.WithStringItems(),
+		),
+		mcp.WithNumber("width",
+			mcp.Description("Chart width in mm (default: 120)"),
+			mcp.Min(20),
+			mcp.Max(400),
+		),
+	), HandleHwpConvertTableToChart)
+
+	addTool(mcpServer, mcp.NewTool(HWP_EXPAND_ROW_TEMPLATE,
+		mcp.WithDescription("Fill a template table the way invoice and roster forms are filled: the row with {{field}} placeholders is the template, and it is repeated once per record with the placeholders replaced by the record's values, keeping the row's formatting. Works on the table under the cursor (HWPX backend: the last inserted table)"),
+		mcp.WithArray("data",
+			mcp.Description("Records, one row each, e.g. [{\"name\": \"노트북\", \"qty\": 2}]. Placeholders use {{field}} or dotted paths such as {{item.price}}; {{row_number}} is the 1-based record number. An empty array removes the template row"),
+			mcp.Items(map[string]any{"type": "object"}),
+			mcp.Required(),
+		),
+		mcp.WithNumber("row",
+			mcp.Description("1-based template row (default: the first row with a {{placeholder}})"),
+			mcp.Min(1),
+		),
+	), HandleHwpExpandRowTemplate)
+
+	// Advanced document creation tools
+	addTool(mcpServer, mcp.NewTool(HWP_CREATE_COMPLETE_DOCUMENT,
+		mcp.WithDescription("Create a complete document from specification (report, letter, memo). Reports accept \"cover\": true to start with a cover page"),
+		mcp.WithObject("spec",
+			mcp.Properties(documentSpecProperties),
+			mcp.Description("Document specification. Fields by type: report (title, subtitle, author, date, organization, logo_path, cover, sections: [{title, content}]), letter (recipient, sender, date, subject, body, closing), memo (to, from, date, subject, body), official (공문서: organization, receiver, via, title, clauses: strings or {text, items} numbered 1. 가. 1) 가) (1) (가) ① ㉮ by depth, attachments, sender, stamp (default true), approvals, document_number, date, received, postal_code, address, homepage, phone, fax, email, disclosure), invoice (견적서: title (default 견 적 서), number, date, valid_until, customer, supplier: {registration_number, name, representative, address, business_type, business_item, phone}, items: [{name, spec, quantity, unit_price, note}] with amounts, 공급가액, 부가세 and 합계 computed and the total written in Korean words, vat (default true, 10%), notes), minutes (회의록: title, date, location, recorder, attendees, agenda, discussions: [{title, content}], decisions, action_items: [{task, owner, due}]), certificate (상장/증명서: title (default 상    장), number, award, affiliation, recipient (required), body, date, issuer, stamp_path for a seal image after the issuer, border: none, solid, double, thin_thick, thick_thin (default) or triple page border (COM backend only), border_color), resume (이력서: title, name (required), name_hanja, name_english, birth_date, phone, email, address, photo_path for the photo cell (COM backend), education: [{period, school, major, status}], career: [{period, company, position, duties}], certifications: [{date, name, issuer}], date for the closing declaration, introduction: [{title, content}] 자기소개서 sections on a new page), other types (title, content). Any type may add letterhead: {logo_path, organization, address, rule} for an organization block in the page header with a rule below (rule defaults to true; COM backend only). Supports templating over a top-level \"data\" object: {{path}} interpolation, {\"if\": \"cond\", \"then\": ..., \"else\": ...}, {\"for_each\": \"list\", \"as\": \"item\", \"do\": ...} and {\"when\": \"cond\"} guards. Conditions: path, !path, path == 'value', path != 'value'"),
+			mcp.Required(),
+		),
+		themeOption(),
+	), HandleHwpCreateCompleteDocument)
+
+	addTool(mcpServer, mcp.NewTool(HWP_GENERATE_DOCUMENTS,
+		mcp.WithDescription("Generate and save several documents from specifications in one call, returning a per-document report"),
+		mcp.WithArray("specs",
+			mcp.Description("Document specifications (same schema as the spec of hwp_create_complete_document)"),
+			mcp.Required(),
+			mcp.Items(map[string]any{
+				"type":       "object",
+				"properties": documentSpecProperties,
+			}),
+		),
+		mcp.WithString("output_dir",
+			mcp.Description("Directory to save the generated documents into"),
+			mcp.Required(),
+		),
+		mcp.WithString("filename_pattern",
+			mcp.Description("File name pattern with {index} and spec field placeholders such as {title} (default: document_{index}.hwp)"),
+		),
+		themeOption(),
+	), HandleHwpGenerateDocuments)
+
+	addTool(mcpServer, mcp.NewTool(HWP_INSERT_COVER_PAGE,
+		mcp.WithDescription("Insert a centered cover page (logo, title, subtitle, date, author, organization) followed by a page break"),
+		mcp.WithString("title",
+			mcp.Description("Document title"),
+			mcp.Required(),
+		),
+		mcp.WithString("subtitle",
+			mcp.Description("Subtitle (optional)"),
+		),
+		mcp.WithString("author",
+			mcp.Description("Author (optional)"),
+		),
+		mcp.WithString("date",
+			mcp.Description("Date (optional)"),
+		),
+		mcp.WithString("organization",
+			mcp.Description("Organization name (optional)"),
+		),
+		mcp.WithString("logo_path",
+			mcp.Description("Logo image file path or URL (optional)"),
+			FilePattern(true, imageExtensions...),
+		),
+	), HandleHwpInsertCoverPage)
+}
+
+// addTool registers a tool with its schema recorded for argument validation
+// and its handler wrapped in the tool middleware, so the tools behave the same
+// in any server they are added to
+func addTool(mcpServer *server.MCPServer, tool mcp.Tool, handler server.ToolHandlerFunc) {
+	RegisterToolSchema(tool)
+	mcpServer.AddTool(tool, RecordMetrics(ValidateArguments(DryRun(ReadOnlyGuard(handler)))))
+}
+
+// imageExtensions are the image file types accepted by the image tools
+var imageExtensions = []string{"png", "jpg", "jpeg", "gif", "bmp", "tif", "tiff", "wmf", "emf"}
+
+// tableRowSchema is the JSON schema of one row of table data
+var tableRowSchema = map[string]any{
+	"type": "array",
+	"items": map[string]any{
+		"type": []string{"string", "number", "boolean", "null"},
+	},
+}
+
+// batchOperationSchema is the JSON schema of one hwp_batch_operations step
+var batchOperationSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"type": map[string]any{
+			"type": "string",
+			"enum": []string{"insert_text", "insert_paragraph", "set_font", "insert_table"},
+		},
+		"text":                map[string]any{"type": "string", "description": "insert_text: text to insert"},
+		"preserve_linebreaks": map[string]any{"type": "boolean", "description": "insert_text: turn newlines into paragraphs"},
+		"name":                map[string]any{"type": "string", "description": "set_font: font name"},
+		"size":                map[string]any{"type": "integer", "description": "set_font: font size (pt)"},
+		"bold":                map[string]any{"type": "boolean"},
+		"italic":              map[string]any{"type": "boolean"},
+		"underline":           map[string]any{"type": "boolean"},
+		"color":               map[string]any{"type": "string", "description": "set_font: color name or #RRGGBB"},
+		"rows":                map[string]any{"type": "integer", "minimum": 1, "description": "insert_table: number of rows"},
+		"cols":                map[string]any{"type": "integer", "minimum": 1, "description": "insert_table: number of columns"},
+	},
+	"required": []string{"type"},
+}
+
+// patchAnchorSchema is the JSON schema of a place in hwp_apply_patch
+var patchAnchorSchema = map[string]any{
+	"type":        "object",
+	"description": "{\"bookmark\": name} or {\"para\": n, \"pos\": m}. A bookmark starting a range or taking an insertion means just after it; one ending a range means just before it",
+	"properties": map[string]any{
+		"bookmark": map[string]any{"type": "string"},
+		"para":     map[string]any{"type": "integer", "minimum": 0, "description": "0-based paragraph of the body text"},
+		"pos":      map[string]any{"type": "integer", "minimum": 0, "description": "0-based character in the paragraph"},
+	},
+}
+
+// patchOperationSchema is the JSON schema of one hwp_apply_patch edit
+var patchOperationSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"op": map[string]any{
+			"type": "string",
+			"enum": hwp.PatchOperations,
+		},
+		"at":        patchAnchorSchema,
+		"start":     patchAnchorSchema,
+		"end":       patchAnchorSchema,
+		"text":      map[string]any{"type": "string", "description": "insert_at, replace_range: new text; line breaks start new paragraphs"},
+		"bold":      map[string]any{"type": "boolean", "description": "format_range: settings left out are kept"},
+		"italic":    map[string]any{"type": "boolean"},
+		"underline": map[string]any{"type": "boolean"},
+		"color":     map[string]any{"type": "string", "description": "format_range: color name or #RRGGBB"},
+		"highlight": map[string]any{"type": "string", "description": "format_range: background color name or #RRGGBB"},
+		"font":      map[string]any{"type": "string"},
+		"size":      map[string]any{"type": "integer", "minimum": 1},
+	},
+	"required": []string{"op"},
+}
+
+// textFrameSchema describes one frame of hwp_insert_linked_text_frames
+var textFrameSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"x_mm":      map[string]any{"type": "number", "description": "Distance from the left edge of the paper"},
+		"y_mm":      map[string]any{"type": "number", "description": "Distance from the top edge of the paper"},
+		"width_mm":  map[string]any{"type": "number", "minimum": 5},
+		"height_mm": map[string]any{"type": "number", "minimum": 5},
+	},
+	"required": []string{"x_mm", "y_mm", "width_mm", "height_mm"},
+}
+
+// diagramNodeProperties describe an org chart node; children are nodes too
+var diagramNodeProperties = map[string]any{
+	"label":    map[string]any{"type": "string", "description": "Text of the box, e.g. a department or a name and title"},
+	"children": map[string]any{"type": "array", "items": map[string]any{"type": "object"}},
+}
+
+// diagramFlowNodeSchema describes a node of hwp_insert_flow_diagram
+var diagramFlowNodeSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"id":    map[string]any{"type": "string"},
+		"label": map[string]any{"type": "string"},
+	},
+	"required": []string{"id", "label"},
+}
+
+// diagramEdgeSchema describes an edge of hwp_insert_flow_diagram
+var diagramEdgeSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"from": map[string]any{"type": "string", "description": "id of the node the edge leaves"},
+		"to":   map[string]any{"type": "string", "description": "id of the node the edge enters"},
+	},
+	"required": []string{"from", "to"},
+}
+
+// documentSpecProperties are the top-level fields of a document spec. Other
+// fields are left untyped because templating may replace any value with an
+// if or for_each block.
+var documentSpecProperties = map[string]any{
+	"type": map[string]any{"type": "string", "description": "report, letter, memo, official, invoice, minutes, certificate, resume, or any other value for a generic document"},
+	"data": map[string]any{"type": "object", "description": "Variables for {{path}} interpolation, conditions and loops"},
+}
+
+// themeOption is the theme argument of the document creation tools
+func themeOption() mcp.ToolOption {
+	return mcp.WithObject("theme",
+		mcp.Description("House style applied over the style presets for this call: font (all text), heading_font (titles and headings), sizes (point size by preset name: 표지제목, 표지부제목, 표지정보, 제목1, 제목2, 제목3, 강조, 본문, 머리말기관, 머리말주소, 결문), accent_color (titles and headings) and margins (page margins in mm; COM backend only)"),
+		mcp.Properties(map[string]any{
+			"font":         map[string]any{"type": "string"},
+			"heading_font": map[string]any{"type": "string"},
+			"sizes":        map[string]any{"type": "object", "description": "e.g. {\"본문\": 10, \"제목1\": 20}"},
+			"accent_color": map[string]any{"type": "string", "description": "Color name or #RRGGBB"},
+			"margins": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"left":   map[string]any{"type": "number", "minimum": 0},
+					"right":  map[string]any{"type": "number", "minimum": 0},
+					"top":    map[string]any{"type": "number", "minimum": 0},
+					"bottom": map[string]any{"type": "number", "minimum": 0},
+				},
+			},
+		}),
+	)
+}
+
+// formatScopeOptions returns the scope parameters of the formatting tools, with
+// note appended to the scope description
+func formatScopeOptions(note string) []mcp.ToolOption {
+	description := "What to format: cursor (the insertion point or the selection, default), selection (fails when nothing is selected), paragraph (the paragraph at the cursor), document, or matches (every match of find). For paragraph, document and matches the cursor is put back afterwards"
+	if note != "" {
+		description += ". " + note
+	}
+	return []mcp.ToolOption{
+		mcp.WithString("scope",
+			mcp.Description(description),
+			mcp.Enum(hwp.FormatScopes...),
+		),
+		mcp.WithString("find",
+			mcp.Description("Text to find for scope matches"),
+		),
+		mcp.WithBoolean("regex",
+			mcp.Description("Treat find as an HWP regular expression (default: false)"),
+		),
+		mcp.WithBoolean("match_case",
+			mcp.Description("Match upper/lower case exactly (default: false)"),
+		),
+	}
+}
+
+// numberFormatOption is the number_format argument of the table fill tools
+func numberFormatOption() mcp.ToolOption {
+	return mcp.WithString("number_format",
+		mcp.Description("Format applied to the values of every column (header row excluded): text, number (1,234,567), number:N (N decimals), integer, currency (₩1,234), won (1,234원), percent (0.125 → 12.5%), percent:N, date (2024년 3월 1일), date:dot, date:iso. Values that are not numbers or dates are written unchanged"),
+	)
+}
+
+// columnFormatsOption is the column_formats argument of the table fill tools
+func columnFormatsOption() mcp.ToolOption {
+	return mcp.WithArray("column_formats",
+		mcp.Description("Per-column formats in data column order, same names as number_format, e.g. [\"text\", \"date:dot\", \"currency\", \"percent:1\"]. An empty entry uses number_format"),
+		mcp.WithStringItems(),
+	)
+}
+
+// imagePlacementOptions returns the wrap and position arguments of the picture tools
+func imagePlacementOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("wrap",
+			mcp.Description("Text wrap: inline (a character of its line, the default of new images), square (text flows around), top_bottom (text above and below only), behind_text or in_front (over the text, e.g. for decorations and stamps)"),
+			mcp.Enum(append([]string{"inline"}, hwp.ImageWraps...)...),
+		),
+		mcp.WithString("horizontal_relative_to",
+			mcp.Description("What the horizontal position is measured from (default: column). Floating wraps only"),
+			mcp.Enum(hwp.ImageHorzRelTo...),
+		),
+		mcp.WithString("horizontal_align",
+			mcp.Description("Horizontal alignment within horizontal_relative_to (default: left). Floating wraps only"),
+			mcp.Enum(hwp.ImageHorzAlign...),
+		),
+		mcp.WithNumber("horizontal_offset_mm",
+			mcp.Description("Horizontal offset from the alignment (mm, default: 0). Floating wraps only"),
+		),
+		mcp.WithString("vertical_relative_to",
+			mcp.Description("What the vertical position is measured from (default: para, the paragraph holding the picture). Floating wraps only"),
+			mcp.Enum(hwp.ImageVertRelTo...),
+		),
+		mcp.WithString("vertical_align",
+			mcp.Description("Vertical alignment within vertical_relative_to (default: top). Floating wraps only"),
+			mcp.Enum(hwp.ImageVertAlign...),
+		),
+		mcp.WithNumber("vertical_offset_mm",
+			mcp.Description("Vertical offset from the alignment (mm, default: 0). Floating wraps only"),
+		),
+	}
+}
+
+// imageTransformOptions returns the rotation and crop arguments of the picture tools
+func imageTransformOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithNumber("rotation",
+			mcp.Description("Rotation in degrees clockwise, rounded to whole degrees; negative turns counterclockwise (e.g. -2 straightens a scan tilted 2° clockwise)"),
+			mcp.Min(-360),
+			mcp.Max(360),
+		),
+		mcp.WithNumber("crop_left_mm",
+			mcp.Description("Millimeters cut off the left edge of the image"),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("crop_top_mm",
+			mcp.Description("Millimeters cut off the top edge of the image"),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("crop_right_mm",
+			mcp.Description("Millimeters cut off the right edge of the image"),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("crop_bottom_mm",
+			mcp.Description("Millimeters cut off the bottom edge of the image"),
+			mcp.Min(0),
+		),
+	}
+}
+
+// pageSetupOptions returns the description and arguments shared by the page setup tools
+func pageSetupOptions(description string) []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithDescription(description),
+		mcp.WithString("orientation",
+			mcp.Description("Page orientation"),
+			mcp.Enum("portrait", "landscape"),
+		),
+		mcp.WithNumber("margin_left",
+			mcp.Description("Left margin (mm)"),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("margin_right",
+			mcp.Description("Right margin (mm)"),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("margin_top",
+			mcp.Description("Top margin (mm)"),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("margin_bottom",
+			mcp.Description("Bottom margin (mm)"),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("margin_header",
+			mcp.Description("Header margin (mm)"),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("margin_footer",
+			mcp.Description("Footer margin (mm)"),
+			mcp.Min(0),
+		),
+		mcp.WithNumber("margin_gutter",
+			mcp.Description("Binding (gutter) margin for print binding (mm)"),
+			mcp.Min(0),
+		),
+		mcp.WithString("binding",
+			mcp.Description("Binding layout: single (gutter on the left), facing (mirrored margins for facing pages), top"),
+			mcp.Enum("single", "facing", "top"),
+		),
+	}
+}
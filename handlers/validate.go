@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Argument validation
+//
+// ValidateArguments checks every tool call against the tool's input schema
+// (required arguments, types, enum values, numeric ranges and file extension
+// patterns) before the handler runs, so bad arguments never reach COM. Tools
+// are registered with RegisterToolSchema. Failures are returned as an error
+// result holding a JSON report an LLM can use to correct its call:
+//
+//	{"error": "invalid_arguments", "tool": "hwp_insert_table",
+//	 "violations": [{"field": "rows", "rule": "minimum", "message": "must be at least 1", "value": 0}]}
+
+// Violation is one failed check of an argument
+type Violation struct {
+	Field   string      `json:"field"`
+	Rule    string      `json:"rule"`
+	Message string      `json:"message"`
+	Value   interface{} `json:"value,omitempty"`
+}
+
+var (
+	toolSchemasMu sync.RWMutex
+	toolSchemas   = map[string]mcp.ToolInputSchema{}
+
+	// patternMessages describe the patterns made by FilePattern
+	patternMessages = map[string]string{}
+	patternCache    sync.Map // pattern -> *regexp.Regexp
+)
+
+// RegisterToolSchema makes ValidateArguments check calls of tool
+func RegisterToolSchema(tool mcp.Tool) {
+	toolSchemasMu.Lock()
+	defer toolSchemasMu.Unlock()
+	toolSchemas[tool.Name] = tool.InputSchema
+}
+
+// FilePattern returns a pattern option accepting paths with one of the given
+// extensions and, if allowURL is set, http(s) URLs
+func FilePattern(allowURL bool, extensions ...string) mcp.PropertyOption {
+	pattern := fmt.Sprintf(`(?i)\.(%s)$`, strings.Join(extensions, "|"))
+	message := "must be a file ending in ." + strings.Join(extensions, ", .")
+	if allowURL {
+		pattern = `(?i)^https?://|` + pattern
+		message += " or an http(s) URL"
+	}
+	patternMessages[pattern] = message
+	return mcp.Pattern(pattern)
+}
+
+// ValidateArguments is tool middleware that rejects calls whose arguments
+// don't match the tool's schema
+func ValidateArguments(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		toolSchemasMu.RLock()
+		schema, ok := toolSchemas[request.Params.Name]
+		toolSchemasMu.RUnlock()
+		if !ok {
+			return next(ctx, request)
+		}
+
+		violations := validateArguments(schema, request.GetArguments())
+		if len(violations) == 0 {
+			return next(ctx, request)
+		}
+
+		report, _ := json.Marshal(map[string]interface{}{
+			"error":      "invalid_arguments",
+			"tool":       request.Params.Name,
+			"violations": violations,
+		})
+		return mcp.NewToolResultError(string(report)), nil
+	}
+}
+
+// validateArguments checks arguments against a tool input schema
+func validateArguments(schema mcp.ToolInputSchema, arguments map[string]interface{}) []Violation {
+	var violations []Violation
+
+	for _, name := range schema.Required {
+		value, ok := arguments[name]
+		if !ok || value == nil || value == "" {
+			violations = append(violations, Violation{Field: name, Rule: "required", Message: "is required"})
+		}
+	}
+
+	names := make([]string, 0, len(arguments))
+	for name := range arguments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, ok := schema.Properties[name].(map[string]interface{})
+		value := arguments[name]
+		if !ok || value == nil {
+			continue
+		}
+		if violation, failed := checkProperty(name, property, value); failed {
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
+
+// checkProperty checks one argument against its property schema. Numbers and
+// booleans sent as strings, and arrays or objects sent as JSON text, are
+// accepted like the handlers accept them.
+func checkProperty(name string, property map[string]interface{}, value interface{}) (Violation, bool) {
+	fail := func(rule, format string, args ...interface{}) (Violation, bool) {
+		return Violation{Field: name, Rule: rule, Message: fmt.Sprintf(format, args...), Value: value}, true
+	}
+
+	switch property["type"] {
+	case "string":
+		text, isString := value.(string)
+		if !isString {
+			return fail("type", "must be a string")
+		}
+		if values, ok := property["enum"].([]string); ok && text != "" && !containsFold(values, text) {
+			return fail("enum", "must be one of: %s", strings.Join(values, ", "))
+		}
+		if pattern, ok := property["pattern"].(string); ok && text != "" && !matchPattern(pattern, text) {
+			if message, ok := patternMessages[pattern]; ok {
+				return fail("pattern", "%s", message)
+			}
+			return fail("pattern", "must match %s", pattern)
+		}
+
+	case "number", "integer":
+		var number float64
+		switch v := value.(type) {
+		case float64:
+			number = v
+		case string:
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return fail("type", "must be a number")
+			}
+			number = parsed
+		default:
+			return fail("type", "must be a number")
+		}
+		if minimum, ok := property["minimum"].(float64); ok && number < minimum {
+			return fail("minimum", "must be at least %g", minimum)
+		}
+		if maximum, ok := property["maximum"].(float64); ok && number > maximum {
+			return fail("maximum", "must be at most %g", maximum)
+		}
+
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+		case string:
+			if _, err := strconv.ParseBool(v); err != nil {
+				return fail("type", "must be a boolean")
+			}
+		default:
+			return fail("type", "must be a boolean")
+		}
+
+	case "array":
+		if text, isString := value.(string); isString {
+			if err := json.Unmarshal([]byte(text), &value); err != nil {
+				return fail("type", "must be an array")
+			}
+		}
+		items, isArray := value.([]interface{})
+		if !isArray {
+			return fail("type", "must be an array")
+		}
+		if minItems, ok := property["minItems"].(int); ok && len(items) < minItems {
+			return fail("minItems", "must have at least %d items", minItems)
+		}
+
+	case "object":
+		if text, isString := value.(string); isString {
+			if err := json.Unmarshal([]byte(text), &value); err != nil {
+				return fail("type", "must be an object")
+			}
+		}
+		if _, isObject := value.(map[string]interface{}); !isObject {
+			return fail("type", "must be an object")
+		}
+	}
+	return Violation{}, false
+}
+
+// containsFold reports whether values contains text, ignoring case
+func containsFold(values []string, text string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, text) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPattern reports whether text matches a schema pattern
+func matchPattern(pattern, text string) bool {
+	cached, ok := patternCache.Load(pattern)
+	if !ok {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			// Don't reject calls over a bad pattern in a tool definition
+			return true
+		}
+		cached, _ = patternCache.LoadOrStore(pattern, compiled)
+	}
+	return cached.(*regexp.Regexp).MatchString(text)
+}
@@ -0,0 +1,112 @@
+package hwp
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Document backends
+const (
+	// BackendCOM drives a running HWP instance through COM automation (Windows only)
+	BackendCOM = "com"
+	// BackendHWPX writes HWPX files directly, without HWP, for document generation
+	BackendHWPX = "hwpx"
+)
+
+// activeBackend is the backend used for new documents. COM is only available
+// on Windows, so other hosts default to the HWPX writer.
+var activeBackend = defaultBackend()
+
+func defaultBackend() string {
+	if runtime.GOOS == "windows" {
+		return BackendCOM
+	}
+	return BackendHWPX
+}
+
+// SetBackend selects the backend used for documents created from now on
+func SetBackend(name string) error {
+	switch strings.ToLower(name) {
+	case BackendCOM:
+		activeBackend = BackendCOM
+	case BackendHWPX:
+		activeBackend = BackendHWPX
+	default:
+		return fmt.Errorf("unknown backend: %s (use %s or %s)", name, BackendCOM, BackendHWPX)
+	}
+	return nil
+}
+
+// ActiveBackend returns the name of the selected backend
+func ActiveBackend() string {
+	return activeBackend
+}
+
+// ErrCOMRequired is returned for operations the HWPX backend does not support
+var ErrCOMRequired = errors.New("this operation needs live HWP; restart the server with the COM backend (-backend com or HWP_BACKEND=com)")
+
+// notConnected returns the error for operations attempted without a COM connection
+func (h *Controller) notConnected() error {
+	if h.hwpx != nil {
+		return ErrCOMRequired
+	}
+	return fmt.Errorf("HWP not connected")
+}
+
+// HasDocument reports whether a document is open in either backend
+func (h *Controller) HasDocument() bool {
+	return h.hwpx != nil || (h.isRunning && h.hwp != nil)
+}
+
+// Backend returns the backend of the open document, or "" if none is open
+func (h *Controller) Backend() string {
+	switch {
+	case h.hwpx != nil:
+		return BackendHWPX
+	case h.isRunning && h.hwp != nil:
+		return BackendCOM
+	}
+	return ""
+}
+
+// CurrentPath returns the path the document was last opened from or saved to
+func (h *Controller) CurrentPath() string {
+	return h.currentPath
+}
+
+// SavePath returns the file a save to path writes: currentPath if path is
+// empty, with the extension the backend produces
+func (h *Controller) SavePath(path string) string {
+	if path == "" {
+		path = h.currentPath
+	}
+	// HWPX is the only format the writer produces
+	if h.hwpx != nil && path != "" {
+		if ext := filepath.Ext(path); !strings.EqualFold(ext, ".hwpx") {
+			path = strings.TrimSuffix(path, ext) + ".hwpx"
+		}
+	}
+	return path
+}
+
+// saveHwpx writes the HWPX document
+func (h *Controller) saveHwpx(path string) error {
+	path = h.SavePath(path)
+	if path == "" {
+		return fmt.Errorf("a file path is required to save with the HWPX backend")
+	}
+
+	if err := h.hwpx.save(path); err != nil {
+		return fmt.Errorf("failed to write HWPX: %v", err)
+	}
+	h.currentPath = path
+	return nil
+}
+
+// bgrToHex converts an HWP BGR color value to #RRGGBB
+func bgrToHex(color int) string {
+	return fmt.Sprintf("#%02X%02X%02X", color&0xFF, (color>>8)&0xFF, (color>>16)&0xFF)
+}
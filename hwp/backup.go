@@ -0,0 +1,99 @@
+package hwp
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// backupDir is the directory, next to the saved file, that holds backups
+const backupDir = "backups"
+
+// backupTimeFormat is the timestamp appended to backup file names
+const backupTimeFormat = "20060102-150405"
+
+// BackupBeforeSave copies the file a save to path would overwrite into the
+// backups directory beside it as name-YYYYMMDD-HHMMSS.ext, then deletes the
+// oldest backups of that file so that at most keep remain. It returns the
+// backup path, or "" if there was no file to back up.
+func (h *Controller) BackupBeforeSave(path string, keep int) (string, error) {
+	if keep <= 0 {
+		return "", nil
+	}
+
+	target := h.SavePath(path)
+	if target == "" {
+		return "", nil
+	}
+	if _, err := os.Stat(target); os.IsNotExist(err) {
+		return "", nil
+	}
+
+	dir := filepath.Join(filepath.Dir(target), backupDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %v", err)
+	}
+
+	ext := filepath.Ext(target)
+	base := strings.TrimSuffix(filepath.Base(target), ext)
+	backup := filepath.Join(dir, fmt.Sprintf("%s-%s%s", base, time.Now().Format(backupTimeFormat), ext))
+	if err := copyFile(target, backup); err != nil {
+		return "", fmt.Errorf("failed to back up %s: %v", target, err)
+	}
+
+	if err := pruneBackups(dir, base, ext, keep); err != nil {
+		return backup, err
+	}
+	return backup, nil
+}
+
+// pruneBackups deletes all but the newest keep backups of a file. The
+// timestamp format sorts chronologically by name.
+func pruneBackups(dir, base, ext string, keep int) error {
+	backups, err := filepath.Glob(filepath.Join(dir, base+"-*"+ext))
+	if err != nil {
+		return err
+	}
+
+	prefix := base + "-"
+	var own []string
+	for _, backup := range backups {
+		stamp := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(backup), prefix), ext)
+		// Skip backups of other files whose names share this prefix
+		if _, err := time.Parse(backupTimeFormat, stamp); err == nil {
+			own = append(own, backup)
+		}
+	}
+
+	sort.Strings(own)
+	for len(own) > keep {
+		if err := os.Remove(own[0]); err != nil {
+			return fmt.Errorf("failed to remove old backup: %v", err)
+		}
+		own = own[1:]
+	}
+	return nil
+}
+
+// copyFile copies src to dst
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
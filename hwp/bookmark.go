@@ -0,0 +1,160 @@
+package hwp
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/go-ole/go-ole"
+)
+
+// Bookmarks
+//
+// A bookmark (책갈피) is a control character in the text, so its position is
+// the anchor of the control. Two bookmarks mark a region of a living document
+// that can be regenerated without disturbing the text around it: the region
+// starts right after the first bookmark and ends right before the second, so
+// replacing it keeps both bookmarks for the next run.
+
+// bookmarkCtrlID is the control ID of bookmarks
+const bookmarkCtrlID = "bokm"
+
+// bookmarkPos returns the position of the bookmark with the given name
+func (h *Controller) bookmarkPos(name string) (listParaPos, error) {
+	ctrlVar, err := safeGetProperty(h.hwp, "HeadCtrl")
+	if err != nil {
+		return listParaPos{}, fmt.Errorf("failed to read the document controls: %v", err)
+	}
+	ctrl := ctrlVar.ToIDispatch()
+	for ctrl != nil {
+		if controlID(ctrl) == bookmarkCtrlID && bookmarkName(ctrl) == name {
+			anchorVar, err := safeCallMethod(ctrl, "GetAnchorPos", 0)
+			if err != nil {
+				return listParaPos{}, fmt.Errorf("failed to locate bookmark %q: %v", name, err)
+			}
+			defer anchorVar.Clear()
+			return readListParaPos(anchorVar.ToIDispatch())
+		}
+
+		nextVar, err := safeGetProperty(ctrl, "Next")
+		if err != nil {
+			break
+		}
+		ctrl = nextVar.ToIDispatch()
+	}
+	return listParaPos{}, fmt.Errorf("bookmark %q not found", name)
+}
+
+// controlID returns the CtrlID of a control, or "" if it can't be read
+func controlID(ctrl *ole.IDispatch) string {
+	idVar, err := safeGetProperty(ctrl, "CtrlID")
+	if err != nil {
+		return ""
+	}
+	defer idVar.Clear()
+	return idVar.ToString()
+}
+
+// bookmarkName returns the name of a bookmark control
+func bookmarkName(ctrl *ole.IDispatch) string {
+	propertiesVar, err := safeGetProperty(ctrl, "Properties")
+	if err != nil {
+		return ""
+	}
+	defer propertiesVar.Clear()
+	nameVar, err := safeCallMethod(propertiesVar.ToIDispatch(), "Item", "Name")
+	if err != nil {
+		return ""
+	}
+	defer nameVar.Clear()
+	return nameVar.ToString()
+}
+
+// selectBetweenBookmarks selects the content between the bookmarks start and
+// end and returns its text; selected is false when the bookmarks are adjacent
+func (h *Controller) selectBetweenBookmarks(start, end string) (text string, selected bool, err error) {
+	if start == end {
+		return "", false, fmt.Errorf("the start and end bookmarks must differ")
+	}
+	from, err := h.bookmarkPos(start)
+	if err != nil {
+		return "", false, err
+	}
+	to, err := h.bookmarkPos(end)
+	if err != nil {
+		return "", false, err
+	}
+	if from.List != to.List {
+		return "", false, fmt.Errorf("bookmarks %q and %q are not in the same text (one is in a table cell, header or text box)", start, end)
+	}
+	if !from.before(to) {
+		return "", false, fmt.Errorf("bookmark %q comes after %q", start, end)
+	}
+
+	// The region starts after the start bookmark's control character
+	from.Pos++
+	if err := h.setPos(from); err != nil {
+		return "", false, err
+	}
+	if from == to {
+		return "", false, nil
+	}
+	if err := h.selectRange(from, to); err != nil {
+		return "", false, err
+	}
+	text, err = h.GetSelectedText()
+	return text, true, err
+}
+
+// TextBetweenBookmarks returns the text between the bookmarks start and end,
+// leaving the cursor where it was
+func (h *Controller) TextBetweenBookmarks(start, end string) (string, error) {
+	if h.hwpx != nil {
+		return "", ErrCOMRequired
+	}
+	if !h.isRunning || h.hwp == nil {
+		return "", h.notConnected()
+	}
+
+	origin, err := h.currentPos()
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		h.runAction("Cancel")
+		h.setPos(origin)
+	}()
+	text, _, err := h.selectBetweenBookmarks(start, end)
+	return text, err
+}
+
+// ReplaceBetweenBookmarks replaces everything between the bookmarks start and
+// end with text, or with blocks when blocks is not empty, and returns the
+// number of characters removed. The bookmarks themselves are kept.
+func (h *Controller) ReplaceBetweenBookmarks(start, end, text string, blocks []Block) (int, error) {
+	if h.hwpx != nil {
+		return 0, ErrCOMRequired
+	}
+	if !h.isRunning || h.hwp == nil {
+		return 0, h.notConnected()
+	}
+
+	removed, selected, err := h.selectBetweenBookmarks(start, end)
+	if err != nil {
+		return 0, err
+	}
+	if selected {
+		if err := h.runAction("Delete"); err != nil {
+			return 0, fmt.Errorf("failed to delete the old content: %v", err)
+		}
+	}
+
+	if len(blocks) > 0 {
+		err = h.ImportModel(&DocumentModel{Version: DocumentModelVersion, Blocks: blocks})
+	} else if text != "" {
+		err = h.InsertText(text, true)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("old content removed but the new content failed: %v", err)
+	}
+	return utf8.RuneCountInString(removed), nil
+}
@@ -0,0 +1,191 @@
+package hwp
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cell value formats
+//
+// Table fills can format cell values as they are written, so clients can send
+// raw numbers and ISO dates. A format is a name with an optional parameter
+// after a colon: "number:2" fixes two decimals, "date:dot" picks a date style.
+// Values that don't parse (headers, labels such as 합계, empty cells) are
+// written unchanged.
+
+// CellFormats lists the format names
+var CellFormats = []string{"text", "number", "integer", "currency", "won", "percent", "date"}
+
+// CellFormat is a parsed cell value format
+type CellFormat struct {
+	Kind      string // one of CellFormats
+	Decimals  int    // fixed decimals, or -1 to keep the value's own
+	DateStyle string // long, dot or iso for dates
+}
+
+// ParseCellFormat parses a format such as "number", "percent:1" or "date:iso".
+// An empty spec is the text format, which leaves values unchanged.
+func ParseCellFormat(spec string) (CellFormat, error) {
+	spec = strings.ToLower(strings.TrimSpace(spec))
+	kind, parameter, hasParameter := strings.Cut(spec, ":")
+	format := CellFormat{Kind: kind, Decimals: -1}
+
+	switch kind {
+	case "", "text":
+		format.Kind = "text"
+		if hasParameter {
+			return format, fmt.Errorf("format %q takes no parameter", spec)
+		}
+	case "number", "percent":
+		if hasParameter {
+			decimals, err := strconv.Atoi(parameter)
+			if err != nil || decimals < 0 || decimals > 10 {
+				return format, fmt.Errorf("invalid decimals in %q (use e.g. %s:2)", spec, kind)
+			}
+			format.Decimals = decimals
+		}
+	case "integer", "currency", "won":
+		format.Decimals = 0
+		if hasParameter {
+			return format, fmt.Errorf("format %q takes no parameter", spec)
+		}
+	case "date":
+		format.DateStyle = "long"
+		if hasParameter {
+			format.DateStyle = parameter
+		}
+		if _, err := FormatKoreanDate(time.Now(), format.DateStyle, "", false, false); err != nil {
+			return format, err
+		}
+	default:
+		return format, fmt.Errorf("unknown cell format %q (use %s)", spec, strings.Join(CellFormats, ", "))
+	}
+	return format, nil
+}
+
+// Apply formats a cell value, returning it unchanged if it doesn't parse as
+// the format's kind of value
+func (f CellFormat) Apply(value string) string {
+	switch f.Kind {
+	case "number", "integer":
+		if number, ok := parseFormattedNumber(value); ok {
+			return FormatNumber(number, f.Decimals)
+		}
+	case "currency":
+		if number, ok := parseFormattedNumber(value); ok {
+			if number = math.Round(number); number < 0 {
+				return "-₩" + FormatNumber(-number, 0)
+			}
+			return "₩" + FormatNumber(number, 0)
+		}
+	case "won":
+		if number, ok := parseFormattedNumber(value); ok {
+			return FormatNumber(number, 0) + "원"
+		}
+	case "percent":
+		// Values are fractions, as in spreadsheets: 0.125 is 12.5%. A value
+		// already written with a percent sign is only reformatted.
+		if number, ok := parseFormattedNumber(value); ok {
+			if !strings.HasSuffix(strings.TrimSpace(value), "%") {
+				number *= 100
+			}
+			return FormatNumber(number, f.Decimals) + "%"
+		}
+	case "date":
+		if date, ok := parseCellDate(value); ok {
+			formatted, err := FormatKoreanDate(date, f.DateStyle, "", false, false)
+			if err == nil {
+				return formatted
+			}
+		}
+	}
+	return value
+}
+
+// FormatNumber formats value with thousands separators and decimals fixed
+// digits, or as few as needed when decimals is -1
+func FormatNumber(value float64, decimals int) string {
+	var text string
+	if decimals < 0 {
+		// Drop floating point noise such as 7.000000000000001
+		text = strconv.FormatFloat(math.Round(value*1e10)/1e10, 'f', -1, 64)
+	} else {
+		text = strconv.FormatFloat(value, 'f', decimals, 64)
+	}
+
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign, text = "-", text[1:]
+	}
+	whole, fraction, hasFraction := strings.Cut(text, ".")
+	if strings.Trim(whole+fraction, "0") == "" {
+		// No negative zero after rounding
+		sign = ""
+	}
+
+	var grouped strings.Builder
+	grouped.WriteString(sign)
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+	if hasFraction {
+		grouped.WriteString("." + fraction)
+	}
+	return grouped.String()
+}
+
+// parseFormattedNumber reads a cell such as "1,234", "₩5,000", "12.5%" or "3,000원"
+func parseFormattedNumber(text string) (float64, bool) {
+	text = strings.TrimSpace(text)
+	for _, affix := range []string{"₩", "$", "%", "원"} {
+		text = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(text, affix), affix))
+	}
+	text = strings.ReplaceAll(text, ",", "")
+	if strings.HasPrefix(text, "(") && strings.HasSuffix(text, ")") {
+		// Accounting style negative numbers
+		text = "-" + text[1:len(text)-1]
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsInf(value, 0) || math.IsNaN(value) {
+		return 0, false
+	}
+	return value, true
+}
+
+// cellDateLayouts are the date forms recognized in cell values
+var cellDateLayouts = []string{"2006-01-02", "2006-1-2", "2006/01/02", "2006/1/2", "2006.01.02", "2006.1.2", "20060102"}
+
+// parseCellDate reads a date cell such as 2024-03-01, 2024/3/1 or 20240301
+func parseCellDate(value string) (time.Time, bool) {
+	value = strings.TrimSuffix(strings.TrimSpace(value), ".")
+	for _, layout := range cellDateLayouts {
+		if date, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// FormatTableData formats every cell of data in place. formats[i] applies to
+// the i-th column of data; columns past the end of formats use fallback. The
+// first row is skipped when skipHeader is set.
+func FormatTableData(data [][]string, fallback CellFormat, formats []CellFormat, skipHeader bool) {
+	for r, row := range data {
+		if skipHeader && r == 0 {
+			continue
+		}
+		for c, value := range row {
+			format := fallback
+			if c < len(formats) {
+				format = formats[c]
+			}
+			row[c] = format.Apply(value)
+		}
+	}
+}
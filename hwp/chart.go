@@ -0,0 +1,516 @@
+package hwp
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// Table charts
+//
+// A chart is drawn from table data as a PNG and embedded as a picture, so it
+// looks the same in every HWP version and needs no chart OLE server. The
+// bitmap font only covers ASCII, so the title and legend are written as
+// document text next to the picture; categories that are not ASCII are
+// numbered on the axis and listed in the legend.
+
+// ChartTypes lists the supported chart types
+var ChartTypes = []string{"bar", "line", "pie"}
+
+// ChartOptions controls how a table becomes a chart
+type ChartOptions struct {
+	Type    string   // bar, line or pie (default: bar)
+	Title   string   // written above the chart; "" for none
+	Series  []string // header names of the columns to plot; empty plots every numeric column
+	WidthMM int      // picture width (default: 120)
+}
+
+// ChartSeries is one plotted column
+type ChartSeries struct {
+	Name   string
+	Values []float64
+}
+
+// ChartData is table data prepared for plotting: the first column holds the
+// categories and each numeric column is a series
+type ChartData struct {
+	Categories []string
+	Series     []ChartSeries
+}
+
+// chartPalette are the series colors, also used for pie slices
+var chartPalette = []color.RGBA{
+	{0x3B, 0x6E, 0xC4, 0xFF}, {0xE0, 0x7A, 0x2E, 0xFF}, {0x4C, 0xA6, 0x5A, 0xFF}, {0xC8, 0x3E, 0x4A, 0xFF},
+	{0x84, 0x5E, 0xC2, 0xFF}, {0x8C, 0x6B, 0x4F, 0xFF}, {0xD8, 0x6F, 0xB4, 0xFF}, {0x7F, 0x7F, 0x7F, 0xFF},
+}
+
+// Chart bitmap layout in pixels
+const (
+	chartWidth        = 640
+	chartHeight       = 360
+	chartMarginLeft   = 56
+	chartMarginRight  = 16
+	chartMarginTop    = 16
+	chartMarginBottom = 32
+	chartGridLines    = 5
+)
+
+// ChartDataFromTable reads the chart data of a table whose first row is the
+// header. Values may carry thousands separators, currency or percent signs.
+func ChartDataFromTable(rows [][]string, series []string) (*ChartData, error) {
+	if len(rows) < 2 {
+		return nil, fmt.Errorf("the table needs a header row and at least one data row")
+	}
+	header := rows[0]
+	body := rows[1:]
+
+	columns := make([]int, 0, len(header))
+	if len(series) > 0 {
+		for _, name := range series {
+			column := -1
+			for i, title := range header {
+				if i > 0 && strings.EqualFold(strings.TrimSpace(title), strings.TrimSpace(name)) {
+					column = i
+					break
+				}
+			}
+			if column < 0 {
+				return nil, fmt.Errorf("series %q is not a column of the table (columns: %s)", name, strings.Join(header[1:], ", "))
+			}
+			columns = append(columns, column)
+		}
+	} else {
+		for i := 1; i < len(header); i++ {
+			if numericColumn(body, i) {
+				columns = append(columns, i)
+			}
+		}
+		if len(columns) == 0 {
+			return nil, fmt.Errorf("the table has no numeric columns to plot")
+		}
+	}
+
+	data := &ChartData{}
+	for _, row := range body {
+		data.Categories = append(data.Categories, strings.TrimSpace(cellAt(row, 0)))
+	}
+	for _, column := range columns {
+		s := ChartSeries{Name: strings.TrimSpace(cellAt(header, column))}
+		for r, row := range body {
+			value, ok := parseFormattedNumber(cellAt(row, column))
+			if !ok && strings.TrimSpace(cellAt(row, column)) != "" {
+				return nil, fmt.Errorf("row %d of column %q is not a number: %q", r+2, s.Name, cellAt(row, column))
+			}
+			s.Values = append(s.Values, value)
+		}
+		data.Series = append(data.Series, s)
+	}
+	return data, nil
+}
+
+// numericColumn reports whether every non-empty cell of a column is a number
+func numericColumn(rows [][]string, column int) bool {
+	found := false
+	for _, row := range rows {
+		text := strings.TrimSpace(cellAt(row, column))
+		if text == "" {
+			continue
+		}
+		if _, ok := parseFormattedNumber(text); !ok {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+func cellAt(row []string, column int) string {
+	if column < len(row) {
+		return row[column]
+	}
+	return ""
+}
+
+// ChartColor returns the #RRGGBB color of a series or pie slice
+func ChartColor(index int) string {
+	c := chartPalette[index%len(chartPalette)]
+	return fmt.Sprintf("#%02X%02X%02X", c.R, c.G, c.B)
+}
+
+// CategoryLabel returns the axis label of a category: the category itself if
+// the bitmap font can draw it, otherwise its 1-based number
+func (d *ChartData) CategoryLabel(index int) string {
+	label := d.Categories[index]
+	if isASCII(label) && len(label) <= 12 {
+		return label
+	}
+	return strconv.Itoa(index + 1)
+}
+
+// NumberedCategories reports whether any category is shown as a number
+func (d *ChartData) NumberedCategories() bool {
+	for i := range d.Categories {
+		if d.CategoryLabel(i) != d.Categories[i] {
+			return true
+		}
+	}
+	return false
+}
+
+func isASCII(text string) bool {
+	for _, r := range text {
+		if r >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// RenderChart draws data as a chart and encodes it as PNG
+func RenderChart(data *ChartData, chartType string) ([]byte, error) {
+	canvas := image.NewRGBA(image.Rect(0, 0, chartWidth, chartHeight))
+	draw.Draw(canvas, canvas.Bounds(), image.White, image.Point{}, draw.Src)
+
+	switch strings.ToLower(chartType) {
+	case "", "bar":
+		drawAxisChart(canvas, data, false)
+	case "line":
+		drawAxisChart(canvas, data, true)
+	case "pie":
+		drawPieChart(canvas, data)
+	default:
+		return nil, fmt.Errorf("invalid chart type: %s (use %s)", chartType, strings.Join(ChartTypes, ", "))
+	}
+
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, canvas); err != nil {
+		return nil, fmt.Errorf("failed to encode chart: %v", err)
+	}
+	return encoded.Bytes(), nil
+}
+
+// valueRange returns the axis range of all series, always including zero,
+// and the grid step that divides it
+func (d *ChartData) valueRange() (low, high, step float64) {
+	for _, s := range d.Series {
+		for _, value := range s.Values {
+			low, high = math.Min(low, value), math.Max(high, value)
+		}
+	}
+	if low == high {
+		high = low + 1
+	}
+	step = niceStep((high - low) / chartGridLines)
+	return math.Floor(low/step) * step, math.Ceil(high/step) * step, step
+}
+
+// niceStep rounds a grid step up to 1, 2 or 5 times a power of ten
+func niceStep(raw float64) float64 {
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, factor := range []float64{1, 2, 5, 10} {
+		if raw <= factor*magnitude {
+			return factor * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// drawAxisChart draws a clustered bar chart, or a line chart when lines is set
+func drawAxisChart(canvas *image.RGBA, data *ChartData, lines bool) {
+	left, right := chartMarginLeft, chartWidth-chartMarginRight
+	top, bottom := chartMarginTop, chartHeight-chartMarginBottom
+	low, high, step := data.valueRange()
+	y := func(value float64) int {
+		return bottom - int(math.Round((value-low)/(high-low)*float64(bottom-top)))
+	}
+
+	grid := color.RGBA{0xDD, 0xDD, 0xDD, 0xFF}
+	axis := color.RGBA{0x44, 0x44, 0x44, 0xFF}
+	gridLines := int(math.Round((high - low) / step))
+	for i := 0; i <= gridLines; i++ {
+		value := low + step*float64(i)
+		fillRect(canvas, left, y(value), right, y(value)+1, grid)
+		label := FormatNumber(value, -1)
+		drawLabel(canvas, left-6-textWidth(label), y(value)+4, label, axis)
+	}
+	fillRect(canvas, left, top, left+1, bottom, axis)
+	fillRect(canvas, left, y(0), right, y(0)+1, axis)
+
+	count := len(data.Categories)
+	slot := float64(right-left) / float64(count)
+	for c := 0; c < count; c++ {
+		center := left + int(slot*(float64(c)+0.5))
+		label := data.CategoryLabel(c)
+		drawLabel(canvas, center-textWidth(label)/2, bottom+16, label, axis)
+	}
+
+	if lines {
+		for s, series := range data.Series {
+			ink := chartPalette[s%len(chartPalette)]
+			for c := range series.Values {
+				x0, y0 := left+int(slot*(float64(c)+0.5)), y(series.Values[c])
+				fillRect(canvas, x0-3, y0-3, x0+4, y0+4, ink)
+				if c+1 < len(series.Values) {
+					x1, y1 := left+int(slot*(float64(c)+1.5)), y(series.Values[c+1])
+					drawLine(canvas, x0, y0, x1, y1, ink)
+				}
+			}
+		}
+		return
+	}
+
+	group := slot * 0.8
+	barWidth := group / float64(len(data.Series))
+	for s, series := range data.Series {
+		ink := chartPalette[s%len(chartPalette)]
+		for c, value := range series.Values {
+			x0 := left + int(slot*float64(c)+(slot-group)/2+barWidth*float64(s))
+			x1 := x0 + int(math.Max(1, barWidth-2))
+			y0, y1 := y(value), y(0)
+			if y0 > y1 {
+				y0, y1 = y1, y0
+			}
+			fillRect(canvas, x0, y0, x1, y1, ink)
+		}
+	}
+}
+
+// drawPieChart draws the first series as a pie with one slice per category
+func drawPieChart(canvas *image.RGBA, data *ChartData) {
+	values := data.Series[0].Values
+	total := 0.0
+	for _, value := range values {
+		total += math.Max(0, value)
+	}
+	if total == 0 {
+		return
+	}
+
+	cx, cy := chartWidth/2, chartHeight/2
+	radius := float64(chartHeight/2 - chartMarginTop)
+	bounds := make([]float64, len(values))
+	sum := 0.0
+	for i, value := range values {
+		sum += math.Max(0, value)
+		bounds[i] = sum / total * 2 * math.Pi
+	}
+
+	for py := cy - int(radius); py <= cy+int(radius); py++ {
+		for px := cx - int(radius); px <= cx+int(radius); px++ {
+			dx, dy := float64(px-cx), float64(py-cy)
+			if dx*dx+dy*dy > radius*radius {
+				continue
+			}
+			// Clockwise from twelve o'clock
+			angle := math.Atan2(dx, -dy)
+			if angle < 0 {
+				angle += 2 * math.Pi
+			}
+			for i, bound := range bounds {
+				if angle <= bound {
+					canvas.Set(px, py, chartPalette[i%len(chartPalette)])
+					break
+				}
+			}
+		}
+	}
+
+	// Category labels sit on the middle of their slice
+	start := 0.0
+	for i, bound := range bounds {
+		if bound > start {
+			middle := (start + bound) / 2
+			label := data.CategoryLabel(i)
+			x := cx + int(math.Sin(middle)*radius*0.65)
+			y := cy - int(math.Cos(middle)*radius*0.65)
+			drawLabel(canvas, x-textWidth(label)/2, y+4, label, color.White)
+		}
+		start = bound
+	}
+}
+
+func fillRect(canvas *image.RGBA, x0, y0, x1, y1 int, ink color.Color) {
+	draw.Draw(canvas, image.Rect(x0, y0, x1, y1), image.NewUniform(ink), image.Point{}, draw.Src)
+}
+
+// drawLine draws a 3-pixel wide line
+func drawLine(canvas *image.RGBA, x0, y0, x1, y1 int, ink color.Color) {
+	steps := int(math.Max(math.Abs(float64(x1-x0)), math.Abs(float64(y1-y0))))
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / math.Max(1, float64(steps))
+		x := x0 + int(math.Round(t*float64(x1-x0)))
+		y := y0 + int(math.Round(t*float64(y1-y0)))
+		fillRect(canvas, x-1, y-1, x+2, y+2, ink)
+	}
+}
+
+func textWidth(text string) int {
+	return font.MeasureString(basicfont.Face7x13, text).Round()
+}
+
+// drawLabel draws ASCII text with its baseline at y
+func drawLabel(canvas *image.RGBA, x, y int, text string, ink color.Color) {
+	drawer := &font.Drawer{
+		Dst:  canvas,
+		Src:  image.NewUniform(ink),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(x, y),
+	}
+	drawer.DrawString(text)
+}
+
+// chartTypeNames are the Korean names of the chart types
+var chartTypeNames = map[string]string{"bar": "막대", "line": "꺾은선", "pie": "원"}
+
+// chartDescription is the alternative text of a chart picture: its kind,
+// title, series and categories, e.g. "막대 차트: 분기 매출 (계열: 매출, 비용 / 항목: 1분기, 2분기)"
+func chartDescription(options ChartOptions, data *ChartData) string {
+	name, ok := chartTypeNames[strings.ToLower(options.Type)]
+	if !ok {
+		name = chartTypeNames["bar"]
+	}
+	description := name + " 차트"
+	if options.Title != "" {
+		description += ": " + options.Title
+	}
+	series := make([]string, len(data.Series))
+	for i, s := range data.Series {
+		series[i] = s.Name
+	}
+	return fmt.Sprintf("%s (계열: %s / 항목: %s)", description, strings.Join(series, ", "), strings.Join(data.Categories, ", "))
+}
+
+// chartDefaultWidthMM is the picture width when ChartOptions.WidthMM is unset
+const chartDefaultWidthMM = 120
+
+// InsertTableChart draws a chart of a table (see ReadTable for index) and
+// inserts it at the cursor; a chart of the table under the cursor goes below
+// the table. The title is written above the picture and the legend below it.
+func (h *Controller) InsertTableChart(index int, options ChartOptions) (*ChartData, error) {
+	rows, err := h.ReadTable(index)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ChartDataFromTable(rows, options.Series)
+	if err != nil {
+		return nil, err
+	}
+	if !h.isRunning || h.hwp == nil {
+		return nil, h.notConnected()
+	}
+
+	path, err := writeChartFile(data, options.Type)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(path)
+
+	if index == 0 {
+		h.exitTable()
+	}
+	if options.Title != "" {
+		if err := h.SetFontStyle("", 0, true, false, false); err != nil {
+			return nil, err
+		}
+		if err := h.InsertText(options.Title, false); err != nil {
+			return nil, err
+		}
+		if err := h.SetFontStyle("", 0, false, false, false); err != nil {
+			return nil, err
+		}
+		if err := h.InsertParagraph(); err != nil {
+			return nil, err
+		}
+	}
+
+	widthMM := options.WidthMM
+	if widthMM <= 0 {
+		widthMM = chartDefaultWidthMM
+	}
+	width := MMToHwpUnit(float64(widthMM))
+	height := width * chartHeight / chartWidth
+	if err := h.InsertImage(path, &width, &height, false, nil, nil, nil, false, true, false, false, 0, chartDescription(options, data), nil, nil, nil); err != nil {
+		return nil, err
+	}
+	if err := h.InsertParagraph(); err != nil {
+		return nil, err
+	}
+	return data, h.insertChartLegend(data, options.Type)
+}
+
+// insertChartLegend writes a colored square and name per series, or per
+// slice for pie charts, and the names of numbered categories
+func (h *Controller) insertChartLegend(data *ChartData, chartType string) error {
+	var names []string
+	if strings.EqualFold(chartType, "pie") {
+		for i, category := range data.Categories {
+			if label := data.CategoryLabel(i); label != category {
+				category = label + ": " + category
+			}
+			names = append(names, category)
+		}
+	} else {
+		for _, s := range data.Series {
+			names = append(names, s.Name)
+		}
+	}
+
+	for i, name := range names {
+		if err := h.SetFontStyle("", 0, false, false, false, ChartColor(i)); err != nil {
+			return err
+		}
+		if err := h.InsertText("■ ", false); err != nil {
+			return err
+		}
+		if err := h.SetFontStyle("", 0, false, false, false, "black"); err != nil {
+			return err
+		}
+		if err := h.InsertText(name+"   ", false); err != nil {
+			return err
+		}
+	}
+
+	if data.NumberedCategories() && !strings.EqualFold(chartType, "pie") {
+		if err := h.InsertParagraph(); err != nil {
+			return err
+		}
+		keys := make([]string, len(data.Categories))
+		for i, category := range data.Categories {
+			keys[i] = fmt.Sprintf("%s: %s", data.CategoryLabel(i), category)
+		}
+		if err := h.InsertText(strings.Join(keys, ", "), false); err != nil {
+			return err
+		}
+	}
+	return h.InsertParagraph()
+}
+
+// writeChartFile renders a chart to a temporary PNG file and returns its path
+func writeChartFile(data *ChartData, chartType string) (string, error) {
+	encoded, err := RenderChart(data, chartType)
+	if err != nil {
+		return "", err
+	}
+	file, err := os.CreateTemp("", "hwp-chart-*.png")
+	if err != nil {
+		return "", fmt.Errorf("failed to create chart file: %v", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(encoded); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write chart file: %v", err)
+	}
+	return file.Name(), nil
+}
@@ -0,0 +1,536 @@
+package hwp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Citations
+//
+// A bibliography is a list of sources loaded for the open document, from
+// JSON or BibTeX. Citations are inserted as plain text, (Kim, 2023) or [1], so
+// they survive any backend and file format; numbers are given in the order
+// sources are first cited, and the references section lists the sources in
+// that order, or by author and year for author-year citations. The
+// bibliography belongs to the document and is dropped when another document
+// is created, opened or the document is closed.
+
+// Citation styles
+const (
+	CitationAuthorYear = "author_year" // (Kim, 2023)
+	CitationNumbered   = "numbered"    // [1]
+)
+
+// CitationStyles lists the citation styles
+var CitationStyles = []string{CitationAuthorYear, CitationNumbered}
+
+// Source is a work that can be cited
+type Source struct {
+	Key       string   `json:"key"`
+	Authors   []string `json:"authors,omitempty"`
+	Title     string   `json:"title"`
+	Year      string   `json:"year,omitempty"`
+	Container string   `json:"container,omitempty"` // journal, or book of a chapter or paper
+	Publisher string   `json:"publisher,omitempty"`
+	Volume    string   `json:"volume,omitempty"`
+	Issue     string   `json:"issue,omitempty"`
+	Pages     string   `json:"pages,omitempty"`
+	DOI       string   `json:"doi,omitempty"`
+	URL       string   `json:"url,omitempty"`
+}
+
+// Bibliography is the set of sources of a document and the order they were
+// cited in
+type Bibliography struct {
+	Style   string
+	sources map[string]Source
+	keys    []string // in load order
+	cited   []string // in order of first citation
+}
+
+// NewBibliography checks sources and returns a bibliography citing them in
+// style
+func NewBibliography(sources []Source, style string) (*Bibliography, error) {
+	if style != CitationAuthorYear && style != CitationNumbered {
+		return nil, fmt.Errorf("invalid citation style: %s (use %s)", style, strings.Join(CitationStyles, ", "))
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("the bibliography has no sources")
+	}
+	b := &Bibliography{Style: style, sources: map[string]Source{}}
+	for i, source := range sources {
+		if source.Key == "" {
+			return nil, fmt.Errorf("source %d has no key", i)
+		}
+		if source.Title == "" {
+			return nil, fmt.Errorf("source %q has no title", source.Key)
+		}
+		if _, dup := b.sources[source.Key]; dup {
+			return nil, fmt.Errorf("source key %q is used twice", source.Key)
+		}
+		b.sources[source.Key] = source
+		b.keys = append(b.keys, source.Key)
+	}
+	return b, nil
+}
+
+// Len returns the number of sources
+func (b *Bibliography) Len() int {
+	return len(b.keys)
+}
+
+// Cited returns the number of sources cited so far
+func (b *Bibliography) Cited() int {
+	return len(b.cited)
+}
+
+// Cite returns the citation text for the sources with the given keys and
+// records them as cited. page, if set, is the cited page or page range.
+func (b *Bibliography) Cite(keys []string, page string) (string, error) {
+	if len(keys) == 0 {
+		return "", fmt.Errorf("no source keys given")
+	}
+	for _, key := range keys {
+		if _, ok := b.sources[key]; !ok {
+			return "", fmt.Errorf("source %q is not in the bibliography", key)
+		}
+	}
+
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		number := b.number(key)
+		if number == 0 {
+			b.cited = append(b.cited, key)
+			number = len(b.cited)
+		}
+		if b.Style == CitationNumbered {
+			parts[i] = fmt.Sprint(number)
+		} else {
+			source := b.sources[key]
+			parts[i] = authorLabel(source) + ", " + yearLabel(source.Year)
+		}
+	}
+
+	if b.Style == CitationNumbered {
+		text := strings.Join(parts, ", ")
+		if page != "" {
+			text += ", " + pageLabel(page)
+		}
+		return "[" + text + "]", nil
+	}
+	text := strings.Join(parts, "; ")
+	if page != "" {
+		text += ", " + pageLabel(page)
+	}
+	return "(" + text + ")", nil
+}
+
+// number returns the citation number of key, or 0 if it hasn't been cited
+func (b *Bibliography) number(key string) int {
+	for i, cited := range b.cited {
+		if cited == key {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// References returns the entries of the references section: the cited
+// sources, or all of them if all is set. Numbered entries are in citation
+// order, sources never cited following in load order; author-year entries
+// are sorted by author and year.
+func (b *Bibliography) References(all bool) []string {
+	keys := append([]string{}, b.cited...)
+	if all {
+		for _, key := range b.keys {
+			if b.number(key) == 0 {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	if b.Style == CitationAuthorYear {
+		sort.SliceStable(keys, func(i, j int) bool {
+			a, c := b.sources[keys[i]], b.sources[keys[j]]
+			if x, y := sortName(a), sortName(c); x != y {
+				return x < y
+			}
+			return a.Year < c.Year
+		})
+	}
+
+	entries := make([]string, len(keys))
+	for i, key := range keys {
+		entry := formatReference(b.sources[key])
+		if b.Style == CitationNumbered {
+			entry = fmt.Sprintf("[%d] %s", i+1, entry)
+		}
+		entries[i] = entry
+	}
+	return entries
+}
+
+// sortName is what author-year references are sorted by: the authors, or the
+// title of a work without authors
+func sortName(source Source) string {
+	if len(source.Authors) == 0 {
+		return strings.ToLower(source.Title)
+	}
+	return strings.ToLower(authorList(source.Authors))
+}
+
+// formatReference writes a source in the author-date form common to Korean
+// and APA reports: 저자 (연도). 제목. 수록지, 권(호), 쪽. 출판사. DOI
+func formatReference(source Source) string {
+	var b strings.Builder
+	if len(source.Authors) > 0 {
+		b.WriteString(authorList(source.Authors) + " (" + yearLabel(source.Year) + "). ")
+		b.WriteString(strings.TrimSuffix(source.Title, ".") + ".")
+	} else {
+		// A work without authors starts with its title
+		b.WriteString(strings.TrimSuffix(source.Title, ".") + " (" + yearLabel(source.Year) + ").")
+	}
+
+	if source.Container != "" {
+		b.WriteString(" " + source.Container)
+		if source.Volume != "" {
+			b.WriteString(", " + source.Volume)
+			if source.Issue != "" {
+				b.WriteString("(" + source.Issue + ")")
+			}
+		}
+		if source.Pages != "" {
+			b.WriteString(", " + strings.ReplaceAll(source.Pages, "--", "–"))
+		}
+		b.WriteString(".")
+	}
+	if source.Publisher != "" {
+		b.WriteString(" " + strings.TrimSuffix(source.Publisher, ".") + ".")
+	}
+	switch {
+	case source.DOI != "":
+		b.WriteString(" https://doi.org/" + strings.TrimPrefix(source.DOI, "https://doi.org/"))
+	case source.URL != "":
+		b.WriteString(" " + source.URL)
+	}
+	return b.String()
+}
+
+// authorList joins the names of all authors
+func authorList(authors []string) string {
+	if hasHangul(authors) {
+		return strings.Join(authors, ", ")
+	}
+	switch len(authors) {
+	case 0:
+		return ""
+	case 1:
+		return authors[0]
+	}
+	return strings.Join(authors[:len(authors)-1], ", ") + ", & " + authors[len(authors)-1]
+}
+
+// authorLabel names the authors of a source in a citation: 홍길동 or Kim; two
+// authors are joined, three or more shortened to the first and 외 or et al.
+// A work without authors is cited by its title.
+func authorLabel(source Source) string {
+	authors := source.Authors
+	if len(authors) == 0 {
+		return source.Title
+	}
+	korean := hasHangul(authors)
+	first := familyName(authors[0], korean)
+	switch {
+	case len(authors) == 1:
+		return first
+	case len(authors) == 2 && korean:
+		return first + "·" + familyName(authors[1], korean)
+	case len(authors) == 2:
+		return first + " & " + familyName(authors[1], korean)
+	case korean:
+		return first + " 외"
+	}
+	return first + " et al."
+}
+
+// familyName returns the family name of "Family, Given" or "Given Family";
+// Korean names are cited whole
+func familyName(name string, korean bool) string {
+	name = strings.TrimSpace(name)
+	if korean {
+		return name
+	}
+	if family, _, found := strings.Cut(name, ","); found {
+		return strings.TrimSpace(family)
+	}
+	if fields := strings.Fields(name); len(fields) > 1 {
+		return fields[len(fields)-1]
+	}
+	return name
+}
+
+// hasHangul reports whether the first author's name is in Hangul
+func hasHangul(authors []string) bool {
+	if len(authors) == 0 {
+		return false
+	}
+	for _, r := range authors[0] {
+		if unicode.Is(unicode.Hangul, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// yearLabel returns the year, or n.d. for an undated source
+func yearLabel(year string) string {
+	if year == "" {
+		return "n.d."
+	}
+	return year
+}
+
+// pageLabel returns "p. 12" or "pp. 12-15"
+func pageLabel(page string) string {
+	if strings.ContainsAny(page, "-–,") {
+		return "pp. " + strings.ReplaceAll(page, "--", "–")
+	}
+	return "p. " + page
+}
+
+// ParseBibTeX reads the entries of a BibTeX database. @string, @preamble and
+// @comment blocks are skipped, and braces used to protect case are removed.
+func ParseBibTeX(text string) ([]Source, error) {
+	var sources []Source
+	p := &bibtexParser{text: text}
+	for {
+		at := strings.IndexByte(p.text[p.pos:], '@')
+		if at < 0 {
+			break
+		}
+		p.pos += at + 1
+		entryType := strings.ToLower(p.identifier())
+		p.skipSpace()
+		if p.pos >= len(p.text) || (p.text[p.pos] != '{' && p.text[p.pos] != '(') {
+			return nil, fmt.Errorf("bibtex line %d: expected { after @%s", p.line(), entryType)
+		}
+		open := p.pos
+		p.pos++
+
+		if entryType == "string" || entryType == "preamble" || entryType == "comment" {
+			p.pos = open
+			closing := byte('}')
+			if p.text[open] == '(' {
+				closing = ')'
+			}
+			if _, err := p.group(p.text[open], closing); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		source, err := p.entry()
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("bibtex: no entries found")
+	}
+	return sources, nil
+}
+
+// bibtexParser reads BibTeX text from pos
+type bibtexParser struct {
+	text string
+	pos  int
+}
+
+// line returns the line number of pos for errors
+func (p *bibtexParser) line() int {
+	return strings.Count(p.text[:p.pos], "\n") + 1
+}
+
+func (p *bibtexParser) skipSpace() {
+	for p.pos < len(p.text) && strings.ContainsRune(" \t\r\n", rune(p.text[p.pos])) {
+		p.pos++
+	}
+}
+
+// identifier reads an entry type, key or field name
+func (p *bibtexParser) identifier() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.text) && !strings.ContainsRune(" \t\r\n{}(),=#\"", rune(p.text[p.pos])) {
+		p.pos++
+	}
+	return p.text[start:p.pos]
+}
+
+// entry reads "key, field = value, ..." up to the closing brace
+func (p *bibtexParser) entry() (Source, error) {
+	key := p.identifier()
+	if key == "" {
+		return Source{}, fmt.Errorf("bibtex line %d: entry has no key", p.line())
+	}
+	fields := map[string]string{}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.text) {
+			return Source{}, fmt.Errorf("bibtex: entry %q is not closed", key)
+		}
+		switch p.text[p.pos] {
+		case ',':
+			p.pos++
+			continue
+		case '}', ')':
+			p.pos++
+			return bibtexSource(key, fields), nil
+		}
+
+		name := strings.ToLower(p.identifier())
+		p.skipSpace()
+		if name == "" || p.pos >= len(p.text) || p.text[p.pos] != '=' {
+			return Source{}, fmt.Errorf("bibtex line %d: expected field = value in entry %q", p.line(), key)
+		}
+		p.pos++
+		value, err := p.value()
+		if err != nil {
+			return Source{}, fmt.Errorf("%v in entry %q", err, key)
+		}
+		fields[name] = value
+	}
+}
+
+// value reads a braced, quoted or bare field value
+func (p *bibtexParser) value() (string, error) {
+	p.skipSpace()
+	if p.pos >= len(p.text) {
+		return "", fmt.Errorf("bibtex: missing value")
+	}
+	switch p.text[p.pos] {
+	case '{':
+		return p.braced()
+	case '"':
+		start := p.pos + 1
+		depth := 0
+		for p.pos = start; p.pos < len(p.text); p.pos++ {
+			switch p.text[p.pos] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+			case '"':
+				if depth == 0 {
+					value := p.text[start:p.pos]
+					p.pos++
+					return cleanBibTeX(value), nil
+				}
+			}
+		}
+		return "", fmt.Errorf("bibtex line %d: unterminated quoted value", p.line())
+	}
+	return p.identifier(), nil
+}
+
+// braced reads a {...} group with nested braces, returning its contents
+func (p *bibtexParser) braced() (string, error) {
+	return p.group('{', '}')
+}
+
+// group reads a group from the open character at pos to its matching close
+// character, returning its contents
+func (p *bibtexParser) group(open, close byte) (string, error) {
+	start := p.pos
+	depth := 0
+	for ; p.pos < len(p.text); p.pos++ {
+		switch p.text[p.pos] {
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				value := p.text[start+1 : p.pos]
+				p.pos++
+				return cleanBibTeX(value), nil
+			}
+		}
+	}
+	p.pos = start
+	return "", fmt.Errorf("bibtex line %d: unbalanced %c%c", p.line(), open, close)
+}
+
+// cleanBibTeX removes protecting braces and collapses white space
+func cleanBibTeX(value string) string {
+	value = strings.NewReplacer("{", "", "}", "", "\\&", "&", "~", " ").Replace(value)
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// bibtexSource maps BibTeX fields to a Source
+func bibtexSource(key string, fields map[string]string) Source {
+	source := Source{
+		Key:       key,
+		Title:     fields["title"],
+		Year:      fields["year"],
+		Container: fields["journal"],
+		Publisher: fields["publisher"],
+		Volume:    fields["volume"],
+		Issue:     fields["number"],
+		Pages:     fields["pages"],
+		DOI:       fields["doi"],
+		URL:       fields["url"],
+	}
+	if source.Container == "" {
+		source.Container = fields["booktitle"]
+	}
+	if source.Publisher == "" {
+		source.Publisher = fields["institution"]
+	}
+	authors := fields["author"]
+	if authors == "" {
+		authors = fields["editor"]
+	}
+	for _, author := range strings.Split(authors, " and ") {
+		if author = strings.TrimSpace(author); author != "" {
+			source.Authors = append(source.Authors, author)
+		}
+	}
+	return source
+}
+
+// SetBibliography makes b the bibliography of the open document, replacing
+// any earlier one and its citation numbers
+func (h *Controller) SetBibliography(b *Bibliography) {
+	h.bibliography = b
+}
+
+// Bibliography returns the bibliography of the open document
+func (h *Controller) Bibliography() (*Bibliography, error) {
+	if h.bibliography == nil {
+		return nil, fmt.Errorf("no bibliography is loaded; load one with hwp_set_bibliography")
+	}
+	return h.bibliography, nil
+}
+
+// InsertCitation inserts the citation of the sources with the given keys at
+// the cursor and returns its text
+func (h *Controller) InsertCitation(keys []string, page string) (string, error) {
+	b, err := h.Bibliography()
+	if err != nil {
+		return "", err
+	}
+	cited := append([]string{}, b.cited...)
+	text, err := b.Cite(keys, page)
+	if err != nil {
+		return "", err
+	}
+	if err := h.InsertText(text, false); err != nil {
+		// The citation isn't in the document, so its numbers aren't used
+		b.cited = cited
+		return "", err
+	}
+	return text, nil
+}
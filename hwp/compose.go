@@ -0,0 +1,150 @@
+package hwp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-ole/go-ole"
+)
+
+// Document composition
+//
+// Pages of another file are brought in through a temporary file rather than
+// the clipboard, so the user's clipboard is left alone: a hidden HWP instance
+// opens the source, cuts it down to the wanted pages and saves the rest, which
+// the InsertFile action then inserts at the cursor of the open document.
+
+// gotoPageIndex is the GotoE selection index that makes Goto move to a page
+const gotoPageIndex = 1
+
+// PageCount returns the number of pages of the open document
+func (h *Controller) PageCount() (int, error) {
+	if h.hwpx != nil {
+		return 0, ErrCOMRequired
+	}
+	if !h.isRunning || h.hwp == nil {
+		return 0, h.notConnected()
+	}
+	countVar, err := safeGetProperty(h.hwp, "PageCount")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the page count: %v", err)
+	}
+	defer countVar.Clear()
+	return variantInt(countVar), nil
+}
+
+// gotoPage moves the cursor to the start of a 1-based page
+func (h *Controller) gotoPage(page int) error {
+	return h.executeAction("Goto", "GotoE", func(pset *ole.IDispatch) error {
+		if err := setDispatchProperties(pset, []propertyValue{{"SetSelectionIndex", gotoPageIndex}}); err != nil {
+			return err
+		}
+		return setParameterItems(pset, map[string]interface{}{"DialogResult": page})
+	})
+}
+
+// deleteFromPage deletes from the start of page towards the start or the end
+// of the document, with selectAction MoveSelDocBegin or MoveSelDocEnd
+func (h *Controller) deleteFromPage(page int, selectAction string) error {
+	if err := h.gotoPage(page); err != nil {
+		return err
+	}
+	if err := h.runAction("MovePageBegin"); err != nil {
+		return err
+	}
+	if err := h.runAction(selectAction); err != nil {
+		return err
+	}
+	return h.runAction("Delete")
+}
+
+// InsertDocumentPages inserts pages from to to (1-based and inclusive; to 0
+// means the last page) of the file at path at the cursor, keeping their
+// character and paragraph formatting and styles. It returns the number of
+// pages taken. The source is opened in a separate hidden HWP instance, so it
+// must run on the HWP operation thread.
+func (h *Controller) InsertDocumentPages(path string, from, to int) (int, error) {
+	if h.hwpx != nil {
+		return 0, ErrCOMRequired
+	}
+	if !h.isRunning || h.hwp == nil {
+		return 0, h.notConnected()
+	}
+	if from < 1 {
+		return 0, fmt.Errorf("from must be at least 1")
+	}
+
+	src, err := filepath.Abs(path)
+	if err != nil {
+		return 0, err
+	}
+	if info, err := os.Stat(src); err != nil {
+		return 0, fmt.Errorf("source file not found: %s", src)
+	} else if info.IsDir() {
+		return 0, fmt.Errorf("source is a directory: %s", src)
+	}
+
+	source, closeSource, err := openHidden(src)
+	if err != nil {
+		return 0, err
+	}
+	defer closeSource()
+
+	pages, err := source.PageCount()
+	if err != nil {
+		return 0, err
+	}
+	if to == 0 {
+		to = pages
+	}
+	if from > pages || to > pages {
+		return 0, fmt.Errorf("%s has %d pages", src, pages)
+	}
+	if to < from {
+		return 0, fmt.Errorf("to (%d) is before from (%d)", to, from)
+	}
+
+	// Cut the tail first so the page numbers of the head stay valid
+	if to < pages {
+		if err := source.deleteFromPage(to+1, "MoveSelDocEnd"); err != nil {
+			return 0, fmt.Errorf("failed to drop pages after %d: %v", to, err)
+		}
+	}
+	if from > 1 {
+		if err := source.deleteFromPage(from, "MoveSelDocBegin"); err != nil {
+			return 0, fmt.Errorf("failed to drop pages before %d: %v", from, err)
+		}
+	}
+
+	temp, err := os.CreateTemp("", "hwp-pages-*.hwp")
+	if err != nil {
+		return 0, err
+	}
+	tempPath := temp.Name()
+	temp.Close()
+	defer os.Remove(tempPath)
+
+	saved, err := safeCallMethod(source.hwp, "SaveAs", tempPath, "HWP", "")
+	if err != nil {
+		return 0, fmt.Errorf("failed to save the extracted pages: %v", err)
+	}
+	defer saved.Clear()
+	if ok, isBool := saved.Value().(bool); isBool && !ok {
+		return 0, fmt.Errorf("HWP could not save the extracted pages")
+	}
+
+	err = h.executeAction("InsertFile", "InsertFile", func(pset *ole.IDispatch) error {
+		return setDispatchProperties(pset, []propertyValue{
+			{"FileName", tempPath},
+			{"KeepSection", 0},
+			{"KeepCharshape", 1},
+			{"KeepParashape", 1},
+			{"KeepStyle", 1},
+		})
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert the pages: %v", err)
+	}
+	return to - from + 1, nil
+}
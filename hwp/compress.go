@@ -0,0 +1,320 @@
+package hwp
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/disintegration/imaging"
+)
+
+// Image compression
+//
+// Large photos make generated documents too big to email. Images are
+// downscaled to a limit on their longest edge in pixels, or on the pixels per
+// inch at the size they are shown, and JPEGs are saved again at a lower
+// quality. An image is only replaced when the result is smaller.
+
+// DefaultImageQuality is the JPEG quality compressed images are saved at
+const DefaultImageQuality = 85
+
+// hwpUnitsPerInch is the number of HWPUNITs in an inch
+const hwpUnitsPerInch = 7200
+
+// screenDPI is the resolution HWP shows images at their original size
+const screenDPI = 96
+
+// ImageCompression limits the resolution of images. Zero limits are not
+// applied; Quality 0 is DefaultImageQuality.
+type ImageCompression struct {
+	MaxPixels int // longest edge in pixels
+	MaxDPI    int // pixels per inch at the displayed size
+	Quality   int // JPEG quality, 1-100
+}
+
+// Validate checks the compression's values
+func (c ImageCompression) Validate() error {
+	if c.MaxPixels < 0 || c.MaxDPI < 0 {
+		return fmt.Errorf("image limits cannot be negative")
+	}
+	if c.Quality < 0 || c.Quality > 100 {
+		return fmt.Errorf("invalid JPEG quality: %d (use 1-100)", c.Quality)
+	}
+	return nil
+}
+
+// quality returns the JPEG quality to save at
+func (c ImageCompression) quality() int {
+	if c.Quality == 0 {
+		return DefaultImageQuality
+	}
+	return c.Quality
+}
+
+// bounds returns the largest width and height in pixels for an image shown
+// widthHwp by heightHwp HWPUNITs; 0 means no limit
+func (c ImageCompression) bounds(widthHwp, heightHwp int) (int, int) {
+	maxWidth, maxHeight := c.MaxPixels, c.MaxPixels
+	limit := func(current, pixels int) int {
+		if pixels < 1 {
+			pixels = 1
+		}
+		if current == 0 || pixels < current {
+			return pixels
+		}
+		return current
+	}
+	if c.MaxDPI > 0 && widthHwp > 0 && heightHwp > 0 {
+		maxWidth = limit(maxWidth, widthHwp*c.MaxDPI/hwpUnitsPerInch)
+		maxHeight = limit(maxHeight, heightHwp*c.MaxDPI/hwpUnitsPerInch)
+	}
+	return maxWidth, maxHeight
+}
+
+// imageFormats maps image file extensions to the formats they are saved in
+var imageFormats = map[string]imaging.Format{
+	".jpg":  imaging.JPEG,
+	".jpeg": imaging.JPEG,
+	".png":  imaging.PNG,
+	".gif":  imaging.GIF,
+	".bmp":  imaging.BMP,
+	".tif":  imaging.TIFF,
+	".tiff": imaging.TIFF,
+}
+
+// compressImage downscales the image data of a file named name to fit
+// maxWidth by maxHeight pixels (0: no limit) and encodes it again in the same
+// format. It returns the data unchanged when the result isn't smaller or the
+// format isn't one it can write.
+func compressImage(data []byte, name string, maxWidth, maxHeight, quality int) ([]byte, bool, error) {
+	format, ok := imageFormats[strings.ToLower(filepath.Ext(name))]
+	if !ok {
+		return data, false, nil
+	}
+	img, err := imaging.Decode(bytes.NewReader(data), imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read %s: %v", name, err)
+	}
+
+	resized := false
+	if size := img.Bounds().Size(); (maxWidth > 0 && size.X > maxWidth) || (maxHeight > 0 && size.Y > maxHeight) {
+		if maxWidth == 0 {
+			maxWidth = size.X
+		}
+		if maxHeight == 0 {
+			maxHeight = size.Y
+		}
+		img = imaging.Fit(img, maxWidth, maxHeight, imaging.Lanczos)
+		resized = true
+	}
+	if !resized && format != imaging.JPEG {
+		// Other formats are lossless, so saving them again gains nothing
+		return data, false, nil
+	}
+
+	var buffer bytes.Buffer
+	if err := imaging.Encode(&buffer, img, format, imaging.JPEGQuality(quality)); err != nil {
+		return nil, false, fmt.Errorf("failed to write %s: %v", name, err)
+	}
+	if buffer.Len() >= len(data) {
+		return data, false, nil
+	}
+	return buffer.Bytes(), true, nil
+}
+
+// compressImageFile compresses the image file at path for showing it
+// widthHwp by heightHwp HWPUNITs, writing the result to a temporary file. It
+// returns "" when the image is kept as it is.
+func compressImageFile(path string, widthHwp, heightHwp int, compression ImageCompression) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	maxWidth, maxHeight := compression.bounds(widthHwp, heightHwp)
+	compressed, changed, err := compressImage(data, path, maxWidth, maxHeight, compression.quality())
+	if err != nil || !changed {
+		return "", err
+	}
+
+	temp, err := os.CreateTemp("", "hwp_image_*"+filepath.Ext(path))
+	if err != nil {
+		return "", err
+	}
+	defer temp.Close()
+	if _, err := temp.Write(compressed); err != nil {
+		os.Remove(temp.Name())
+		return "", err
+	}
+	return temp.Name(), nil
+}
+
+// imageDisplaySize returns the size in HWPUNITs HWP shows an image file at
+// when inserted at its original size
+func imageDisplaySize(path string) (int, int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+	config, _, err := image.DecodeConfig(file)
+	if err != nil {
+		return 0, 0, err
+	}
+	unitsPerPixel := hwpUnitsPerInch / screenDPI
+	return config.Width * unitsPerPixel, config.Height * unitsPerPixel, nil
+}
+
+// ImageCompressionReport is the result of CompressDocumentImages
+type ImageCompressionReport struct {
+	Images      int   `json:"images"`
+	Compressed  int   `json:"compressed"`
+	BytesBefore int64 `json:"bytes_before"`
+	BytesAfter  int64 `json:"bytes_after"`
+}
+
+// CompressDocumentImages writes a copy of the document at src to dst with its
+// images compressed. HWPX documents are zip packages whose images are the
+// BinData entries, compressed directly; .hwp documents are converted to HWPX
+// and back in a hidden HWP instance, which needs the COM backend. Only
+// MaxPixels applies, as the displayed sizes aren't read. It must run on the
+// HWP operation thread.
+func CompressDocumentImages(src, dst string, compression ImageCompression) (*ImageCompressionReport, error) {
+	if err := compression.Validate(); err != nil {
+		return nil, err
+	}
+	srcExt := strings.ToLower(filepath.Ext(src))
+	dstExt := strings.ToLower(filepath.Ext(dst))
+	for _, ext := range []string{srcExt, dstExt} {
+		if ext != ".hwp" && ext != ".hwpx" {
+			return nil, fmt.Errorf("images can only be compressed in .hwp and .hwpx files, not %s", ext)
+		}
+	}
+	info, err := os.Stat(src)
+	if err != nil {
+		return nil, fmt.Errorf("source file not found: %s", src)
+	}
+	if absSrc, absDst := absolutePath(src), absolutePath(dst); strings.EqualFold(absSrc, absDst) {
+		return nil, fmt.Errorf("source and target are the same file; write the compressed copy to another file")
+	}
+	if srcExt == ".hwpx" && dstExt == ".hwpx" {
+		report, err := compressHwpxImages(src, dst, compression)
+		if err != nil {
+			return nil, err
+		}
+		report.BytesBefore = info.Size()
+		return report, nil
+	}
+	if activeBackend != BackendCOM {
+		return nil, ErrCOMRequired
+	}
+
+	workDir, err := os.MkdirTemp("", "hwp_compress_*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(workDir)
+	original := filepath.Join(workDir, "original.hwpx")
+	compressed := filepath.Join(workDir, "compressed.hwpx")
+
+	if srcExt == ".hwpx" {
+		original = src
+	} else if err := ConvertFile(src, original, "hwpx"); err != nil {
+		return nil, err
+	}
+	target := compressed
+	if dstExt == ".hwpx" {
+		target = dst
+	}
+	report, err := compressHwpxImages(original, target, compression)
+	if err != nil {
+		return nil, err
+	}
+	if dstExt == ".hwp" {
+		if err := ConvertFile(compressed, dst, "hwp"); err != nil {
+			return nil, err
+		}
+		if info, err := os.Stat(dst); err == nil {
+			report.BytesAfter = info.Size()
+		}
+	}
+	report.BytesBefore = info.Size()
+	return report, nil
+}
+
+// compressHwpxImages copies the HWPX package src to dst, compressing the
+// images under BinData/
+func compressHwpxImages(src, dst string, compression ImageCompression) (*ImageCompressionReport, error) {
+	reader, err := zip.OpenReader(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s as an HWPX package: %v", src, err)
+	}
+	defer reader.Close()
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	report := &ImageCompressionReport{}
+	maxWidth, maxHeight := compression.bounds(0, 0)
+	for _, file := range reader.File {
+		_, isImage := imageFormats[strings.ToLower(filepath.Ext(file.Name))]
+		if !strings.HasPrefix(file.Name, "BinData/") || !isImage {
+			// Entries such as the stored mimetype are copied as they are
+			if err := archive.Copy(file); err != nil {
+				return nil, fmt.Errorf("failed to copy %s: %v", file.Name, err)
+			}
+			continue
+		}
+
+		report.Images++
+		data, err := readZipEntry(file)
+		if err != nil {
+			return nil, err
+		}
+		compressed, changed, err := compressImage(data, file.Name, maxWidth, maxHeight, compression.quality())
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			report.Compressed++
+		}
+		header := &zip.FileHeader{Name: file.Name, Method: zip.Deflate, Modified: file.Modified}
+		writer, err := archive.CreateHeader(header)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write(compressed); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(dst, buffer.Bytes(), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %v", dst, err)
+	}
+	report.BytesAfter = int64(buffer.Len())
+	return report, nil
+}
+
+// absolutePath returns the absolute form of path, or path if it has none
+func absolutePath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// readZipEntry reads the contents of a zip entry
+func readZipEntry(file *zip.File) ([]byte, error) {
+	entry, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", file.Name, err)
+	}
+	defer entry.Close()
+	return io.ReadAll(entry)
+}
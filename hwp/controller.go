@@ -1,6 +1,7 @@
 package hwp
 
 import (
+	"encoding/base64"
 	"fmt"
 	"io"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -24,6 +26,17 @@ type Controller struct {
 	visible     bool
 	isRunning   bool
 	currentPath string
+	// lastMatches holds the results of the last Find for GotoMatch
+	lastMatches []FindMatch
+	// hwpx is the open document when the HWPX backend is active
+	hwpx *hwpxDocument
+	// readOnly is set when the document was opened for reading only
+	readOnly bool
+	// bibliography holds the sources cited in the open document
+	bibliography *Bibliography
+	// document counts the documents created, opened or closed, so that
+	// state kept for one document is not applied to the next
+	document int
 }
 
 var globalController *Controller
@@ -90,6 +103,11 @@ func ExecuteHWPOperationWithError(operation func() error) error {
 	return ExecuteHWPOperationWithResult(operation)
 }
 
+// QueueDepth returns the number of HWP operations waiting for the COM thread
+func QueueDepth() int {
+	return len(hwpOperationCh)
+}
+
 
 // safeCallMethod safely calls a COM method with panic recovery
 func safeCallMethod(obj *ole.IDispatch, method string, params ...interface{}) (result *ole.VARIANT, err error) {
@@ -123,6 +141,170 @@ func safeGetProperty(obj *ole.IDispatch, property string) (result *ole.VARIANT,
 	return result, err
 }
 
+// safePutProperty safely sets a COM property with panic recovery
+func safePutProperty(obj *ole.IDispatch, property string, value interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("COM property set panic: %v", r)
+		}
+	}()
+
+	if obj == nil {
+		return fmt.Errorf("COM object is nil")
+	}
+
+	_, err = oleutil.PutProperty(obj, property, value)
+	return err
+}
+
+// runAction runs a parameterless HWP action such as "TableMergeCell"
+func (h *Controller) runAction(action string) error {
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+
+	if _, err := safeCallMethod(h.hwp, "Run", action); err != nil {
+		return fmt.Errorf("failed to run %s: %v", action, err)
+	}
+	return nil
+}
+
+// executeAction runs an HWP action with its parameter set. The apply callback
+// receives the parameter set object (e.g. HParameterSet.HTableStrToTbl) after
+// its defaults have been loaded and may set any items before execution.
+func (h *Controller) executeAction(action, parameterSet string, apply func(pset *ole.IDispatch) error) error {
+	_, err := h.executeActionResult(action, parameterSet, apply)
+	return err
+}
+
+// executeActionResult is executeAction for actions whose outcome matters, such as
+// RepeatFind; it reports whether HWP's Execute call succeeded.
+func (h *Controller) executeActionResult(action, parameterSet string, apply func(pset *ole.IDispatch) error) (bool, error) {
+	set, err := h.actionDefaults(action, parameterSet)
+	if err != nil {
+		return false, err
+	}
+	defer set.Clear()
+
+	if apply != nil {
+		if err := apply(set.pset); err != nil {
+			return false, err
+		}
+	}
+
+	executed, err := safeCallMethod(set.hAction, "Execute", action, set.hSet)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute %s: %v", action, err)
+	}
+	defer executed.Clear()
+	ok, _ := executed.Value().(bool)
+	return ok, nil
+}
+
+// readActionDefaults loads the current values of an action's parameter set,
+// such as the document's summary information, without executing the action
+func (h *Controller) readActionDefaults(action, parameterSet string, read func(pset *ole.IDispatch) error) error {
+	set, err := h.actionDefaults(action, parameterSet)
+	if err != nil {
+		return err
+	}
+	defer set.Clear()
+	return read(set.pset)
+}
+
+// actionSet is a parameter set loaded with an action's defaults
+type actionSet struct {
+	hAction *ole.IDispatch
+	hSet    *ole.IDispatch
+	pset    *ole.IDispatch
+	clear   []*ole.VARIANT
+}
+
+// Clear releases the COM objects of the set
+func (s *actionSet) Clear() {
+	for i := len(s.clear) - 1; i >= 0; i-- {
+		s.clear[i].Clear()
+	}
+}
+
+// actionDefaults gets HParameterSet.H<parameterSet> and fills it with the
+// defaults of action
+func (h *Controller) actionDefaults(action, parameterSet string) (*actionSet, error) {
+	if !h.isRunning || h.hwp == nil {
+		return nil, h.notConnected()
+	}
+
+	set := &actionSet{}
+	hActionVar, err := safeGetProperty(h.hwp, "HAction")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HAction: %v", err)
+	}
+	set.clear = append(set.clear, hActionVar)
+	set.hAction = hActionVar.ToIDispatch()
+
+	hParameterSetVar, err := safeGetProperty(h.hwp, "HParameterSet")
+	if err != nil {
+		set.Clear()
+		return nil, fmt.Errorf("failed to get HParameterSet: %v", err)
+	}
+	set.clear = append(set.clear, hParameterSetVar)
+
+	psetVar, err := safeGetProperty(hParameterSetVar.ToIDispatch(), "H"+parameterSet)
+	if err != nil {
+		set.Clear()
+		return nil, fmt.Errorf("failed to get parameter set %s: %v", parameterSet, err)
+	}
+	set.clear = append(set.clear, psetVar)
+	set.pset = psetVar.ToIDispatch()
+
+	hSetVar, err := safeGetProperty(set.pset, "HSet")
+	if err != nil {
+		set.Clear()
+		return nil, fmt.Errorf("failed to get HSet of %s: %v", parameterSet, err)
+	}
+	set.clear = append(set.clear, hSetVar)
+	set.hSet = hSetVar.ToIDispatch()
+
+	if _, err := safeCallMethod(set.hAction, "GetDefault", action, set.hSet); err != nil {
+		set.Clear()
+		return nil, fmt.Errorf("failed to get default for %s: %v", action, err)
+	}
+	return set, nil
+}
+
+// setParameterItems sets raw items on a parameter set's HSet
+func setParameterItems(pset *ole.IDispatch, items map[string]interface{}) error {
+	hSetVar, err := safeGetProperty(pset, "HSet")
+	if err != nil {
+		return fmt.Errorf("failed to get HSet: %v", err)
+	}
+	defer hSetVar.Clear()
+	hSet := hSetVar.ToIDispatch()
+
+	for name, value := range items {
+		if _, err := safeCallMethod(hSet, "SetItem", name, value); err != nil {
+			return fmt.Errorf("failed to set %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// propertyValue is a named COM property value for setDispatchProperties
+type propertyValue struct {
+	name  string
+	value interface{}
+}
+
+// setDispatchProperties sets properties on a COM object in order
+func setDispatchProperties(obj *ole.IDispatch, properties []propertyValue) error {
+	for _, property := range properties {
+		if err := safePutProperty(obj, property.name, property.value); err != nil {
+			return fmt.Errorf("failed to set %s: %v", property.name, err)
+		}
+	}
+	return nil
+}
+
 // CreateTextResult creates a text result for MCP responses
 func CreateTextResult(text string) *mcp.CallToolResult {
 	return &mcp.CallToolResult{
@@ -135,6 +317,12 @@ func CreateTextResult(text string) *mcp.CallToolResult {
 	}
 }
 
+// CreateImageResult creates a result with a text and an image for MCP
+// responses, so clients can show the image without access to the files
+func CreateImageResult(text string, image []byte, mimeType string) *mcp.CallToolResult {
+	return mcp.NewToolResultImage(text, base64.StdEncoding.EncodeToString(image), mimeType)
+}
+
 // NewController creates a new Controller instance
 func NewController() *Controller {
 	return &Controller{}
@@ -229,6 +417,9 @@ func (h *Controller) Disconnect() error {
 	h.isRunning = false
 	h.visible = false
 	h.currentPath = ""
+	h.hwpx = nil
+	h.readOnly = false
+	h.document++
 	return nil
 }
 
@@ -244,6 +435,15 @@ func (h *Controller) GetHwp() *ole.IDispatch {
 
 // CreateNewDocument creates a new document
 func (h *Controller) CreateNewDocument() error {
+	h.readOnly = false
+	h.bibliography = nil
+	h.document++
+	if activeBackend == BackendHWPX {
+		h.hwpx = newHwpxDocument()
+		h.currentPath = ""
+		return nil
+	}
+
 	// Always ensure we have a valid connection
 	if !h.isRunning || h.hwp == nil {
 		if err := h.Connect(true); err != nil {
@@ -277,8 +477,13 @@ func (h *Controller) CreateNewDocument() error {
 	return nil
 }
 
-// OpenDocument opens a document
-func (h *Controller) OpenDocument(path string) error {
+// OpenDocument opens a document. A read-only document is put in HWP's
+// read-only edit mode and tools that modify it are refused.
+func (h *Controller) OpenDocument(path string, readOnly bool) error {
+	if activeBackend == BackendHWPX {
+		return ErrCOMRequired
+	}
+
 	if !h.isRunning {
 		if err := h.Connect(true); err != nil {
 			return err
@@ -286,16 +491,38 @@ func (h *Controller) OpenDocument(path string) error {
 	}
 	
 	_, err := safeCallMethod(h.hwp, "Open", path)
-	if err == nil {
-		h.currentPath = path
+	if err != nil {
+		return err
 	}
-	return err
+	h.currentPath = path
+	h.readOnly = false
+	h.bibliography = nil
+	h.document++
+
+	if readOnly {
+		if err := h.SetEditMode("read_only"); err != nil {
+			return err
+		}
+		h.readOnly = true
+	}
+	return nil
+}
+
+// DocumentSerial identifies the open document: it changes whenever a
+// document is created, opened, reverted or closed and when HWP is
+// disconnected
+func (h *Controller) DocumentSerial() int {
+	return h.document
 }
 
 // SaveDocument saves the document
 func (h *Controller) SaveDocument(path string) error {
+	if h.hwpx != nil {
+		return h.saveHwpx(path)
+	}
+
 	if !h.isRunning || h.hwp == nil {
-		return fmt.Errorf("HWP not connected")
+		return h.notConnected()
 	}
 
 	if path != "" {
@@ -313,10 +540,53 @@ func (h *Controller) SaveDocument(path string) error {
 	}
 }
 
+// CloseDocument closes the active document window while keeping HWP running
+func (h *Controller) CloseDocument() error {
+	h.readOnly = false
+	h.bibliography = nil
+	h.document++
+	if h.hwpx != nil {
+		h.hwpx = nil
+		h.currentPath = ""
+		return nil
+	}
+
+	if err := h.runAction("FileClose"); err != nil {
+		return err
+	}
+	h.currentPath = ""
+	return nil
+}
+
+// RevertDocument discards unsaved changes by reopening the document from
+// currentPath. The read-only mode it was opened with is kept.
+func (h *Controller) RevertDocument() error {
+	if h.hwpx != nil {
+		return ErrCOMRequired
+	}
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+	if h.currentPath == "" {
+		return fmt.Errorf("the document has never been saved; there is nothing to revert to")
+	}
+
+	// Clear(1) drops the document without the "save changes?" dialog
+	if _, err := safeCallMethod(h.hwp, "Clear", 1); err != nil {
+		return fmt.Errorf("failed to discard changes: %v", err)
+	}
+	return h.OpenDocument(h.currentPath, h.readOnly)
+}
+
 // InsertText inserts text at current cursor position
 func (h *Controller) InsertText(text string, preserveLinebreaks bool) error {
+	if h.hwpx != nil {
+		h.hwpx.insertText(text, preserveLinebreaks)
+		return nil
+	}
+
 	if !h.isRunning || h.hwp == nil {
-		return fmt.Errorf("HWP not connected")
+		return h.notConnected()
 	}
 
 	if preserveLinebreaks && strings.Contains(text, "\n") {
@@ -340,6 +610,11 @@ func (h *Controller) InsertText(text string, preserveLinebreaks bool) error {
 }
 
 func (h *Controller) insertTextDirect(text string) error {
+	if h.hwpx != nil {
+		h.hwpx.insertText(text, false)
+		return nil
+	}
+
 	if h.hwp == nil {
 		return fmt.Errorf("HWP connection is not available")
 	}
@@ -417,8 +692,21 @@ func (h *Controller) insertTextDirect(text string) error {
 
 // SetFontStyle sets font style properties with color support
 func (h *Controller) SetFontStyle(fontName string, fontSize int, bold, italic, underline bool, color ...string) error {
+	if h.hwpx != nil {
+		rgb := ""
+		if len(color) > 0 && color[0] != "" {
+			colorValue, err := ParseColor(color[0])
+			if err != nil {
+				colorValue = colorNames["black"] // default
+			}
+			rgb = bgrToHex(colorValue)
+		}
+		h.hwpx.setCharShape(fontName, fontSize, bold, italic, underline, rgb)
+		return nil
+	}
+
 	if !h.isRunning {
-		return fmt.Errorf("HWP not connected")
+		return h.notConnected()
 	}
 
 	hAction := oleutil.MustGetProperty(h.hwp, "HAction").ToIDispatch()
@@ -452,22 +740,9 @@ func (h *Controller) SetFontStyle(fontName string, fontSize int, bold, italic, u
 
 	// Add color support
 	if len(color) > 0 && color[0] != "" {
-		// HWP uses BGR format (Blue-Green-Red)
-		// 문서 예제: 0xFF0000 = 파란색 (BGR에서 FF는 Blue 위치)
-		colorMap := map[string]int{
-			"black":  0x000000, // 검정
-			"red":    0x0000FF, // 빨강 (BGR: 00-00-FF)
-			"blue":   0xFF0000, // 파랑 (BGR: FF-00-00) - 문서 예제 확인
-			"green":  0x00FF00, // 초록 (BGR: 00-FF-00) 
-			"yellow": 0x00FFFF, // 노랑 (BGR: 00-FF-FF = 초록+빨강)
-			"purple": 0xFF00FF, // 자홍 (BGR: FF-00-FF = 파랑+빨강)
-			"cyan":   0xFFFF00, // 청록 (BGR: FF-FF-00 = 파랑+초록)
-		}
-
-		
-		colorValue := colorMap["black"] // default
-		if c, exists := colorMap[strings.ToLower(color[0])]; exists {
-			colorValue = c
+		colorValue, err := ParseColor(color[0])
+		if err != nil {
+			colorValue = colorNames["black"] // default
 		}
 		oleutil.PutProperty(hCharShape, "TextColor", colorValue)
 	}
@@ -476,10 +751,47 @@ func (h *Controller) SetFontStyle(fontName string, fontSize int, bold, italic, u
 	return err
 }
 
+// HWP uses BGR format (Blue-Green-Red)
+// 문서 예제: 0xFF0000 = 파란색 (BGR에서 FF는 Blue 위치)
+var colorNames = map[string]int{
+	"black":  0x000000, // 검정
+	"white":  0xFFFFFF, // 흰색
+	"gray":   0x808080, // 회색
+	"red":    0x0000FF, // 빨강 (BGR: 00-00-FF)
+	"blue":   0xFF0000, // 파랑 (BGR: FF-00-00) - 문서 예제 확인
+	"green":  0x00FF00, // 초록 (BGR: 00-FF-00)
+	"yellow": 0x00FFFF, // 노랑 (BGR: 00-FF-FF = 초록+빨강)
+	"purple": 0xFF00FF, // 자홍 (BGR: FF-00-FF = 파랑+빨강)
+	"cyan":   0xFFFF00, // 청록 (BGR: FF-FF-00 = 파랑+초록)
+}
+
+// ParseColor converts a color name or #RRGGBB string to an HWP BGR color value
+func ParseColor(color string) (int, error) {
+	color = strings.ToLower(strings.TrimSpace(color))
+	if c, exists := colorNames[color]; exists {
+		return c, nil
+	}
+
+	hex := strings.TrimPrefix(color, "#")
+	if len(hex) == 6 {
+		if rgb, err := strconv.ParseUint(hex, 16, 32); err == nil {
+			r, g, b := int(rgb>>16)&0xFF, int(rgb>>8)&0xFF, int(rgb)&0xFF
+			return b<<16 | g<<8 | r, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid color: %s (use a color name or #RRGGBB)", color)
+}
+
 // InsertParagraph inserts a new paragraph
 func (h *Controller) InsertParagraph() error {
+	if h.hwpx != nil {
+		h.hwpx.newParagraph()
+		return nil
+	}
+
 	if !h.isRunning {
-		return fmt.Errorf("HWP not connected")
+		return h.notConnected()
 	}
 
 	hAction := oleutil.MustGetProperty(h.hwp, "HAction").ToIDispatch()
@@ -487,10 +799,54 @@ func (h *Controller) InsertParagraph() error {
 	return err
 }
 
+// InsertPageBreak starts a new page at the cursor
+func (h *Controller) InsertPageBreak() error {
+	if h.hwpx != nil {
+		h.hwpx.insertPageBreak()
+		return nil
+	}
+	return h.runAction("BreakPage")
+}
+
+// InsertDateCode inserts a live date field that HWP updates automatically,
+// rendered with the date code format configured in HWP
+func (h *Controller) InsertDateCode() error {
+	return h.runAction("InsertDateCode")
+}
+
+// SetParagraphAlignment sets the alignment of the current paragraph
+func (h *Controller) SetParagraphAlignment(align string) error {
+	var action string
+	switch strings.ToLower(align) {
+	case "left":
+		action = "ParagraphShapeAlignLeft"
+	case "center":
+		action = "ParagraphShapeAlignCenter"
+	case "right":
+		action = "ParagraphShapeAlignRight"
+	case "justify":
+		action = "ParagraphShapeAlignJustify"
+	case "distribute":
+		action = "ParagraphShapeAlignDistribute"
+	default:
+		return fmt.Errorf("invalid alignment: %s", align)
+	}
+
+	if h.hwpx != nil {
+		h.hwpx.setAlignment(strings.ToLower(align))
+		return nil
+	}
+	return h.runAction(action)
+}
+
 // GetText gets the document text
 func (h *Controller) GetText() (string, error) {
+	if h.hwpx != nil {
+		return h.hwpx.text(), nil
+	}
+
 	if !h.isRunning {
-		return "", fmt.Errorf("HWP not connected")
+		return "", h.notConnected()
 	}
 
 	result, err := oleutil.CallMethod(h.hwp, "GetTextFile", "TEXT", "")
@@ -502,8 +858,16 @@ func (h *Controller) GetText() (string, error) {
 
 // InsertTable inserts a table
 func (h *Controller) InsertTable(rows, cols int) error {
+	if h.hwpx != nil {
+		if rows < 1 || cols < 1 {
+			return fmt.Errorf("table needs at least one row and column")
+		}
+		h.hwpx.insertTable(rows, cols)
+		return nil
+	}
+
 	if !h.isRunning {
-		return fmt.Errorf("HWP not connected")
+		return h.notConnected()
 	}
 
 	hAction := oleutil.MustGetProperty(h.hwp, "HAction").ToIDispatch()
@@ -533,10 +897,32 @@ func (h *Controller) InsertTable(rows, cols int) error {
 
 // FillTableWithData fills table with 2D data
 func (h *Controller) FillTableWithData(data [][]string, startRow, startCol int, hasHeader bool) error {
+	if h.hwpx != nil {
+		return h.hwpx.fillTable(data, startRow, startCol, hasHeader)
+	}
+
 	if !h.isRunning {
-		return fmt.Errorf("HWP not connected")
+		return h.notConnected()
+	}
+
+	h.moveToTableCellAt(startRow, startCol)
+
+	// Fill data
+	for rowIdx, rowData := range data {
+		h.fillTableRow(rowData, hasHeader && rowIdx == 0)
+
+		if rowIdx < len(data)-1 {
+			oleutil.CallMethod(h.hwp, "Run", "TableLowerCell")
+		}
 	}
 
+	h.exitTable()
+
+	return nil
+}
+
+// moveToTableCellAt moves the cursor to a 1-based cell of the table under the cursor
+func (h *Controller) moveToTableCellAt(row, col int) {
 	// Move to table start
 	oleutil.CallMethod(h.hwp, "Run", "TableSelCell")
 	oleutil.CallMethod(h.hwp, "Run", "TableSelTable")
@@ -545,45 +931,94 @@ func (h *Controller) FillTableWithData(data [][]string, startRow, startCol int,
 	oleutil.CallMethod(h.hwp, "Run", "Cancel")
 
 	// Move to start position
-	for i := 0; i < startRow-1; i++ {
+	for i := 0; i < row-1; i++ {
 		oleutil.CallMethod(h.hwp, "Run", "TableLowerCell")
 	}
-	for i := 0; i < startCol-1; i++ {
+	for i := 0; i < col-1; i++ {
 		oleutil.CallMethod(h.hwp, "Run", "TableRightCell")
 	}
+}
 
-	// Fill data
-	for rowIdx, rowData := range data {
-		for colIdx, cellValue := range rowData {
-			oleutil.CallMethod(h.hwp, "Run", "TableSelCell")
-			oleutil.CallMethod(h.hwp, "Run", "Delete")
-
-			if hasHeader && rowIdx == 0 {
-				h.SetFontStyle("", 0, true, false, false)
-				h.insertTextDirect(cellValue)
-				h.SetFontStyle("", 0, false, false, false)
-			} else {
-				h.insertTextDirect(cellValue)
-			}
+// fillTableRow fills cells to the right of the cursor and returns to the row's first cell
+func (h *Controller) fillTableRow(rowData []string, bold bool) {
+	for colIdx, cellValue := range rowData {
+		oleutil.CallMethod(h.hwp, "Run", "TableSelCell")
+		oleutil.CallMethod(h.hwp, "Run", "Delete")
 
-			if colIdx < len(rowData)-1 {
-				oleutil.CallMethod(h.hwp, "Run", "TableRightCell")
-			}
+		if bold {
+			h.SetFontStyle("", 0, true, false, false)
+			h.insertTextDirect(cellValue)
+			h.SetFontStyle("", 0, false, false, false)
+		} else {
+			h.insertTextDirect(cellValue)
 		}
 
-		if rowIdx < len(data)-1 {
-			for i := 0; i < len(rowData)-1; i++ {
-				oleutil.CallMethod(h.hwp, "Run", "TableLeftCell")
-			}
-			oleutil.CallMethod(h.hwp, "Run", "TableLowerCell")
+		if colIdx < len(rowData)-1 {
+			oleutil.CallMethod(h.hwp, "Run", "TableRightCell")
 		}
 	}
 
-	// Move cursor out of table
+	for i := 0; i < len(rowData)-1; i++ {
+		oleutil.CallMethod(h.hwp, "Run", "TableLeftCell")
+	}
+}
+
+// exitTable moves the cursor out of the table below it
+func (h *Controller) exitTable() {
 	oleutil.CallMethod(h.hwp, "Run", "TableSelCell")
 	oleutil.CallMethod(h.hwp, "Run", "Cancel")
 	oleutil.CallMethod(h.hwp, "Run", "MoveDown")
+}
+
+// Chunked table fill methods. A fill session keeps the cursor parked on the
+// first cell of the last written row between calls, so rows can be streamed
+// into a table across several tool calls.
+
+// BeginTableFill positions the cursor at the cell where a chunked fill starts
+func (h *Controller) BeginTableFill(startRow, startCol int) error {
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+
+	h.moveToTableCellAt(startRow, startCol)
+	return nil
+}
+
+// AppendTableRows writes rows during a chunked fill. When continued is true the
+// cursor first advances past the previously written row; when grow is true a new
+// row is inserted for every row written after the first one.
+func (h *Controller) AppendTableRows(data [][]string, continued, grow, boldFirst bool, progress func(written int)) error {
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+
+	for rowIdx, rowData := range data {
+		if continued || rowIdx > 0 {
+			if grow {
+				if _, err := safeCallMethod(h.hwp, "Run", "TableInsertLowerRow"); err != nil {
+					return fmt.Errorf("failed to insert row: %v", err)
+				}
+			}
+			oleutil.CallMethod(h.hwp, "Run", "TableLowerCell")
+		}
 
+		h.fillTableRow(rowData, boldFirst && rowIdx == 0)
+
+		if progress != nil {
+			progress(rowIdx + 1)
+		}
+	}
+
+	return nil
+}
+
+// EndTableFill moves the cursor out of the table after a chunked fill
+func (h *Controller) EndTableFill() error {
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+
+	h.exitTable()
 	return nil
 }
 
@@ -705,15 +1140,23 @@ func (h *Controller) calculateProportionalSize(originalWidth, originalHeight int
 	return originalWidth, originalHeight
 }
 
-// InsertImage inserts an image at the current cursor position with full Python functionality
-func (h *Controller) InsertImage(imagePath string, width, height *int, useOriginalSize bool, maxWidth, maxHeight *int, scale *float64, keepAspectRatio bool, embedded, reverse, watermark bool, effect int) error {
+// InsertImage inserts an image at the current cursor position with full Python functionality.
+// A non-empty description is set as the picture's alternative text, a
+// placement other than nil sets its text wrap and position, a transform
+// other than nil its rotation and crop, and a compression other than nil
+// downscales the image before it is inserted.
+func (h *Controller) InsertImage(imagePath string, width, height *int, useOriginalSize bool, maxWidth, maxHeight *int, scale *float64, keepAspectRatio bool, embedded, reverse, watermark bool, effect int, description string, placement *ImagePlacement, transform *ImageTransform, compression *ImageCompression) error {
 	if !h.isRunning || h.hwp == nil {
-		return fmt.Errorf("HWP not connected")
+		return h.notConnected()
+	}
+
+	items, err := pictureItems(description, placement, transform)
+	if err != nil {
+		return err
 	}
 	
 	var tempFilePath string
 	var absPath string
-	var err error
 	
 	// Handle URL or local file path
 	if strings.HasPrefix(imagePath, "http://") || strings.HasPrefix(imagePath, "https://") {
@@ -789,11 +1232,40 @@ func (h *Controller) InsertImage(imagePath string, width, height *int, useOrigin
 		}
 	}
 	
+	if compression != nil {
+		if sizeOption == 0 {
+			// Keep the size the original image would be shown at
+			displayWidth, displayHeight, err := imageDisplaySize(absPath)
+			if err != nil {
+				return fmt.Errorf("failed to read the image size: %v", err)
+			}
+			sizeOption, actualWidth, actualHeight = 1, displayWidth, displayHeight
+		}
+		compressedPath, err := compressImageFile(absPath, actualWidth, actualHeight, *compression)
+		if err != nil {
+			return fmt.Errorf("failed to compress the image: %v", err)
+		}
+		if compressedPath != "" {
+			defer os.Remove(compressedPath)
+			absPath = compressedPath
+		}
+	}
+
 	// Call InsertPicture with all parameters
-	_, err = safeCallMethod(h.hwp, "InsertPicture", absPath, embedded, sizeOption, reverse, watermark, effect, actualWidth, actualHeight)
+	pictureVar, err := safeCallMethod(h.hwp, "InsertPicture", absPath, embedded, sizeOption, reverse, watermark, effect, actualWidth, actualHeight)
 	if err != nil {
 		return fmt.Errorf("failed to insert picture: %v", err)
 	}
+	defer pictureVar.Clear()
+	if len(items) > 0 {
+		picture := pictureVar.ToIDispatch()
+		if picture == nil {
+			return fmt.Errorf("picture inserted, but HWP returned no picture to set the properties of")
+		}
+		if err := setControlItems(picture, items); err != nil {
+			return fmt.Errorf("picture inserted, but %v", err)
+		}
+	}
 	
 	// Move cursor to the right after image insertion
 	_, err = safeCallMethod(h.hwp, "Run", "CharRight")
@@ -809,7 +1281,7 @@ func (h *Controller) InsertImage(imagePath string, width, height *int, useOrigin
 // MoveToTableCell moves to a specific table cell in the given direction
 func (h *Controller) MoveToTableCell(direction string) error {
 	if !h.isRunning || h.hwp == nil {
-		return fmt.Errorf("HWP not connected")
+		return h.notConnected()
 	}
 
 	var command string
@@ -834,50 +1306,59 @@ func (h *Controller) MoveToTableCell(direction string) error {
 
 // Table manipulation methods
 
-// InsertTableColumn inserts a column in the specified direction
-func (h *Controller) InsertTableColumn(direction string) error {
-	if !h.isRunning || h.hwp == nil {
-		return fmt.Errorf("HWP not connected")
-	}
-
-	var command string
+// InsertTableColumn inserts count columns in the specified direction
+func (h *Controller) InsertTableColumn(direction string, count int) error {
 	switch direction {
 	case "left":
-		command = "TableInsertLeftColumn"
+		return h.insertTableLines(tableSideLeft, "TableInsertLeftColumn", count)
 	case "right":
-		command = "TableInsertRightColumn"
-	default:
-		return fmt.Errorf("invalid direction: %s", direction)
+		return h.insertTableLines(tableSideRight, "TableInsertRightColumn", count)
 	}
-
-	_, err := safeCallMethod(h.hwp, "Run", command)
-	return err
+	return fmt.Errorf("invalid direction: %s", direction)
 }
 
-// InsertTableRow inserts a row in the specified direction
-func (h *Controller) InsertTableRow(direction string) error {
-	if !h.isRunning || h.hwp == nil {
-		return fmt.Errorf("HWP not connected")
-	}
-
-	var command string
+// InsertTableRow inserts count rows in the specified direction
+func (h *Controller) InsertTableRow(direction string, count int) error {
 	switch direction {
 	case "upper":
-		command = "TableInsertUpperRow"
+		return h.insertTableLines(tableSideUpper, "TableInsertUpperRow", count)
 	case "lower":
-		command = "TableInsertLowerRow"
-	default:
-		return fmt.Errorf("invalid direction: %s", direction)
+		return h.insertTableLines(tableSideLower, "TableInsertLowerRow", count)
 	}
+	return fmt.Errorf("invalid direction: %s", direction)
+}
 
-	_, err := safeCallMethod(h.hwp, "Run", command)
-	return err
+// Sides of the TableInsertLine parameter set
+const (
+	tableSideLeft = iota
+	tableSideRight
+	tableSideUpper
+	tableSideLower
+)
+
+// insertTableLines inserts count rows or columns beside the current cell. A
+// single line uses the plain action; more go through TableInsertRowColumn,
+// which adds them all in one call instead of one COM round trip each.
+func (h *Controller) insertTableLines(side int, action string, count int) error {
+	if count < 1 {
+		return fmt.Errorf("count must be at least 1")
+	}
+	if count == 1 {
+		return h.runAction(action)
+	}
+
+	return h.executeAction("TableInsertRowColumn", "TableInsertLine", func(pset *ole.IDispatch) error {
+		return setDispatchProperties(pset, []propertyValue{
+			{"Side", side},
+			{"Count", count},
+		})
+	})
 }
 
 // MergeTableCells merges the currently selected table cells
 func (h *Controller) MergeTableCells() error {
 	if !h.isRunning || h.hwp == nil {
-		return fmt.Errorf("HWP not connected")
+		return h.notConnected()
 	}
 
 	_, err := safeCallMethod(h.hwp, "Run", "TableMergeCell")
@@ -887,19 +1368,74 @@ func (h *Controller) MergeTableCells() error {
 // MergeTables merges adjacent tables into one table
 func (h *Controller) MergeTables() error {
 	if !h.isRunning || h.hwp == nil {
-		return fmt.Errorf("HWP not connected")
+		return h.notConnected()
 	}
 
 	_, err := safeCallMethod(h.hwp, "Run", "TableMergeTable")
 	return err
 }
 
+// Table conversion methods
+
+// delimiterType maps a delimiter name to HWP's DelimiterType item value.
+// Any other single string is treated as a user-defined delimiter.
+func delimiterType(delimiter string) (int, string) {
+	switch strings.ToLower(delimiter) {
+	case "", "tab", "\t":
+		return 0, ""
+	case "comma", ",":
+		return 1, ""
+	case "space", " ":
+		return 2, ""
+	default:
+		return 3, delimiter
+	}
+}
+
+// ConvertTextToTable converts the selected delimited text into a table
+func (h *Controller) ConvertTextToTable(delimiter string) error {
+	delimType, userDefine := delimiterType(delimiter)
+
+	return h.executeAction("TableStringToTable", "TableStrToTbl", func(pset *ole.IDispatch) error {
+		// 0: detect columns automatically from the delimiter
+		if err := safePutProperty(pset, "AutoOrDefine", 0); err != nil {
+			return fmt.Errorf("failed to set AutoOrDefine: %v", err)
+		}
+		if err := safePutProperty(pset, "DelimiterType", delimType); err != nil {
+			return fmt.Errorf("failed to set DelimiterType: %v", err)
+		}
+		if userDefine != "" {
+			if err := safePutProperty(pset, "UserDefine", userDefine); err != nil {
+				return fmt.Errorf("failed to set UserDefine: %v", err)
+			}
+		}
+		return nil
+	})
+}
+
+// ConvertTableToText flattens the table under the cursor into delimited text
+func (h *Controller) ConvertTableToText(delimiter string) error {
+	delimType, userDefine := delimiterType(delimiter)
+
+	return h.executeAction("TableTableToString", "TableTblToStr", func(pset *ole.IDispatch) error {
+		if err := safePutProperty(pset, "DelimiterType", delimType); err != nil {
+			return fmt.Errorf("failed to set DelimiterType: %v", err)
+		}
+		if userDefine != "" {
+			if err := safePutProperty(pset, "UserDefine", userDefine); err != nil {
+				return fmt.Errorf("failed to set UserDefine: %v", err)
+			}
+		}
+		return nil
+	})
+}
+
 // Additional table utility methods
 
 // SelectTableCell selects the current table cell
 func (h *Controller) SelectTableCell() error {
 	if !h.isRunning || h.hwp == nil {
-		return fmt.Errorf("HWP not connected")
+		return h.notConnected()
 	}
 
 	_, err := safeCallMethod(h.hwp, "Run", "Select")
@@ -909,7 +1445,7 @@ func (h *Controller) SelectTableCell() error {
 // DeleteTableCellContent deletes the content of the current table cell
 func (h *Controller) DeleteTableCellContent() error {
 	if !h.isRunning || h.hwp == nil {
-		return fmt.Errorf("HWP not connected")
+		return h.notConnected()
 	}
 
 	_, err := safeCallMethod(h.hwp, "Run", "Delete")
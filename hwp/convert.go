@@ -0,0 +1,122 @@
+package hwp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// convertFormats maps conversion targets to the format names of HWP's SaveAs
+var convertFormats = map[string]string{
+	"hwp":  "HWP",
+	"hwpx": "HWPX",
+	"pdf":  "PDF",
+	"docx": "OOXML",
+	"odt":  "ODT",
+	"html": "HTML",
+	"rtf":  "RTF",
+	"txt":  "UNICODE", // UTF-16 text, so Korean survives on any code page
+}
+
+// ConvertFormats lists the formats ConvertFile can write
+func ConvertFormats() []string {
+	formats := make([]string, 0, len(convertFormats))
+	for format := range convertFormats {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// ConvertFormat returns the target format for dst: format if given,
+// otherwise the extension of dst
+func ConvertFormat(dst, format string) (string, error) {
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(dst), ".")
+	}
+	format = strings.ToLower(format)
+	if _, ok := convertFormats[format]; !ok {
+		return "", fmt.Errorf("unsupported format %q (use %s)", format, strings.Join(ConvertFormats(), ", "))
+	}
+	return format, nil
+}
+
+// ConvertFile opens src in a separate hidden HWP instance, saves it to dst in
+// format and closes it, so the open document and its state are not touched.
+// An empty format is taken from the extension of dst. It must run on the HWP
+// operation thread.
+func ConvertFile(src, dst, format string) error {
+	if activeBackend != BackendCOM {
+		return ErrCOMRequired
+	}
+	format, err := ConvertFormat(dst, format)
+	if err != nil {
+		return err
+	}
+
+	src, err = filepath.Abs(src)
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(src); err != nil {
+		return fmt.Errorf("source file not found: %s", src)
+	} else if info.IsDir() {
+		return fmt.Errorf("source is a directory: %s", src)
+	}
+	dst, err = filepath.Abs(dst)
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(filepath.Dir(dst)); err != nil || !info.IsDir() {
+		return fmt.Errorf("target directory not found: %s", filepath.Dir(dst))
+	}
+	if strings.EqualFold(src, dst) {
+		return fmt.Errorf("source and target are the same file")
+	}
+
+	controller, closeHidden, err := openHidden(src)
+	if err != nil {
+		return err
+	}
+	defer closeHidden()
+
+	saved, err := safeCallMethod(controller.hwp, "SaveAs", dst, convertFormats[format], "")
+	if err != nil {
+		return fmt.Errorf("failed to save %s: %v", dst, err)
+	}
+	defer saved.Clear()
+	if ok, isBool := saved.Value().(bool); isBool && !ok {
+		return fmt.Errorf("HWP could not save %s as %s", dst, format)
+	}
+	return nil
+}
+
+// openHidden opens the file at the absolute path src in a separate hidden HWP
+// instance. The returned function drops the document without the "save
+// changes?" dialog and quits the instance.
+func openHidden(src string) (*Controller, func(), error) {
+	controller := NewController()
+	if err := controller.Connect(false); err != nil {
+		return nil, nil, err
+	}
+	closeHidden := func() {
+		safeCallMethod(controller.hwp, "Clear", 1)
+		safeCallMethod(controller.hwp, "Quit")
+		controller.Disconnect()
+	}
+
+	// forceopen skips the dialogs HWP shows for files from other programs
+	opened, err := safeCallMethod(controller.hwp, "Open", src, "", "forceopen:true")
+	if err != nil {
+		closeHidden()
+		return nil, nil, fmt.Errorf("failed to open %s: %v", src, err)
+	}
+	defer opened.Clear()
+	if ok, isBool := opened.Value().(bool); isBool && !ok {
+		closeHidden()
+		return nil, nil, fmt.Errorf("HWP could not open %s", src)
+	}
+	return controller, closeHidden, nil
+}
@@ -0,0 +1,136 @@
+package hwp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// diagnosticsText is the text written and read back by the self-test
+const diagnosticsText = "HWP MCP 진단"
+
+// DiagnosticStep is the outcome of one self-test step
+type DiagnosticStep struct {
+	Name       string  `json:"name"`
+	Passed     bool    `json:"passed"`
+	Skipped    bool    `json:"skipped,omitempty"`
+	DurationMs float64 `json:"duration_ms"`
+	Detail     string  `json:"detail,omitempty"`
+}
+
+// DiagnosticsReport is the result of RunDiagnostics
+type DiagnosticsReport struct {
+	Backend   string           `json:"backend"`
+	Passed    bool             `json:"passed"`
+	Timestamp string           `json:"timestamp"`
+	Steps     []DiagnosticStep `json:"steps"`
+}
+
+// RunDiagnostics runs a self-test on a separate hidden controller, leaving the
+// open document alone: it creates the COM object, then creates a document,
+// inserts text, reads it back and saves it in a temporary directory that is
+// deleted afterwards. Steps after a failure are skipped. It must run on the
+// HWP operation thread.
+func RunDiagnostics() DiagnosticsReport {
+	report := DiagnosticsReport{
+		Backend:   activeBackend,
+		Passed:    true,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+
+	failed := false
+	step := func(name string, run func() (string, error)) {
+		if failed {
+			report.Steps = append(report.Steps, DiagnosticStep{Name: name, Skipped: true, Detail: "skipped after an earlier failure"})
+			return
+		}
+
+		start := time.Now()
+		detail, err := run()
+		result := DiagnosticStep{
+			Name:       name,
+			Passed:     err == nil,
+			DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+			Detail:     detail,
+		}
+		if err != nil {
+			result.Detail = err.Error()
+			failed = true
+			report.Passed = false
+		}
+		report.Steps = append(report.Steps, result)
+	}
+
+	controller := NewController()
+	defer controller.Disconnect()
+
+	if activeBackend == BackendCOM {
+		step("com_object", func() (string, error) {
+			return "HWPFrame.HwpObject created", controller.Connect(false)
+		})
+	} else {
+		report.Steps = append(report.Steps, DiagnosticStep{Name: "com_object", Passed: true, Skipped: true, Detail: "not used by the hwpx backend"})
+	}
+
+	step("create_document", func() (string, error) {
+		return "", controller.CreateNewDocument()
+	})
+
+	step("insert_text", func() (string, error) {
+		return "", controller.InsertText(diagnosticsText, false)
+	})
+
+	step("read_text", func() (string, error) {
+		text, err := controller.GetText()
+		if err != nil {
+			return "", err
+		}
+		if !strings.Contains(text, diagnosticsText) {
+			return "", fmt.Errorf("inserted text not found in document text %q", text)
+		}
+		return "inserted text read back", nil
+	})
+
+	dir, err := os.MkdirTemp("", "hwp-mcp-diagnostics-")
+	if err == nil {
+		defer os.RemoveAll(dir)
+	}
+	step("save_document", func() (string, error) {
+		if err != nil {
+			return "", fmt.Errorf("failed to create temporary directory: %v", err)
+		}
+		if err := controller.SaveDocument(filepath.Join(dir, "diagnostics.hwp")); err != nil {
+			return "", err
+		}
+		info, err := os.Stat(controller.CurrentPath())
+		if err != nil {
+			return "", fmt.Errorf("saved file not found: %v", err)
+		}
+		if info.Size() == 0 {
+			return "", fmt.Errorf("saved file is empty")
+		}
+		return fmt.Sprintf("%d bytes written", info.Size()), nil
+	})
+
+	step("delete_document", func() (string, error) {
+		if activeBackend == BackendCOM {
+			// Close the document so HWP releases the file
+			if _, err := safeCallMethod(controller.hwp, "Clear", 1); err != nil {
+				return "", fmt.Errorf("failed to close document: %v", err)
+			}
+		}
+		if err := os.Remove(controller.CurrentPath()); err != nil {
+			return "", err
+		}
+		return "temporary file removed", nil
+	})
+
+	if activeBackend == BackendCOM && controller.hwp != nil {
+		// Quit the hidden HWP instance started for the test
+		safeCallMethod(controller.hwp, "Quit")
+	}
+
+	return report
+}
@@ -0,0 +1,426 @@
+package hwp
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/go-ole/go-ole"
+)
+
+// Diagrams
+//
+// Organization charts and simple flow diagrams are drawn with HWP's own
+// drawing objects, so they stay editable: a text box for each node and
+// straight lines for the connectors, all grouped into one object that sits
+// at the cursor with the text above and below it.
+//
+// An organization chart is a tree laid out top-down, each parent centered
+// over its children and joined to them by elbow connectors. A flow diagram
+// is a graph without cycles laid out in layers: each node goes one layer
+// below the lowest of the nodes leading to it, so flows read from top to
+// bottom. Connectors are only horizontal and vertical lines, which keeps
+// the layout independent of the direction HWP draws a slanted line in.
+
+// DiagramKinds are the kinds of diagram LayoutDiagram takes
+var DiagramKinds = []string{"org", "flow"}
+
+// DiagramNode is a node of a diagram. Org charts nest nodes in Children;
+// flow diagram nodes are joined by edges between their IDs.
+type DiagramNode struct {
+	ID       string
+	Label    string
+	Children []DiagramNode
+}
+
+// DiagramEdge joins two flow diagram nodes by ID
+type DiagramEdge struct {
+	From string
+	To   string
+}
+
+// Diagram is an organization chart (Kind org, with Root) or a flow diagram
+// (Kind flow, with Nodes and Edges). WidthMM is the width it may take,
+// DefaultDiagramWidthMM when 0.
+type Diagram struct {
+	Kind    string
+	Root    *DiagramNode
+	Nodes   []DiagramNode
+	Edges   []DiagramEdge
+	WidthMM float64
+}
+
+// Diagram sizes in millimeters
+const (
+	DefaultDiagramWidthMM = 160
+	diagramBoxHeightMM    = 12
+	diagramMinBoxWidthMM  = 18
+	diagramMaxBoxWidthMM  = 40
+	diagramHGapMM         = 6
+	diagramVGapMM         = 10
+)
+
+// MaxDiagramNodes is the most nodes a diagram can have
+const MaxDiagramNodes = 60
+
+// DiagramBox is a node's box in millimeters from the diagram's top left
+// corner
+type DiagramBox struct {
+	Label    string  `json:"label"`
+	XMM      float64 `json:"x_mm"`
+	YMM      float64 `json:"y_mm"`
+	WidthMM  float64 `json:"width_mm"`
+	HeightMM float64 `json:"height_mm"`
+}
+
+// DiagramLine is a horizontal or vertical connector segment from (X1, Y1)
+// to (X2, Y2), with X1 <= X2 and Y1 <= Y2
+type DiagramLine struct {
+	X1MM float64 `json:"x1_mm"`
+	Y1MM float64 `json:"y1_mm"`
+	X2MM float64 `json:"x2_mm"`
+	Y2MM float64 `json:"y2_mm"`
+}
+
+// DiagramLayout is where the boxes and connectors of a diagram go
+type DiagramLayout struct {
+	Boxes    []DiagramBox  `json:"boxes"`
+	Lines    []DiagramLine `json:"lines"`
+	WidthMM  float64       `json:"width_mm"`
+	HeightMM float64       `json:"height_mm"`
+}
+
+// diagramGrid places boxes on a grid of columns and rows centered in the
+// diagram width
+type diagramGrid struct {
+	width    float64
+	boxWidth float64
+	shift    float64 // left margin centering the widest row
+}
+
+// newDiagramGrid sizes the boxes so that columns of them fit in width
+func newDiagramGrid(width float64, columns int) (diagramGrid, error) {
+	boxWidth := math.Min(diagramMaxBoxWidthMM, (width-float64(columns-1)*diagramHGapMM)/float64(columns))
+	if boxWidth < diagramMinBoxWidthMM {
+		fit := int((width + diagramHGapMM) / (diagramMinBoxWidthMM + diagramHGapMM))
+		return diagramGrid{}, fmt.Errorf("the diagram is %d boxes wide; at most %d fit in %gmm", columns, fit, width)
+	}
+	used := float64(columns)*boxWidth + float64(columns-1)*diagramHGapMM
+	return diagramGrid{width: width, boxWidth: boxWidth, shift: (width - used) / 2}, nil
+}
+
+// center returns the x of the center of column, counted from 0
+func (g diagramGrid) center(column float64) float64 {
+	return g.shift + column*(g.boxWidth+diagramHGapMM) + g.boxWidth/2
+}
+
+// top returns the y of the top of row
+func (g diagramGrid) top(row int) float64 {
+	return float64(row) * (diagramBoxHeightMM + diagramVGapMM)
+}
+
+// box returns the box of label centered at x in row
+func (g diagramGrid) box(label string, x float64, row int) DiagramBox {
+	return DiagramBox{Label: label, XMM: x - g.boxWidth/2, YMM: g.top(row), WidthMM: g.boxWidth, HeightMM: diagramBoxHeightMM}
+}
+
+// addLine appends a connector segment, dropping ones of no length
+func (l *DiagramLayout) addLine(x1, y1, x2, y2 float64) {
+	if x1 == x2 && y1 == y2 {
+		return
+	}
+	l.Lines = append(l.Lines, DiagramLine{
+		X1MM: math.Min(x1, x2), Y1MM: math.Min(y1, y2),
+		X2MM: math.Max(x1, x2), Y2MM: math.Max(y1, y2),
+	})
+}
+
+// addElbow joins the bottom center of an upper box to the top center of a
+// lower one: down to y, across, then down
+func (l *DiagramLayout) addElbow(x1, y1, y, x2, y2 float64) {
+	l.addLine(x1, y1, x1, y)
+	l.addLine(x1, y, x2, y)
+	l.addLine(x2, y, x2, y2)
+}
+
+// LayoutDiagram checks a diagram and places its boxes and connectors
+func LayoutDiagram(d Diagram) (*DiagramLayout, error) {
+	width := d.WidthMM
+	if width == 0 {
+		width = DefaultDiagramWidthMM
+	}
+	if width < diagramMinBoxWidthMM {
+		return nil, fmt.Errorf("invalid width: %gmm (at least %dmm)", width, diagramMinBoxWidthMM)
+	}
+
+	switch strings.ToLower(d.Kind) {
+	case "org":
+		return layoutOrgChart(d, width)
+	case "flow":
+		return layoutFlowDiagram(d, width)
+	}
+	return nil, fmt.Errorf("invalid diagram type: %s (use %s)", d.Kind, strings.Join(DiagramKinds, ", "))
+}
+
+// layoutOrgChart gives each leaf its own column and centers each parent
+// over its first and last child
+func layoutOrgChart(d Diagram, width float64) (*DiagramLayout, error) {
+	if d.Root == nil {
+		return nil, fmt.Errorf("an org chart needs a root node")
+	}
+	if len(d.Nodes) > 0 || len(d.Edges) > 0 {
+		return nil, fmt.Errorf("an org chart is a tree under root; nodes and edges are for flow diagrams")
+	}
+
+	// Count the leaves and nodes, checking the labels
+	leaves, nodes, depth := 0, 0, 0
+	var count func(node DiagramNode, path string, level int) error
+	count = func(node DiagramNode, path string, level int) error {
+		if strings.TrimSpace(node.Label) == "" {
+			return fmt.Errorf("%s: the label is required", path)
+		}
+		nodes++
+		depth = max(depth, level+1)
+		if len(node.Children) == 0 {
+			leaves++
+		}
+		for i, child := range node.Children {
+			if err := count(child, fmt.Sprintf("%s.children[%d]", path, i), level+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := count(*d.Root, "root", 0); err != nil {
+		return nil, err
+	}
+	if nodes > MaxDiagramNodes {
+		return nil, fmt.Errorf("the chart has %d nodes; at most %d fit", nodes, MaxDiagramNodes)
+	}
+
+	grid, err := newDiagramGrid(width, leaves)
+	if err != nil {
+		return nil, err
+	}
+	layout := &DiagramLayout{WidthMM: width, HeightMM: grid.top(depth) - diagramVGapMM}
+
+	// place returns the center x of node's box, placing its subtree from
+	// the next free leaf column
+	next := 0
+	var place func(node DiagramNode, row int) float64
+	place = func(node DiagramNode, row int) float64 {
+		if len(node.Children) == 0 {
+			x := grid.center(float64(next))
+			next++
+			layout.Boxes = append(layout.Boxes, grid.box(node.Label, x, row))
+			return x
+		}
+		index := len(layout.Boxes)
+		layout.Boxes = append(layout.Boxes, DiagramBox{})
+		centers := make([]float64, len(node.Children))
+		for i, child := range node.Children {
+			centers[i] = place(child, row+1)
+		}
+		x := (centers[0] + centers[len(centers)-1]) / 2
+		layout.Boxes[index] = grid.box(node.Label, x, row)
+
+		// Down from the parent to a bar over the children, then down to each
+		bottom := grid.top(row) + diagramBoxHeightMM
+		bar := bottom + diagramVGapMM/2
+		layout.addLine(x, bottom, x, bar)
+		layout.addLine(centers[0], bar, centers[len(centers)-1], bar)
+		for _, center := range centers {
+			layout.addLine(center, bar, center, grid.top(row+1))
+		}
+		return x
+	}
+	place(*d.Root, 0)
+	return layout, nil
+}
+
+// layoutFlowDiagram puts each node one layer below the lowest node leading
+// to it and centers each layer
+func layoutFlowDiagram(d Diagram, width float64) (*DiagramLayout, error) {
+	if d.Root != nil {
+		return nil, fmt.Errorf("a flow diagram takes nodes and edges; root is for org charts")
+	}
+	if len(d.Nodes) == 0 {
+		return nil, fmt.Errorf("a flow diagram needs nodes")
+	}
+	if len(d.Nodes) > MaxDiagramNodes {
+		return nil, fmt.Errorf("the diagram has %d nodes; at most %d fit", len(d.Nodes), MaxDiagramNodes)
+	}
+
+	index := map[string]int{}
+	for i, node := range d.Nodes {
+		if node.ID == "" {
+			return nil, fmt.Errorf("nodes[%d]: the id is required", i)
+		}
+		if _, ok := index[node.ID]; ok {
+			return nil, fmt.Errorf("nodes[%d]: id %q is used twice", i, node.ID)
+		}
+		if strings.TrimSpace(node.Label) == "" {
+			return nil, fmt.Errorf("nodes[%d]: the label is required", i)
+		}
+		if len(node.Children) > 0 {
+			return nil, fmt.Errorf("nodes[%d]: flow diagram nodes are joined by edges, not children", i)
+		}
+		index[node.ID] = i
+	}
+
+	incoming := make([]int, len(d.Nodes))
+	outgoing := make([][]int, len(d.Nodes))
+	for i, edge := range d.Edges {
+		from, ok := index[edge.From]
+		if !ok {
+			return nil, fmt.Errorf("edges[%d]: unknown node %q", i, edge.From)
+		}
+		to, ok := index[edge.To]
+		if !ok {
+			return nil, fmt.Errorf("edges[%d]: unknown node %q", i, edge.To)
+		}
+		if from == to {
+			return nil, fmt.Errorf("edges[%d]: node %q joins itself", i, edge.From)
+		}
+		incoming[to]++
+		outgoing[from] = append(outgoing[from], to)
+	}
+
+	// Layers by the longest path from a start, in topological order
+	layer := make([]int, len(d.Nodes))
+	var queue []int
+	for i := range d.Nodes {
+		if incoming[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+	visited := 0
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		visited++
+		for _, to := range outgoing[node] {
+			layer[to] = max(layer[to], layer[node]+1)
+			if incoming[to]--; incoming[to] == 0 {
+				queue = append(queue, to)
+			}
+		}
+	}
+	if visited < len(d.Nodes) {
+		return nil, fmt.Errorf("the edges form a loop; flow diagrams read from top to bottom")
+	}
+
+	var rows [][]int
+	for i := range d.Nodes {
+		for len(rows) <= layer[i] {
+			rows = append(rows, nil)
+		}
+		rows[layer[i]] = append(rows[layer[i]], i)
+	}
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	grid, err := newDiagramGrid(width, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	layout := &DiagramLayout{WidthMM: width, HeightMM: grid.top(len(rows)) - diagramVGapMM}
+	centers := make([]float64, len(d.Nodes))
+	layout.Boxes = make([]DiagramBox, len(d.Nodes))
+	for r, row := range rows {
+		// Center short rows under the widest
+		offset := float64(columns-len(row)) / 2
+		for c, node := range row {
+			centers[node] = grid.center(offset + float64(c))
+			layout.Boxes[node] = grid.box(d.Nodes[node].Label, centers[node], r)
+		}
+	}
+	for _, edge := range d.Edges {
+		from, to := index[edge.From], index[edge.To]
+		top := grid.top(layer[to])
+		layout.addElbow(centers[from], grid.top(layer[from])+diagramBoxHeightMM, top-diagramVGapMM/2, centers[to], top)
+	}
+	return layout, nil
+}
+
+// diagramItems returns the ShapeObject items placing a part of a diagram in
+// front of the text, relative to the column and the paragraph at the cursor
+func diagramItems(x, y, width, height float64) []propertyValue {
+	return []propertyValue{
+		{"TreatAsChar", false},
+		{"TextWrap", 3},
+		{"HorzRelTo", 2},
+		{"VertRelTo", 2},
+		{"HorzAlign", 0},
+		{"VertAlign", 0},
+		{"HorzOffset", MMToHwpUnit(x)},
+		{"VertOffset", MMToHwpUnit(y)},
+		{"Width", MMToHwpUnit(width)},
+		{"Height", MMToHwpUnit(height)},
+	}
+}
+
+// InsertDiagram draws a diagram at the cursor and groups it into one
+// object, with the text above and below it. It returns the layout drawn.
+func (h *Controller) InsertDiagram(d Diagram) (*DiagramLayout, error) {
+	layout, err := LayoutDiagram(d)
+	if err != nil {
+		return nil, err
+	}
+	if h.hwpx != nil {
+		return nil, ErrCOMRequired
+	}
+	if !h.isRunning || h.hwp == nil {
+		return nil, h.notConnected()
+	}
+
+	var controls []*ole.IDispatch
+	var labels []string
+	for i, box := range layout.Boxes {
+		label := fmt.Sprintf("box %d", i+1)
+		ctrl, err := h.insertDrawingObject("DrawObjCreatorTextBox", diagramItems(box.XMM, box.YMM, box.WidthMM, box.HeightMM))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", label, err)
+		}
+		if err := h.typeIntoTextBox(ctrl, label, box.Label); err != nil {
+			return nil, err
+		}
+		controls = append(controls, ctrl)
+		labels = append(labels, label)
+	}
+	for i, line := range layout.Lines {
+		label := fmt.Sprintf("connector %d", i+1)
+		ctrl, err := h.insertDrawingObject("DrawObjCreatorLine", diagramItems(line.X1MM, line.Y1MM, line.X2MM-line.X1MM, line.Y2MM-line.Y1MM))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", label, err)
+		}
+		controls = append(controls, ctrl)
+		labels = append(labels, label)
+	}
+	if len(controls) == 1 {
+		return layout, setControlItems(controls[0], []propertyValue{{"TextWrap", 1}})
+	}
+
+	existing := map[string]bool{}
+	all, err := h.drawingControls()
+	if err != nil {
+		return nil, err
+	}
+	for _, ctrl := range all {
+		existing[controlInstID(ctrl)] = true
+	}
+	if err := h.groupControls(controls, labels); err != nil {
+		return nil, err
+	}
+	group, err := h.newDrawingControl(existing)
+	if err != nil {
+		return nil, err
+	}
+	// The group keeps the text off the diagram, above and below it
+	if err := setControlItems(group, []propertyValue{{"TextWrap", 1}}); err != nil {
+		return nil, err
+	}
+	return layout, nil
+}
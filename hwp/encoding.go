@@ -0,0 +1,94 @@
+package hwp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Text file encodings
+//
+// Korean text files are usually UTF-8 or EUC-KR (CP949, the code page of
+// Korean Windows). Reading one in the wrong encoding silently turns Hangul
+// into mojibake, so DecodeText refuses bytes that aren't valid in the
+// requested encoding instead of replacing them.
+
+// TextEncodings lists the encodings DecodeText accepts
+var TextEncodings = []string{"auto", "utf-8", "utf-16le", "utf-16be", "cp949", "euc-kr"}
+
+// DecodeText converts file contents in encoding to a string with \n line
+// breaks. "auto" honors a byte order mark, then takes valid UTF-8 as UTF-8
+// and anything else as CP949. It returns the encoding that was used.
+func DecodeText(data []byte, encoding string) (string, string, error) {
+	encoding = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(encoding), "_", "-"))
+	switch encoding {
+	case "", "auto":
+		switch {
+		case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+			encoding = "utf-16le"
+		case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+			encoding = "utf-16be"
+		case utf8.Valid(data):
+			encoding = "utf-8"
+		default:
+			encoding = "cp949"
+		}
+	case "utf8":
+		encoding = "utf-8"
+	case "uhc", "ks-c-5601-1987", "ms949":
+		encoding = "cp949"
+	}
+
+	var text string
+	switch encoding {
+	case "utf-8":
+		data = bytes.TrimPrefix(data, []byte{0xEF, 0xBB, 0xBF})
+		if !utf8.Valid(data) {
+			return "", encoding, fmt.Errorf("the file is not valid UTF-8 at byte %d; it may be EUC-KR (cp949)", invalidUTF8Offset(data))
+		}
+		text = string(data)
+	case "utf-16le", "utf-16be":
+		var order binary.ByteOrder = binary.LittleEndian
+		bom := []byte{0xFF, 0xFE}
+		if encoding == "utf-16be" {
+			order, bom = binary.BigEndian, []byte{0xFE, 0xFF}
+		}
+		data = bytes.TrimPrefix(data, bom)
+		if len(data)%2 != 0 {
+			return "", encoding, fmt.Errorf("the file has an odd number of bytes, so it is not %s", strings.ToUpper(encoding))
+		}
+		units := make([]uint16, len(data)/2)
+		for i := range units {
+			units[i] = order.Uint16(data[2*i:])
+		}
+		text = string(utf16.Decode(units))
+	case "cp949", "euc-kr":
+		// CP949 is a superset of EUC-KR, so one decoder reads both
+		decoded, err := decodeCP949(data)
+		if err != nil {
+			return "", encoding, err
+		}
+		text = decoded
+	default:
+		return "", encoding, fmt.Errorf("unknown encoding %q (use %s)", encoding, strings.Join(TextEncodings, ", "))
+	}
+
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	return text, encoding, nil
+}
+
+// invalidUTF8Offset returns the offset of the first byte that isn't valid UTF-8
+func invalidUTF8Offset(data []byte) int {
+	for offset := 0; offset < len(data); {
+		r, size := utf8.DecodeRune(data[offset:])
+		if r == utf8.RuneError && size == 1 {
+			return offset
+		}
+		offset += size
+	}
+	return len(data)
+}
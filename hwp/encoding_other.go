@@ -0,0 +1,10 @@
+//go:build !windows
+
+package hwp
+
+import "fmt"
+
+// decodeCP949 needs the Windows code page tables
+func decodeCP949(data []byte) (string, error) {
+	return "", fmt.Errorf("CP949/EUC-KR files can only be read on Windows; convert the file to UTF-8 first")
+}
@@ -0,0 +1,34 @@
+//go:build windows
+
+package hwp
+
+import (
+	"fmt"
+	"unicode/utf16"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	// codePageKorean is the Windows code page of CP949 (Unified Hangul Code)
+	codePageKorean = 949
+	// mbErrInvalidChars makes MultiByteToWideChar fail on invalid bytes
+	// rather than turn them into U+FFFD
+	mbErrInvalidChars = 0x8
+)
+
+// decodeCP949 decodes CP949 text with the Windows code page tables
+func decodeCP949(data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", nil
+	}
+	n, err := windows.MultiByteToWideChar(codePageKorean, mbErrInvalidChars, &data[0], int32(len(data)), nil, 0)
+	if err != nil {
+		return "", fmt.Errorf("the file is not valid CP949/EUC-KR: %v", err)
+	}
+	units := make([]uint16, n)
+	if _, err := windows.MultiByteToWideChar(codePageKorean, mbErrInvalidChars, &data[0], int32(len(data)), &units[0], n); err != nil {
+		return "", fmt.Errorf("the file is not valid CP949/EUC-KR: %v", err)
+	}
+	return string(utf16.Decode(units)), nil
+}
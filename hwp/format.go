@@ -0,0 +1,412 @@
+package hwp
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-ole/go-ole"
+)
+
+// OutlineLevel describes the numbering of one outline (개요) level.
+// Format uses HWP's level placeholders, e.g. "^1.^2." renders as "1.2."
+type OutlineLevel struct {
+	Format     string `json:"format"`
+	NumberType string `json:"type"`
+}
+
+// maxOutlineLevels is the number of outline levels HWP supports
+const maxOutlineLevels = 7
+
+// numberTypes maps number type names to HWP NumFormat values
+var numberTypes = map[string]int{
+	"digit":          0,  // 1, 2, 3
+	"circled_digit":  1,  // ①, ②, ③
+	"roman_upper":    2,  // I, II, III
+	"roman_lower":    3,  // i, ii, iii
+	"latin_upper":    4,  // A, B, C
+	"latin_lower":    5,  // a, b, c
+	"hangul":         8,  // 가, 나, 다
+	"circled_hangul": 9,  // ㉮, ㉯, ㉰
+	"hangul_jamo":    10, // ㄱ, ㄴ, ㄷ
+	"hangul_number":  12, // 일, 이, 삼
+	"hanja_number":   13, // 一, 二, 三
+}
+
+// OutlineSchemes are the predefined outline numbering schemes
+var OutlineSchemes = map[string][]OutlineLevel{
+	// 1. / 1.1 / 1.1.1
+	"decimal": {
+		{"^1.", "digit"},
+		{"^1.^2.", "digit"},
+		{"^1.^2.^3.", "digit"},
+		{"^1.^2.^3.^4.", "digit"},
+		{"^1.^2.^3.^4.^5.", "digit"},
+		{"^1.^2.^3.^4.^5.^6.", "digit"},
+		{"^1.^2.^3.^4.^5.^6.^7.", "digit"},
+	},
+	// 1. / 가. / 1) / 가) / (1) / (가) / ① as used in official documents
+	"korean": {
+		{"^1.", "digit"},
+		{"^2.", "hangul"},
+		{"^3)", "digit"},
+		{"^4)", "hangul"},
+		{"(^5)", "digit"},
+		{"(^6)", "hangul"},
+		{"^7", "circled_digit"},
+	},
+	// 가. / 1) / 가) / (1) / (가) / ① / ㉮
+	"hangul": {
+		{"^1.", "hangul"},
+		{"^2)", "digit"},
+		{"^3)", "hangul"},
+		{"(^4)", "digit"},
+		{"(^5)", "hangul"},
+		{"^6", "circled_digit"},
+		{"^7", "circled_hangul"},
+	},
+}
+
+// SetOutlineNumbering configures the outline numbering of the current section
+func (h *Controller) SetOutlineNumbering(levels []OutlineLevel) error {
+	if len(levels) == 0 || len(levels) > maxOutlineLevels {
+		return fmt.Errorf("outline numbering needs 1 to %d levels, got %d", maxOutlineLevels, len(levels))
+	}
+
+	numFormats := make([]int, len(levels))
+	for i, level := range levels {
+		numFormat, ok := numberTypes[strings.ToLower(level.NumberType)]
+		if !ok {
+			return fmt.Errorf("level %d: unknown number type: %s", i+1, level.NumberType)
+		}
+		numFormats[i] = numFormat
+	}
+
+	return h.executeAction("OutlineNumber", "SecDef", func(pset *ole.IDispatch) error {
+		shapeVar, err := safeGetProperty(pset, "OutlineShape")
+		if err != nil {
+			return fmt.Errorf("failed to get OutlineShape: %v", err)
+		}
+		defer shapeVar.Clear()
+		shape := shapeVar.ToIDispatch()
+
+		for i, level := range levels {
+			if err := safePutProperty(shape, fmt.Sprintf("StrFormatLevel%d", i), level.Format); err != nil {
+				return fmt.Errorf("failed to set level %d format: %v", i+1, err)
+			}
+			if err := safePutProperty(shape, fmt.Sprintf("NumFormatLevel%d", i), numFormats[i]); err != nil {
+				return fmt.Errorf("failed to set level %d number type: %v", i+1, err)
+			}
+		}
+		return nil
+	})
+}
+
+// ApplyOutlineLevel applies the built-in "개요 N" heading style to the current paragraph
+func (h *Controller) ApplyOutlineLevel(level int) error {
+	if level < 1 || level > maxOutlineLevels {
+		return fmt.Errorf("outline level must be between 1 and %d", maxOutlineLevels)
+	}
+
+	// Default style list: 바탕글(0), 본문(1), 개요 1(2) ... 개요 7(8)
+	return h.executeAction("Style", "Style", func(pset *ole.IDispatch) error {
+		return safePutProperty(pset, "Apply", level+1)
+	})
+}
+
+// LineSpacingPresets maps named line spacing presets to percentages
+var LineSpacingPresets = map[string]int{
+	"single": 100,
+	"1.15":   115,
+	"1.5":    150,
+	"160%":   160,
+	"double": 200,
+}
+
+// hwpUnitsPerPoint is the number of HWPUNITs in a typographic point
+const hwpUnitsPerPoint = 100
+
+// FirstLinePresets maps named first-line indent presets to points; a
+// negative indent hangs the first line out of the paragraph
+var FirstLinePresets = map[string]float64{
+	"none":    0,
+	"indent":  10, // one character of 10pt body text, as Korean prose is set
+	"wide":    20,
+	"hanging": -10,
+}
+
+// ParagraphSpacing holds line and paragraph spacing settings; nil values
+// leave the current setting unchanged
+type ParagraphSpacing struct {
+	LineSpacingPercent *int
+	BeforePt           *float64
+	AfterPt            *float64
+	FirstLinePt        *float64 // first-line indent; negative for a hanging indent
+}
+
+// SetParagraphSpacing applies spacing to the current paragraph or selection
+func (h *Controller) SetParagraphSpacing(spacing ParagraphSpacing) error {
+	return h.executeAction("ParagraphShape", "ParaShape", func(pset *ole.IDispatch) error {
+		if spacing.LineSpacingPercent != nil {
+			// LineSpacingType 0: percent of the font size
+			if err := safePutProperty(pset, "LineSpacingType", 0); err != nil {
+				return fmt.Errorf("failed to set line spacing type: %v", err)
+			}
+			if err := safePutProperty(pset, "LineSpacing", *spacing.LineSpacingPercent); err != nil {
+				return fmt.Errorf("failed to set line spacing: %v", err)
+			}
+		}
+		if spacing.BeforePt != nil {
+			if err := safePutProperty(pset, "PrevSpacing", int(*spacing.BeforePt*hwpUnitsPerPoint)); err != nil {
+				return fmt.Errorf("failed to set spacing before: %v", err)
+			}
+		}
+		if spacing.AfterPt != nil {
+			if err := safePutProperty(pset, "NextSpacing", int(*spacing.AfterPt*hwpUnitsPerPoint)); err != nil {
+				return fmt.Errorf("failed to set spacing after: %v", err)
+			}
+		}
+		if spacing.FirstLinePt != nil {
+			if err := safePutProperty(pset, "Indentation", int(*spacing.FirstLinePt*hwpUnitsPerPoint)); err != nil {
+				return fmt.Errorf("failed to set first-line indent: %v", err)
+			}
+		}
+		return nil
+	})
+}
+
+// DropCapStyles maps drop cap styles to the Style values of HWP's DropCap
+// parameter set (모양 > 문단 첫 글자 장식)
+var DropCapStyles = map[string]int{
+	"none":    0,
+	"2_lines": 1, // first letter two lines tall, set into the text
+	"3_lines": 2,
+	"margin":  3, // first letter in the left margin
+}
+
+// SetDropCap enlarges the first letter of the current paragraph. An empty
+// font keeps the paragraph font.
+func (h *Controller) SetDropCap(style, fontName string) error {
+	value, ok := DropCapStyles[style]
+	if !ok {
+		return fmt.Errorf("unknown drop cap style: %s (use none, 2_lines, 3_lines or margin)", style)
+	}
+
+	return h.executeAction("DropCap", "DropCap", func(pset *ole.IDispatch) error {
+		properties := []propertyValue{{"Style", value}}
+		if fontName != "" {
+			properties = append(properties, propertyValue{"FaceName", fontName})
+		}
+		return setDispatchProperties(pset, properties)
+	})
+}
+
+// CleanFormatting resets direct character formatting of the selection, or of the
+// whole document when wholeDocument is true, to a baseline font with no bold,
+// italic, underline or color, and optionally reapplies paragraph spacing
+func (h *Controller) CleanFormatting(wholeDocument bool, fontName string, fontSize int, spacing *ParagraphSpacing) error {
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+
+	if wholeDocument {
+		if err := h.runAction("SelectAll"); err != nil {
+			return err
+		}
+		defer h.runAction("Cancel")
+	}
+
+	if err := h.SetFontStyle(fontName, fontSize, false, false, false, "black"); err != nil {
+		return fmt.Errorf("failed to reset character formatting: %v", err)
+	}
+
+	if spacing != nil {
+		if err := h.SetParagraphSpacing(*spacing); err != nil {
+			return fmt.Errorf("failed to reset spacing: %v", err)
+		}
+	}
+	return nil
+}
+
+// TextTransforms lists the transformations accepted by TransformSelection
+var TextTransforms = []string{"upper", "lower", "full_width", "half_width", "hanja_to_hangul", "hangul_to_hanja"}
+
+// hanjaActions maps Hangul/Hanja transforms to HWP's conversion actions
+var hanjaActions = map[string]string{
+	"hanja_to_hangul": "ConvertHanjaToHangul",
+	"hangul_to_hanja": "ConvertHangulToHanja",
+}
+
+// GetSelectedText returns the text of the current selection
+func (h *Controller) GetSelectedText() (string, error) {
+	if !h.isRunning || h.hwp == nil {
+		return "", h.notConnected()
+	}
+
+	result, err := safeCallMethod(h.hwp, "GetTextFile", "UNICODE", "saveblock:true")
+	if err != nil {
+		return "", fmt.Errorf("failed to get selected text: %v", err)
+	}
+	return result.ToString(), nil
+}
+
+// TransformSelection applies a text transformation to the current selection.
+// Case and width conversions rewrite the selected text, so character formatting
+// of the selection takes that of its first character.
+func (h *Controller) TransformSelection(transform string) error {
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+
+	transform = strings.ToLower(transform)
+	if action, ok := hanjaActions[transform]; ok {
+		return h.runAction(action)
+	}
+
+	text, converted, err := h.PreviewTransform(transform)
+	if err != nil {
+		return err
+	}
+	if converted == text {
+		return nil
+	}
+	return h.InsertText(converted, true)
+}
+
+// PreviewTransform returns the selected text and what a case or width
+// transformation would turn it into, without changing the document. Hanja
+// conversions are done by HWP, so their result can't be previewed.
+func (h *Controller) PreviewTransform(transform string) (string, string, error) {
+	if !h.isRunning || h.hwp == nil {
+		return "", "", h.notConnected()
+	}
+
+	var convert func(string) string
+	switch strings.ToLower(transform) {
+	case "upper":
+		convert = strings.ToUpper
+	case "lower":
+		convert = strings.ToLower
+	case "full_width":
+		convert = ToFullWidth
+	case "half_width":
+		convert = ToHalfWidth
+	default:
+		if _, ok := hanjaActions[strings.ToLower(transform)]; ok {
+			return "", "", fmt.Errorf("the result of %s can't be previewed", transform)
+		}
+		return "", "", fmt.Errorf("invalid transform: %s (use %s)", transform, strings.Join(TextTransforms, ", "))
+	}
+
+	text, err := h.GetSelectedText()
+	if err != nil {
+		return "", "", err
+	}
+	text = strings.TrimRight(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if text == "" {
+		return "", "", fmt.Errorf("no text selected")
+	}
+	return text, convert(text), nil
+}
+
+// SortOrders lists the orders accepted by SortSelection
+var SortOrders = []string{"alphabetical", "numeric"}
+
+// leadingNumber matches the number a line starts with, e.g. "12" in "12. 서론"
+// or "-3.5" in "-3.5 kg"
+var leadingNumber = regexp.MustCompile(`^\s*[-+]?\d[\d,]*(\.\d+)?`)
+
+// SortLines sorts lines like HWP's 정렬: alphabetical compares the text without
+// regard to case (Hangul in 가나다 order); numeric compares the number each line
+// starts with and puts lines without one after the rest, in alphabetical order.
+// The sort is stable, so equal lines keep their order.
+func SortLines(lines []string, order string, reverse bool) ([]string, error) {
+	type key struct {
+		text      string
+		number    float64
+		hasNumber bool
+	}
+	keys := make([]key, len(lines))
+	for i, line := range lines {
+		keys[i].text = strings.ToLower(strings.TrimSpace(line))
+		if number := leadingNumber.FindString(line); number != "" {
+			value, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(number), ",", ""), 64)
+			keys[i].number, keys[i].hasNumber = value, err == nil
+		}
+	}
+
+	var less func(a, b key) bool
+	switch strings.ToLower(order) {
+	case "alphabetical":
+		less = func(a, b key) bool { return a.text < b.text }
+	case "numeric":
+		less = func(a, b key) bool {
+			if a.hasNumber != b.hasNumber {
+				return a.hasNumber
+			}
+			if a.hasNumber && a.number != b.number {
+				return a.number < b.number
+			}
+			return a.text < b.text
+		}
+	default:
+		return nil, fmt.Errorf("invalid sort order: %s (use %s)", order, strings.Join(SortOrders, ", "))
+	}
+
+	indices := make([]int, len(lines))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		a, b := keys[indices[i]], keys[indices[j]]
+		if reverse {
+			return less(b, a)
+		}
+		return less(a, b)
+	})
+
+	sorted := make([]string, len(lines))
+	for i, index := range indices {
+		sorted[i] = lines[index]
+	}
+	return sorted, nil
+}
+
+// SortSelection sorts the lines of the selected text and returns how many
+// there were. Each paragraph of the selection is a line; like case
+// conversion, the sorted text takes the formatting of the first character.
+func (h *Controller) SortSelection(order string, reverse bool) (int, error) {
+	lines, sorted, err := h.PreviewSort(order, reverse)
+	if err != nil {
+		return 0, err
+	}
+	if strings.Join(sorted, "\n") == strings.Join(lines, "\n") {
+		return len(lines), nil
+	}
+	return len(lines), h.InsertText(strings.Join(sorted, "\n"), true)
+}
+
+// PreviewSort returns the lines of the selection and the order SortSelection
+// would put them in, without changing the document
+func (h *Controller) PreviewSort(order string, reverse bool) ([]string, []string, error) {
+	if !h.isRunning || h.hwp == nil {
+		return nil, nil, h.notConnected()
+	}
+
+	text, err := h.GetSelectedText()
+	if err != nil {
+		return nil, nil, err
+	}
+	text = strings.TrimRight(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if text == "" {
+		return nil, nil, fmt.Errorf("no text selected")
+	}
+	lines := strings.Split(text, "\n")
+	sorted, err := SortLines(lines, order, reverse)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lines, sorted, nil
+}
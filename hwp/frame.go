@@ -0,0 +1,185 @@
+package hwp
+
+import (
+	"fmt"
+
+	"github.com/go-ole/go-ole"
+)
+
+// Linked text frames
+//
+// Brochure-style layouts place text in boxes and let it run on from one box
+// to the next, as 글상자 연결 does in the editor. Each frame is a text box
+// (a drawing object whose UserDesc is 글상자) floating in front of the text,
+// placed from the top left corner of the paper of the page at the cursor.
+// The boxes are created with DrawObjCreatorTextBox, then each is selected
+// together with the box after it and LinkTextBox joins them, so text typed
+// into the first box flows through the chain in order.
+
+// TextFrame is the geometry of a text box in millimeters from the top left
+// corner of the paper
+type TextFrame struct {
+	XMM      float64 `json:"x_mm"`
+	YMM      float64 `json:"y_mm"`
+	WidthMM  float64 `json:"width_mm"`
+	HeightMM float64 `json:"height_mm"`
+}
+
+// minFrameSizeMM is the smallest width or height of a text frame
+const minFrameSizeMM = 5
+
+// Validate checks the frame's geometry
+func (f TextFrame) Validate() error {
+	if f.XMM < 0 || f.YMM < 0 {
+		return fmt.Errorf("position %gx%gmm is off the paper", f.XMM, f.YMM)
+	}
+	if f.WidthMM < minFrameSizeMM || f.HeightMM < minFrameSizeMM {
+		return fmt.Errorf("size %gx%gmm is too small (at least %dmm each way)", f.WidthMM, f.HeightMM, minFrameSizeMM)
+	}
+	return nil
+}
+
+// items returns the ShapeObject items placing a text box at the frame
+func (f TextFrame) items() []propertyValue {
+	return []propertyValue{
+		{"TreatAsChar", false},
+		{"TextWrap", 3}, // in front of the text
+		{"HorzRelTo", 0},
+		{"VertRelTo", 0},
+		{"HorzAlign", 0},
+		{"VertAlign", 0},
+		{"HorzOffset", MMToHwpUnit(f.XMM)},
+		{"VertOffset", MMToHwpUnit(f.YMM)},
+		{"Width", MMToHwpUnit(f.WidthMM)},
+		{"Height", MMToHwpUnit(f.HeightMM)},
+	}
+}
+
+// controlInstID returns the instance ID of a control, which stays the same
+// while other controls come and go
+func controlInstID(ctrl *ole.IDispatch) string {
+	instVar, err := safeCallMethod(ctrl, "GetCtrlInstID")
+	if err != nil {
+		return ""
+	}
+	defer instVar.Clear()
+	return fmt.Sprint(instVar.Value())
+}
+
+// insertDrawingObject runs a DrawObjCreator action with the ShapeObject
+// items and returns the control it created
+func (h *Controller) insertDrawingObject(action string, items []propertyValue) (*ole.IDispatch, error) {
+	before, err := h.drawingControls()
+	if err != nil {
+		return nil, err
+	}
+	existing := map[string]bool{}
+	for _, ctrl := range before {
+		existing[controlInstID(ctrl)] = true
+	}
+
+	err = h.executeAction(action, "ShapeObject", func(pset *ole.IDispatch) error {
+		return setDispatchProperties(pset, items)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return h.newDrawingControl(existing)
+}
+
+// newDrawingControl returns the drawing object control whose instance ID is
+// not among existing
+func (h *Controller) newDrawingControl(existing map[string]bool) (*ole.IDispatch, error) {
+	after, err := h.drawingControls()
+	if err != nil {
+		return nil, err
+	}
+	for _, ctrl := range after {
+		if !existing[controlInstID(ctrl)] {
+			return ctrl, nil
+		}
+	}
+	return nil, fmt.Errorf("HWP did not create the object")
+}
+
+// typeIntoTextBox types text into a text box control and returns the cursor
+// to the body text
+func (h *Controller) typeIntoTextBox(ctrl *ole.IDispatch, label, text string) error {
+	if err := h.selectControl(ctrl, label); err != nil {
+		return err
+	}
+	if err := h.runAction("ShapeObjTextBoxEdit"); err != nil {
+		return err
+	}
+	if err := h.InsertText(text, true); err != nil {
+		return err
+	}
+	// Leave the box, back to the body text
+	return h.runAction("CloseEx")
+}
+
+// InsertLinkedTextFrames creates a chain of text boxes on the page at the
+// cursor, links each to the next and types text into the first, from where
+// it flows through the others. It returns the IDs of the boxes, as listed by
+// DrawingObjects, in chain order.
+func (h *Controller) InsertLinkedTextFrames(frames []TextFrame, text string) ([]int, error) {
+	if len(frames) < 2 {
+		return nil, fmt.Errorf("a chain takes at least 2 frames, got %d", len(frames))
+	}
+	for i, frame := range frames {
+		if err := frame.Validate(); err != nil {
+			return nil, fmt.Errorf("frame %d: %v", i+1, err)
+		}
+	}
+	if h.hwpx != nil {
+		return nil, ErrCOMRequired
+	}
+	if !h.isRunning || h.hwp == nil {
+		return nil, h.notConnected()
+	}
+
+	controls := make([]*ole.IDispatch, len(frames))
+	for i, frame := range frames {
+		ctrl, err := h.insertDrawingObject("DrawObjCreatorTextBox", frame.items())
+		if err != nil {
+			return nil, fmt.Errorf("frame %d: %v", i+1, err)
+		}
+		controls[i] = ctrl
+	}
+
+	for i := 0; i+1 < len(controls); i++ {
+		if err := h.selectControl(controls[i], fmt.Sprintf("frame %d", i+1)); err != nil {
+			return nil, err
+		}
+		if err := h.addToSelection(controls[i+1], fmt.Sprintf("frame %d", i+2)); err != nil {
+			return nil, err
+		}
+		if err := h.runAction("LinkTextBox"); err != nil {
+			return nil, fmt.Errorf("failed to link frame %d to frame %d: %v", i+1, i+2, err)
+		}
+	}
+
+	if text != "" {
+		if err := h.typeIntoTextBox(controls[0], "frame 1", text); err != nil {
+			return nil, err
+		}
+	}
+
+	// Report the boxes by the IDs the object tools take
+	all, err := h.drawingControls()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, len(controls))
+	for i, ctrl := range controls {
+		ids[i] = -1
+		inst := controlInstID(ctrl)
+		for id, other := range all {
+			if controlInstID(other) == inst {
+				ids[i] = id
+				break
+			}
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,71 @@
+package hwp
+
+import (
+	"fmt"
+
+	"github.com/go-ole/go-ole"
+)
+
+// Header and footer types of HWP's HeaderFooter parameter set
+const (
+	headerFooterHeader = 0
+	headerFooterFooter = 1
+
+	applyPageBoth = 0 // every page, odd and even
+)
+
+// EditPageHeader opens the header (머리말) of every page of the current
+// section, runs write with the cursor in it and returns to the body. An
+// existing header of the section is replaced.
+func (h *Controller) EditPageHeader(write func() error) error {
+	return h.editHeaderFooter(headerFooterHeader, write)
+}
+
+// EditPageFooter is EditPageHeader for the footer (꼬리말)
+func (h *Controller) EditPageFooter(write func() error) error {
+	return h.editHeaderFooter(headerFooterFooter, write)
+}
+
+func (h *Controller) editHeaderFooter(kind int, write func() error) error {
+	if h.hwpx != nil {
+		return ErrCOMRequired
+	}
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+
+	err := h.executeAction("HeaderFooter", "HeaderFooter", func(pset *ole.IDispatch) error {
+		return setDispatchProperties(pset, []propertyValue{
+			{"Type", kind},
+			{"ApplyPageType", applyPageBoth},
+		})
+	})
+	if err != nil {
+		return err
+	}
+	// Leave the header even when writing fails, so later text goes to the body
+	defer h.runAction("CloseEx")
+	return write()
+}
+
+// SetParagraphRule draws a line under the current paragraph, across the
+// text width, such as the rule under a letterhead
+func (h *Controller) SetParagraphRule(widthMM float64, color string) error {
+	lineColor, err := ParseColor(color)
+	if err != nil {
+		return err
+	}
+
+	return h.executeAction("ParagraphShape", "ParaShape", func(pset *ole.IDispatch) error {
+		borderVar, err := safeGetProperty(pset, "BorderFill")
+		if err != nil {
+			return fmt.Errorf("failed to get BorderFill: %v", err)
+		}
+		defer borderVar.Clear()
+		return setDispatchProperties(borderVar.ToIDispatch(), []propertyValue{
+			{"BorderTypeBottom", BorderTypes["solid"]},
+			{"BorderWidthBottom", borderWidthIndex(widthMM)},
+			{"BorderColorBottom", lineColor},
+		})
+	})
+}
@@ -0,0 +1,744 @@
+package hwp
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// HWPX direct-write backend
+//
+// hwpxDocument builds a document in memory and writes it as an HWPX (OWPML)
+// package without HWP installed. It supports the operations used to generate
+// documents: text, paragraphs, character styles, alignment, page breaks and
+// simple tables. Everything else needs the COM backend.
+
+// Default character style of new HWPX documents, matching HWP's 바탕글 style
+const (
+	hwpxDefaultFont = "함초롬바탕"
+	hwpxDefaultSize = 10
+)
+
+// A4 page layout in HWPUNIT with HWP's default margins
+const (
+	hwpxPageWidth    = 59528
+	hwpxPageHeight   = 84186
+	hwpxMarginLeft   = 8504
+	hwpxMarginRight  = 8504
+	hwpxMarginTop    = 5668
+	hwpxMarginBottom = 4252
+	hwpxMarginHeader = 4252
+	hwpxMarginFooter = 4252
+	hwpxTextWidth    = hwpxPageWidth - hwpxMarginLeft - hwpxMarginRight
+	hwpxCellHeight   = 1000
+)
+
+// hwpxAlignments lists paragraph alignments; the index is the paraPr id
+var hwpxAlignments = []string{"justify", "left", "right", "center", "distribute"}
+
+// hwpxCharShape is a character style
+type hwpxCharShape struct {
+	font      string
+	size      int // points
+	bold      bool
+	italic    bool
+	underline bool
+	color     string // #RRGGBB
+}
+
+// hwpxRun is text in one character style; "\n" marks a line break
+type hwpxRun struct {
+	charShape int
+	text      string
+}
+
+// hwpxParagraph is a paragraph holding either runs or a single table
+type hwpxParagraph struct {
+	align     string
+	pageBreak bool
+	runs      []hwpxRun
+	table     *hwpxTable
+}
+
+// hwpxCell is a table cell
+type hwpxCell struct {
+	text       string
+	charShape  int
+	lead       hwpxRun // written before text in its own style, such as a label
+	borderFill int     // 0 for the plain solid border
+}
+
+// content returns the text of the cell with its lead
+func (c hwpxCell) content() string {
+	return c.lead.text + c.text
+}
+
+// hwpxFill is the border color and background of shaded cells
+type hwpxFill struct {
+	border     string // #RRGGBB
+	background string // #RRGGBB
+}
+
+// hwpxTable is a table of rows x cols cells
+type hwpxTable struct {
+	rows   int
+	cols   int
+	cells  [][]hwpxCell
+	indent int // left indent in HWPUNIT
+}
+
+// hwpxDocument is an HWPX document under construction. The cursor is always at
+// the end of the last paragraph.
+type hwpxDocument struct {
+	paragraphs []*hwpxParagraph
+	charShapes []hwpxCharShape
+	current    hwpxCharShape
+	align      string
+	lastTable  *hwpxTable
+	fills      []hwpxFill // borders and backgrounds of shaded cells
+	metadata   DocumentMetadata
+	// modified is set by edits and cleared when the document is saved
+	modified bool
+}
+
+// newHwpxDocument creates an empty HWPX document
+func newHwpxDocument() *hwpxDocument {
+	d := &hwpxDocument{
+		current: hwpxCharShape{font: hwpxDefaultFont, size: hwpxDefaultSize, color: "#000000"},
+		align:   "justify",
+	}
+	d.metadata.Created = time.Now().Format("2006-01-02")
+	d.charShapeID(d.current)
+	d.newParagraph()
+	d.modified = false
+	return d
+}
+
+// charShapeID returns the id of a character style, registering it if needed
+func (d *hwpxDocument) charShapeID(shape hwpxCharShape) int {
+	for i, existing := range d.charShapes {
+		if existing == shape {
+			return i
+		}
+	}
+	d.charShapes = append(d.charShapes, shape)
+	return len(d.charShapes) - 1
+}
+
+// hwpxFirstFillID is the border fill id of the first shaded cell background;
+// 1 and 2 are the fixed border fills
+const hwpxFirstFillID = 3
+
+// fillID returns the border fill id of a shaded cell, registering it if needed
+func (d *hwpxDocument) fillID(fill hwpxFill) int {
+	for i, existing := range d.fills {
+		if existing == fill {
+			return hwpxFirstFillID + i
+		}
+	}
+	d.fills = append(d.fills, fill)
+	return hwpxFirstFillID + len(d.fills) - 1
+}
+
+// newParagraph starts a paragraph after the cursor
+func (d *hwpxDocument) newParagraph() *hwpxParagraph {
+	d.modified = true
+	paragraph := &hwpxParagraph{align: d.align}
+	d.paragraphs = append(d.paragraphs, paragraph)
+	return paragraph
+}
+
+// textParagraph returns the paragraph text is written to, leaving a table first
+func (d *hwpxDocument) textParagraph() *hwpxParagraph {
+	paragraph := d.paragraphs[len(d.paragraphs)-1]
+	if paragraph.table != nil {
+		return d.newParagraph()
+	}
+	return paragraph
+}
+
+// insertText writes text at the cursor. With preserveLinebreaks each line
+// becomes a paragraph; otherwise newlines are line breaks within the paragraph.
+func (d *hwpxDocument) insertText(text string, preserveLinebreaks bool) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if !preserveLinebreaks {
+		d.appendRun(text)
+		return
+	}
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			d.newParagraph()
+		}
+		d.appendRun(line)
+	}
+}
+
+// appendRun adds text in the current character style to the current paragraph
+func (d *hwpxDocument) appendRun(text string) {
+	if text == "" {
+		return
+	}
+	d.modified = true
+	paragraph := d.textParagraph()
+	id := d.charShapeID(d.current)
+	if n := len(paragraph.runs); n > 0 && paragraph.runs[n-1].charShape == id {
+		paragraph.runs[n-1].text += text
+		return
+	}
+	paragraph.runs = append(paragraph.runs, hwpxRun{charShape: id, text: text})
+}
+
+// setCharShape changes the character style for following text. Empty font and
+// zero size keep the current values.
+func (d *hwpxDocument) setCharShape(fontName string, fontSize int, bold, italic, underline bool, color string) {
+	if fontName != "" {
+		d.current.font = fontName
+	}
+	if fontSize > 0 {
+		d.current.size = fontSize
+	}
+	d.current.bold = bold
+	d.current.italic = italic
+	d.current.underline = underline
+	if color != "" {
+		d.current.color = color
+	}
+}
+
+// setAlignment sets the alignment of the current paragraph and those after it
+func (d *hwpxDocument) setAlignment(align string) {
+	d.align = align
+	d.textParagraph().align = align
+	d.modified = true
+}
+
+// insertPageBreak starts a new paragraph on a new page
+func (d *hwpxDocument) insertPageBreak() {
+	d.newParagraph().pageBreak = true
+}
+
+// insertTable adds an empty table after the cursor
+func (d *hwpxDocument) insertTable(rows, cols int) {
+	paragraph := d.paragraphs[len(d.paragraphs)-1]
+	if len(paragraph.runs) > 0 || paragraph.table != nil {
+		paragraph = d.newParagraph()
+	}
+
+	table := &hwpxTable{rows: rows, cols: cols, cells: make([][]hwpxCell, rows)}
+	id := d.charShapeID(d.current)
+	for r := range table.cells {
+		table.cells[r] = make([]hwpxCell, cols)
+		for c := range table.cells[r] {
+			table.cells[r][c].charShape = id
+		}
+	}
+	paragraph.table = table
+	d.lastTable = table
+	d.modified = true
+}
+
+// fillTable writes data into the last inserted table from a 1-based cell;
+// values outside the table are ignored
+func (d *hwpxDocument) fillTable(data [][]string, startRow, startCol int, hasHeader bool) error {
+	table := d.lastTable
+	if table == nil {
+		return fmt.Errorf("no table to fill")
+	}
+
+	header := d.current
+	header.bold = true
+	headerID := d.charShapeID(header)
+	bodyID := d.charShapeID(d.current)
+	d.modified = true
+
+	for r, rowData := range data {
+		row := startRow - 1 + r
+		if row < 0 || row >= table.rows {
+			continue
+		}
+		for c, value := range rowData {
+			col := startCol - 1 + c
+			if col < 0 || col >= table.cols {
+				continue
+			}
+			cell := &table.cells[row][col]
+			cell.text = value
+			cell.charShape = bodyID
+			if hasHeader && r == 0 {
+				cell.charShape = headerID
+			}
+		}
+	}
+	return nil
+}
+
+// expandTableRow replaces row (1-based) of the last inserted table with a
+// copy per entry of rows, as Controller.ExpandTableRow does
+func (d *hwpxDocument) expandTableRow(row int, rows [][]string) error {
+	table := d.lastTable
+	if table == nil {
+		return fmt.Errorf("the document has no table")
+	}
+	if row < 1 || row > table.rows {
+		return fmt.Errorf("row %d not found; the table has %d rows", row, table.rows)
+	}
+	if len(rows) == 0 && table.rows == 1 {
+		return fmt.Errorf("cannot remove the only row of the table")
+	}
+
+	template := table.cells[row-1]
+	copies := make([][]hwpxCell, len(rows))
+	for i, values := range rows {
+		cells := make([]hwpxCell, len(template))
+		copy(cells, template)
+		for c := range cells {
+			cells[c].lead = hwpxRun{}
+			cells[c].text = ""
+			if c < len(values) {
+				cells[c].text = values[c]
+			}
+		}
+		copies[i] = cells
+	}
+
+	expanded := append([][]hwpxCell{}, table.cells[:row-1]...)
+	expanded = append(expanded, copies...)
+	table.cells = append(expanded, table.cells[row:]...)
+	table.rows = len(table.cells)
+	d.modified = true
+	return nil
+}
+
+// tableRows returns the cell text of the last inserted table when index is
+// 0, otherwise of the index-th table (1-based)
+func (d *hwpxDocument) tableRows(index int) ([][]string, error) {
+	table := d.lastTable
+	if index > 0 {
+		table = nil
+		found := 0
+		for _, paragraph := range d.paragraphs {
+			if paragraph.table != nil {
+				found++
+				if found == index {
+					table = paragraph.table
+					break
+				}
+			}
+		}
+		if table == nil {
+			return nil, fmt.Errorf("table %d not found; the document has %d tables", index, found)
+		}
+	}
+	if table == nil {
+		return nil, fmt.Errorf("the document has no table")
+	}
+
+	rows := make([][]string, len(table.cells))
+	for r, cells := range table.cells {
+		rows[r] = make([]string, len(cells))
+		for c, cell := range cells {
+			rows[r][c] = cell.content()
+		}
+	}
+	return rows, nil
+}
+
+// text returns the plain text of the document
+func (d *hwpxDocument) text() string {
+	var lines []string
+	for _, paragraph := range d.paragraphs {
+		if paragraph.table != nil {
+			for _, row := range paragraph.table.cells {
+				values := make([]string, len(row))
+				for i, cell := range row {
+					values[i] = cell.content()
+				}
+				lines = append(lines, strings.Join(values, "\t"))
+			}
+			continue
+		}
+		var line strings.Builder
+		for _, run := range paragraph.runs {
+			line.WriteString(run.text)
+		}
+		lines = append(lines, line.String())
+	}
+	return strings.Join(lines, "\r\n")
+}
+
+// replaceAll replaces find with replacement in every run and table cell. Like
+// a search in HWP it does not match text that spans two runs.
+func (d *hwpxDocument) replaceAll(find, replacement string) {
+	for _, paragraph := range d.paragraphs {
+		for i, run := range paragraph.runs {
+			if strings.Contains(run.text, find) {
+				paragraph.runs[i].text = strings.ReplaceAll(run.text, find, replacement)
+				d.modified = true
+			}
+		}
+		if paragraph.table == nil {
+			continue
+		}
+		for _, row := range paragraph.table.cells {
+			for c := range row {
+				for _, text := range []*string{&row[c].lead.text, &row[c].text} {
+					if strings.Contains(*text, find) {
+						*text = strings.ReplaceAll(*text, find, replacement)
+						d.modified = true
+					}
+				}
+			}
+		}
+	}
+}
+
+// save writes the document as an HWPX package
+func (d *hwpxDocument) save(path string) error {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %v", err)
+		}
+	}
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+
+	// The mimetype entry must come first and be stored uncompressed
+	mimetype, err := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err := mimetype.Write([]byte("application/hwp+zip")); err != nil {
+		return err
+	}
+
+	entries := []struct {
+		name    string
+		content string
+	}{
+		{"version.xml", hwpxVersionXML},
+		{"META-INF/container.xml", hwpxContainerXML},
+		{"META-INF/manifest.xml", hwpxManifestXML},
+		{"Contents/content.hpf", d.contentHPF()},
+		{"Contents/header.xml", d.headerXML()},
+		{"Contents/section0.xml", d.sectionXML()},
+		{"settings.xml", hwpxSettingsXML},
+		{"Preview/PrvText.txt", d.text()},
+	}
+	for _, entry := range entries {
+		writer, err := archive.Create(entry.name)
+		if err != nil {
+			return err
+		}
+		if _, err := writer.Write([]byte(entry.content)); err != nil {
+			return err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, buffer.Bytes(), 0644); err != nil {
+		return err
+	}
+	d.modified = false
+	return nil
+}
+
+// fonts returns the distinct fonts used by the character styles
+func (d *hwpxDocument) fonts() []string {
+	var fonts []string
+	seen := map[string]bool{}
+	for _, shape := range d.charShapes {
+		if !seen[shape.font] {
+			seen[shape.font] = true
+			fonts = append(fonts, shape.font)
+		}
+	}
+	return fonts
+}
+
+// hwpxNamespaces are the OWPML namespace declarations used by the content parts
+const hwpxNamespaces = `xmlns:ha="http://www.hancom.co.kr/hwpml/2011/app" ` +
+	`xmlns:hp="http://www.hancom.co.kr/hwpml/2011/paragraph" ` +
+	`xmlns:hs="http://www.hancom.co.kr/hwpml/2011/section" ` +
+	`xmlns:hc="http://www.hancom.co.kr/hwpml/2011/core" ` +
+	`xmlns:hh="http://www.hancom.co.kr/hwpml/2011/head"`
+
+// hwpxFontLanguages are the script groups each with their own font list
+var hwpxFontLanguages = []string{"HANGUL", "LATIN", "HANJA", "JAPANESE", "OTHER", "SYMBOL", "USER"}
+
+// headerXML renders Contents/header.xml: fonts, border fills, character and
+// paragraph properties and the default style
+func (d *hwpxDocument) headerXML() string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	fmt.Fprintf(&b, `<hh:head %s version="1.4" secCnt="1">`, hwpxNamespaces)
+	b.WriteString(`<hh:beginNum page="1" footnote="1" endnote="1" pic="1" tbl="1" equation="1"/>`)
+	b.WriteString(`<hh:refList>`)
+
+	// Fonts: the same list for every script so font ids line up
+	fonts := d.fonts()
+	fontIDs := map[string]int{}
+	fmt.Fprintf(&b, `<hh:fontfaces itemCnt="%d">`, len(hwpxFontLanguages))
+	for _, language := range hwpxFontLanguages {
+		fmt.Fprintf(&b, `<hh:fontface lang="%s" fontCnt="%d">`, language, len(fonts))
+		for id, font := range fonts {
+			fontIDs[font] = id
+			fmt.Fprintf(&b, `<hh:font id="%d" face="%s" type="TTF" isEmbedded="0"/>`, id, xmlEscape(font))
+		}
+		b.WriteString(`</hh:fontface>`)
+	}
+	b.WriteString(`</hh:fontfaces>`)
+
+	// Border fills: 1 has no borders, 2 has thin solid borders for table
+	// cells, and from hwpxFirstFillID on shaded cells
+	borderFills := []struct {
+		border string
+		fill   hwpxFill
+	}{{"NONE", hwpxFill{border: "#000000"}}, {"SOLID", hwpxFill{border: "#000000"}}}
+	for _, fill := range d.fills {
+		borderFills = append(borderFills, struct {
+			border string
+			fill   hwpxFill
+		}{"SOLID", fill})
+	}
+	fmt.Fprintf(&b, `<hh:borderFills itemCnt="%d">`, len(borderFills))
+	for id, borderFill := range borderFills {
+		fmt.Fprintf(&b, `<hh:borderFill id="%d" threeD="0" shadow="0" centerLine="NONE" breakCellSeparateLine="0">`, id+1)
+		b.WriteString(`<hh:slash type="NONE" Crooked="0" isCounter="0"/><hh:backSlash type="NONE" Crooked="0" isCounter="0"/>`)
+		for _, edge := range []string{"left", "right", "top", "bottom"} {
+			fmt.Fprintf(&b, `<hh:%sBorder type="%s" width="0.12 mm" color="%s"/>`, edge, borderFill.border, borderFill.fill.border)
+		}
+		b.WriteString(`<hh:diagonal type="SOLID" width="0.1 mm" color="#000000"/>`)
+		if borderFill.fill.background != "" {
+			fmt.Fprintf(&b, `<hc:fillBrush><hc:winBrush faceColor="%s" hatchColor="#999999" alpha="0"/></hc:fillBrush>`, borderFill.fill.background)
+		}
+		b.WriteString(`</hh:borderFill>`)
+	}
+	b.WriteString(`</hh:borderFills>`)
+
+	fmt.Fprintf(&b, `<hh:charProperties itemCnt="%d">`, len(d.charShapes))
+	for id, shape := range d.charShapes {
+		fontID := fontIDs[shape.font]
+		fmt.Fprintf(&b, `<hh:charPr id="%d" height="%d" textColor="%s" shadeColor="none" useFontSpace="0" useKerning="0" symMark="NONE" borderFillIDRef="1">`,
+			id, shape.size*100, shape.color)
+		fmt.Fprintf(&b, `<hh:fontRef hangul="%[1]d" latin="%[1]d" hanja="%[1]d" japanese="%[1]d" other="%[1]d" symbol="%[1]d" user="%[1]d"/>`, fontID)
+		b.WriteString(`<hh:ratio hangul="100" latin="100" hanja="100" japanese="100" other="100" symbol="100" user="100"/>`)
+		b.WriteString(`<hh:spacing hangul="0" latin="0" hanja="0" japanese="0" other="0" symbol="0" user="0"/>`)
+		b.WriteString(`<hh:relSz hangul="100" latin="100" hanja="100" japanese="100" other="100" symbol="100" user="100"/>`)
+		b.WriteString(`<hh:offset hangul="0" latin="0" hanja="0" japanese="0" other="0" symbol="0" user="0"/>`)
+		if shape.bold {
+			b.WriteString(`<hh:bold/>`)
+		}
+		if shape.italic {
+			b.WriteString(`<hh:italic/>`)
+		}
+		if shape.underline {
+			fmt.Fprintf(&b, `<hh:underline type="BOTTOM" shape="SOLID" color="%s"/>`, shape.color)
+		} else {
+			b.WriteString(`<hh:underline type="NONE" shape="SOLID" color="#000000"/>`)
+		}
+		b.WriteString(`<hh:strikeout shape="NONE" color="#000000"/><hh:outline type="NONE"/>`)
+		b.WriteString(`<hh:shadow type="NONE" color="#B2B2B2" offsetX="10" offsetY="10"/>`)
+		b.WriteString(`</hh:charPr>`)
+	}
+	b.WriteString(`</hh:charProperties>`)
+
+	b.WriteString(`<hh:tabProperties itemCnt="1"><hh:tabPr id="0" autoTabLeft="0" autoTabRight="0"/></hh:tabProperties>`)
+
+	fmt.Fprintf(&b, `<hh:paraProperties itemCnt="%d">`, len(hwpxAlignments))
+	for id, align := range hwpxAlignments {
+		fmt.Fprintf(&b, `<hh:paraPr id="%d" tabPrIDRef="0" condense="0" fontLineHeight="0" snapToGrid="1" suppressLineNumbers="0" checked="0">`, id)
+		fmt.Fprintf(&b, `<hh:align horizontal="%s" vertical="BASELINE"/>`, strings.ToUpper(align))
+		b.WriteString(`<hh:heading type="NONE" idRef="0" level="0"/>`)
+		b.WriteString(`<hh:breakSetting breakLatinWord="KEEP_WORD" breakNonLatinWord="KEEP_WORD" widowOrphan="0" keepWithNext="0" keepLines="0" pageBreakBefore="0" lineWrap="BREAK"/>`)
+		b.WriteString(`<hh:autoSpacing eAsianEng="0" eAsianNum="0"/>`)
+		b.WriteString(`<hh:margin><hc:intent value="0" unit="HWPUNIT"/><hc:left value="0" unit="HWPUNIT"/><hc:right value="0" unit="HWPUNIT"/><hc:prev value="0" unit="HWPUNIT"/><hc:next value="0" unit="HWPUNIT"/></hh:margin>`)
+		b.WriteString(`<hh:lineSpacing type="PERCENT" value="160" unit="HWPUNIT"/>`)
+		b.WriteString(`<hh:border borderFillIDRef="1" offsetLeft="0" offsetRight="0" offsetTop="0" offsetBottom="0" connect="0" ignoreMargin="0"/>`)
+		b.WriteString(`</hh:paraPr>`)
+	}
+	b.WriteString(`</hh:paraProperties>`)
+
+	b.WriteString(`<hh:styles itemCnt="1">`)
+	b.WriteString(`<hh:style id="0" type="PARA" name="바탕글" engName="Normal" paraPrIDRef="0" charPrIDRef="0" nextStyleIDRef="0" langID="1042" lockForm="0"/>`)
+	b.WriteString(`</hh:styles>`)
+
+	b.WriteString(`</hh:refList>`)
+	b.WriteString(`<hh:compatibleDocument targetProgram="HWP201X"><hh:layoutCompatibility/></hh:compatibleDocument>`)
+	b.WriteString(`<hh:docOption><hh:linkinfo path="" pageInherit="0" footnoteInherit="0"/></hh:docOption>`)
+	b.WriteString(`</hh:head>`)
+	return b.String()
+}
+
+// sectionXML renders Contents/section0.xml with the page setup and all paragraphs
+func (d *hwpxDocument) sectionXML() string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	fmt.Fprintf(&b, `<hs:sec %s>`, hwpxNamespaces)
+	for i, paragraph := range d.paragraphs {
+		writeHwpxParagraph(&b, paragraph, i == 0)
+	}
+	b.WriteString(`</hs:sec>`)
+	return b.String()
+}
+
+// writeHwpxParagraph renders a paragraph; the first one carries the section definition
+func writeHwpxParagraph(b *strings.Builder, paragraph *hwpxParagraph, first bool) {
+	pageBreak := 0
+	if paragraph.pageBreak && !first {
+		pageBreak = 1
+	}
+	fmt.Fprintf(b, `<hp:p id="0" paraPrIDRef="%d" styleIDRef="0" pageBreak="%d" columnBreak="0" merged="0">`,
+		hwpxAlignmentID(paragraph.align), pageBreak)
+
+	if first {
+		b.WriteString(`<hp:run charPrIDRef="0">`)
+		b.WriteString(`<hp:secPr id="" textDirection="HORIZONTAL" spaceColumns="1134" tabStop="8000" tabStopVal="4000" tabStopUnit="HWPUNIT" outlineShapeIDRef="0" memoShapeIDRef="0" textVerticalWidthHead="0" masterPageCnt="0">`)
+		b.WriteString(`<hp:grid lineGrid="0" charGrid="0" wonggojiFormat="0"/>`)
+		b.WriteString(`<hp:startNum pageStartsOn="BOTH" page="0" pic="0" tbl="0" equation="0"/>`)
+		b.WriteString(`<hp:visibility hideFirstHeader="0" hideFirstFooter="0" hideFirstMasterPage="0" border="SHOW_ALL" fill="SHOW_ALL" hideFirstPageNum="0" hideFirstEmptyLine="0" showLineNumber="0"/>`)
+		b.WriteString(`<hp:lineNumberShape restartType="0" countBy="0" distance="0" startNumber="0"/>`)
+		fmt.Fprintf(b, `<hp:pagePr landscape="WIDELY" width="%d" height="%d" gutterType="LEFT_ONLY">`, hwpxPageWidth, hwpxPageHeight)
+		fmt.Fprintf(b, `<hp:margin header="%d" footer="%d" gutter="0" left="%d" right="%d" top="%d" bottom="%d"/>`,
+			hwpxMarginHeader, hwpxMarginFooter, hwpxMarginLeft, hwpxMarginRight, hwpxMarginTop, hwpxMarginBottom)
+		b.WriteString(`</hp:pagePr>`)
+		for _, fill := range []string{"BOTH", "EVEN", "ODD"} {
+			fmt.Fprintf(b, `<hp:pageBorderFill type="%s" borderFillIDRef="1" textBorder="PAPER" headerInside="0" footerInside="0" fillArea="PAPER"><hp:offset left="1417" right="1417" top="1417" bottom="1417"/></hp:pageBorderFill>`, fill)
+		}
+		b.WriteString(`</hp:secPr>`)
+		b.WriteString(`<hp:ctrl><hp:colPr id="" type="NEWSPAPER" layout="LEFT" colCount="1" sameSz="1" sameGap="0"/></hp:ctrl>`)
+		b.WriteString(`</hp:run>`)
+	}
+
+	if paragraph.table != nil {
+		b.WriteString(`<hp:run charPrIDRef="0">`)
+		writeHwpxTable(b, paragraph.table)
+		b.WriteString(`<hp:t/></hp:run>`)
+	}
+
+	for _, run := range paragraph.runs {
+		fmt.Fprintf(b, `<hp:run charPrIDRef="%d"><hp:t>`, run.charShape)
+		for i, line := range strings.Split(run.text, "\n") {
+			if i > 0 {
+				b.WriteString(`<hp:lineBreak/>`)
+			}
+			b.WriteString(xmlEscape(line))
+		}
+		b.WriteString(`</hp:t></hp:run>`)
+	}
+
+	if paragraph.table == nil && len(paragraph.runs) == 0 {
+		b.WriteString(`<hp:run charPrIDRef="0"/>`)
+	}
+	b.WriteString(`</hp:p>`)
+}
+
+// writeHwpxTable renders a table with equal column widths spanning the text area
+func writeHwpxTable(b *strings.Builder, table *hwpxTable) {
+	cellWidth := (hwpxTextWidth - table.indent) / table.cols
+	fmt.Fprintf(b, `<hp:tbl id="0" zOrder="0" numberingType="TABLE" textWrap="TOP_AND_BOTTOM" textFlow="BOTH_SIDES" lock="0" dropcapstyle="None" pageBreak="CELL" repeatHeader="1" rowCnt="%d" colCnt="%d" cellSpacing="0" borderFillIDRef="2" noAdjust="0">`,
+		table.rows, table.cols)
+	fmt.Fprintf(b, `<hp:sz width="%d" widthRelTo="ABSOLUTE" height="%d" heightRelTo="ABSOLUTE" protect="0"/>`,
+		cellWidth*table.cols, hwpxCellHeight*table.rows)
+	b.WriteString(`<hp:pos treatAsChar="0" affectLSpacing="0" flowWithText="1" allowOverlap="0" holdAnchorAndSO="0" vertRelTo="PARA" horzRelTo="COLUMN" vertAlign="TOP" horzAlign="LEFT" vertOffset="0" horzOffset="0"/>`)
+	fmt.Fprintf(b, `<hp:outMargin left="%d" right="283" top="283" bottom="283"/>`, 283+table.indent)
+	b.WriteString(`<hp:inMargin left="510" right="510" top="141" bottom="141"/>`)
+
+	for r, row := range table.cells {
+		b.WriteString(`<hp:tr>`)
+		for c, cell := range row {
+			borderFill := cell.borderFill
+			if borderFill == 0 {
+				borderFill = 2
+			}
+			fmt.Fprintf(b, `<hp:tc name="" header="0" hasMargin="0" protect="0" editable="0" dirty="0" borderFillIDRef="%d">`, borderFill)
+			b.WriteString(`<hp:subList id="" textDirection="HORIZONTAL" lineWrap="BREAK" vertAlign="CENTER" linkListIDRef="0" linkListNextIDRef="0" textWidth="0" textHeight="0" hasTextRef="0" hasNumRef="0">`)
+			runs := []hwpxRun{{charShape: cell.charShape, text: cell.text}}
+			if cell.lead.text != "" {
+				runs = append([]hwpxRun{cell.lead}, runs...)
+			}
+			writeHwpxParagraph(b, &hwpxParagraph{align: "justify", runs: runs}, false)
+			b.WriteString(`</hp:subList>`)
+			fmt.Fprintf(b, `<hp:cellAddr colAddr="%d" rowAddr="%d"/>`, c, r)
+			b.WriteString(`<hp:cellSpan colSpan="1" rowSpan="1"/>`)
+			fmt.Fprintf(b, `<hp:cellSz width="%d" height="%d"/>`, cellWidth, hwpxCellHeight)
+			b.WriteString(`<hp:cellMargin left="510" right="510" top="141" bottom="141"/>`)
+			b.WriteString(`</hp:tc>`)
+		}
+		b.WriteString(`</hp:tr>`)
+	}
+	b.WriteString(`</hp:tbl>`)
+}
+
+// hwpxAlignmentID returns the paraPr id of an alignment
+func hwpxAlignmentID(align string) int {
+	for id, name := range hwpxAlignments {
+		if name == align {
+			return id
+		}
+	}
+	return 0
+}
+
+// contentHPF builds the package description, which carries the document
+// summary as OPF metadata
+func (d *hwpxDocument) contentHPF() string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<opf:package xmlns:opf="http://www.idpf.org/2007/opf/" xmlns:hpf="http://www.hancom.co.kr/schema/2011/hpf" version="" unique-identifier="" id="">`)
+	fmt.Fprintf(&b, `<opf:metadata><opf:title>%s</opf:title><opf:language>ko</opf:language>`, xmlEscape(d.metadata.Title))
+	for _, meta := range []struct{ name, content string }{
+		{"creator", d.metadata.Author},
+		{"subject", d.metadata.Subject},
+		{"description", d.metadata.Comments},
+		{"keyword", d.metadata.Keywords},
+		{"CreatedDate", d.metadata.Created},
+		{"ModifiedDate", time.Now().Format(time.RFC3339)},
+	} {
+		fmt.Fprintf(&b, `<opf:meta name="%s" content="text">%s</opf:meta>`, meta.name, xmlEscape(meta.content))
+	}
+	b.WriteString(`</opf:metadata>`)
+	b.WriteString(`<opf:manifest>`)
+	b.WriteString(`<opf:item id="header" href="Contents/header.xml" media-type="application/xml"/>`)
+	b.WriteString(`<opf:item id="section0" href="Contents/section0.xml" media-type="application/xml"/>`)
+	b.WriteString(`<opf:item id="settings" href="settings.xml" media-type="application/xml"/>`)
+	b.WriteString(`</opf:manifest>`)
+	b.WriteString(`<opf:spine><opf:itemref idref="header" linear="yes"/><opf:itemref idref="section0" linear="yes"/></opf:spine>`)
+	b.WriteString(`</opf:package>`)
+	return b.String()
+}
+
+// xmlEscape escapes text for XML character data and attribute values
+func xmlEscape(text string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(text))
+	return b.String()
+}
+
+// Static HWPX package parts
+const (
+	hwpxVersionXML = xml.Header +
+		`<hv:HCFVersion xmlns:hv="http://www.hancom.co.kr/hwpml/2011/version" tagetApplication="WORDPROCESSOR" major="5" minor="1" micro="0" buildNumber="1" os="1" xmlVersion="1.4" application="hwp-mcp-go" appVersion="1.0.0"/>`
+
+	hwpxContainerXML = xml.Header +
+		`<ocf:container xmlns:ocf="urn:oasis:names:tc:opendocument:xmlns:container" xmlns:hpf="http://www.hancom.co.kr/schema/2011/hpf">` +
+		`<ocf:rootfiles>` +
+		`<ocf:rootfile full-path="Contents/content.hpf" media-type="application/hwpml-package+xml"/>` +
+		`<ocf:rootfile full-path="Preview/PrvText.txt" media-type="text/plain"/>` +
+		`</ocf:rootfiles></ocf:container>`
+
+	hwpxManifestXML = xml.Header +
+		`<odf:manifest xmlns:odf="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0"/>`
+
+	hwpxSettingsXML = xml.Header +
+		`<ha:HWPApplicationSetting xmlns:ha="http://www.hancom.co.kr/hwpml/2011/app" xmlns:config="urn:oasis:names:tc:opendocument:xmlns:config:1.0">` +
+		`<ha:CaretPosition listIDRef="0" paraIDRef="0" pos="0"/>` +
+		`</ha:HWPApplicationSetting>`
+)
@@ -0,0 +1,81 @@
+package hwp
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Hyperlink is a link found in the document
+type Hyperlink struct {
+	Index  int    `json:"index"`
+	Text   string `json:"text"`
+	Target string `json:"target"`
+}
+
+var (
+	anchorPattern = regexp.MustCompile(`(?is)<a\s[^>]*?href\s*=\s*["']([^"']*)["'][^>]*>(.*?)</a>`)
+	tagPattern    = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// GetHTML exports the document as HTML
+func (h *Controller) GetHTML() (string, error) {
+	if !h.isRunning || h.hwp == nil {
+		return "", h.notConnected()
+	}
+
+	result, err := safeCallMethod(h.hwp, "GetTextFile", "HTML", "")
+	if err != nil {
+		return "", fmt.Errorf("failed to export HTML: %v", err)
+	}
+	return result.ToString(), nil
+}
+
+// ListHyperlinks returns all hyperlinks in the document with their display text.
+// Links are read from HWP's HTML export, which renders hyperlink fields as anchors.
+func (h *Controller) ListHyperlinks() ([]Hyperlink, error) {
+	document, err := h.GetHTML()
+	if err != nil {
+		return nil, err
+	}
+	return extractHyperlinks(document), nil
+}
+
+// extractHyperlinks parses anchors out of exported HTML
+func extractHyperlinks(document string) []Hyperlink {
+	links := []Hyperlink{}
+	for _, match := range anchorPattern.FindAllStringSubmatch(document, -1) {
+		text := html.UnescapeString(tagPattern.ReplaceAllString(match[2], ""))
+		links = append(links, Hyperlink{
+			Index:  len(links) + 1,
+			Text:   strings.Join(strings.Fields(text), " "),
+			Target: html.UnescapeString(match[1]),
+		})
+	}
+	return links
+}
+
+// UsedFonts returns the distinct fonts of the document's text, in order of
+// first use
+func (h *Controller) UsedFonts() ([]string, error) {
+	if h.hwpx != nil {
+		return h.hwpx.fonts(), nil
+	}
+	model, err := h.ExportModel()
+	if err != nil {
+		return nil, err
+	}
+
+	var fonts []string
+	seen := map[string]bool{}
+	for _, block := range model.Blocks {
+		for _, run := range block.Runs {
+			if run.Font != "" && strings.TrimSpace(run.Text) != "" && !seen[run.Font] {
+				seen[run.Font] = true
+				fonts = append(fonts, run.Font)
+			}
+		}
+	}
+	return fonts, nil
+}
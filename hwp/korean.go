@@ -0,0 +1,180 @@
+package hwp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Korean text formatting helpers
+
+// dangiOffset is the difference between the Dangi (단기) and Gregorian years
+const dangiOffset = 2333
+
+var koreanWeekdays = []string{"일", "월", "화", "수", "목", "금", "토"}
+
+// FormatKoreanDate formats t per Korean conventions.
+// style: "long" (2025년 1월 15일), "dot" (2025. 1. 15.), "iso" (2025-01-15)
+// era: "" (none), "seogi" (서기 2025년), "dangi" (단기 4358년)
+func FormatKoreanDate(t time.Time, style, era string, withWeekday, withTime bool) (string, error) {
+	year := t.Year()
+	eraPrefix := ""
+	switch strings.ToLower(era) {
+	case "", "none":
+	case "seogi", "서기":
+		eraPrefix = "서기 "
+	case "dangi", "단기":
+		eraPrefix = "단기 "
+		year += dangiOffset
+	default:
+		return "", fmt.Errorf("invalid era: %s (use none, seogi or dangi)", era)
+	}
+
+	var date string
+	switch strings.ToLower(style) {
+	case "", "long":
+		date = fmt.Sprintf("%s%d년 %d월 %d일", eraPrefix, year, int(t.Month()), t.Day())
+	case "dot":
+		date = fmt.Sprintf("%s%d. %d. %d.", eraPrefix, year, int(t.Month()), t.Day())
+	case "iso":
+		date = fmt.Sprintf("%s%04d-%02d-%02d", eraPrefix, year, int(t.Month()), t.Day())
+	default:
+		return "", fmt.Errorf("invalid date style: %s (use long, dot or iso)", style)
+	}
+
+	if withWeekday {
+		date += fmt.Sprintf(" (%s)", koreanWeekdays[t.Weekday()])
+	}
+
+	if withTime {
+		hour := t.Hour()
+		period := "오전"
+		if hour >= 12 {
+			period = "오후"
+		}
+		if hour%12 == 0 {
+			hour = 12
+		} else {
+			hour %= 12
+		}
+		date += fmt.Sprintf(" %s %d시 %d분", period, hour, t.Minute())
+	}
+
+	return date, nil
+}
+
+// Full-width forms (전각) of printable ASCII start at U+FF01; the ideographic
+// space U+3000 is the full-width space
+const (
+	fullWidthOffset = 0xFF01 - 0x21
+	fullWidthSpace  = '\u3000'
+)
+
+// ToFullWidth converts printable ASCII characters to their full-width forms
+func ToFullWidth(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == ' ':
+			return fullWidthSpace
+		case r >= 0x21 && r <= 0x7E:
+			return r + fullWidthOffset
+		}
+		return r
+	}, text)
+}
+
+// ToHalfWidth converts full-width forms back to printable ASCII characters
+func ToHalfWidth(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == fullWidthSpace:
+			return ' '
+		case r >= 0xFF01 && r <= 0xFF5E:
+			return r - fullWidthOffset
+		}
+		return r
+	}, text)
+}
+
+var (
+	koreanDigits     = []string{"", "일", "이", "삼", "사", "오", "육", "칠", "팔", "구"}
+	koreanSmallUnits = []string{"천", "백", "십", ""}
+	koreanLargeUnits = []string{"", "만", "억", "조", "경"}
+)
+
+// koreanGroups splits a non-negative number into groups of four digits,
+// lowest first, as Korean counts in 만, 억, 조 and 경
+func koreanGroups(n int64) []int64 {
+	var groups []int64
+	for n > 0 {
+		groups = append(groups, n%10000)
+		n /= 10000
+	}
+	return groups
+}
+
+// KoreanNumberWords spells a whole number in Sino-Korean numerals. The formal
+// form, used for amounts on invoices and receipts, keeps 일 before 십, 백, 천
+// and 만 so the amount cannot be altered by adding a digit: 1210000 →
+// 일백이십일만. The everyday form drops it: 백이십일만.
+func KoreanNumberWords(n int64, formal bool) string {
+	if n == 0 {
+		return "영"
+	}
+	sign := ""
+	if n < 0 {
+		sign, n = "마이너스 ", -n
+	}
+
+	groups := koreanGroups(n)
+	var words strings.Builder
+	words.WriteString(sign)
+	for unit := len(groups) - 1; unit >= 0; unit-- {
+		group := groups[unit]
+		if group == 0 {
+			continue
+		}
+		if group == 1 && unit == 1 && !formal {
+			// 만, not 일만, though 일억 and 일조 keep it
+			words.WriteString(koreanLargeUnits[unit])
+			continue
+		}
+		for i, divisor := range []int64{1000, 100, 10, 1} {
+			digit := group / divisor % 10
+			if digit == 0 {
+				continue
+			}
+			if digit != 1 || formal || koreanSmallUnits[i] == "" {
+				words.WriteString(koreanDigits[digit])
+			}
+			words.WriteString(koreanSmallUnits[i])
+		}
+		words.WriteString(koreanLargeUnits[unit])
+	}
+	return words.String()
+}
+
+// KoreanMixedNumber writes a whole number in digits grouped by Korean units,
+// as prices and statistics are quoted: 123456789 → 1억 2,345만 6,789
+func KoreanMixedNumber(n int64) string {
+	if n == 0 {
+		return "0"
+	}
+	sign := ""
+	if n < 0 {
+		sign, n = "-", -n
+	}
+	groups := koreanGroups(n)
+	var parts []string
+	for unit := len(groups) - 1; unit >= 0; unit-- {
+		if groups[unit] != 0 {
+			parts = append(parts, FormatNumber(float64(groups[unit]), 0)+koreanLargeUnits[unit])
+		}
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+// KoreanAmount writes an amount of won in words, e.g. 1200000 → 금 일백이십만원정
+func KoreanAmount(won int64) string {
+	return "금 " + KoreanNumberWords(won, true) + "원정"
+}
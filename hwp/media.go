@@ -0,0 +1,149 @@
+package hwp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-ole/go-ole"
+)
+
+// Media links
+//
+// HWP can't embed playable video or audio, so training materials reference
+// media with a placeholder block instead: a thumbnail picture, a caption
+// marked with the kind of media and the address as a hyperlink, written out
+// so it can still be typed in from a printout.
+
+// MediaKinds are the kinds of media a link can stand for
+var MediaKinds = []string{"video", "audio"}
+
+// mediaMarks are the marks put before the caption of each kind of media
+var mediaMarks = map[string]string{
+	"video": "▶",
+	"audio": "♪",
+}
+
+// mediaDefaultCaptions are the captions of media links without one
+var mediaDefaultCaptions = map[string]string{
+	"video": "동영상",
+	"audio": "음성 자료",
+}
+
+// mediaThumbnailWidthMM is the thumbnail width when MediaLink.WidthMM is unset
+const mediaThumbnailWidthMM = 80
+
+// MediaLink is a referenced video or audio clip
+type MediaLink struct {
+	URL       string
+	Kind      string // video (default) or audio
+	Caption   string
+	Duration  string // e.g. 3:25, shown after the caption
+	Thumbnail string // image file path or URL; none inserts no picture
+	WidthMM   int    // thumbnail width
+}
+
+// Validate checks the link's values
+func (m MediaLink) Validate() error {
+	if strings.TrimSpace(m.URL) == "" {
+		return fmt.Errorf("the media URL is required")
+	}
+	if _, ok := mediaMarks[m.kind()]; !ok {
+		return fmt.Errorf("invalid media kind: %s (use %s)", m.Kind, strings.Join(MediaKinds, ", "))
+	}
+	if m.WidthMM < 0 {
+		return fmt.Errorf("invalid thumbnail width: %dmm", m.WidthMM)
+	}
+	return nil
+}
+
+// kind returns the kind of media, video by default
+func (m MediaLink) kind() string {
+	if m.Kind == "" {
+		return "video"
+	}
+	return strings.ToLower(m.Kind)
+}
+
+// caption returns the caption, or the kind of media when there is none
+func (m MediaLink) caption() string {
+	if m.Caption == "" {
+		return mediaDefaultCaptions[m.kind()]
+	}
+	return m.Caption
+}
+
+// Label returns the caption line of the link, e.g. "▶ 안전교육 (3:25)"
+func (m MediaLink) Label() string {
+	label := mediaMarks[m.kind()] + " " + m.caption()
+	if m.Duration != "" {
+		label += " (" + m.Duration + ")"
+	}
+	return label
+}
+
+// InsertHyperlink inserts text at the cursor linked to target, a web
+// address or file
+func (h *Controller) InsertHyperlink(text, target string) error {
+	if h.hwpx != nil {
+		return ErrCOMRequired
+	}
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+
+	return h.executeAction("InsertHyperlink", "HyperLink", func(pset *ole.IDispatch) error {
+		// Command is the target followed by the link type and options HWP
+		// writes for links to web pages
+		return setParameterItems(pset, map[string]interface{}{
+			"Text":    text,
+			"Command": target + ";1;0;0;",
+		})
+	})
+}
+
+// InsertMediaLink inserts a placeholder block for a video or audio clip at
+// the cursor: the thumbnail, if any, then the caption line and the address,
+// both linked to the clip. It ends on a new paragraph.
+func (h *Controller) InsertMediaLink(media MediaLink) error {
+	if err := media.Validate(); err != nil {
+		return err
+	}
+	if h.hwpx != nil {
+		return ErrCOMRequired
+	}
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+
+	if media.Thumbnail != "" {
+		widthMM := media.WidthMM
+		if widthMM == 0 {
+			widthMM = mediaThumbnailWidthMM
+		}
+		maxWidth := MMToHwpUnit(float64(widthMM))
+		description := media.caption() + " 썸네일"
+		if err := h.InsertImage(media.Thumbnail, nil, nil, false, &maxWidth, nil, nil, true, true, false, false, 0, description, nil, nil, nil); err != nil {
+			return err
+		}
+		if err := h.InsertParagraph(); err != nil {
+			return err
+		}
+	}
+
+	if err := h.SetFontStyle("", 0, true, false, false); err != nil {
+		return err
+	}
+	if err := h.InsertHyperlink(media.Label(), media.URL); err != nil {
+		return err
+	}
+	if err := h.SetFontStyle("", 0, false, false, false); err != nil {
+		return err
+	}
+	if err := h.InsertParagraph(); err != nil {
+		return err
+	}
+	if err := h.InsertHyperlink(media.URL, media.URL); err != nil {
+		return err
+	}
+	return h.InsertParagraph()
+}
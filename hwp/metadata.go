@@ -0,0 +1,121 @@
+package hwp
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-ole/go-ole"
+)
+
+// DocumentMetadata is the summary information of a document (파일 > 문서 정보 >
+// 문서 요약). Created is the date stored in the summary; Modified is the time
+// the file was last written, empty for a document that was never saved.
+type DocumentMetadata struct {
+	Title    string `json:"title"`
+	Subject  string `json:"subject"`
+	Author   string `json:"author"`
+	Keywords string `json:"keywords"`
+	Comments string `json:"comments"`
+	Created  string `json:"created"`
+	Modified string `json:"modified,omitempty"`
+}
+
+// MetadataUpdate holds summary fields to change; nil fields are kept
+type MetadataUpdate struct {
+	Title    *string
+	Subject  *string
+	Author   *string
+	Keywords *string
+	Comments *string
+	Created  *string
+}
+
+// summaryItems maps the SummaryInfo parameter set items to metadata fields
+func summaryItems(metadata *DocumentMetadata) []struct {
+	name  string
+	value *string
+} {
+	return []struct {
+		name  string
+		value *string
+	}{
+		{"Title", &metadata.Title},
+		{"Subject", &metadata.Subject},
+		{"Author", &metadata.Author},
+		{"Keywords", &metadata.Keywords},
+		{"Comments", &metadata.Comments},
+		{"Date", &metadata.Created},
+	}
+}
+
+// GetMetadata returns the summary information of the document
+func (h *Controller) GetMetadata() (*DocumentMetadata, error) {
+	var metadata DocumentMetadata
+	if h.hwpx != nil {
+		metadata = h.hwpx.metadata
+	} else {
+		err := h.readActionDefaults("DocSummaryInfo", "SummaryInfo", func(pset *ole.IDispatch) error {
+			for _, item := range summaryItems(&metadata) {
+				value, err := safeGetProperty(pset, item.name)
+				if err != nil {
+					return fmt.Errorf("failed to read %s: %v", item.name, err)
+				}
+				*item.value = value.ToString()
+				value.Clear()
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if h.currentPath != "" {
+		if info, err := os.Stat(h.currentPath); err == nil {
+			metadata.Modified = info.ModTime().Format(time.RFC3339)
+		}
+	}
+	return &metadata, nil
+}
+
+// SetMetadata changes the summary information of the document; it is stored
+// with the document on the next save
+func (h *Controller) SetMetadata(update MetadataUpdate) error {
+	values := map[string]string{}
+	for name, value := range map[string]*string{
+		"Title":    update.Title,
+		"Subject":  update.Subject,
+		"Author":   update.Author,
+		"Keywords": update.Keywords,
+		"Comments": update.Comments,
+		"Date":     update.Created,
+	} {
+		if value != nil {
+			values[name] = *value
+		}
+	}
+	if len(values) == 0 {
+		return fmt.Errorf("no metadata fields to set")
+	}
+
+	if h.hwpx != nil {
+		for _, item := range summaryItems(&h.hwpx.metadata) {
+			if value, ok := values[item.name]; ok {
+				*item.value = value
+			}
+		}
+		h.hwpx.modified = true
+		return nil
+	}
+
+	return h.executeAction("DocSummaryInfo", "SummaryInfo", func(pset *ole.IDispatch) error {
+		var properties []propertyValue
+		for _, item := range summaryItems(&DocumentMetadata{}) {
+			if value, ok := values[item.name]; ok {
+				properties = append(properties, propertyValue{item.name, value})
+			}
+		}
+		return setDispatchProperties(pset, properties)
+	})
+}
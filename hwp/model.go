@@ -0,0 +1,475 @@
+package hwp
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Structured document model
+//
+// A DocumentModel is a JSON-friendly, flattened view of a document: a list of
+// blocks that are paragraphs made of styled runs, tables of plain-text cells,
+// images and page breaks. It is exported from HWP's HTML rendering and
+// imported by replaying the blocks through the controller, so the round trip
+// keeps text, character styles, alignment and table contents but not layout
+// details such as section settings, cell merges or image positioning.
+
+// DocumentModelVersion is the current version of the model format
+const DocumentModelVersion = 1
+
+// DocumentModel is the structured representation of a document
+type DocumentModel struct {
+	Version int     `json:"version"`
+	Blocks  []Block `json:"blocks"`
+}
+
+// Block types
+const (
+	BlockParagraph = "paragraph"
+	BlockTable     = "table"
+	BlockImage     = "image"
+	BlockPageBreak = "page_break"
+)
+
+// Block is one top-level element of a document
+type Block struct {
+	Type string `json:"type"`
+
+	// Paragraph
+	Align string `json:"align,omitempty"`
+	Runs  []Run  `json:"runs,omitempty"`
+
+	// Table
+	Rows      [][]string `json:"rows,omitempty"`
+	HeaderRow bool       `json:"header_row,omitempty"`
+
+	// Image
+	Source string `json:"source,omitempty"`
+	Alt    string `json:"alt,omitempty"` // alternative text
+}
+
+// Run is a span of text sharing one character style
+type Run struct {
+	Text      string  `json:"text"`
+	Font      string  `json:"font,omitempty"`
+	Size      float64 `json:"size,omitempty"`
+	Bold      bool    `json:"bold,omitempty"`
+	Italic    bool    `json:"italic,omitempty"`
+	Underline bool    `json:"underline,omitempty"`
+	Color     string  `json:"color,omitempty"`
+}
+
+// sameStyle reports whether two runs can be merged
+func (r Run) sameStyle(other Run) bool {
+	r.Text, other.Text = "", ""
+	return r == other
+}
+
+// ExportModel converts the current document to a DocumentModel
+func (h *Controller) ExportModel() (*DocumentModel, error) {
+	document, err := h.GetHTML()
+	if err != nil {
+		return nil, err
+	}
+	return ParseHTMLModel(document), nil
+}
+
+// ImportModel writes the blocks of model at the cursor
+func (h *Controller) ImportModel(model *DocumentModel) error {
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+	if model.Version > DocumentModelVersion {
+		return fmt.Errorf("unsupported model version %d (max %d)", model.Version, DocumentModelVersion)
+	}
+
+	for i, block := range model.Blocks {
+		if err := h.importBlock(block); err != nil {
+			return fmt.Errorf("block %d (%s): %v", i, block.Type, err)
+		}
+	}
+	return nil
+}
+
+// importBlock writes one block and leaves the cursor on a new paragraph after it
+func (h *Controller) importBlock(block Block) error {
+	switch block.Type {
+	case BlockParagraph:
+		align := block.Align
+		if align == "" {
+			align = "justify"
+		}
+		if err := h.SetParagraphAlignment(align); err != nil {
+			return err
+		}
+		for _, run := range block.Runs {
+			color := run.Color
+			if color == "" {
+				color = "black"
+			}
+			size := int(math.Round(run.Size))
+			if err := h.SetFontStyle(run.Font, size, run.Bold, run.Italic, run.Underline, color); err != nil {
+				return err
+			}
+			if err := h.InsertText(run.Text, true); err != nil {
+				return err
+			}
+		}
+		return h.InsertParagraph()
+
+	case BlockTable:
+		if len(block.Rows) == 0 {
+			return nil
+		}
+		cols := 0
+		for _, row := range block.Rows {
+			if len(row) > cols {
+				cols = len(row)
+			}
+		}
+		if cols == 0 {
+			return nil
+		}
+		if err := h.InsertTable(len(block.Rows), cols); err != nil {
+			return err
+		}
+		return h.FillTableWithData(block.Rows, 1, 1, block.HeaderRow)
+
+	case BlockImage:
+		if block.Source == "" {
+			return fmt.Errorf("image source is empty")
+		}
+		if err := h.InsertImage(block.Source, nil, nil, true, nil, nil, nil, true, true, false, false, 0, block.Alt, nil, nil, nil); err != nil {
+			return err
+		}
+		return h.InsertParagraph()
+
+	case BlockPageBreak:
+		return h.InsertPageBreak()
+	}
+
+	return fmt.Errorf("unknown block type")
+}
+
+var (
+	htmlTagPattern   = regexp.MustCompile(`(?s)<(/?)([a-zA-Z][a-zA-Z0-9]*)([^>]*)>|<!--.*?-->`)
+	htmlAttrPattern  = regexp.MustCompile(`(?is)([a-zA-Z-]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+	htmlSpacePattern = regexp.MustCompile(`[ \t\r\n\f]+`)
+	cssFontSizeUnits = strings.NewReplacer("pt", "", "px", "")
+)
+
+// modelParser builds a DocumentModel from a stream of HTML tokens
+type modelParser struct {
+	model      DocumentModel
+	styles     []Run // character style stack; the last entry is current
+	styleTags  []string
+	paragraph  *Block
+	table      *Block
+	tableDepth int
+	row        []string
+	cell       *strings.Builder
+}
+
+// ParseHTMLModel converts HTML, as exported by HWP, to a DocumentModel
+func ParseHTMLModel(document string) *DocumentModel {
+	p := &modelParser{
+		model:  DocumentModel{Version: DocumentModelVersion, Blocks: []Block{}},
+		styles: []Run{{}},
+	}
+
+	// Only the body is content
+	if start := strings.Index(strings.ToLower(document), "<body"); start >= 0 {
+		document = document[start:]
+	}
+
+	last := 0
+	for _, match := range htmlTagPattern.FindAllStringSubmatchIndex(document, -1) {
+		p.text(document[last:match[0]])
+		last = match[1]
+		if match[4] < 0 {
+			continue // comment
+		}
+		closing := match[3] > match[2]
+		name := strings.ToLower(document[match[4]:match[5]])
+		attrs := parseAttributes(document[match[6]:match[7]])
+		if closing {
+			p.closeTag(name)
+		} else {
+			p.openTag(name, attrs)
+		}
+	}
+	p.text(document[last:])
+	p.endParagraph()
+
+	return &p.model
+}
+
+func (p *modelParser) openTag(name string, attrs map[string]string) {
+	if p.tableDepth > 1 && name != "table" {
+		// Content of nested tables is flattened into the outer cell
+		if name == "br" || name == "p" {
+			p.lineBreak()
+		}
+		return
+	}
+
+	if p.cell == nil && hasPageBreakBefore(attrs) {
+		p.endParagraph()
+		if len(p.model.Blocks) > 0 {
+			p.model.Blocks = append(p.model.Blocks, Block{Type: BlockPageBreak})
+		}
+	}
+
+	switch name {
+	case "p", "div", "h1", "h2", "h3", "h4", "h5", "h6", "li":
+		if p.cell != nil {
+			if p.cell.Len() > 0 {
+				p.cell.WriteString("\n")
+			}
+			return
+		}
+		p.endParagraph()
+		p.paragraph = &Block{Type: BlockParagraph, Align: alignFromAttributes(attrs)}
+		p.pushStyle(name, attrs)
+		if strings.HasPrefix(name, "h") {
+			p.styles[len(p.styles)-1].Bold = true
+		}
+	case "span", "font", "b", "strong", "i", "em", "u":
+		p.pushStyle(name, attrs)
+	case "br":
+		p.lineBreak()
+	case "table":
+		p.tableDepth++
+		if p.tableDepth == 1 {
+			p.endParagraph()
+			p.table = &Block{Type: BlockTable}
+		}
+	case "tr":
+		p.row = []string{}
+	case "td", "th":
+		p.cell = &strings.Builder{}
+		if name == "th" && p.table != nil && len(p.table.Rows) == 0 {
+			p.table.HeaderRow = true
+		}
+	case "img":
+		if p.cell != nil {
+			return
+		}
+		// An image alone in a paragraph becomes an image block in its place
+		if p.paragraph != nil && len(p.paragraph.Runs) == 0 {
+			p.paragraph = nil
+		}
+		p.endParagraph()
+		p.model.Blocks = append(p.model.Blocks, Block{Type: BlockImage, Source: attrs["src"], Alt: attrs["alt"]})
+	}
+}
+
+func (p *modelParser) closeTag(name string) {
+	if p.tableDepth > 1 && name != "table" {
+		return
+	}
+
+	switch name {
+	case "p", "div", "h1", "h2", "h3", "h4", "h5", "h6", "li":
+		if p.cell != nil {
+			return
+		}
+		p.popStyle(name)
+		p.endParagraph()
+	case "span", "font", "b", "strong", "i", "em", "u":
+		p.popStyle(name)
+	case "td", "th":
+		if p.cell != nil {
+			p.row = append(p.row, strings.TrimSpace(p.cell.String()))
+			p.cell = nil
+		}
+	case "tr":
+		if p.table != nil && p.row != nil {
+			p.table.Rows = append(p.table.Rows, p.row)
+		}
+		p.row = nil
+	case "table":
+		p.tableDepth--
+		if p.tableDepth == 0 && p.table != nil {
+			p.model.Blocks = append(p.model.Blocks, *p.table)
+			p.table = nil
+			p.cell = nil
+		}
+	}
+}
+
+// text adds character data to the current cell or paragraph
+func (p *modelParser) text(raw string) {
+	// HTML whitespace collapses to single spaces
+	text := htmlSpacePattern.ReplaceAllString(html.UnescapeString(raw), " ")
+	if text == "" {
+		return
+	}
+
+	if p.cell != nil {
+		p.cell.WriteString(text)
+		return
+	}
+	if p.table != nil {
+		return // stray text between cells
+	}
+	if p.paragraph == nil {
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		p.paragraph = &Block{Type: BlockParagraph}
+	}
+	if text == " " && len(p.paragraph.Runs) == 0 {
+		return
+	}
+	p.appendRun(text)
+}
+
+// lineBreak adds a line break within the current cell or paragraph
+func (p *modelParser) lineBreak() {
+	if p.cell != nil {
+		p.cell.WriteString("\n")
+		return
+	}
+	if p.paragraph == nil {
+		p.paragraph = &Block{Type: BlockParagraph}
+	}
+	p.appendRun("\n")
+}
+
+// appendRun adds text in the current style, merging it into the previous run
+// when the styles match
+func (p *modelParser) appendRun(text string) {
+	run := p.styles[len(p.styles)-1]
+	run.Text = text
+	if n := len(p.paragraph.Runs); n > 0 && p.paragraph.Runs[n-1].sameStyle(run) {
+		p.paragraph.Runs[n-1].Text += text
+		return
+	}
+	p.paragraph.Runs = append(p.paragraph.Runs, run)
+}
+
+// endParagraph finishes the open paragraph, trimming the whitespace HTML adds
+func (p *modelParser) endParagraph() {
+	if p.paragraph == nil {
+		return
+	}
+	runs := p.paragraph.Runs
+	if n := len(runs); n > 0 {
+		runs[0].Text = strings.TrimLeft(runs[0].Text, " ")
+		runs[n-1].Text = strings.TrimRight(runs[n-1].Text, " ")
+	}
+	p.model.Blocks = append(p.model.Blocks, *p.paragraph)
+	p.paragraph = nil
+}
+
+// pushStyle derives a character style from the current one and a tag
+func (p *modelParser) pushStyle(name string, attrs map[string]string) {
+	style := p.styles[len(p.styles)-1]
+	style.Text = ""
+
+	switch name {
+	case "b", "strong":
+		style.Bold = true
+	case "i", "em":
+		style.Italic = true
+	case "u":
+		style.Underline = true
+	case "font":
+		if face := attrs["face"]; face != "" {
+			style.Font = face
+		}
+		if color := attrs["color"]; color != "" {
+			style.Color = normalizeCSSColor(color)
+		}
+	}
+
+	for _, declaration := range strings.Split(attrs["style"], ";") {
+		property, value, found := strings.Cut(declaration, ":")
+		if !found {
+			continue
+		}
+		property = strings.ToLower(strings.TrimSpace(property))
+		value = strings.TrimSpace(value)
+		switch property {
+		case "font-family":
+			family := strings.Split(value, ",")[0]
+			style.Font = strings.Trim(strings.TrimSpace(family), `'"`)
+		case "font-size":
+			if size, err := strconv.ParseFloat(strings.TrimSpace(cssFontSizeUnits.Replace(strings.ToLower(value))), 64); err == nil {
+				style.Size = size
+			}
+		case "font-weight":
+			weight, err := strconv.Atoi(value)
+			style.Bold = value == "bold" || value == "bolder" || (err == nil && weight >= 600)
+		case "font-style":
+			style.Italic = value == "italic" || value == "oblique"
+		case "text-decoration":
+			style.Underline = strings.Contains(value, "underline")
+		case "color":
+			style.Color = normalizeCSSColor(value)
+		}
+	}
+
+	p.styles = append(p.styles, style)
+	p.styleTags = append(p.styleTags, name)
+}
+
+// popStyle closes the innermost style opened by name
+func (p *modelParser) popStyle(name string) {
+	for i := len(p.styleTags) - 1; i >= 0; i-- {
+		if p.styleTags[i] == name {
+			p.styleTags = p.styleTags[:i]
+			p.styles = p.styles[:i+1]
+			return
+		}
+	}
+}
+
+// parseAttributes parses the attributes of an HTML tag
+func parseAttributes(raw string) map[string]string {
+	attrs := map[string]string{}
+	for _, match := range htmlAttrPattern.FindAllStringSubmatch(raw, -1) {
+		value := match[2] + match[3] + match[4]
+		attrs[strings.ToLower(match[1])] = html.UnescapeString(value)
+	}
+	return attrs
+}
+
+// alignFromAttributes reads paragraph alignment from align= or text-align
+func alignFromAttributes(attrs map[string]string) string {
+	align := strings.ToLower(attrs["align"])
+	for _, declaration := range strings.Split(attrs["style"], ";") {
+		property, value, found := strings.Cut(declaration, ":")
+		if found && strings.EqualFold(strings.TrimSpace(property), "text-align") {
+			align = strings.ToLower(strings.TrimSpace(value))
+		}
+	}
+	switch align {
+	case "left", "center", "right", "justify":
+		return align
+	}
+	return ""
+}
+
+// normalizeCSSColor returns a CSS color as #RRGGBB when possible
+func normalizeCSSColor(color string) string {
+	color = strings.ToLower(strings.TrimSpace(color))
+	if len(color) == 4 && color[0] == '#' {
+		return "#" + strings.Repeat(color[1:2], 2) + strings.Repeat(color[2:3], 2) + strings.Repeat(color[3:4], 2)
+	}
+	if color == "#000000" || color == "black" {
+		return ""
+	}
+	return color
+}
+
+// hasPageBreakBefore reports whether an element's style starts a new page
+func hasPageBreakBefore(attrs map[string]string) bool {
+	style := strings.ToLower(strings.ReplaceAll(attrs["style"], " ", ""))
+	return strings.Contains(style, "page-break-before:always")
+}
@@ -0,0 +1,333 @@
+package hwp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-ole/go-ole"
+)
+
+// Objects
+//
+// Drawing objects (CtrlID "gso") are the pictures, shapes, text boxes, OLE
+// objects and groups floating in or sitting in the text; their UserDesc names
+// the kind, such as 그림 or 사각형. They are numbered from 0 in document
+// order, walking the control list from HeadCtrl, which is the ID the object
+// tools take.
+//
+// Grouping selects the objects one after another, adding each to the
+// selection with SelectCtrl, and runs ShapeObjGroup; the group replaces them
+// in the list, so their IDs change. The z-order actions stack the selected
+// object over or under the others it overlaps.
+//
+// OLE objects keep the program that made them: an embedded Excel sheet opens
+// in Excel when double-clicked in HWP and shows its first sheet's print area
+// on the page. The OleCreateNew action embeds a file (or links to it) when
+// its OleCreation set names the file instead of a program.
+
+// DrawingObject is a drawing object of the document
+type DrawingObject struct {
+	ID          int     `json:"id"`
+	Kind        string  `json:"kind"`
+	Para        int     `json:"para"`
+	WidthMM     float64 `json:"width_mm"`
+	HeightMM    float64 `json:"height_mm"`
+	Description string  `json:"description,omitempty"`
+}
+
+// groupUserDesc is the UserDesc of grouped drawing objects
+const groupUserDesc = "묶음 개체"
+
+// ObjectOrders are the z-order moves ArrangeObject takes
+var ObjectOrders = []string{"front", "back", "forward", "backward"}
+
+// objectOrderActions are the actions behind each z-order move
+var objectOrderActions = map[string]string{
+	"front":    "ShapeObjBringToFront",
+	"back":     "ShapeObjSendToBack",
+	"forward":  "ShapeObjBringForward",
+	"backward": "ShapeObjSendBack",
+}
+
+// OLEExtensions are the file types hwp_insert_ole_object embeds
+var OLEExtensions = []string{"xlsx", "xlsm", "xls", "pptx", "ppt", "docx", "doc"}
+
+// oleFromFile is the OleCreation Type that creates the object from a file
+const oleFromFile = 1
+
+// InsertOLEObject embeds the file at path as an OLE object at the cursor, or
+// links to it so the object shows the file's current contents
+func (h *Controller) InsertOLEObject(path string, link bool) error {
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	supported := false
+	for _, e := range OLEExtensions {
+		if e == ext {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return fmt.Errorf("cannot embed .%s files (use .%s)", ext, strings.Join(OLEExtensions, ", ."))
+	}
+	if h.hwpx != nil {
+		return ErrCOMRequired
+	}
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path: %v", err)
+	}
+	if info, err := os.Stat(absPath); err != nil || info.IsDir() {
+		return fmt.Errorf("file not found: %s", absPath)
+	}
+
+	return h.executeAction("OleCreateNew", "OleCreation", func(pset *ole.IDispatch) error {
+		return setParameterItems(pset, map[string]interface{}{
+			"Type": oleFromFile,
+			"Path": absPath,
+			"Link": link,
+		})
+	})
+}
+
+// drawingControls returns the drawing object controls of the document in
+// order
+func (h *Controller) drawingControls() ([]*ole.IDispatch, error) {
+	ctrlVar, err := safeGetProperty(h.hwp, "HeadCtrl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the document controls: %v", err)
+	}
+	var controls []*ole.IDispatch
+	for ctrl := ctrlVar.ToIDispatch(); ctrl != nil; {
+		if controlID(ctrl) == drawingCtrlID {
+			controls = append(controls, ctrl)
+		}
+		nextVar, err := safeGetProperty(ctrl, "Next")
+		if err != nil {
+			break
+		}
+		ctrl = nextVar.ToIDispatch()
+	}
+	return controls, nil
+}
+
+// controlPara returns the paragraph a control is anchored in, or -1 if it
+// can't be read
+func controlPara(ctrl *ole.IDispatch) int {
+	anchorVar, err := safeCallMethod(ctrl, "GetAnchorPos", 0)
+	if err != nil {
+		return -1
+	}
+	defer anchorVar.Clear()
+	pos, err := readListParaPos(anchorVar.ToIDispatch())
+	if err != nil {
+		return -1
+	}
+	return pos.Para
+}
+
+// controlSizeMM returns the size of a drawing object in millimeters
+func controlSizeMM(ctrl *ole.IDispatch) (float64, float64) {
+	var size [2]float64
+	for i, item := range []string{"Width", "Height"} {
+		if itemVar, err := controlItem(ctrl, item); err == nil {
+			size[i] = HwpUnitToMM(variantInt(itemVar))
+			itemVar.Clear()
+		}
+	}
+	return size[0], size[1]
+}
+
+// describeObject reads the listing of a drawing object control
+func describeObject(id int, ctrl *ole.IDispatch) DrawingObject {
+	width, height := controlSizeMM(ctrl)
+	return DrawingObject{
+		ID:          id,
+		Kind:        controlUserDesc(ctrl),
+		Para:        controlPara(ctrl),
+		WidthMM:     width,
+		HeightMM:    height,
+		Description: pictureDescription(ctrl),
+	}
+}
+
+// DrawingObjects lists the drawing objects of the document
+func (h *Controller) DrawingObjects() ([]DrawingObject, error) {
+	if h.hwpx != nil {
+		return nil, ErrCOMRequired
+	}
+	if !h.isRunning || h.hwp == nil {
+		return nil, h.notConnected()
+	}
+
+	controls, err := h.drawingControls()
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]DrawingObject, len(controls))
+	for i, ctrl := range controls {
+		objects[i] = describeObject(i, ctrl)
+	}
+	return objects, nil
+}
+
+// SelectObject selects the drawing object with the given ID, as listed by
+// DrawingObjects, so the actions that work on the selected object apply to it
+func (h *Controller) SelectObject(id int) (*DrawingObject, error) {
+	ctrl, err := h.objectControl(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.selectControl(ctrl, fmt.Sprintf("object %d", id)); err != nil {
+		return nil, err
+	}
+	object := describeObject(id, ctrl)
+	return &object, nil
+}
+
+// objectControl returns the drawing object control with the given ID
+func (h *Controller) objectControl(id int) (*ole.IDispatch, error) {
+	if h.hwpx != nil {
+		return nil, ErrCOMRequired
+	}
+	if !h.isRunning || h.hwp == nil {
+		return nil, h.notConnected()
+	}
+
+	controls, err := h.drawingControls()
+	if err != nil {
+		return nil, err
+	}
+	if id < 0 || id >= len(controls) {
+		if len(controls) == 0 {
+			return nil, fmt.Errorf("the document has no drawing objects")
+		}
+		return nil, fmt.Errorf("object ID %d out of range (0-%d)", id, len(controls)-1)
+	}
+	return controls[id], nil
+}
+
+// selectControl selects a drawing object control, replacing the selection;
+// label names it in errors, e.g. "object 3"
+func (h *Controller) selectControl(ctrl *ole.IDispatch, label string) error {
+	// Put the cursor at the object's anchor, then select the control there
+	anchorVar, err := safeCallMethod(ctrl, "GetAnchorPos", 0)
+	if err != nil {
+		return fmt.Errorf("failed to find %s: %v", label, err)
+	}
+	defer anchorVar.Clear()
+	if _, err := safeCallMethod(h.hwp, "SetPosBySet", anchorVar.ToIDispatch()); err != nil {
+		return fmt.Errorf("failed to move to %s: %v", label, err)
+	}
+	found, err := safeCallMethod(h.hwp, "FindCtrl")
+	if err != nil {
+		return fmt.Errorf("failed to select %s: %v", label, err)
+	}
+	defer found.Clear()
+	if ok, isBool := found.Value().(bool); isBool && !ok {
+		return fmt.Errorf("HWP could not select %s", label)
+	}
+	return nil
+}
+
+// addToSelection adds a drawing object control to the selected objects
+func (h *Controller) addToSelection(ctrl *ole.IDispatch, label string) error {
+	instVar, err := safeCallMethod(ctrl, "GetCtrlInstID")
+	if err != nil {
+		return fmt.Errorf("failed to read the instance ID of %s: %v", label, err)
+	}
+	defer instVar.Clear()
+	// Option 1 keeps the objects already selected
+	selected, err := safeCallMethod(h.hwp, "SelectCtrl", fmt.Sprint(instVar.Value()), 1)
+	if err != nil {
+		return fmt.Errorf("failed to select %s: %v", label, err)
+	}
+	defer selected.Clear()
+	if ok, isBool := selected.Value().(bool); isBool && !ok {
+		return fmt.Errorf("HWP could not add %s to the selection", label)
+	}
+	return nil
+}
+
+// GroupObjects groups the drawing objects with the given IDs into one, so
+// they move and resize together. The group takes the place of the first of
+// them in the list.
+func (h *Controller) GroupObjects(ids []int) error {
+	if len(ids) < 2 {
+		return fmt.Errorf("grouping takes at least 2 objects, got %d", len(ids))
+	}
+	seen := map[int]bool{}
+	for _, id := range ids {
+		if seen[id] {
+			return fmt.Errorf("object %d is listed twice", id)
+		}
+		seen[id] = true
+	}
+
+	controls := make([]*ole.IDispatch, len(ids))
+	for i, id := range ids {
+		ctrl, err := h.objectControl(id)
+		if err != nil {
+			return err
+		}
+		controls[i] = ctrl
+	}
+
+	labels := make([]string, len(ids))
+	for i, id := range ids {
+		labels[i] = fmt.Sprintf("object %d", id)
+	}
+	return h.groupControls(controls, labels)
+}
+
+// groupControls selects the drawing object controls, named in errors by
+// labels, and groups them
+func (h *Controller) groupControls(controls []*ole.IDispatch, labels []string) error {
+	if err := h.selectControl(controls[0], labels[0]); err != nil {
+		return err
+	}
+	for i := 1; i < len(controls); i++ {
+		if err := h.addToSelection(controls[i], labels[i]); err != nil {
+			return err
+		}
+	}
+	return h.runAction("ShapeObjGroup")
+}
+
+// UngroupObject splits a group back into its objects, which take its place
+// in the list
+func (h *Controller) UngroupObject(id int) error {
+	ctrl, err := h.objectControl(id)
+	if err != nil {
+		return err
+	}
+	if kind := controlUserDesc(ctrl); kind != groupUserDesc {
+		return fmt.Errorf("object %d is a %s, not a group", id, kind)
+	}
+	if err := h.selectControl(ctrl, fmt.Sprintf("object %d", id)); err != nil {
+		return err
+	}
+	return h.runAction("ShapeObjUngroup")
+}
+
+// ArrangeObject moves a drawing object in the z-order: to the front or back
+// of everything, or one step forward or backward
+func (h *Controller) ArrangeObject(id int, order string) error {
+	action, ok := objectOrderActions[order]
+	if !ok {
+		return fmt.Errorf("invalid order: %s (use %s)", order, strings.Join(ObjectOrders, ", "))
+	}
+	ctrl, err := h.objectControl(id)
+	if err != nil {
+		return err
+	}
+	if err := h.selectControl(ctrl, fmt.Sprintf("object %d", id)); err != nil {
+		return err
+	}
+	return h.runAction(action)
+}
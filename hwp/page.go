@@ -0,0 +1,326 @@
+package hwp
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-ole/go-ole"
+)
+
+// PageSetup holds page layout settings. Margins are in millimeters;
+// nil values leave the current setting unchanged.
+type PageSetup struct {
+	Orientation  string // "portrait", "landscape" or "" to keep
+	LeftMargin   *float64
+	RightMargin  *float64
+	TopMargin    *float64
+	BottomMargin *float64
+	HeaderMargin *float64
+	FooterMargin *float64
+	GutterMargin *float64 // binding margin
+	Binding      string   // "single" (gutter left), "facing" (mirrored), "top" or "" to keep
+}
+
+// gutterTypes maps binding layouts to PageDef GutterType values
+var gutterTypes = map[string]int{
+	"single": 0, // 한쪽 편집: gutter on the left of every page
+	"facing": 1, // 맞쪽 편집: mirrored margins, gutter on the inside
+	"top":    2, // 위로 넘기기: gutter at the top
+}
+
+// hwpUnitsPerMM is the number of HWPUNITs (1/7200 inch) in a millimeter
+const hwpUnitsPerMM = 7200 / 25.4
+
+// MMToHwpUnit converts millimeters to HWPUNIT
+func MMToHwpUnit(mm float64) int {
+	return int(mm*hwpUnitsPerMM + 0.5)
+}
+
+// HwpUnitToMM converts HWPUNIT to millimeters, rounded to 0.1mm
+func HwpUnitToMM(units int) float64 {
+	return math.Round(float64(units)/hwpUnitsPerMM*10) / 10
+}
+
+// Section page setup scope values for the SecDef parameter set
+const (
+	secDefApplyClass     = 24 // page definition settings
+	secDefApplyToSection = 3  // current section
+)
+
+// SetPageSetup applies page layout settings to the current section
+func (h *Controller) SetPageSetup(setup PageSetup) error {
+	landscape := -1
+	switch strings.ToLower(setup.Orientation) {
+	case "":
+	case "portrait":
+		landscape = 0
+	case "landscape":
+		landscape = 1
+	default:
+		return fmt.Errorf("invalid orientation: %s (use portrait or landscape)", setup.Orientation)
+	}
+
+	gutterType := -1
+	if setup.Binding != "" {
+		var ok bool
+		if gutterType, ok = gutterTypes[strings.ToLower(setup.Binding)]; !ok {
+			return fmt.Errorf("invalid binding: %s (use single, facing or top)", setup.Binding)
+		}
+	}
+
+	return h.executeAction("PageSetup", "SecDef", func(pset *ole.IDispatch) error {
+		pageDefVar, err := safeGetProperty(pset, "PageDef")
+		if err != nil {
+			return fmt.Errorf("failed to get PageDef: %v", err)
+		}
+		defer pageDefVar.Clear()
+		pageDef := pageDefVar.ToIDispatch()
+
+		if landscape >= 0 {
+			if err := safePutProperty(pageDef, "Landscape", landscape); err != nil {
+				return fmt.Errorf("failed to set orientation: %v", err)
+			}
+		}
+
+		margins := []struct {
+			item  string
+			value *float64
+		}{
+			{"LeftMargin", setup.LeftMargin},
+			{"RightMargin", setup.RightMargin},
+			{"TopMargin", setup.TopMargin},
+			{"BottomMargin", setup.BottomMargin},
+			{"HeaderLen", setup.HeaderMargin},
+			{"FooterLen", setup.FooterMargin},
+			{"GutterLen", setup.GutterMargin},
+		}
+		for _, margin := range margins {
+			if margin.value == nil {
+				continue
+			}
+			if err := safePutProperty(pageDef, margin.item, MMToHwpUnit(*margin.value)); err != nil {
+				return fmt.Errorf("failed to set %s: %v", margin.item, err)
+			}
+		}
+
+		if gutterType >= 0 {
+			if err := safePutProperty(pageDef, "GutterType", gutterType); err != nil {
+				return fmt.Errorf("failed to set binding: %v", err)
+			}
+		}
+
+		return setParameterItems(pset, map[string]interface{}{
+			"ApplyClass": secDefApplyClass,
+			"ApplyTo":    secDefApplyToSection,
+		})
+	})
+}
+
+// InsertSection starts a new section at the cursor and applies setup to it
+func (h *Controller) InsertSection(setup PageSetup) error {
+	if err := h.runAction("BreakSection"); err != nil {
+		return err
+	}
+	return h.SetPageSetup(setup)
+}
+
+// BorderTypes maps border line style names to HWP border type values
+var BorderTypes = map[string]int{
+	"none":       0,
+	"solid":      1,
+	"dash":       2,
+	"dot":        3,
+	"dash_dot":   4,
+	"double":     8,
+	"thin_thick": 9,
+	"thick_thin": 10,
+	"triple":     11,
+}
+
+// borderWidthsMM lists HWP's selectable border widths; the item value is the index
+var borderWidthsMM = []float64{0.1, 0.12, 0.15, 0.2, 0.25, 0.3, 0.4, 0.5, 0.6, 0.7, 1.0, 1.5, 2.0, 3.0, 4.0, 5.0}
+
+// borderWidthIndex returns the index of the selectable width closest to mm
+func borderWidthIndex(mm float64) int {
+	best := 0
+	for i, width := range borderWidthsMM {
+		if math.Abs(width-mm) < math.Abs(borderWidthsMM[best]-mm) {
+			best = i
+		}
+	}
+	return best
+}
+
+// PageBorder describes a page border; Edges lists any of left, right, top, bottom
+type PageBorder struct {
+	Style   string
+	WidthMM float64
+	Color   string
+	Edges   []string
+}
+
+// withPageBorderFill runs the PageBorder action on the current section and hands
+// apply the BorderFill parameter set shared by odd and even pages
+func (h *Controller) withPageBorderFill(apply func(borderFill *ole.IDispatch) error) error {
+	return h.executeAction("PageBorder", "SecDef", func(pset *ole.IDispatch) error {
+		pageBorderFillVar, err := safeGetProperty(pset, "PageBorderFillBoth")
+		if err != nil {
+			return fmt.Errorf("failed to get PageBorderFillBoth: %v", err)
+		}
+		defer pageBorderFillVar.Clear()
+
+		borderFillVar, err := safeGetProperty(pageBorderFillVar.ToIDispatch(), "BorderFill")
+		if err != nil {
+			return fmt.Errorf("failed to get BorderFill: %v", err)
+		}
+		defer borderFillVar.Clear()
+
+		if err := apply(borderFillVar.ToIDispatch()); err != nil {
+			return err
+		}
+
+		return setParameterItems(pset, map[string]interface{}{
+			"ApplyTo": secDefApplyToSection,
+		})
+	})
+}
+
+// SetPageBorder draws a border around the pages of the current section
+func (h *Controller) SetPageBorder(border PageBorder) error {
+	borderType, ok := BorderTypes[strings.ToLower(border.Style)]
+	if !ok {
+		return fmt.Errorf("invalid border style: %s", border.Style)
+	}
+	color, err := ParseColor(border.Color)
+	if err != nil {
+		return err
+	}
+	edges := border.Edges
+	if len(edges) == 0 {
+		edges = []string{"left", "right", "top", "bottom"}
+	}
+	for _, edge := range edges {
+		switch strings.ToLower(edge) {
+		case "left", "right", "top", "bottom":
+		default:
+			return fmt.Errorf("invalid border edge: %s (use left, right, top, bottom)", edge)
+		}
+	}
+	widthIndex := borderWidthIndex(border.WidthMM)
+
+	return h.withPageBorderFill(func(borderFill *ole.IDispatch) error {
+		for _, edge := range edges {
+			suffix := strings.ToUpper(edge[:1]) + strings.ToLower(edge[1:])
+			if err := safePutProperty(borderFill, "BorderType"+suffix, borderType); err != nil {
+				return fmt.Errorf("failed to set %s border type: %v", edge, err)
+			}
+			if err := safePutProperty(borderFill, "BorderWidth"+suffix, widthIndex); err != nil {
+				return fmt.Errorf("failed to set %s border width: %v", edge, err)
+			}
+			if err := safePutProperty(borderFill, "BorderColor"+suffix, color); err != nil {
+				return fmt.Errorf("failed to set %s border color: %v", edge, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Fill types for the DrawFillAttr parameter set
+const (
+	fillTypeBrush = 1
+	fillTypeImage = 2
+	// fillImageTotal stretches a fill image over the whole area
+	fillImageTotal = 9
+)
+
+// SetPageBackground fills the pages of the current section with a color or image.
+// An image path takes precedence over a color.
+func (h *Controller) SetPageBackground(color, imagePath string) error {
+	var colorValue int
+	var absPath string
+	if imagePath != "" {
+		var err error
+		absPath, err = filepath.Abs(imagePath)
+		if err != nil {
+			return fmt.Errorf("failed to get absolute path: %v", err)
+		}
+		if _, err := os.Stat(absPath); os.IsNotExist(err) {
+			return fmt.Errorf("image file not found: %s", absPath)
+		}
+	} else {
+		var err error
+		colorValue, err = ParseColor(color)
+		if err != nil {
+			return err
+		}
+	}
+
+	return h.withPageBorderFill(func(borderFill *ole.IDispatch) error {
+		fillAttrVar, err := safeGetProperty(borderFill, "FillAttr")
+		if err != nil {
+			return fmt.Errorf("failed to get FillAttr: %v", err)
+		}
+		defer fillAttrVar.Clear()
+		fillAttr := fillAttrVar.ToIDispatch()
+
+		if absPath != "" {
+			return setDispatchProperties(fillAttr, []propertyValue{
+				{"Type", fillTypeImage},
+				{"FileName", absPath},
+				{"Embedded", true},
+				{"DrawFillImageType", fillImageTotal},
+			})
+		}
+		return setDispatchProperties(fillAttr, []propertyValue{
+			{"Type", fillTypeBrush},
+			{"WindowsBrush", 1},
+			{"WinBrushFaceColor", colorValue},
+		})
+	})
+}
+
+// LineNumbering describes per-line numbering of a section
+type LineNumbering struct {
+	Enabled     bool
+	Interval    int     // number every Nth line
+	StartNumber int     // first line number
+	DistanceMM  float64 // gap between the numbers and the text
+	Restart     string  // "page", "section" or "continuous"
+}
+
+// lineNumberRestartTypes maps restart names to SecDef LineNumberRestartType values
+var lineNumberRestartTypes = map[string]int{
+	"continuous": 0,
+	"page":       1,
+	"section":    2,
+}
+
+// SetLineNumbering turns line numbering of the current section on or off
+func (h *Controller) SetLineNumbering(numbering LineNumbering) error {
+	restart, ok := lineNumberRestartTypes[strings.ToLower(numbering.Restart)]
+	if !ok {
+		return fmt.Errorf("invalid restart: %s (use page, section or continuous)", numbering.Restart)
+	}
+	if numbering.Enabled && numbering.Interval < 1 {
+		return fmt.Errorf("interval must be at least 1")
+	}
+
+	// A count-by of 0 disables line numbers
+	countBy := 0
+	if numbering.Enabled {
+		countBy = numbering.Interval
+	}
+
+	return h.executeAction("PageSetup", "SecDef", func(pset *ole.IDispatch) error {
+		return setParameterItems(pset, map[string]interface{}{
+			"LineNumberCountBy":     countBy,
+			"LineNumberStartNumber": numbering.StartNumber,
+			"LineNumberDistance":    MMToHwpUnit(numbering.DistanceMM),
+			"LineNumberRestartType": restart,
+			"ApplyTo":               secDefApplyToSection,
+		})
+	})
+}
@@ -0,0 +1,346 @@
+package hwp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-ole/go-ole"
+)
+
+// Paragraphs
+//
+// Paragraphs are numbered from 0 in the body text, the way HWP numbers them
+// in cursor positions, so an index from Paragraphs is also the para of
+// hwp_find matches and hwp_apply_patch anchors. A table is one paragraph of
+// the body; its cells are lists of their own and are not counted.
+
+// Paragraph is a paragraph of the body text
+type Paragraph struct {
+	Index int    `json:"index"`
+	Style string `json:"style"`
+	Text  string `json:"text"`
+}
+
+// defaultStyleNames are the names of the first styles of HWP's default style
+// list, by style index; the styles after them differ between HWP versions
+var defaultStyleNames = []string{"바탕글", "본문", "개요 1", "개요 2", "개요 3", "개요 4", "개요 5", "개요 6", "개요 7"}
+
+// styleName returns the name of the style with the given index
+func styleName(index int) string {
+	if index >= 0 && index < len(defaultStyleNames) {
+		return defaultStyleNames[index]
+	}
+	return fmt.Sprintf("style %d", index)
+}
+
+// Paragraphs returns up to count paragraphs from index start (count 0 means
+// all the rest) and the number of paragraphs in the body
+func (h *Controller) Paragraphs(start, count int) ([]Paragraph, int, error) {
+	if start < 0 {
+		return nil, 0, fmt.Errorf("start index must not be negative")
+	}
+	if h.hwpx != nil {
+		paragraphs, total := h.hwpx.paragraphTexts(start, count)
+		return paragraphs, total, nil
+	}
+	if !h.isRunning || h.hwp == nil {
+		return nil, 0, h.notConnected()
+	}
+
+	origin, err := h.currentPos()
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		h.runAction("Cancel")
+		h.setPos(origin)
+	}()
+
+	total, err := h.paragraphCount()
+	if err != nil {
+		return nil, 0, err
+	}
+	end := total
+	if count > 0 && start+count < total {
+		end = start + count
+	}
+
+	paragraphs := []Paragraph{}
+	for i := start; i < end; i++ {
+		paragraph, err := h.readParagraph(i)
+		if err != nil {
+			return nil, 0, fmt.Errorf("paragraph %d: %v", i, err)
+		}
+		paragraphs = append(paragraphs, paragraph)
+	}
+	return paragraphs, total, nil
+}
+
+// paragraphCount returns the number of paragraphs of the body text
+func (h *Controller) paragraphCount() (int, error) {
+	if err := h.setPos(listParaPos{}); err != nil {
+		return 0, err
+	}
+	if err := h.runAction("MoveDocEnd"); err != nil {
+		return 0, err
+	}
+	last, err := h.currentPos()
+	if err != nil {
+		return 0, err
+	}
+	return last.Para + 1, nil
+}
+
+// readParagraph reads the style and text of a body paragraph, leaving it
+// selected
+func (h *Controller) readParagraph(index int) (Paragraph, error) {
+	if err := h.setPos(listParaPos{0, index, 0}); err != nil {
+		return Paragraph{}, err
+	}
+	style := 0
+	err := h.readActionDefaults("Style", "Style", func(pset *ole.IDispatch) error {
+		applied, err := safeGetProperty(pset, "Apply")
+		if err != nil {
+			return fmt.Errorf("failed to read the style: %v", err)
+		}
+		defer applied.Clear()
+		style = variantInt(applied)
+		return nil
+	})
+	if err != nil {
+		return Paragraph{}, err
+	}
+
+	if err := h.runAction("MoveSelParaEnd"); err != nil {
+		return Paragraph{}, err
+	}
+	text, err := h.GetSelectedText()
+	if err != nil {
+		return Paragraph{}, err
+	}
+	return Paragraph{Index: index, Style: styleName(style), Text: strings.TrimRight(text, "\r\n")}, nil
+}
+
+// paragraphTexts returns up to count paragraphs from index start (count 0
+// means all the rest) and the number of paragraphs. Every HWPX paragraph has
+// the default style; a table's text has its rows on separate lines.
+func (d *hwpxDocument) paragraphTexts(start, count int) ([]Paragraph, int) {
+	total := len(d.paragraphs)
+	end := total
+	if count > 0 && start+count < total {
+		end = start + count
+	}
+
+	paragraphs := []Paragraph{}
+	for i := start; i < end; i++ {
+		paragraph := d.paragraphs[i]
+		var text strings.Builder
+		if paragraph.table != nil {
+			rows := make([]string, len(paragraph.table.cells))
+			for r, row := range paragraph.table.cells {
+				values := make([]string, len(row))
+				for c, cell := range row {
+					values[c] = cell.content()
+				}
+				rows[r] = strings.Join(values, "\t")
+			}
+			text.WriteString(strings.Join(rows, "\n"))
+		}
+		for _, run := range paragraph.runs {
+			text.WriteString(run.text)
+		}
+		paragraphs = append(paragraphs, Paragraph{Index: i, Style: styleName(0), Text: text.String()})
+	}
+	return paragraphs, total
+}
+
+// checkParagraphRange checks that count paragraphs from start exist
+func checkParagraphRange(start, count, total int) error {
+	if start < 0 || count < 1 {
+		return fmt.Errorf("start index must not be negative and count must be at least 1")
+	}
+	if start+count > total {
+		return fmt.Errorf("paragraphs %d to %d are past the end of the document, which has %d", start, start+count-1, total)
+	}
+	return nil
+}
+
+// DeleteParagraphs deletes count paragraphs from index start. Deleting every
+// paragraph leaves one empty paragraph.
+func (h *Controller) DeleteParagraphs(start, count int) error {
+	if h.hwpx != nil {
+		return h.hwpx.deleteParagraphs(start, count)
+	}
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+
+	total, err := h.paragraphCount()
+	if err != nil {
+		return err
+	}
+	if err := checkParagraphRange(start, count, total); err != nil {
+		return err
+	}
+	if err := h.selectParagraphs(start, count, total); err != nil {
+		return err
+	}
+	return h.runAction("Delete")
+}
+
+// selectParagraphs selects count paragraphs from start with their paragraph
+// breaks. The last paragraph has no break of its own, so a range ending with
+// it takes the break before it instead.
+func (h *Controller) selectParagraphs(start, count, total int) error {
+	if start+count < total {
+		if err := h.setPos(listParaPos{0, start, 0}); err != nil {
+			return err
+		}
+		return h.selectRange(listParaPos{0, start, 0}, listParaPos{0, start + count, 0})
+	}
+	if start == 0 {
+		if err := h.setPos(listParaPos{}); err != nil {
+			return err
+		}
+		return h.runAction("MoveSelDocEnd")
+	}
+	if err := h.setPos(listParaPos{0, start - 1, 0}); err != nil {
+		return err
+	}
+	if err := h.runAction("MoveParaEnd"); err != nil {
+		return err
+	}
+	return h.runAction("MoveSelDocEnd")
+}
+
+// MoveParagraphs moves count paragraphs from index start so that they come
+// before the paragraph that has index to now; to equal to the number of
+// paragraphs moves them to the end. Formatting moves with the text. On COM
+// the paragraphs are carried as HWP data rather than through the clipboard,
+// and the document is restored if a step fails.
+func (h *Controller) MoveParagraphs(start, count, to int) error {
+	if h.hwpx != nil {
+		return h.hwpx.moveParagraphs(start, count, to)
+	}
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+
+	total, err := h.paragraphCount()
+	if err != nil {
+		return err
+	}
+	if err := CheckMoveTarget(start, count, to, total); err != nil {
+		return err
+	}
+
+	return h.restoreOnFailure(func() error {
+		// Give the last paragraph a break, so that every paragraph moved has
+		// one and there is a paragraph start to move to at the end
+		padded := start+count == total || to == total
+		if padded {
+			if err := h.runAction("MoveDocEnd"); err != nil {
+				return err
+			}
+			if err := h.InsertParagraph(); err != nil {
+				return err
+			}
+			total++
+		}
+
+		if err := h.selectParagraphs(start, count, total); err != nil {
+			return err
+		}
+		blockVar, err := safeCallMethod(h.hwp, "GetTextFile", "HWP", "saveblock")
+		if err != nil {
+			return fmt.Errorf("failed to copy the paragraphs: %v", err)
+		}
+		block := blockVar.ToString()
+		blockVar.Clear()
+		if err := h.runAction("Cancel"); err != nil {
+			return err
+		}
+
+		// Edit the later place first so the index of the earlier one holds
+		insert := func() error {
+			if err := h.setPos(listParaPos{0, to, 0}); err != nil {
+				return err
+			}
+			if _, err := safeCallMethod(h.hwp, "SetTextFile", block, "HWP", "insertfile"); err != nil {
+				return fmt.Errorf("failed to insert the paragraphs: %v", err)
+			}
+			return nil
+		}
+		remove := func() error {
+			if err := h.selectParagraphs(start, count, total); err != nil {
+				return err
+			}
+			return h.runAction("Delete")
+		}
+		steps := []func() error{remove, insert}
+		if to > start {
+			steps = []func() error{insert, remove}
+		}
+		for _, step := range steps {
+			if err := step(); err != nil {
+				return err
+			}
+		}
+
+		if padded {
+			if err := h.runAction("MoveDocEnd"); err != nil {
+				return err
+			}
+			return h.runAction("DeleteBack")
+		}
+		return nil
+	})
+}
+
+// CheckMoveTarget checks that count paragraphs from start can be moved before
+// the paragraph at to in a body of total paragraphs
+func CheckMoveTarget(start, count, to, total int) error {
+	if err := checkParagraphRange(start, count, total); err != nil {
+		return err
+	}
+	if to < 0 || to > total {
+		return fmt.Errorf("target index %d is outside the document, which has %d paragraphs", to, total)
+	}
+	if to >= start && to <= start+count {
+		return fmt.Errorf("the paragraphs are already at index %d", to)
+	}
+	return nil
+}
+
+// deleteParagraphs deletes count paragraphs from start
+func (d *hwpxDocument) deleteParagraphs(start, count int) error {
+	if err := checkParagraphRange(start, count, len(d.paragraphs)); err != nil {
+		return err
+	}
+	for _, paragraph := range d.paragraphs[start : start+count] {
+		if paragraph.table != nil && paragraph.table == d.lastTable {
+			d.lastTable = nil
+		}
+	}
+	d.paragraphs = append(d.paragraphs[:start], d.paragraphs[start+count:]...)
+	d.modified = true
+	if len(d.paragraphs) == 0 {
+		d.newParagraph()
+	}
+	return nil
+}
+
+// moveParagraphs moves count paragraphs from start before the paragraph at to
+func (d *hwpxDocument) moveParagraphs(start, count, to int) error {
+	if err := CheckMoveTarget(start, count, to, len(d.paragraphs)); err != nil {
+		return err
+	}
+	moved := append([]*hwpxParagraph{}, d.paragraphs[start:start+count]...)
+	rest := append(append([]*hwpxParagraph{}, d.paragraphs[:start]...), d.paragraphs[start+count:]...)
+	if to > start {
+		to -= count
+	}
+	d.paragraphs = append(append(append([]*hwpxParagraph{}, rest[:to]...), moved...), rest[to:]...)
+	d.modified = true
+	return nil
+}
@@ -0,0 +1,227 @@
+package hwp
+
+import "fmt"
+
+// Document patches
+//
+// A patch is a list of edits computed offline, e.g. from a diff of the text,
+// each addressed by a paragraph position or a bookmark. The edits run in
+// order, so a position refers to the document as the edits before it left it;
+// a diff is simplest to apply from the end of the document backwards.
+//
+// A patch is applied all or nothing: if an edit fails the document is put
+// back as it was by restoreOnFailure, which other multi-step edits use too.
+
+// Patch operations
+const (
+	PatchInsertAt     = "insert_at"     // insert text at a place
+	PatchDeleteRange  = "delete_range"  // delete a range
+	PatchReplaceRange = "replace_range" // replace a range with text
+	PatchFormatRange  = "format_range"  // apply character formatting to a range
+)
+
+// PatchOperations lists the patch operations
+var PatchOperations = []string{PatchInsertAt, PatchDeleteRange, PatchReplaceRange, PatchFormatRange}
+
+// PatchAnchor is a place in the document: a bookmark, or else a 0-based
+// paragraph of the body text and a 0-based character offset in it, as in the
+// para and pos of hwp_find matches. A bookmark starting a range or taking an
+// insertion stands for the point just after it; a bookmark ending a range
+// stands for the point just before it, so bookmarks are never edited away.
+type PatchAnchor struct {
+	Bookmark string
+	Para     int
+	Pos      int
+}
+
+// String describes the anchor for error messages
+func (a PatchAnchor) String() string {
+	if a.Bookmark != "" {
+		return fmt.Sprintf("bookmark %q", a.Bookmark)
+	}
+	return fmt.Sprintf("paragraph %d position %d", a.Para, a.Pos)
+}
+
+// PatchOperation is one edit of a patch. At is the place of insert_at; Start
+// and End bound the range of the other operations.
+type PatchOperation struct {
+	Op     string
+	At     PatchAnchor
+	Start  PatchAnchor
+	End    PatchAnchor
+	Text   string
+	Format CharFormat
+}
+
+// anchors returns the anchors the operation uses
+func (op PatchOperation) anchors() []PatchAnchor {
+	if op.Op == PatchInsertAt {
+		return []PatchAnchor{op.At}
+	}
+	return []PatchAnchor{op.Start, op.End}
+}
+
+// CheckPatch checks that every bookmark the patch names exists, without
+// changing the document
+func (h *Controller) CheckPatch(operations []PatchOperation) error {
+	if h.hwpx != nil {
+		return ErrCOMRequired
+	}
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+	for i, op := range operations {
+		for _, anchor := range op.anchors() {
+			if anchor.Bookmark == "" {
+				continue
+			}
+			if _, err := h.bookmarkPos(anchor.Bookmark); err != nil {
+				return fmt.Errorf("operation %d (%s): %v", i, op.Op, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyPatch runs the operations in order. If one fails the document is put
+// back as it was before the patch and the error names the operation.
+func (h *Controller) ApplyPatch(operations []PatchOperation) error {
+	if err := h.CheckPatch(operations); err != nil {
+		return err
+	}
+	return h.restoreOnFailure(func() error {
+		for i, op := range operations {
+			if err := h.applyPatchOperation(op); err != nil {
+				return fmt.Errorf("operation %d (%s): %v", i, op.Op, err)
+			}
+		}
+		return nil
+	})
+}
+
+// restoreOnFailure runs edit on a COM document and, if it fails, puts the
+// document back as it was before, with the cursor where it was.
+//
+// Ideally the edits would form one undo step that is undone on failure, but
+// HWP's automation API cannot group them: HAction runs Undo and Redo only,
+// with nothing like Word's UndoRecord to open and close a group, and every
+// action and inserted text is an undo step of its own. How many steps an edit
+// made is not reported either, so undoing step by step could stop short or
+// undo the user's own earlier work. The fallback is a copy of the document
+// kept in memory (GetTextFile "HWP"), which is only put back with
+// SetTextFile if edit fails. Restoring this way replaces the undo history.
+func (h *Controller) restoreOnFailure(edit func() error) error {
+	snapshot, err := safeCallMethod(h.hwp, "GetTextFile", "HWP", "")
+	if err != nil {
+		return fmt.Errorf("failed to keep a copy of the document for undoing the edit: %v", err)
+	}
+	original := snapshot.ToString()
+	snapshot.Clear()
+	cursor, cursorErr := h.currentPos()
+
+	err = edit()
+	if err == nil {
+		return nil
+	}
+	h.runAction("Cancel")
+	if _, restoreErr := safeCallMethod(h.hwp, "SetTextFile", original, "HWP", ""); restoreErr != nil {
+		return fmt.Errorf("%v; restoring the document also failed: %v", err, restoreErr)
+	}
+	if cursorErr == nil {
+		h.setPos(cursor)
+	}
+	return fmt.Errorf("%v; the document was left unchanged", err)
+}
+
+// applyPatchOperation runs one operation
+func (h *Controller) applyPatchOperation(op PatchOperation) error {
+	if op.Op == PatchInsertAt {
+		at, err := h.resolveAnchor(op.At, false)
+		if err != nil {
+			return err
+		}
+		if err := h.moveTo(at); err != nil {
+			return err
+		}
+		return h.InsertText(op.Text, true)
+	}
+
+	start, err := h.resolveAnchor(op.Start, false)
+	if err != nil {
+		return err
+	}
+	end, err := h.resolveAnchor(op.End, true)
+	if err != nil {
+		return err
+	}
+	if start.List != end.List {
+		return fmt.Errorf("%s and %s are not in the same text", op.Start, op.End)
+	}
+	if end.before(start) {
+		return fmt.Errorf("%s comes after %s", op.Start, op.End)
+	}
+	if err := h.moveTo(start); err != nil {
+		return err
+	}
+
+	if start != end {
+		if err := h.selectRange(start, end); err != nil {
+			return err
+		}
+		switch op.Op {
+		case PatchDeleteRange, PatchReplaceRange:
+			if err := h.runAction("Delete"); err != nil {
+				return err
+			}
+		case PatchFormatRange:
+			defer h.runAction("Cancel")
+			return h.SetCharFormat(op.Format)
+		}
+	}
+	if op.Op == PatchReplaceRange && op.Text != "" {
+		return h.InsertText(op.Text, true)
+	}
+	return nil
+}
+
+// resolveAnchor returns the position an anchor stands for, at the end of a
+// range if end is set
+func (h *Controller) resolveAnchor(anchor PatchAnchor, end bool) (listParaPos, error) {
+	if anchor.Bookmark == "" {
+		return listParaPos{0, anchor.Para, anchor.Pos}, nil
+	}
+	pos, err := h.bookmarkPos(anchor.Bookmark)
+	if err != nil {
+		return listParaPos{}, err
+	}
+	if !end {
+		// After the bookmark's control character
+		pos.Pos++
+	}
+	return pos, nil
+}
+
+// moveTo moves the cursor to pos and checks that it got there, which it
+// doesn't when pos is past the end of a paragraph or of the document
+func (h *Controller) moveTo(pos listParaPos) error {
+	if err := h.setPos(pos); err != nil {
+		return err
+	}
+	got, err := h.currentPos()
+	if err != nil {
+		return err
+	}
+	if got != pos {
+		return fmt.Errorf("paragraph %d position %d is outside the document", pos.Para, pos.Pos)
+	}
+	return nil
+}
+
+// selectRange selects from to to, which must be in the same list as the
+// cursor
+func (h *Controller) selectRange(from, to listParaPos) error {
+	if _, err := safeCallMethod(h.hwp, "SelectText", from.Para, from.Pos, to.Para, to.Pos); err != nil {
+		return fmt.Errorf("failed to select the range: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,324 @@
+package hwp
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/go-ole/go-ole"
+)
+
+// Pictures
+//
+// Pictures are drawing object controls (CtrlID "gso") whose UserDesc is 그림;
+// other drawing objects such as lines and text boxes share the control ID.
+// Their settings are items of the control's properties (the ShapeObject
+// parameter set), written back by assigning Properties: the description
+// (개체 설명문, ShapeComment) that screen readers and HWP's accessibility
+// checker read as alternative text, the wrap and position, the rotation
+// (RotateAngle) and the crop of each edge (CropLeft, CropTop, CropRight,
+// CropBottom, in HWP units).
+
+// drawingCtrlID is the control ID of drawing objects, pictures among them
+const drawingCtrlID = "gso"
+
+// pictureUserDesc is the UserDesc of picture controls
+const pictureUserDesc = "그림"
+
+// Picture is a picture of the document, numbered from 0 in document order
+type Picture struct {
+	Index       int    `json:"index"`
+	Para        int    `json:"para"`
+	Description string `json:"description"`
+}
+
+// ImagePlacement is how a picture sits in the text. An inline picture is
+// treated as a character of its line; the other wraps float the picture,
+// placed relative to the paper, page, column or paragraph with an alignment
+// and an offset from it in millimeters.
+type ImagePlacement struct {
+	Wrap         string // inline, square, top_bottom, behind_text or in_front
+	HorzRelTo    string // paper, page, column or para (default: column)
+	HorzAlign    string // left, center or right (default: left)
+	HorzOffsetMM float64
+	VertRelTo    string // paper, page or para (default: para)
+	VertAlign    string // top, center or bottom (default: top)
+	VertOffsetMM float64
+}
+
+// Placement choices, in the order of their ShapeObject values
+var (
+	ImageWraps     = []string{"square", "top_bottom", "behind_text", "in_front"} // TextWrap 0-3
+	ImageHorzRelTo = []string{"paper", "page", "column", "para"}                 // HorzRelTo 0-3
+	ImageVertRelTo = []string{"paper", "page", "para"}                           // VertRelTo 0-2
+	ImageHorzAlign = []string{"left", "center", "right"}                         // HorzAlign 0-2
+	ImageVertAlign = []string{"top", "center", "bottom"}                         // VertAlign 0-2
+)
+
+// placementIndex returns the ShapeObject value of choice, or an error naming
+// the choices; an empty choice is fallback
+func placementIndex(name, choice, fallback string, choices []string) (int, error) {
+	if choice == "" {
+		choice = fallback
+	}
+	for i, c := range choices {
+		if strings.EqualFold(c, choice) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid %s: %s (use %s)", name, choice, strings.Join(choices, ", "))
+}
+
+// items returns the ShapeObject items of the placement, checking its values
+func (p ImagePlacement) items() ([]propertyValue, error) {
+	if p.Wrap == "" || strings.EqualFold(p.Wrap, "inline") {
+		return []propertyValue{{"TreatAsChar", true}}, nil
+	}
+	wrap, err := placementIndex("wrap", p.Wrap, "", ImageWraps)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wrap: %s (use inline, %s)", p.Wrap, strings.Join(ImageWraps, ", "))
+	}
+	horzRelTo, err := placementIndex("horizontal anchor", p.HorzRelTo, "column", ImageHorzRelTo)
+	if err != nil {
+		return nil, err
+	}
+	vertRelTo, err := placementIndex("vertical anchor", p.VertRelTo, "para", ImageVertRelTo)
+	if err != nil {
+		return nil, err
+	}
+	horzAlign, err := placementIndex("horizontal alignment", p.HorzAlign, "left", ImageHorzAlign)
+	if err != nil {
+		return nil, err
+	}
+	vertAlign, err := placementIndex("vertical alignment", p.VertAlign, "top", ImageVertAlign)
+	if err != nil {
+		return nil, err
+	}
+	return []propertyValue{
+		{"TreatAsChar", false},
+		{"TextWrap", wrap},
+		{"HorzRelTo", horzRelTo},
+		{"HorzAlign", horzAlign},
+		{"HorzOffset", MMToHwpUnit(p.HorzOffsetMM)},
+		{"VertRelTo", vertRelTo},
+		{"VertAlign", vertAlign},
+		{"VertOffset", MMToHwpUnit(p.VertOffsetMM)},
+	}, nil
+}
+
+// Validate checks the placement's values
+func (p ImagePlacement) Validate() error {
+	_, err := p.items()
+	return err
+}
+
+// ImageTransform turns and trims a picture, e.g. to straighten a scanned
+// stamp. Only the fields set change: Rotation is in degrees clockwise and
+// the crops are millimeters cut off each edge of the image.
+type ImageTransform struct {
+	Rotation     *float64
+	CropLeftMM   *float64
+	CropTopMM    *float64
+	CropRightMM  *float64
+	CropBottomMM *float64
+}
+
+// items returns the ShapeObject items of the transform, checking its values
+func (t ImageTransform) items() ([]propertyValue, error) {
+	var items []propertyValue
+	if t.Rotation != nil {
+		// HWP keeps whole degrees from 0 to 359
+		angle := int(math.Round(math.Mod(*t.Rotation, 360)))
+		if angle < 0 {
+			angle += 360
+		}
+		items = append(items, propertyValue{"RotateAngle", angle % 360})
+	}
+	crops := []struct {
+		name, edge string
+		mm         *float64
+	}{
+		{"CropLeft", "left", t.CropLeftMM},
+		{"CropTop", "top", t.CropTopMM},
+		{"CropRight", "right", t.CropRightMM},
+		{"CropBottom", "bottom", t.CropBottomMM},
+	}
+	for _, crop := range crops {
+		if crop.mm == nil {
+			continue
+		}
+		if *crop.mm < 0 {
+			return nil, fmt.Errorf("invalid %s crop: %gmm (crops cannot be negative)", crop.edge, *crop.mm)
+		}
+		items = append(items, propertyValue{crop.name, MMToHwpUnit(*crop.mm)})
+	}
+	return items, nil
+}
+
+// Validate checks the transform's values
+func (t ImageTransform) Validate() error {
+	_, err := t.items()
+	return err
+}
+
+// pictureItems returns the ShapeObject items setting a picture's
+// description, when not empty, and the placement and transform other than nil
+func pictureItems(description string, placement *ImagePlacement, transform *ImageTransform) ([]propertyValue, error) {
+	var items []propertyValue
+	if description != "" {
+		items = append(items, propertyValue{"ShapeComment", description})
+	}
+	if placement != nil {
+		placementItems, err := placement.items()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, placementItems...)
+	}
+	if transform != nil {
+		transformItems, err := transform.items()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, transformItems...)
+	}
+	return items, nil
+}
+
+// pictureControls returns the picture controls of the document in order
+func (h *Controller) pictureControls() ([]*ole.IDispatch, error) {
+	controls, err := h.drawingControls()
+	if err != nil {
+		return nil, err
+	}
+	var pictures []*ole.IDispatch
+	for _, ctrl := range controls {
+		if controlUserDesc(ctrl) == pictureUserDesc {
+			pictures = append(pictures, ctrl)
+		}
+	}
+	return pictures, nil
+}
+
+// controlUserDesc returns the UserDesc of a control, or "" if it can't be read
+func controlUserDesc(ctrl *ole.IDispatch) string {
+	descVar, err := safeGetProperty(ctrl, "UserDesc")
+	if err != nil {
+		return ""
+	}
+	defer descVar.Clear()
+	return descVar.ToString()
+}
+
+// Pictures lists the pictures of the document with their descriptions
+func (h *Controller) Pictures() ([]Picture, error) {
+	if h.hwpx != nil {
+		return nil, ErrCOMRequired
+	}
+	if !h.isRunning || h.hwp == nil {
+		return nil, h.notConnected()
+	}
+
+	controls, err := h.pictureControls()
+	if err != nil {
+		return nil, err
+	}
+	pictures := make([]Picture, len(controls))
+	for i, ctrl := range controls {
+		pictures[i] = Picture{Index: i, Para: controlPara(ctrl), Description: pictureDescription(ctrl)}
+	}
+	return pictures, nil
+}
+
+// pictureDescription returns the description of a picture control
+func pictureDescription(ctrl *ole.IDispatch) string {
+	commentVar, err := controlItem(ctrl, "ShapeComment")
+	if err != nil {
+		return ""
+	}
+	defer commentVar.Clear()
+	return commentVar.ToString()
+}
+
+// controlItem reads an item of a drawing object control's properties
+func controlItem(ctrl *ole.IDispatch, item string) (*ole.VARIANT, error) {
+	propertiesVar, err := safeGetProperty(ctrl, "Properties")
+	if err != nil {
+		return nil, err
+	}
+	defer propertiesVar.Clear()
+	return safeCallMethod(propertiesVar.ToIDispatch(), "Item", item)
+}
+
+// setControlItems sets items of a drawing object control's properties
+func setControlItems(ctrl *ole.IDispatch, items []propertyValue) error {
+	propertiesVar, err := safeGetProperty(ctrl, "Properties")
+	if err != nil {
+		return fmt.Errorf("failed to read the picture properties: %v", err)
+	}
+	defer propertiesVar.Clear()
+	properties := propertiesVar.ToIDispatch()
+	for _, item := range items {
+		if _, err := safeCallMethod(properties, "SetItem", item.name, item.value); err != nil {
+			return fmt.Errorf("failed to set %s: %v", item.name, err)
+		}
+	}
+	if err := safePutProperty(ctrl, "Properties", properties); err != nil {
+		return fmt.Errorf("failed to apply the picture properties: %v", err)
+	}
+	return nil
+}
+
+// SetPictureDescription sets the description (alternative text) of the
+// picture with the given index, as listed by Pictures
+func (h *Controller) SetPictureDescription(index int, description string) error {
+	if h.hwpx != nil {
+		return ErrCOMRequired
+	}
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+
+	control, err := h.pictureControl(index)
+	if err != nil {
+		return err
+	}
+	return setControlItems(control, []propertyValue{{"ShapeComment", description}})
+}
+
+// pictureControl returns the picture control with the given index
+func (h *Controller) pictureControl(index int) (*ole.IDispatch, error) {
+	controls, err := h.pictureControls()
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(controls) {
+		if len(controls) == 0 {
+			return nil, fmt.Errorf("the document has no pictures")
+		}
+		return nil, fmt.Errorf("picture index %d out of range (0-%d)", index, len(controls)-1)
+	}
+	return controls[index], nil
+}
+
+// UpdatePicture sets how the picture with the given index, as listed by
+// Pictures, sits in the text and how it is turned and trimmed; a nil
+// placement or transform is left as it is
+func (h *Controller) UpdatePicture(index int, placement *ImagePlacement, transform *ImageTransform) error {
+	items, err := pictureItems("", placement, transform)
+	if err != nil {
+		return err
+	}
+	if h.hwpx != nil {
+		return ErrCOMRequired
+	}
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+
+	control, err := h.pictureControl(index)
+	if err != nil {
+		return err
+	}
+	return setControlItems(control, items)
+}
@@ -0,0 +1,338 @@
+package hwp
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/go-ole/go-ole"
+)
+
+// Personal data
+//
+// Personal data is found by matching regular expressions against the plain
+// text of the document, so it is recognized the same way on both backends.
+// Redaction then replaces each distinct match with a literal find and replace
+// over the whole document, which keeps the formatting around it.
+
+// PIIPattern is a kind of personal data recognized in document text
+type PIIPattern struct {
+	Name        string
+	Description string
+	pattern     *regexp.Regexp
+	mask        func(match string) string
+}
+
+// PIIPatterns are the built-in kinds of personal data
+var PIIPatterns = []PIIPattern{
+	{
+		Name:        "resident_registration_number",
+		Description: "주민등록번호 and 외국인등록번호 (YYMMDD-NNNNNNN)",
+		pattern:     regexp.MustCompile(`\b\d{2}(?:0[1-9]|1[0-2])(?:0[1-9]|[12]\d|3[01])-?[1-8]\d{6}\b`),
+		mask:        maskRegistrationNumber,
+	},
+	{
+		Name:        "phone",
+		Description: "mobile and landline phone numbers (010-1234-5678, 02-123-4567)",
+		pattern:     regexp.MustCompile(`\b(?:01[016789]|02|0[3-6][1-5]|070)[-. ]?\d{3,4}[-. ]?\d{4}\b`),
+		mask:        maskPhoneNumber,
+	},
+	{
+		Name:        "email",
+		Description: "email addresses",
+		pattern:     regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`),
+		mask:        maskEmail,
+	},
+}
+
+// Redaction modes
+const (
+	RedactBox  = "box"  // every character becomes a black box
+	RedactMask = "mask" // identifying characters become asterisks
+)
+
+// RedactModes lists the redaction modes
+var RedactModes = []string{RedactBox, RedactMask}
+
+// redactBox is the character that blacks out redacted text
+const redactBox = "■"
+
+// LookupPIIPattern returns the built-in pattern with the given name
+func LookupPIIPattern(name string) (PIIPattern, bool) {
+	for _, pattern := range PIIPatterns {
+		if pattern.Name == name {
+			return pattern, true
+		}
+	}
+	return PIIPattern{}, false
+}
+
+// PIIPatternNames returns the names of the built-in patterns
+func PIIPatternNames() []string {
+	names := make([]string, len(PIIPatterns))
+	for i, pattern := range PIIPatterns {
+		names[i] = pattern.Name
+	}
+	return names
+}
+
+// NewPIIPattern compiles a custom pattern. Its matches are masked by hiding
+// every letter and digit. A pattern that matches empty text is rejected, as
+// it would match between every two characters.
+func NewPIIPattern(name, expression string) (PIIPattern, error) {
+	pattern, err := regexp.Compile(expression)
+	if err != nil {
+		return PIIPattern{}, fmt.Errorf("invalid regular expression: %v", err)
+	}
+	if pattern.MatchString("") {
+		return PIIPattern{}, fmt.Errorf("regular expression %q matches empty text", expression)
+	}
+	return PIIPattern{Name: name, Description: expression, pattern: pattern, mask: maskAll}, nil
+}
+
+// FindAll returns the matches of the pattern in text, in order
+func (p PIIPattern) FindAll(text string) []string {
+	var matches []string
+	for _, span := range p.FindAllIndex(text) {
+		matches = append(matches, text[span[0]:span[1]])
+	}
+	return matches
+}
+
+// FindAllIndex returns the byte offsets of the matches of the pattern in
+// text. Empty matches, which an expression such as `\b` or `x*` can still
+// make in non-empty text, are left out.
+func (p PIIPattern) FindAllIndex(text string) [][]int {
+	var spans [][]int
+	for _, span := range p.pattern.FindAllStringIndex(text, -1) {
+		if span[1] > span[0] {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// Replacement returns what a match is redacted to in the given mode
+func (p PIIPattern) Replacement(match, mode string) string {
+	if mode == RedactMask {
+		return p.mask(match)
+	}
+	return strings.Repeat(redactBox, utf8.RuneCountInString(match))
+}
+
+// maskRunes replaces the letters and digits of s for which hide returns true
+// with asterisks, keeping separators
+func maskRunes(s string, hide func(i int, r rune) bool) string {
+	var b strings.Builder
+	i := 0
+	for _, r := range s {
+		if (unicode.IsLetter(r) || unicode.IsDigit(r)) && hide(i, r) {
+			b.WriteRune('*')
+		} else {
+			b.WriteRune(r)
+		}
+		i++
+	}
+	return b.String()
+}
+
+// maskAll hides every letter and digit
+func maskAll(match string) string {
+	return maskRunes(match, func(int, rune) bool { return true })
+}
+
+// maskRegistrationNumber keeps the birth date and the first digit after it:
+// 900101-1******
+func maskRegistrationNumber(match string) string {
+	digits := 0
+	return maskRunes(match, func(_ int, r rune) bool {
+		digits++
+		return digits > 7
+	})
+}
+
+// maskPhoneNumber hides the middle group: 010-****-5678. Numbers without
+// separators keep their first three and last four digits.
+func maskPhoneNumber(match string) string {
+	groups := strings.FieldsFunc(match, func(r rune) bool { return !unicode.IsDigit(r) })
+	if len(groups) == 3 {
+		start := len(groups[0]) + 1
+		end := start + len(groups[1])
+		return maskRunes(match, func(i int, _ rune) bool { return i >= start && i < end })
+	}
+	n := utf8.RuneCountInString(match)
+	return maskRunes(match, func(i int, _ rune) bool { return i >= 3 && i < n-4 })
+}
+
+// maskEmail keeps the first character of the local part and the domain:
+// h*****@example.com
+func maskEmail(match string) string {
+	at := strings.LastIndex(match, "@")
+	local, domain := match[:at], match[at:]
+	return maskRunes(local, func(i int, _ rune) bool { return i > 0 }) + domain
+}
+
+// Redaction is a distinct match of a pattern and what it is redacted to
+type Redaction struct {
+	Pattern     string
+	Text        string
+	Replacement string
+	Count       int
+}
+
+// PlanRedactions finds the matches of patterns in text, in pattern order, and
+// returns the distinct matches with their replacements and counts. Text a
+// pattern has redacted is not matched again by the patterns after it.
+//
+// The redactions are replayed as literal find and replace, so each pattern's
+// matches are ordered longest first: a match that is part of a longer one
+// (123 in 12345) would otherwise be replaced first and leave the rest of the
+// longer match to show.
+func PlanRedactions(text string, patterns []PIIPattern, mode string) []Redaction {
+	var redactions []Redaction
+	for _, pattern := range patterns {
+		index := map[string]int{}
+		var planned []Redaction
+		for _, match := range pattern.FindAll(text) {
+			if i, seen := index[match]; seen {
+				planned[i].Count++
+				continue
+			}
+			index[match] = len(planned)
+			planned = append(planned, Redaction{
+				Pattern:     pattern.Name,
+				Text:        match,
+				Replacement: pattern.Replacement(match, mode),
+				Count:       1,
+			})
+		}
+		sort.SliceStable(planned, func(i, j int) bool { return len(planned[i].Text) > len(planned[j].Text) })
+		for _, redaction := range planned {
+			text = strings.ReplaceAll(text, redaction.Text, redaction.Replacement)
+		}
+		redactions = append(redactions, planned...)
+	}
+	return redactions
+}
+
+// Redact replaces every match of patterns in the document as PlanRedactions
+// plans it and returns the redactions made. On COM the document is restored
+// if a replacement fails, so that it is never left partly redacted.
+func (h *Controller) Redact(patterns []PIIPattern, mode string) ([]Redaction, error) {
+	text, err := h.GetText()
+	if err != nil {
+		return nil, err
+	}
+	redactions := PlanRedactions(text, patterns, mode)
+	replaceAll := func() error {
+		for _, redaction := range redactions {
+			if err := h.ReplaceAllText(redaction.Text, redaction.Replacement); err != nil {
+				return fmt.Errorf("failed to redact %s: %v", redaction.Pattern, err)
+			}
+		}
+		return nil
+	}
+	if h.hwpx != nil {
+		err = replaceAll()
+	} else {
+		err = h.restoreOnFailure(replaceAll)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return redactions, nil
+}
+
+// replaceAllDirection is the FindReplace direction that covers the whole
+// document
+const replaceAllDirection = 2
+
+// ReplaceAllText replaces every occurrence of the literal text find in the
+// document, tables included, with replacement
+func (h *Controller) ReplaceAllText(find, replacement string) error {
+	if find == "" {
+		return fmt.Errorf("search text is empty")
+	}
+	if h.hwpx != nil {
+		h.hwpx.replaceAll(find, replacement)
+		return nil
+	}
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+	return h.executeAction("AllReplace", "FindReplace", func(pset *ole.IDispatch) error {
+		return setDispatchProperties(pset, []propertyValue{
+			{"FindString", find},
+			{"ReplaceString", replacement},
+			{"FindRegExp", false},
+			{"MatchCase", true},
+			{"Direction", replaceAllDirection},
+			// Suppress the "n replacements made" message
+			{"IgnoreMessage", 1},
+		})
+	})
+}
+
+// PIIMatch is personal data found in the document. Line is the 1-based line
+// of the document text (a paragraph or a table row, whose cells are separated
+// by tabs) and Column the 1-based character in it.
+type PIIMatch struct {
+	Type    string `json:"type"`
+	Text    string `json:"text"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Context string `json:"context"`
+}
+
+// ScanPII returns the matches of patterns in text in document order. Like
+// PlanRedactions it gives text matched by a pattern to that pattern only, so
+// a match overlapping one of an earlier pattern is left out.
+func ScanPII(text string, patterns []PIIPattern) []PIIMatch {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	var matches []PIIMatch
+	for number, line := range strings.Split(text, "\n") {
+		var claimed [][]int
+		var found []PIIMatch
+		for _, pattern := range patterns {
+		next:
+			for _, span := range pattern.FindAllIndex(line) {
+				for _, other := range claimed {
+					if span[0] < other[1] && other[0] < span[1] {
+						continue next
+					}
+				}
+				claimed = append(claimed, span)
+				found = append(found, PIIMatch{
+					Type:    pattern.Name,
+					Text:    line[span[0]:span[1]],
+					Line:    number + 1,
+					Column:  utf8.RuneCountInString(line[:span[0]]) + 1,
+					Context: lineContext(line, span[0], span[1]),
+				})
+			}
+		}
+		sort.SliceStable(found, func(i, j int) bool { return found[i].Column < found[j].Column })
+		matches = append(matches, found...)
+	}
+	return matches
+}
+
+// lineContext returns line around the bytes start to end, cut to contextRunes
+// characters on each side
+func lineContext(line string, start, end int) string {
+	before := []rune(line[:start])
+	after := []rune(line[end:])
+	context := line[start:end]
+	if len(before) > contextRunes {
+		context = "…" + string(before[len(before)-contextRunes:]) + context
+	} else {
+		context = string(before) + context
+	}
+	if len(after) > contextRunes {
+		return context + string(after[:contextRunes]) + "…"
+	}
+	return context + string(after)
+}
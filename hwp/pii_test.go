@@ -0,0 +1,170 @@
+package hwp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const piiSample = "성명: 홍길동, 주민번호 900101-1234567입니다.\n" +
+	"연락처는 010-1234-5678(휴대폰), 사무실 02-123-4567로 주세요.\n" +
+	"메일은 hong.gildong@example.co.kr로 보내 주세요."
+
+func TestScanPIIFindsMatchesInKoreanText(t *testing.T) {
+	got := ScanPII(piiSample, PIIPatterns)
+	want := []PIIMatch{
+		{Type: "resident_registration_number", Text: "900101-1234567", Line: 1, Column: 15},
+		{Type: "phone", Text: "010-1234-5678", Line: 2, Column: 6},
+		{Type: "phone", Text: "02-123-4567", Line: 2, Column: 30},
+		{Type: "email", Text: "hong.gildong@example.co.kr", Line: 3, Column: 5},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ScanPII found %d matches, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		got[i].Context = ""
+		if got[i] != want[i] {
+			t.Errorf("match %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestPlanRedactions(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		mode string
+		want []Redaction
+	}{
+		{
+			name: "resident number masked",
+			text: "주민번호 900101-1234567입니다",
+			mode: RedactMask,
+			want: []Redaction{{Pattern: "resident_registration_number", Text: "900101-1234567", Replacement: "900101-1******", Count: 1}},
+		},
+		{
+			name: "resident number without hyphen boxed",
+			text: "번호:9001011234567",
+			mode: RedactBox,
+			want: []Redaction{{Pattern: "resident_registration_number", Text: "9001011234567", Replacement: "■■■■■■■■■■■■■", Count: 1}},
+		},
+		{
+			name: "repeated phone counted once",
+			text: "전화 010-1234-5678, 다시 010-1234-5678 또는 01098765432",
+			mode: RedactMask,
+			want: []Redaction{
+				{Pattern: "phone", Text: "010-1234-5678", Replacement: "010-****-5678", Count: 2},
+				{Pattern: "phone", Text: "01098765432", Replacement: "010****5432", Count: 1},
+			},
+		},
+		{
+			name: "email masked",
+			text: "담당자 이메일: hong@example.com 입니다",
+			mode: RedactMask,
+			want: []Redaction{{Pattern: "email", Text: "hong@example.com", Replacement: "h***@example.com", Count: 1}},
+		},
+		{
+			name: "no personal data",
+			text: "개인정보가 없는 문단입니다.",
+			mode: RedactBox,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PlanRedactions(tt.text, PIIPatterns, tt.mode)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PlanRedactions(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPIIPatternRejectsEmptyMatches(t *testing.T) {
+	for _, expression := range []string{"", "x*", "(?:사번)?", "^"} {
+		if _, err := NewPIIPattern("custom", expression); err == nil {
+			t.Errorf("NewPIIPattern(%q) accepted a pattern matching empty text", expression)
+		}
+	}
+}
+
+func TestPlanRedactionsSkipsEmptyMatches(t *testing.T) {
+	// `\b` matches no empty text but only empty text elsewhere
+	pattern, err := NewPIIPattern("boundary", `\b`)
+	if err != nil {
+		t.Fatalf("NewPIIPattern: %v", err)
+	}
+	if got := PlanRedactions("사번 A123 입니다", []PIIPattern{pattern}, RedactBox); len(got) != 0 {
+		t.Errorf("PlanRedactions planned empty matches: %+v", got)
+	}
+	if got := ScanPII("사번 A123 입니다", []PIIPattern{pattern}); len(got) != 0 {
+		t.Errorf("ScanPII found empty matches: %+v", got)
+	}
+}
+
+// applyRedactions replays a plan the way Redact does, one literal replace
+// all per redaction
+func applyRedactions(text string, redactions []Redaction) string {
+	for _, redaction := range redactions {
+		text = strings.ReplaceAll(text, redaction.Text, redaction.Replacement)
+	}
+	return text
+}
+
+func TestPlanRedactionsCoversSubstringMatches(t *testing.T) {
+	digits, err := NewPIIPattern("digits", `\d{3,}`)
+	if err != nil {
+		t.Fatalf("NewPIIPattern: %v", err)
+	}
+	tests := []struct {
+		name     string
+		text     string
+		patterns []PIIPattern
+		mode     string
+		want     string
+	}{
+		{
+			name:     "shorter number first, masked",
+			text:     "사번 123, 내선 12345",
+			patterns: []PIIPattern{digits},
+			mode:     RedactMask,
+			want:     "사번 ***, 내선 *****",
+		},
+		{
+			name:     "shorter number first, boxed",
+			text:     "사번 123, 내선 12345",
+			patterns: []PIIPattern{digits},
+			mode:     RedactBox,
+			want:     "사번 ■■■, 내선 ■■■■■",
+		},
+		{
+			name:     "overlapping numbers, masked",
+			text:     "번호 234, 1234, 12345와 2345",
+			patterns: []PIIPattern{digits},
+			mode:     RedactMask,
+			want:     "번호 ***, ****, *****와 ****",
+		},
+		{
+			name:     "email inside a longer one, boxed",
+			text:     "a@b.com 과 xa@b.com",
+			patterns: PIIPatterns,
+			mode:     RedactBox,
+			want:     "■■■■■■■ 과 ■■■■■■■■",
+		},
+		{
+			name:     "email inside a longer one, masked",
+			text:     "ab@c.com 과 xab@c.com",
+			patterns: PIIPatterns,
+			mode:     RedactMask,
+			want:     "a*@c.com 과 x**@c.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redactions := PlanRedactions(tt.text, tt.patterns, tt.mode)
+			if got := applyRedactions(tt.text, redactions); got != tt.want {
+				t.Errorf("redacted %q to %q, want %q (plan %+v)", tt.text, got, tt.want, redactions)
+			}
+		})
+	}
+}
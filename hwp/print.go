@@ -0,0 +1,124 @@
+package hwp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-ole/go-ole"
+)
+
+// Printing to PDF
+//
+// SaveAs PDF renders with HWP's own PDF writer, which can lay out some
+// documents differently from paper. Printing to a PDF printer driver goes
+// through the print path instead, so the file matches a printout. The driver
+// writes the file from the print spooler after the Print action returns, so
+// PrintToPDF waits for it to appear.
+
+// PDFPrinters maps the short printer names to the Windows printer names
+var PDFPrinters = map[string]string{
+	"microsoft": "Microsoft Print to PDF",
+	"hancom":    "Hancom PDF",
+}
+
+// Print parameter set values
+const (
+	printRangeAll    = 0 // every page
+	printRangeCustom = 3 // the pages in RangeCustom
+)
+
+// printWaitTimeout bounds the wait for the printer driver to write the file
+const printWaitTimeout = 2 * time.Minute
+
+// pageRangePattern matches page lists such as 1-3,5
+var pageRangePattern = regexp.MustCompile(`^\d+(-\d+)?(,\d+(-\d+)?)*$`)
+
+// PDFPrinterName returns the Windows printer name for printer: a short name
+// of PDFPrinters, or any other printer name as given
+func PDFPrinterName(printer string) string {
+	if name, ok := PDFPrinters[strings.ToLower(printer)]; ok {
+		return name
+	}
+	return printer
+}
+
+// ValidatePageRange checks a page list such as 1-3,5; empty means all pages
+func ValidatePageRange(pages string) error {
+	if pages != "" && !pageRangePattern.MatchString(strings.ReplaceAll(pages, " ", "")) {
+		return fmt.Errorf("invalid page range %q (use e.g. 1-3,5)", pages)
+	}
+	return nil
+}
+
+// PrintToPDF prints the document, or the pages in pages, to the PDF printer
+// driver printer, which writes the file dst. It replaces dst if it exists and
+// returns once the driver has finished writing it.
+func (h *Controller) PrintToPDF(dst, printer, pages string) error {
+	if h.hwpx != nil {
+		return ErrCOMRequired
+	}
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+	if err := ValidatePageRange(pages); err != nil {
+		return err
+	}
+
+	dst, err := filepath.Abs(dst)
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(filepath.Dir(dst)); err != nil || !info.IsDir() {
+		return fmt.Errorf("target directory not found: %s", filepath.Dir(dst))
+	}
+	// Remove an old file first so waiting can tell when the new one is written
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to replace %s: %v", dst, err)
+	}
+
+	printRange := []propertyValue{{"Range", printRangeAll}}
+	if pages != "" {
+		printRange = []propertyValue{
+			{"Range", printRangeCustom},
+			{"RangeCustom", strings.ReplaceAll(pages, " ", "")},
+		}
+	}
+	printed, err := h.executeActionResult("Print", "Print", func(pset *ole.IDispatch) error {
+		return setDispatchProperties(pset, append([]propertyValue{
+			{"PrinterName", PDFPrinterName(printer)},
+			{"PrintToFile", 1},
+			{"FileName", dst},
+			{"NumCopy", 1},
+		}, printRange...))
+	})
+	if err != nil {
+		return err
+	}
+	if !printed {
+		return fmt.Errorf("HWP could not print to %s; check that the printer is installed", PDFPrinterName(printer))
+	}
+	return waitForFile(dst, printWaitTimeout)
+}
+
+// waitForFile waits until path exists and its size has stopped changing
+func waitForFile(path string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	lastSize := int64(-1)
+	for time.Now().Before(deadline) {
+		if info, err := os.Stat(path); err == nil && info.Size() > 0 {
+			if info.Size() == lastSize {
+				return nil
+			}
+			lastSize = info.Size()
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	if lastSize > 0 {
+		return fmt.Errorf("%s was still being written after %v", path, timeout)
+	}
+	return fmt.Errorf("the printer did not write %s within %v", path, timeout)
+}
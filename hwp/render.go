@@ -0,0 +1,77 @@
+package hwp
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/disintegration/imaging"
+)
+
+// Page rendering
+//
+// CreatePageImage draws a page as HWP lays it out, the same way the editor
+// shows it. It only writes BMP and GIF, so the BMP is read back and encoded
+// as PNG, which is smaller and what MCP clients show inline.
+
+// Page rendering resolutions in dots per inch
+const (
+	DefaultRenderDPI = 96
+	MinRenderDPI     = 36
+	MaxRenderDPI     = 300
+)
+
+// RenderPage renders a 1-based page, or the page at the cursor when page is
+// 0, to PNG at dpi, returning the image and the page it shows
+func (h *Controller) RenderPage(page, dpi int) ([]byte, int, error) {
+	if h.hwpx != nil {
+		return nil, 0, ErrCOMRequired
+	}
+	if !h.isRunning || h.hwp == nil {
+		return nil, 0, h.notConnected()
+	}
+	if dpi < MinRenderDPI || dpi > MaxRenderDPI {
+		return nil, 0, fmt.Errorf("invalid resolution: %d dpi (use %d-%d)", dpi, MinRenderDPI, MaxRenderDPI)
+	}
+
+	if page == 0 {
+		if page = h.currentPage(); page == 0 {
+			return nil, 0, fmt.Errorf("failed to find the page at the cursor")
+		}
+	}
+	pages, err := h.PageCount()
+	if err != nil {
+		return nil, 0, err
+	}
+	if page < 1 || page > pages {
+		return nil, 0, fmt.Errorf("page %d out of range (1-%d)", page, pages)
+	}
+
+	dir, err := os.MkdirTemp("", "hwp_page_*")
+	if err != nil {
+		return nil, 0, err
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "page.bmp")
+
+	// CreatePageImage numbers pages from 0
+	created, err := safeCallMethod(h.hwp, "CreatePageImage", path, page-1, dpi, 24, "bmp")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to render page %d: %v", page, err)
+	}
+	defer created.Clear()
+	if ok, isBool := created.Value().(bool); isBool && !ok {
+		return nil, 0, fmt.Errorf("HWP could not render page %d", page)
+	}
+
+	img, err := imaging.Open(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read the rendered page: %v", err)
+	}
+	var buffer bytes.Buffer
+	if err := imaging.Encode(&buffer, img, imaging.PNG); err != nil {
+		return nil, 0, fmt.Errorf("failed to encode the rendered page: %v", err)
+	}
+	return buffer.Bytes(), page, nil
+}
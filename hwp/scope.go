@@ -0,0 +1,205 @@
+package hwp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-ole/go-ole"
+)
+
+// Formatting scopes
+//
+// HWP's CharShape and ParagraphShape actions format the selection, or the
+// insertion point when nothing is selected. ApplyToScope selects the text a
+// scope names, runs the formatting and puts the cursor back, so formatting can
+// be applied to text that is already in the document.
+
+// Formatting scopes
+const (
+	ScopeCursor    = "cursor"    // the insertion point, or the selection if there is one
+	ScopeSelection = "selection" // the selection; nothing selected is an error
+	ScopeParagraph = "paragraph" // the paragraph at the cursor
+	ScopeDocument  = "document"  // the whole document
+	ScopeMatches   = "matches"   // every match of a search
+)
+
+// FormatScopes lists the formatting scopes
+var FormatScopes = []string{ScopeCursor, ScopeSelection, ScopeParagraph, ScopeDocument, ScopeMatches}
+
+// FormatTarget is where formatting applies: a scope and, for ScopeMatches,
+// the search
+type FormatTarget struct {
+	Scope     string
+	Find      string
+	Regex     bool
+	MatchCase bool
+}
+
+// Validate checks the scope and that ScopeMatches has a search
+func (t FormatTarget) Validate() error {
+	switch t.Scope {
+	case ScopeCursor, ScopeSelection, ScopeParagraph, ScopeDocument:
+		return nil
+	case ScopeMatches:
+		if t.Find == "" {
+			return fmt.Errorf("scope matches needs the text to find")
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown scope %q (use %s)", t.Scope, strings.Join(FormatScopes, ", "))
+}
+
+// ApplyToScope runs apply with the target's text selected, once per match for
+// ScopeMatches, and returns the number of times it ran. Except for
+// ScopeCursor and ScopeSelection the selection is cleared and the cursor put
+// back afterwards.
+func (h *Controller) ApplyToScope(target FormatTarget, apply func() error) (int, error) {
+	if err := target.Validate(); err != nil {
+		return 0, err
+	}
+	if target.Scope == ScopeCursor {
+		if err := apply(); err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}
+	if h.hwpx != nil {
+		return 0, ErrCOMRequired
+	}
+	if !h.isRunning || h.hwp == nil {
+		return 0, h.notConnected()
+	}
+
+	if target.Scope == ScopeSelection {
+		if _, selected, err := h.GetSelection(); err != nil {
+			return 0, err
+		} else if !selected {
+			return 0, fmt.Errorf("nothing is selected")
+		}
+		if err := apply(); err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}
+
+	origin, err := h.currentPos()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		h.runAction("Cancel")
+		h.setPos(origin)
+	}()
+
+	switch target.Scope {
+	case ScopeParagraph:
+		if err := h.runAction("MoveParaBegin"); err != nil {
+			return 0, err
+		}
+		if err := h.runAction("MoveSelParaEnd"); err != nil {
+			return 0, err
+		}
+	case ScopeDocument:
+		if err := h.runAction("SelectAll"); err != nil {
+			return 0, err
+		}
+	case ScopeMatches:
+		matches, err := h.Find(target.Find, target.Regex, target.MatchCase, 0)
+		if err != nil {
+			return 0, err
+		}
+		for i := range matches {
+			if _, err := h.GotoMatch(i); err != nil {
+				return i, err
+			}
+			if err := apply(); err != nil {
+				return i, fmt.Errorf("failed to format match %d: %v", i, err)
+			}
+		}
+		return len(matches), nil
+	}
+
+	if err := apply(); err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
+
+// CharFormat is character formatting to apply; zero and nil values leave the
+// current setting unchanged
+type CharFormat struct {
+	Font      string
+	Size      int // points
+	Bold      *bool
+	Italic    *bool
+	Underline *bool
+	Color     string // color name or #RRGGBB
+	Highlight string // background color name or #RRGGBB
+}
+
+// SetCharFormat applies format to the selection, or to the insertion point
+// when nothing is selected. Unlike SetFontStyle it only changes the settings
+// format gives, so formatting existing text keeps the rest of its shape.
+func (h *Controller) SetCharFormat(format CharFormat) error {
+	var color, highlight int
+	var err error
+	if format.Color != "" {
+		if color, err = ParseColor(format.Color); err != nil {
+			return err
+		}
+	}
+	if format.Highlight != "" {
+		if highlight, err = ParseColor(format.Highlight); err != nil {
+			return fmt.Errorf("highlight: %v", err)
+		}
+	}
+	if h.hwpx != nil {
+		if format.Highlight != "" {
+			return ErrCOMRequired
+		}
+		// HWPX text is only ever formatted at the cursor, where every setting
+		// is given, so the unset ones fall back to SetFontStyle's defaults
+		var colors []string
+		if format.Color != "" {
+			colors = append(colors, format.Color)
+		}
+		return h.SetFontStyle(format.Font, format.Size, isSet(format.Bold), isSet(format.Italic), isSet(format.Underline), colors...)
+	}
+
+	return h.executeAction("CharShape", "CharShape", func(pset *ole.IDispatch) error {
+		var values []propertyValue
+		if format.Font != "" {
+			for _, face := range []string{"FaceNameHangul", "FaceNameLatin", "FaceNameHanja", "FaceNameJapanese", "FaceNameOther", "FaceNameSymbol", "FaceNameUser"} {
+				values = append(values, propertyValue{face, format.Font})
+			}
+		}
+		if format.Size > 0 {
+			values = append(values, propertyValue{"Height", format.Size * 100})
+		}
+		if format.Bold != nil {
+			values = append(values, propertyValue{"Bold", *format.Bold})
+		}
+		if format.Italic != nil {
+			values = append(values, propertyValue{"Italic", *format.Italic})
+		}
+		if format.Underline != nil {
+			underlineType := 0
+			if *format.Underline {
+				underlineType = 1
+			}
+			values = append(values, propertyValue{"UnderlineType", underlineType})
+		}
+		if format.Color != "" {
+			values = append(values, propertyValue{"TextColor", color})
+		}
+		if format.Highlight != "" {
+			values = append(values, propertyValue{"ShadeColor", highlight})
+		}
+		return setDispatchProperties(pset, values)
+	})
+}
+
+// isSet reports whether an optional flag is given and true
+func isSet(flag *bool) bool {
+	return flag != nil && *flag
+}
@@ -0,0 +1,342 @@
+package hwp
+
+import (
+	"fmt"
+
+	"github.com/go-ole/go-ole"
+)
+
+// FindMatch is a search hit. List, Para and Pos locate the start of the match
+// (list 0 is the body text); EndPara and EndPos locate its end.
+type FindMatch struct {
+	Index   int    `json:"index"`
+	List    int    `json:"list"`
+	Para    int    `json:"para"`
+	Pos     int    `json:"pos"`
+	EndPara int    `json:"end_para"`
+	EndPos  int    `json:"end_pos"`
+	Page    int    `json:"page,omitempty"`
+	Text    string `json:"text"`
+	Context string `json:"context"`
+}
+
+// listParaPos is a cursor position as used by HWP's GetPos/SetPos
+type listParaPos struct {
+	List int
+	Para int
+	Pos  int
+}
+
+// before reports whether p comes before other in the document
+func (p listParaPos) before(other listParaPos) bool {
+	if p.List != other.List {
+		return p.List < other.List
+	}
+	if p.Para != other.Para {
+		return p.Para < other.Para
+	}
+	return p.Pos < other.Pos
+}
+
+// contextRunes is the number of characters shown on each side of a match
+const contextRunes = 30
+
+// Find searches the document from the top and returns every match with its
+// position, page and surrounding text. The cursor is restored afterwards and
+// the matches are kept for GotoMatch until the next search.
+func (h *Controller) Find(text string, regex, matchCase bool, maxResults int) ([]FindMatch, error) {
+	if !h.isRunning || h.hwp == nil {
+		return nil, h.notConnected()
+	}
+	if text == "" {
+		return nil, fmt.Errorf("search text is empty")
+	}
+
+	origin, err := h.currentPos()
+	if err != nil {
+		return nil, err
+	}
+	defer h.setPos(origin)
+
+	if err := h.runAction("MoveDocBegin"); err != nil {
+		return nil, err
+	}
+
+	var matches []FindMatch
+	for maxResults <= 0 || len(matches) < maxResults {
+		found, err := h.findNext(text, regex, matchCase)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			break
+		}
+
+		start, end, err := h.selectedRange()
+		if err != nil {
+			return nil, err
+		}
+		// HWP wraps around to the top once the end of the document is reached
+		if len(matches) > 0 {
+			last := matches[len(matches)-1]
+			if !(listParaPos{last.List, last.Para, last.Pos}).before(start) {
+				break
+			}
+		}
+
+		matchText, err := h.GetSelectedText()
+		if err != nil {
+			return nil, err
+		}
+
+		match := FindMatch{
+			Index:   len(matches),
+			List:    start.List,
+			Para:    start.Para,
+			Pos:     start.Pos,
+			EndPara: end.Para,
+			EndPos:  end.Pos,
+			Page:    h.currentPage(),
+			Text:    matchText,
+		}
+		match.Context = h.matchContext(start, end)
+		matches = append(matches, match)
+
+		// Continue searching after this match
+		if err := h.setPos(end); err != nil {
+			return nil, err
+		}
+	}
+
+	h.runAction("Cancel")
+	h.lastMatches = matches
+	return matches, nil
+}
+
+// GotoMatch moves the cursor to a match of the last Find and selects it
+func (h *Controller) GotoMatch(index int) (FindMatch, error) {
+	if !h.isRunning || h.hwp == nil {
+		return FindMatch{}, h.notConnected()
+	}
+	if len(h.lastMatches) == 0 {
+		return FindMatch{}, fmt.Errorf("no search results; run a find first")
+	}
+	if index < 0 || index >= len(h.lastMatches) {
+		return FindMatch{}, fmt.Errorf("match index %d out of range (0-%d)", index, len(h.lastMatches)-1)
+	}
+
+	match := h.lastMatches[index]
+	if err := h.setPos(listParaPos{match.List, match.Para, match.Pos}); err != nil {
+		return FindMatch{}, err
+	}
+	if _, err := safeCallMethod(h.hwp, "SelectText", match.Para, match.Pos, match.EndPara, match.EndPos); err != nil {
+		return FindMatch{}, fmt.Errorf("failed to select match: %v", err)
+	}
+	return match, nil
+}
+
+// Selection is the current selection and where it lies in the document
+type Selection struct {
+	Text    string `json:"text"`
+	List    int    `json:"list"`
+	Para    int    `json:"para"`
+	Pos     int    `json:"pos"`
+	EndPara int    `json:"end_para"`
+	EndPos  int    `json:"end_pos"`
+	Page    int    `json:"page,omitempty"`
+}
+
+// GetSelection returns the selected text and its position. ok is false when
+// nothing is selected.
+func (h *Controller) GetSelection() (selection Selection, ok bool, err error) {
+	if !h.isRunning || h.hwp == nil {
+		return Selection{}, false, h.notConnected()
+	}
+
+	modeVar, err := safeGetProperty(h.hwp, "SelectionMode")
+	if err != nil {
+		return Selection{}, false, fmt.Errorf("failed to get selection mode: %v", err)
+	}
+	mode := variantInt(modeVar)
+	modeVar.Clear()
+	if mode == 0 {
+		return Selection{}, false, nil
+	}
+
+	start, end, err := h.selectedRange()
+	if err != nil {
+		return Selection{}, false, err
+	}
+	text, err := h.GetSelectedText()
+	if err != nil {
+		return Selection{}, false, err
+	}
+
+	return Selection{
+		Text:    text,
+		List:    start.List,
+		Para:    start.Para,
+		Pos:     start.Pos,
+		EndPara: end.Para,
+		EndPos:  end.Pos,
+		Page:    h.currentPage(),
+	}, true, nil
+}
+
+// findNext selects the next occurrence of text after the cursor
+func (h *Controller) findNext(text string, regex, matchCase bool) (bool, error) {
+	return h.executeActionResult("RepeatFind", "FindReplace", func(pset *ole.IDispatch) error {
+		return setDispatchProperties(pset, []propertyValue{
+			{"FindString", text},
+			{"FindRegExp", regex},
+			{"MatchCase", matchCase},
+			// Forward search
+			{"Direction", 0},
+			// Suppress the "continue from the beginning?" dialog
+			{"IgnoreMessage", 1},
+		})
+	})
+}
+
+// matchContext returns the text of the match's paragraph around the match,
+// leaving the paragraph selected
+func (h *Controller) matchContext(start, end listParaPos) string {
+	if err := h.setPos(listParaPos{start.List, start.Para, 0}); err != nil {
+		return ""
+	}
+	if err := h.runAction("MoveSelParaEnd"); err != nil {
+		return ""
+	}
+	paragraph, err := h.GetSelectedText()
+	if err != nil {
+		return ""
+	}
+
+	runes := []rune(paragraph)
+	from := start.Pos - contextRunes
+	if from < 0 {
+		from = 0
+	}
+	to := len(runes)
+	if end.Para == start.Para && end.Pos+contextRunes < to {
+		to = end.Pos + contextRunes
+	}
+	if from > to {
+		from = to
+	}
+
+	context := string(runes[from:to])
+	if from > 0 {
+		context = "…" + context
+	}
+	if to < len(runes) {
+		context += "…"
+	}
+	return context
+}
+
+// currentPos returns the cursor position
+func (h *Controller) currentPos() (listParaPos, error) {
+	posVar, err := safeCallMethod(h.hwp, "GetPosBySet")
+	if err != nil {
+		return listParaPos{}, fmt.Errorf("failed to get cursor position: %v", err)
+	}
+	defer posVar.Clear()
+	return readListParaPos(posVar.ToIDispatch())
+}
+
+// setPos moves the cursor, clearing any selection
+func (h *Controller) setPos(pos listParaPos) error {
+	if _, err := safeCallMethod(h.hwp, "SetPos", pos.List, pos.Para, pos.Pos); err != nil {
+		return fmt.Errorf("failed to set cursor position: %v", err)
+	}
+	return nil
+}
+
+// selectedRange returns the start and end of the current selection
+func (h *Controller) selectedRange() (listParaPos, listParaPos, error) {
+	startVar, err := safeCallMethod(h.hwp, "CreateSet", "ListParaPos")
+	if err != nil {
+		return listParaPos{}, listParaPos{}, fmt.Errorf("failed to create position set: %v", err)
+	}
+	defer startVar.Clear()
+	endVar, err := safeCallMethod(h.hwp, "CreateSet", "ListParaPos")
+	if err != nil {
+		return listParaPos{}, listParaPos{}, fmt.Errorf("failed to create position set: %v", err)
+	}
+	defer endVar.Clear()
+
+	if _, err := safeCallMethod(h.hwp, "GetSelectedPosBySet", startVar.ToIDispatch(), endVar.ToIDispatch()); err != nil {
+		return listParaPos{}, listParaPos{}, fmt.Errorf("failed to get selection: %v", err)
+	}
+
+	start, err := readListParaPos(startVar.ToIDispatch())
+	if err != nil {
+		return listParaPos{}, listParaPos{}, err
+	}
+	end, err := readListParaPos(endVar.ToIDispatch())
+	if err != nil {
+		return listParaPos{}, listParaPos{}, err
+	}
+	return start, end, nil
+}
+
+// readListParaPos reads a ListParaPos parameter set
+func readListParaPos(set *ole.IDispatch) (listParaPos, error) {
+	var values [3]int
+	for i, item := range []string{"List", "Para", "Pos"} {
+		itemVar, err := safeCallMethod(set, "Item", item)
+		if err != nil {
+			return listParaPos{}, fmt.Errorf("failed to read %s: %v", item, err)
+		}
+		values[i] = variantInt(itemVar)
+		itemVar.Clear()
+	}
+	return listParaPos{values[0], values[1], values[2]}, nil
+}
+
+// currentPage returns the 1-based page at the cursor, or 0 if it is unavailable
+func (h *Controller) currentPage() int {
+	dispatch := h.hwp
+	for _, property := range []string{"XHwpDocuments", "Active_XHwpDocument", "XHwpDocumentInfo"} {
+		propertyVar, err := safeGetProperty(dispatch, property)
+		if err != nil {
+			return 0
+		}
+		defer propertyVar.Clear()
+		dispatch = propertyVar.ToIDispatch()
+	}
+
+	pageVar, err := safeGetProperty(dispatch, "CurrentPage")
+	if err != nil {
+		return 0
+	}
+	defer pageVar.Clear()
+	// CurrentPage is zero-based
+	return variantInt(pageVar) + 1
+}
+
+// variantInt converts an integer VARIANT of any width to int
+func variantInt(v *ole.VARIANT) int {
+	switch value := v.Value().(type) {
+	case int8:
+		return int(value)
+	case int16:
+		return int(value)
+	case int32:
+		return int(value)
+	case int64:
+		return int(value)
+	case uint8:
+		return int(value)
+	case uint16:
+		return int(value)
+	case uint32:
+		return int(value)
+	case uint64:
+		return int(value)
+	case int:
+		return value
+	}
+	return 0
+}
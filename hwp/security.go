@@ -0,0 +1,121 @@
+package hwp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-ole/go-ole"
+)
+
+// editModes maps edit mode names to values of the HwpObject EditMode property
+var editModes = map[string]int{
+	"read_only": 0, // 읽기 전용
+	"normal":    1, // 일반 편집
+	"form":      2, // 양식 모드: only form fields (누름틀) are editable
+}
+
+// SetEditMode restricts editing of the current document
+func (h *Controller) SetEditMode(mode string) error {
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+
+	value, ok := editModes[strings.ToLower(mode)]
+	if !ok {
+		return fmt.Errorf("invalid edit mode: %s (use normal, read_only or form)", mode)
+	}
+
+	if err := safePutProperty(h.hwp, "EditMode", value); err != nil {
+		return fmt.Errorf("failed to set edit mode: %v", err)
+	}
+	return nil
+}
+
+// GetEditMode returns the edit mode name of the current document
+func (h *Controller) GetEditMode() (string, error) {
+	if !h.isRunning || h.hwp == nil {
+		return "", h.notConnected()
+	}
+
+	modeVar, err := safeGetProperty(h.hwp, "EditMode")
+	if err != nil {
+		return "", fmt.Errorf("failed to get edit mode: %v", err)
+	}
+	defer modeVar.Clear()
+
+	value, _ := modeVar.Value().(int32)
+	for name, mode := range editModes {
+		if int32(mode) == value {
+			return name, nil
+		}
+	}
+	return fmt.Sprintf("unknown (%d)", value), nil
+}
+
+// SetDocumentPassword sets the password required to open the document when it
+// is next saved. An empty password removes the existing password.
+func (h *Controller) SetDocumentPassword(password string) error {
+	return h.executeAction("FilePassword", "Password", func(pset *ole.IDispatch) error {
+		return setDispatchProperties(pset, []propertyValue{
+			{"String", password},
+			// Do not prompt the user for confirmation
+			{"Ask", 0},
+		})
+	})
+}
+
+// ChangeDocumentPassword replaces the open password of the document, given
+// its current password; an empty password removes it. Like SetDocumentPassword
+// it takes effect when the document is next saved.
+func (h *Controller) ChangeDocumentPassword(current, password string) error {
+	if h.hwpx != nil {
+		return ErrCOMRequired
+	}
+	if current == "" {
+		return fmt.Errorf("the current password is required")
+	}
+	accepted, err := h.executeActionResult("FilePassword", "Password", func(pset *ole.IDispatch) error {
+		if err := setDispatchProperties(pset, []propertyValue{
+			{"String", password},
+			{"Ask", 0},
+		}); err != nil {
+			return err
+		}
+		return setParameterItems(pset, map[string]interface{}{"OldString": current})
+	})
+	if err != nil {
+		return err
+	}
+	if !accepted {
+		return fmt.Errorf("HWP did not accept the current password")
+	}
+	return nil
+}
+
+// IsReadOnly reports whether the document was opened read-only
+func (h *Controller) IsReadOnly() bool {
+	return h.readOnly
+}
+
+// IsModified reports whether the document has unsaved changes
+func (h *Controller) IsModified() (bool, error) {
+	if h.hwpx != nil {
+		return h.hwpx.modified, nil
+	}
+	if !h.isRunning || h.hwp == nil {
+		return false, h.notConnected()
+	}
+
+	modifiedVar, err := safeGetProperty(h.hwp, "IsModified")
+	if err != nil {
+		return false, fmt.Errorf("failed to get modified state: %v", err)
+	}
+	defer modifiedVar.Clear()
+
+	switch value := modifiedVar.Value().(type) {
+	case bool:
+		return value, nil
+	default:
+		return variantInt(modifiedVar) != 0, nil
+	}
+}
@@ -0,0 +1,154 @@
+package hwp
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// numberedSymbol is a run of consecutive code points selected by a 1-based number
+type numberedSymbol struct {
+	first rune
+	count int
+}
+
+// numberedSymbols are symbol categories picked by number, e.g. circled_number 3 is ③
+var numberedSymbols = map[string][]numberedSymbol{
+	"circled_number":       {{0x2460, 20}, {0x3251, 15}, {0x32B1, 15}}, // ①-⑳, ㉑-㉟, ㊱-㊿
+	"parenthesized_number": {{0x2474, 20}},                             // ⑴-⒇
+	"number_period":        {{0x2488, 20}},                             // ⒈-⒛
+	"circled_hangul":       {{0x326E, 14}},                             // ㉮-㉻ (가-하)
+	"parenthesized_hangul": {{0x320E, 14}},                             // ㈎-㈛ (가-하)
+	"circled_latin":        {{0x24D0, 26}},                             // ⓐ-ⓩ
+	"roman_upper":          {{0x2160, 12}},                             // Ⅰ-Ⅻ
+	"roman_lower":          {{0x2170, 12}},                             // ⅰ-ⅻ
+}
+
+// namedSymbols are symbol categories picked by name
+var namedSymbols = map[string]map[string]string{
+	"box": {
+		"horizontal":        "─",
+		"vertical":          "│",
+		"top_left":          "┌",
+		"top_right":         "┐",
+		"bottom_left":       "└",
+		"bottom_right":      "┘",
+		"tee_left":          "├",
+		"tee_right":         "┤",
+		"tee_top":           "┬",
+		"tee_bottom":        "┴",
+		"cross":             "┼",
+		"double_horizontal": "═",
+		"double_vertical":   "║",
+	},
+	"currency": {
+		"won":    "₩",
+		"dollar": "$",
+		"yen":    "¥",
+		"yuan":   "元",
+		"euro":   "€",
+		"pound":  "£",
+		"cent":   "¢",
+	},
+	"mark": {
+		"reference":       "※",
+		"bullet":          "•",
+		"middle_dot":      "·",
+		"square":          "□",
+		"square_filled":   "■",
+		"circle":          "○",
+		"circle_filled":   "●",
+		"double_circle":   "◎",
+		"diamond":         "◇",
+		"diamond_filled":  "◆",
+		"triangle":        "△",
+		"triangle_filled": "▲",
+		"star":            "☆",
+		"star_filled":     "★",
+		"check":           "✓",
+		"ballot_check":    "☑",
+		"ballot":          "☐",
+		"degree":          "°",
+		"celsius":         "℃",
+		"tilde":           "～",
+	},
+	"arrow": {
+		"right":          "→",
+		"left":           "←",
+		"up":             "↑",
+		"down":           "↓",
+		"left_right":     "↔",
+		"double_right":   "⇒",
+		"double_both":    "⇔",
+		"triangle_right": "▶",
+	},
+}
+
+// SymbolCategories returns the names of all symbol categories in sorted order
+func SymbolCategories() []string {
+	var categories []string
+	for category := range numberedSymbols {
+		categories = append(categories, category)
+	}
+	for category := range namedSymbols {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+// LookupSymbol returns the symbol called name in category. Numbered categories
+// take a number as the name, named categories a symbol name.
+func LookupSymbol(category, name string) (string, error) {
+	category = strings.ToLower(category)
+
+	if runs, ok := numberedSymbols[category]; ok {
+		number, err := strconv.Atoi(strings.TrimSpace(name))
+		if err != nil || number < 1 {
+			return "", fmt.Errorf("category %s takes a number starting at 1, got %q", category, name)
+		}
+		index := number - 1
+		for _, run := range runs {
+			if index < run.count {
+				return string(run.first + rune(index)), nil
+			}
+			index -= run.count
+		}
+		return "", fmt.Errorf("category %s has no symbol %d", category, number)
+	}
+
+	symbols, ok := namedSymbols[category]
+	if !ok {
+		return "", fmt.Errorf("unknown symbol category: %s (use %s)", category, strings.Join(SymbolCategories(), ", "))
+	}
+	symbol, ok := symbols[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, 0, len(symbols))
+		for symbolName := range symbols {
+			names = append(names, symbolName)
+		}
+		sort.Strings(names)
+		return "", fmt.Errorf("unknown %s symbol: %s (use %s)", category, name, strings.Join(names, ", "))
+	}
+	return symbol, nil
+}
+
+// ParseCodePoint parses a Unicode code point written as U+2460, 0x2460 or 2460 (hex)
+func ParseCodePoint(codePoint string) (string, error) {
+	digits := strings.TrimSpace(codePoint)
+	for _, prefix := range []string{"U+", "u+", "0x", "0X"} {
+		digits = strings.TrimPrefix(digits, prefix)
+	}
+
+	value, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return "", fmt.Errorf("invalid code point: %s (use e.g. U+2460)", codePoint)
+	}
+	r := rune(value)
+	if !utf8.ValidRune(r) || r < 0x20 {
+		return "", fmt.Errorf("code point %s is not a printable character", codePoint)
+	}
+	return string(r), nil
+}
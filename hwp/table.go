@@ -0,0 +1,240 @@
+package hwp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-ole/go-ole"
+)
+
+// CellStyle holds table cell settings for the current cell or selected cells.
+// Padding is in millimeters; nil values leave the current setting unchanged.
+type CellStyle struct {
+	PaddingLeft   *float64
+	PaddingRight  *float64
+	PaddingTop    *float64
+	PaddingBottom *float64
+
+	Diagonal        string // "none", "slash", "backslash", "cross" or "" to keep
+	DiagonalStyle   string // line style from BorderTypes (default: solid)
+	DiagonalWidthMM float64
+	DiagonalColor   string // default: black
+}
+
+// HasPadding reports whether any padding side is set
+func (s CellStyle) HasPadding() bool {
+	return s.PaddingLeft != nil || s.PaddingRight != nil || s.PaddingTop != nil || s.PaddingBottom != nil
+}
+
+// DiagonalLines lists the diagonal split lines a cell can have
+var DiagonalLines = []string{"none", "slash", "backslash", "cross"}
+
+// diagonalCenter is the BorderFill slash flag value for a corner-to-corner line
+const diagonalCenter = 2
+
+// SetCellStyle applies padding and diagonal lines to the current cell, or to
+// every selected cell when cells are selected
+func (h *Controller) SetCellStyle(style CellStyle) error {
+	slash, backslash := 0, 0
+	switch strings.ToLower(style.Diagonal) {
+	case "", "none":
+	case "slash":
+		slash = diagonalCenter
+	case "backslash":
+		backslash = diagonalCenter
+	case "cross":
+		slash, backslash = diagonalCenter, diagonalCenter
+	default:
+		return fmt.Errorf("invalid diagonal: %s (use %s)", style.Diagonal, strings.Join(DiagonalLines, ", "))
+	}
+
+	lineStyle := style.DiagonalStyle
+	if lineStyle == "" {
+		lineStyle = "solid"
+	}
+	diagonalType, ok := BorderTypes[strings.ToLower(lineStyle)]
+	if !ok {
+		return fmt.Errorf("invalid diagonal style: %s", style.DiagonalStyle)
+	}
+	colorName := style.DiagonalColor
+	if colorName == "" {
+		colorName = "black"
+	}
+	color, err := ParseColor(colorName)
+	if err != nil {
+		return err
+	}
+	if !style.HasPadding() && style.Diagonal == "" {
+		return fmt.Errorf("nothing to change: set a padding side or diagonal")
+	}
+
+	if style.HasPadding() {
+		if err := h.setCellPadding(style); err != nil {
+			return err
+		}
+	}
+	if style.Diagonal == "" {
+		return nil
+	}
+
+	return h.executeAction("CellBorderFill", "CellBorderFill", func(pset *ole.IDispatch) error {
+		return setDispatchProperties(pset, []propertyValue{
+			{"SlashFlag", slash},
+			{"BackSlashFlag", backslash},
+			{"DiagonalType", diagonalType},
+			{"DiagonalWidth", borderWidthIndex(style.DiagonalWidthMM)},
+			{"DiagonalColor", color},
+		})
+	})
+}
+
+// setCellPadding sets the inner margins of the current cells through the
+// cell tab of the table properties
+func (h *Controller) setCellPadding(style CellStyle) error {
+	return h.executeAction("TablePropertyDialog", "ShapeObject", func(pset *ole.IDispatch) error {
+		cellVar, err := safeGetProperty(pset, "ShapeTableCell")
+		if err != nil {
+			return fmt.Errorf("failed to get ShapeTableCell: %v", err)
+		}
+		defer cellVar.Clear()
+
+		properties := []propertyValue{{"HasMargin", 1}}
+		for _, side := range []struct {
+			name string
+			mm   *float64
+		}{
+			{"MarginLeft", style.PaddingLeft},
+			{"MarginRight", style.PaddingRight},
+			{"MarginTop", style.PaddingTop},
+			{"MarginBottom", style.PaddingBottom},
+		} {
+			if side.mm != nil {
+				properties = append(properties, propertyValue{side.name, MMToHwpUnit(*side.mm)})
+			}
+		}
+		return setDispatchProperties(cellVar.ToIDispatch(), properties)
+	})
+}
+
+// ReadTable returns the cell text of a table by rows: the table under the
+// cursor when index is 0, otherwise the index-th table of the document
+// (1-based). Merged cells appear once, as in the document model.
+func (h *Controller) ReadTable(index int) ([][]string, error) {
+	if h.hwpx != nil {
+		return h.hwpx.tableRows(index)
+	}
+	if !h.isRunning || h.hwp == nil {
+		return nil, h.notConnected()
+	}
+
+	var model *DocumentModel
+	if index > 0 {
+		exported, err := h.ExportModel()
+		if err != nil {
+			return nil, err
+		}
+		model = exported
+	} else {
+		document, err := h.selectedTableHTML()
+		if err != nil {
+			return nil, err
+		}
+		model = ParseHTMLModel(document)
+	}
+
+	found := 0
+	for _, block := range model.Blocks {
+		if block.Type != BlockTable {
+			continue
+		}
+		found++
+		if index == 0 || found == index {
+			return block.Rows, nil
+		}
+	}
+	if index == 0 {
+		return nil, fmt.Errorf("the cursor is not in a table")
+	}
+	return nil, fmt.Errorf("table %d not found; the document has %d tables", index, found)
+}
+
+// selectedTableHTML selects the table under the cursor and exports the
+// selection as HTML, leaving the cursor in the table
+func (h *Controller) selectedTableHTML() (string, error) {
+	if err := h.runAction("TableSelCell"); err != nil {
+		return "", err
+	}
+	if err := h.runAction("TableSelTable"); err != nil {
+		return "", err
+	}
+	defer h.runAction("Cancel")
+
+	result, err := safeCallMethod(h.hwp, "GetTextFile", "HTML", "saveblock")
+	if err != nil {
+		return "", fmt.Errorf("failed to export the table: %v", err)
+	}
+	return result.ToString(), nil
+}
+
+// MergeCellsDown merges the cell under the cursor with the count-1 cells
+// below it, such as the photo cell spanning the rows of a resume's personal
+// details, and leaves the cursor in the merged cell
+func (h *Controller) MergeCellsDown(count int) error {
+	if h.hwpx != nil {
+		return ErrCOMRequired
+	}
+	if count < 2 {
+		return nil
+	}
+	// F5 selects the cell; the moves then extend the cell block downwards
+	if err := h.runAction("TableCellBlock"); err != nil {
+		return err
+	}
+	if err := h.runAction("TableCellBlockExtend"); err != nil {
+		return err
+	}
+	for i := 0; i < count-1; i++ {
+		if err := h.runAction("TableLowerCell"); err != nil {
+			return err
+		}
+	}
+	if err := h.runAction("TableMergeCell"); err != nil {
+		return err
+	}
+	return h.runAction("Cancel")
+}
+
+// ExpandTableRow replaces row (1-based) of the table under the cursor, or of
+// the last inserted table with the HWPX backend, with one copy per entry of
+// rows, each holding that entry's cell text. The copies keep the row's cell
+// formatting. No rows removes the row. With COM the cursor ends below the
+// table.
+func (h *Controller) ExpandTableRow(row int, rows [][]string) error {
+	if h.hwpx != nil {
+		return h.hwpx.expandTableRow(row, rows)
+	}
+	if !h.isRunning || h.hwp == nil {
+		return h.notConnected()
+	}
+
+	h.moveToTableCellAt(row, 1)
+	defer h.exitTable()
+	if len(rows) == 0 {
+		return h.runAction("TableDeleteRow")
+	}
+	if len(rows) > 1 {
+		// Rows inserted below take the cell shapes of the template row
+		if err := h.InsertTableRow("lower", len(rows)-1); err != nil {
+			return err
+		}
+	}
+	for i, cells := range rows {
+		if i > 0 {
+			if err := h.runAction("TableLowerCell"); err != nil {
+				return err
+			}
+		}
+		h.fillTableRow(cells, false)
+	}
+	return nil
+}
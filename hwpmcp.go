@@ -0,0 +1,127 @@
+// Package hwpmcp embeds the HWP tool set in a Go MCP server.
+//
+// A server built with mcp-go adds the tools alongside its own:
+//
+//	mcpServer := server.NewMCPServer("my-server", "1.0.0",
+//		server.WithToolCapabilities(true),
+//		server.WithResourceCapabilities(true, false),
+//	)
+//	if err := hwpmcp.RegisterTools(mcpServer, hwpmcp.Options{Backend: "hwpx", Resources: true}); err != nil {
+//		log.Fatal(err)
+//	}
+//	defer hwpmcp.Close()
+package hwpmcp
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"hwp-mcp-go/handlers"
+	"hwp-mcp-go/hwp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Options configures the HWP tool set
+type Options struct {
+	// Backend is "com" (live HWP through COM) or "hwpx" (write HWPX files
+	// directly). Empty keeps the default: com on Windows and hwpx elsewhere.
+	Backend string
+
+	// DryRun makes every destructive tool only report what it would do
+	DryRun bool
+
+	// Resources also adds the hwp://current/text document resource. The
+	// server needs resource capabilities for clients to see it.
+	Resources bool
+
+	// RecentFiles is the JSON file where opened and saved documents are
+	// recorded for hwp_list_recent; DefaultRecentFilesPath is the usual
+	// place. Empty turns tracking off.
+	RecentFiles string
+
+	// AllowedDirs are the directories, with their subdirectories, that
+	// hwp_list_files may browse. Nothing can be listed when it is empty.
+	AllowedDirs []string
+
+	// Presets is a JSON file of style presets for hwp_apply_preset and the
+	// built-in document types, replacing built-in presets of the same name.
+	// Empty keeps the built-in presets.
+	Presets string
+}
+
+// RegisterTools adds the HWP tools, and the document resource if requested,
+// to mcpServer. Every tool already has argument validation, metrics, dry-run
+// and read-only protection applied, so no server middleware is needed.
+func RegisterTools(mcpServer *server.MCPServer, opts Options) error {
+	if opts.Backend != "" {
+		if err := hwp.SetBackend(opts.Backend); err != nil {
+			return err
+		}
+	}
+	handlers.SetDryRun(opts.DryRun)
+	handlers.SetRecentFilesPath(opts.RecentFiles)
+	if err := handlers.SetAllowedDirs(opts.AllowedDirs); err != nil {
+		return err
+	}
+	if err := handlers.SetPresetsFile(opts.Presets); err != nil {
+		return err
+	}
+
+	if opts.Resources {
+		mcpServer.AddResource(mcp.NewResource(handlers.RESOURCE_CURRENT_TEXT, "Current document text",
+			mcp.WithResourceDescription("Plain text of the open document. Subscribed clients get a resources/updated notification when it changes"),
+			mcp.WithMIMEType("text/plain"),
+		), handlers.HandleCurrentTextResource)
+	}
+
+	handlers.RegisterTools(mcpServer)
+	return nil
+}
+
+// Subscriptions records the clients subscribed to the document resource. The
+// transports pass each incoming message to its HandleMessage, which answers
+// resources/subscribe and resources/unsubscribe, and its AddHooks goes in
+// the server's hooks so ended sessions are dropped.
+type Subscriptions = handlers.ResourceSubscriptions
+
+// NewSubscriptions returns an empty subscription record
+func NewSubscriptions() *Subscriptions {
+	return handlers.NewResourceSubscriptions()
+}
+
+// WatchDocument notifies the clients subscribed to the document resource
+// when the open document changes, checking every interval while any are
+// subscribed until ctx is done. It needs the document resource from
+// Options.Resources.
+func WatchDocument(ctx context.Context, mcpServer *server.MCPServer, subscriptions *Subscriptions, interval time.Duration) {
+	handlers.WatchDocument(ctx, mcpServer, subscriptions, interval)
+}
+
+// MetricsHandler serves the tool call metrics in the Prometheus text format
+func MetricsHandler() http.Handler {
+	return handlers.MetricsHandler()
+}
+
+// DefaultRecentFilesPath returns the recent files store under the user
+// config directory
+func DefaultRecentFilesPath() string {
+	return handlers.DefaultRecentFilesPath()
+}
+
+// Backend returns the document backend in use
+func Backend() string {
+	return hwp.ActiveBackend()
+}
+
+// Close releases the HWP instance controlled by the tools, if any
+func Close() {
+	controller := hwp.GetGlobalController()
+	if controller != nil {
+		hwp.ExecuteHWPOperation(func() {
+			controller.Disconnect()
+		})
+	}
+}